@@ -27,6 +27,8 @@ export enum FilterType {
 
     rev = 'rev',
     select = 'select',
+    signed = 'signed',
+    submodules = 'submodules',
     timeout = 'timeout',
     type = 'type',
     visibility = 'visibility',
@@ -315,6 +317,46 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         description: 'Select repo, file, symbol, content, or commit result types.',
         singular: true,
     },
+    [FilterType.signed]: {
+        discreteValues: () => [
+            {
+                label: 'yes',
+                description: 'Commits signed by a trusted key',
+            },
+            {
+                label: 'no',
+                description: 'Commits without a signature',
+            },
+            {
+                label: 'invalid',
+                description: 'Commits with a bad, expired, revoked or unverifiable signature',
+            },
+            {
+                label: 'untrusted',
+                description: 'Commits with a good signature made by an untrusted key',
+            },
+        ],
+        description: 'Include only commits or diffs with a verified, missing, invalid or untrusted signature.',
+        singular: true,
+    },
+    [FilterType.submodules]: {
+        discreteValues: () => [
+            {
+                label: 'yes',
+                description: 'Also search the submodules of repositories',
+            },
+            {
+                label: 'only',
+                description: 'Only search the submodules of repositories',
+            },
+            {
+                label: 'no',
+                description: 'Do not search submodules (default)',
+            },
+        ],
+        description: 'Search submodules at the commits pinned by their repositories.',
+        singular: true,
+    },
     [FilterType.timeout]: {
         description: 'Duration before timeout, e.g. 30s, 1m, 2h, 3d, 4w, 5y.',
         placeholder: 'duration-value',
@@ -23,10 +23,14 @@ export const PREDICATES: PredicateDefinition[] = [
     { field: 'repo', name: 'has.key' },
     { field: 'repo', name: 'has.meta' },
     { field: 'repo', name: 'has.topic' },
+    { field: 'repo', name: 'has.symbol' },
     { field: 'file', name: 'contains.content' },
     { field: 'file', name: 'has.content' },
     { field: 'file', name: 'has.owner' },
+    { field: 'file', name: 'has.symbol' },
     { field: 'rev', name: 'at.time' },
+    { field: 'rev', name: 'compare' },
+    { field: 'rev', name: 'compare.time' },
 ]
 
 /** Represents a predicate's components corresponding to the syntax path(parameters). */
@@ -139,6 +143,12 @@ export const predicateCompletion = (field: FilterType): Completion[] => {
                 asSnippet: true,
                 description: 'Search only in repositories that contain matching file paths and contents',
             },
+            {
+                label: 'has.symbol(...)',
+                insertText: 'has.symbol(${1})',
+                asSnippet: true,
+                description: 'Search only inside repositories that define a symbol that matches a pattern',
+            },
             {
                 label: 'has.topic(...)',
                 insertText: 'has.topic(${1})',
@@ -186,6 +196,12 @@ export const predicateCompletion = (field: FilterType): Completion[] => {
                 asSnippet: true,
                 description: 'Search only inside files that have a contributor that matches a pattern',
             },
+            {
+                label: 'has.symbol(...)',
+                insertText: 'has.symbol(${1})',
+                asSnippet: true,
+                description: 'Search only inside files that define a symbol that matches a pattern',
+            },
         ]
     }
     if (field === 'rev') {
@@ -197,6 +213,19 @@ export const predicateCompletion = (field: FilterType): Completion[] => {
                 description:
                     'Search repos at a specific time in history. Optionally, a base revision can be specified as a second parameter like rev:at.time(yesterday, my-branch)',
             },
+            {
+                label: 'compare(...)',
+                insertText: 'compare(${1:base}, ${2:head})',
+                asSnippet: true,
+                description: 'Only return matches that were added or removed between two revisions',
+            },
+            {
+                label: 'compare.time(...)',
+                insertText: 'compare.time(${1:1 month ago}, ${2:now})',
+                asSnippet: true,
+                description:
+                    'Only return matches that were added or removed between two points in time. Optionally, a base revision can be specified as a third parameter like rev:compare.time(last month, now, my-branch)',
+            },
         ]
     }
     return []
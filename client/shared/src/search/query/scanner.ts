@@ -586,10 +586,12 @@ export const scanSearchQuery = (
         case SearchPatternType.codycontext: {
             return scanStandard(query)
         }
-        case SearchPatternType.keyword: {
+        case SearchPatternType.keyword:
+        case SearchPatternType.fuzzy: {
             return scanKeyword(query)
         }
-        case SearchPatternType.literal: {
+        case SearchPatternType.literal:
+        case SearchPatternType.treesitter: {
             patternKind = PatternKind.Literal
             break
         }
@@ -38,6 +38,11 @@ export type SearchEvent =
 
 export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch | OwnerMatch
 
+/**
+ * How a match differs between the base and head revisions of a rev:compare() search.
+ */
+export type MatchChange = 'added' | 'removed'
+
 export interface PathMatch {
     type: 'path'
     path: string
@@ -48,6 +53,8 @@ export interface PathMatch {
     branches?: string[]
     commit?: string
     language?: string
+    /** Set for matches of rev:compare() searches. */
+    change?: MatchChange
     debug?: string
 }
 
@@ -64,6 +71,8 @@ export interface ContentMatch {
     chunkMatches?: ChunkMatch[]
     hunks?: DecoratedHunk[]
     language?: string
+    /** Set for matches of rev:compare() searches. */
+    change?: MatchChange
     debug?: string
 }
 
@@ -119,6 +128,8 @@ export interface SymbolMatch {
     commit?: string
     symbols: MatchedSymbol[]
     language?: string
+    /** Set for matches of rev:compare() searches. */
+    change?: MatchChange
     debug?: string
 }
 
@@ -151,6 +162,8 @@ export interface CommitMatch {
     repoLastFetched?: string
 
     content: MarkdownText
+    /** Set for matches of rev:compare() searches. */
+    change?: MatchChange
     // Array of [line, character, length] triplets
     ranges: number[][]
 }
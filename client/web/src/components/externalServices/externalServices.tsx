@@ -6,6 +6,7 @@ import BitbucketIcon from 'mdi-react/BitbucketIcon'
 import GithubIcon from 'mdi-react/GithubIcon'
 import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
+import LanguageCsharpIcon from 'mdi-react/LanguageCsharpIcon'
 import LanguageGoIcon from 'mdi-react/LanguageGoIcon'
 import LanguageJavaIcon from 'mdi-react/LanguageJavaIcon'
 import LanguagePythonIcon from 'mdi-react/LanguagePythonIcon'
@@ -28,6 +29,7 @@ import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
 import goModulesSchemaJSON from '../../../../../schema/go-modules.schema.json'
 import jvmPackagesSchemaJSON from '../../../../../schema/jvm-packages.schema.json'
 import npmPackagesSchemaJSON from '../../../../../schema/npm-packages.schema.json'
+import nugetPackagesSchemaJSON from '../../../../../schema/nuget-packages.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
 import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
@@ -35,6 +37,7 @@ import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json
 import pythonPackagesJSON from '../../../../../schema/python-packages.schema.json'
 import rubyPackagesSchemaJSON from '../../../../../schema/ruby-packages.schema.json'
 import rustPackagesJSON from '../../../../../schema/rust-packages.schema.json'
+import subversionSchemaJSON from '../../../../../schema/subversion.schema.json'
 import {
     type ExternalRepositoryFields,
     ExternalServiceKind,
@@ -1288,6 +1291,62 @@ const PERFORCE: AddExternalServiceOptions = {
         },
     ],
 }
+
+const SUBVERSION: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.SUBVERSION,
+    title: 'Subversion',
+    icon: GitIcon,
+    jsonSchema: subversionSchemaJSON,
+    defaultDisplayName: 'Subversion',
+    defaultConfig: `{
+  "url": "https://svn.example.com/svn/",
+  "username": "<username>",
+  "password": "<password>",
+  "repos": []
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the base URL of the Subversion server.
+                </li>
+                <li>
+                    Set the <Field>username</Field> and <Field>password</Field> fields to the credentials of a user
+                    with read access, or remove them for anonymous access.
+                </li>
+                <li>
+                    Add the paths of the repositories to mirror, relative to <Field>url</Field>, to the{' '}
+                    <Field>repos</Field> field.
+                </li>
+            </ol>
+            <Text>
+                The history of each repository is converted to Git with git-svn. The <Code>trunk</Code> becomes the
+                default branch, and <Code>branches</Code> and <Code>tags</Code> become Git branches and tags. Set the{' '}
+                <Field>layout</Field> field for repositories that don't follow this layout.
+            </Text>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'setLayout',
+            label: 'Set layout',
+            run: (config: string) => {
+                const value = { trunk: 'trunk', branches: 'branches', tags: 'tags' }
+                const edits = modify(config, ['layout'], value, defaultModificationOptions)
+                return { edits, selectText: '"layout": {' }
+            },
+        },
+        {
+            id: 'mapAuthors',
+            label: 'Map authors',
+            run: (config: string) => {
+                const value = { '<username>': 'Name <email>' }
+                const edits = modify(config, ['authors'], value, defaultModificationOptions)
+                return { edits, selectText: '"<username>"' }
+            },
+        },
+    ],
+}
 const JVM_PACKAGES: AddExternalServiceOptions = {
     kind: ExternalServiceKind.JVMPACKAGES,
     title: 'JVM Dependencies',
@@ -1515,6 +1574,40 @@ const PYTHON_PACKAGES: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const NUGET_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.NUGETPACKAGES,
+    title: 'NuGet Dependencies',
+    icon: LanguageCsharpIcon,
+    jsonSchema: nugetPackagesSchemaJSON,
+    defaultDisplayName: 'NuGet Dependencies',
+    defaultConfig: `{
+  "registry": "https://api.nuget.org/v3/index.json",
+  "dependencies": []
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>registry</Field> to the service index of the NuGet v3 feed
+                    you want to sync dependency repositories from. For example,{' '}
+                    <Code>"https://api.nuget.org/v3/index.json"</Code>.
+                </li>
+                <li>
+                    For private feeds, set <Field>credentials</Field> to <Code>"username:password"</Code>. Feeds that use
+                    personal access tokens usually accept any username with the token as the password.
+                </li>
+                <li>
+                    In the configuration below, set <Field>dependencies</Field> to the list of packages that you want to
+                    manually add. For example, <Code>"Newtonsoft.Json@13.0.3"</Code>.
+                </li>
+            </ol>
+            <Text>⚠️ NuGet package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one NuGet packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const RUST_PACKAGES: AddExternalServiceOptions = {
     kind: ExternalServiceKind.RUSTPACKAGES,
     title: 'Rust Dependencies',
@@ -1593,6 +1686,7 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     azuredevops: AZUREDEVOPS,
     phabricator: PHABRICATOR_SERVICE,
     ...(window.context?.experimentalFeatures?.perforce !== 'disabled' ? { perforce: PERFORCE } : {}),
+    subversion: SUBVERSION,
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
 }
 
@@ -1603,6 +1697,7 @@ export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptio
     ...(window.context?.experimentalFeatures?.goPackages === 'enabled' ? { goModules: GO_MODULES } : {}),
     ...(window.context?.experimentalFeatures?.jvmPackages === 'enabled' ? { jvmPackages: JVM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.npmPackages === 'enabled' ? { npmPackages: NPM_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.nugetPackages === 'enabled' ? { nugetPackages: NUGET_PACKAGES } : {}),
 }
 
 export const allExternalServices = {
@@ -1626,9 +1721,11 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
     [ExternalServiceKind.NPMPACKAGES]: NPM_PACKAGES,
+    [ExternalServiceKind.NUGETPACKAGES]: NUGET_PACKAGES,
     [ExternalServiceKind.PYTHONPACKAGES]: PYTHON_PACKAGES,
     [ExternalServiceKind.RUSTPACKAGES]: RUST_PACKAGES,
     [ExternalServiceKind.RUBYPACKAGES]: RUBY_PACKAGES,
+    [ExternalServiceKind.SUBVERSION]: SUBVERSION,
 }
 
 export const externalRepoIcon = (
@@ -10,6 +10,7 @@ export function isPackageServiceType(serviceType?: string): boolean {
     switch (serviceType) {
         case 'jvmPackages':
         case 'npmPackages':
+        case 'nugetPackages':
         case 'pythonPackages':
         case 'rubyPackages':
         case 'goModules':
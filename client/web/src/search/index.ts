@@ -40,7 +40,9 @@ export function parseSearchURLPatternType(query: string): SearchPatternType | un
         case SearchPatternType.structural:
         case SearchPatternType.lucky:
         case SearchPatternType.codycontext:
-        case SearchPatternType.keyword: {
+        case SearchPatternType.keyword:
+        case SearchPatternType.treesitter:
+        case SearchPatternType.fuzzy: {
             return patternType
         }
     }
@@ -43,6 +43,8 @@ const v2CodeHostKind: { [key in ExternalServiceKind]: number } = {
     PYTHONPACKAGES: 15,
     RUBYPACKAGES: 16,
     RUSTPACKAGES: 17,
+    SUBVERSION: 18,
+    NUGETPACKAGES: 19,
 }
 
 /**
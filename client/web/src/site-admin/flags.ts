@@ -2,6 +2,7 @@ export const isPackagesEnabled = (): boolean =>
     window.context?.experimentalFeatures?.npmPackages === 'enabled' ||
     window.context?.experimentalFeatures?.goPackages === 'enabled' ||
     window.context?.experimentalFeatures?.jvmPackages === 'enabled' ||
+    window.context?.experimentalFeatures?.nugetPackages === 'enabled' ||
     window.context?.experimentalFeatures?.rubyPackages === 'enabled' ||
     window.context?.experimentalFeatures?.pythonPackages === 'enabled' ||
     window.context?.experimentalFeatures?.rustPackages === 'enabled'
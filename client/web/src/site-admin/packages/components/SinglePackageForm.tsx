@@ -20,7 +20,7 @@ import {
 } from '@sourcegraph/wildcard'
 
 import type { FilterOption } from '../../../components/FilteredConnection'
-import type { PackageRepoMatchFields, PackageRepoReferenceKind } from '../../../graphql-operations'
+import { type PackageRepoMatchFields, PackageRepoReferenceKind } from '../../../graphql-operations'
 import { useMatchingPackages } from '../hooks/useMatchingPackages'
 import { useMatchingVersions } from '../hooks/useMatchingVersions'
 import type { BlockType } from '../modal-content/AddPackageFilterModalContent'
@@ -223,7 +223,11 @@ const VersionFilterSummary: React.FunctionComponent<VersionFilterSummaryProps> =
                                     <Link
                                         to={toRepoURL({
                                             repoName: node.repository.name,
-                                            revision: `v${version}`,
+                                            // NuGet versions are used as tags as they are.
+                                            revision:
+                                                blockState.ecosystem === PackageRepoReferenceKind.NUGETPACKAGES
+                                                    ? version
+                                                    : `v${version}`,
                                         })}
                                     >
                                         <Badge className="px-2 py-0" as="code">
@@ -21,6 +21,10 @@ export const ExternalServicePackageMap: Partial<
         label: 'JVM',
         value: PackageRepoReferenceKind.JVMPACKAGES,
     },
+    [ExternalServiceKind.NUGETPACKAGES]: {
+        label: 'NuGet',
+        value: PackageRepoReferenceKind.NUGETPACKAGES,
+    },
     [ExternalServiceKind.RUBYPACKAGES]: {
         label: 'Ruby',
         value: PackageRepoReferenceKind.RUBYPACKAGES,
@@ -56,6 +60,10 @@ export const PackageExternalServiceMap: Partial<
         label: 'JVM',
         value: ExternalServiceKind.JVMPACKAGES,
     },
+    [PackageRepoReferenceKind.NUGETPACKAGES]: {
+        label: 'NuGet',
+        value: ExternalServiceKind.NUGETPACKAGES,
+    },
     [PackageRepoReferenceKind.RUBYPACKAGES]: {
         label: 'Ruby',
         value: ExternalServiceKind.RUBYPACKAGES,
@@ -182,6 +182,11 @@ type DeleteBatchChangesCredentialArgs struct {
 	BatchChangesCredential graphql.ID
 }
 
+type CreateBatchStepDefinitionArgs struct {
+	Namespace  graphql.ID
+	Definition string
+}
+
 type ListBatchChangesCodeHostsArgs struct {
 	First  int32
 	After  *string
@@ -273,6 +278,7 @@ type BatchChangesResolver interface {
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
 	DeleteBatchChangesCredential(ctx context.Context, args *DeleteBatchChangesCredentialArgs) (*EmptyResponse, error)
+	CreateBatchStepDefinition(ctx context.Context, args *CreateBatchStepDefinitionArgs) (BatchStepDefinitionResolver, error)
 
 	CreateChangesetSpec(ctx context.Context, args *CreateChangesetSpecArgs) (ChangesetSpecResolver, error)
 	CreateChangesetSpecs(ctx context.Context, args *CreateChangesetSpecsArgs) ([]ChangesetSpecResolver, error)
@@ -573,6 +579,17 @@ type BatchChangesCredentialResolver interface {
 	GitHubApp(context.Context) (GitHubAppResolver, error)
 }
 
+type BatchStepDefinitionResolver interface {
+	ID() graphql.ID
+	Namespace(ctx context.Context) (NamespaceResolver, error)
+	Name() string
+	Version() string
+	Description() *string
+	Definition() string
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+}
+
 // Only GitHubApps are supported for commit signing for now.
 type CommitSigningConfigResolver interface {
 	ToGitHubApp() (GitHubAppResolver, bool)
@@ -822,6 +839,11 @@ type ExternalChangesetResolver interface {
 
 	Error() *string
 	SyncerError() *string
+	AutoMergeBlockedReason() *string
+	DependsOn(ctx context.Context) ([]ChangesetResolver, error)
+	Dependents(ctx context.Context) ([]ChangesetResolver, error)
+	BlockedByDependencies(ctx context.Context) (bool, error)
+	DependenciesBlockedReason(ctx context.Context) (*string, error)
 	ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
@@ -33,6 +33,7 @@ var externalServiceToPackageSchemeMap = map[string]string{
 	extsvc.KindPythonPackages: dependencies.PythonPackagesScheme,
 	extsvc.KindRustPackages:   dependencies.RustPackagesScheme,
 	extsvc.KindRubyPackages:   dependencies.RubyPackagesScheme,
+	extsvc.KindNuGetPackages:  dependencies.NuGetPackagesScheme,
 }
 
 var packageSchemeToExternalServiceMap = map[string]string{
@@ -42,6 +43,7 @@ var packageSchemeToExternalServiceMap = map[string]string{
 	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
 	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
 	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.NuGetPackagesScheme:  extsvc.KindNuGetPackages,
 }
 
 func (r *schemaResolver) PackageRepoReferences(ctx context.Context, args *PackageRepoReferenceConnectionArgs) (_ *packageRepoReferenceConnectionResolver, err error) {
@@ -232,6 +234,8 @@ func dependencyRepoToRepoName(dep dependencies.PackageRepoReference) (repoName a
 		repoName = reposource.ParsePythonPackageFromName(dep.Name).RepoName()
 	case "scip-ruby":
 		repoName = reposource.ParseRubyPackageFromName(dep.Name).RepoName()
+	case "scip-dotnet":
+		repoName = reposource.ParseNuGetPackageFromName(dep.Name).RepoName()
 	case "semanticdb":
 		pkg, err := reposource.ParseMavenPackageFromName(dep.Name)
 		if err != nil {
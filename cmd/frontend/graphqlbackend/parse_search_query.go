@@ -53,6 +53,10 @@ func (r *schemaResolver) ParseSearchQuery(ctx context.Context, args *args) (stri
 		searchType = query.SearchTypeLiteral
 	case "structural":
 		searchType = query.SearchTypeStructural
+	case "treesitter":
+		searchType = query.SearchTypeTreeSitter
+	case "fuzzy":
+		searchType = query.SearchTypeFuzzy
 	case "regexp", "regex":
 		searchType = query.SearchTypeRegex
 	default:
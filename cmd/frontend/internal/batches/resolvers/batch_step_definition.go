@@ -0,0 +1,75 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+const batchStepDefinitionIDKind = "BatchStepDefinition"
+
+func marshalBatchStepDefinitionID(id int64) graphql.ID {
+	return relay.MarshalID(batchStepDefinitionIDKind, id)
+}
+
+type batchStepDefinitionResolver struct {
+	store          *store.Store
+	stepDefinition *btypes.BatchStepDefinition
+}
+
+var _ graphqlbackend.BatchStepDefinitionResolver = &batchStepDefinitionResolver{}
+
+func (r *batchStepDefinitionResolver) ID() graphql.ID {
+	return marshalBatchStepDefinitionID(r.stepDefinition.ID)
+}
+
+func (r *batchStepDefinitionResolver) Namespace(ctx context.Context) (n graphqlbackend.NamespaceResolver, err error) {
+	if r.stepDefinition.NamespaceUserID != 0 {
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.stepDefinition.NamespaceUserID)
+	} else {
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.store.DatabaseDB(), r.stepDefinition.NamespaceOrgID)
+	}
+	return n, err
+}
+
+func (r *batchStepDefinitionResolver) Name() string {
+	return r.stepDefinition.Name
+}
+
+func (r *batchStepDefinitionResolver) Version() string {
+	return r.stepDefinition.Version
+}
+
+func (r *batchStepDefinitionResolver) Description() *string {
+	if r.stepDefinition.Definition == nil || r.stepDefinition.Definition.Description == "" {
+		return nil
+	}
+	return &r.stepDefinition.Definition.Description
+}
+
+func (r *batchStepDefinitionResolver) Definition() string {
+	return r.stepDefinition.RawDefinition
+}
+
+func (r *batchStepDefinitionResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.stepDefinition.CreatorID == 0 {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.stepDefinition.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchStepDefinitionResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.stepDefinition.CreatedAt}
+}
@@ -407,6 +407,78 @@ func (r *changesetResolver) Error() *string {
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
 
+func (r *changesetResolver) AutoMergeBlockedReason() *string {
+	return r.changeset.AutoMergeBlockedReason
+}
+
+func (r *changesetResolver) DependsOn(ctx context.Context) ([]graphqlbackend.ChangesetResolver, error) {
+	deps, err := r.store.ListChangesetDependencies(ctx, store.ListChangesetDependenciesOpts{
+		ChangesetIDs: []int64{r.changeset.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(deps))
+	for _, d := range deps {
+		ids = append(ids, d.DependsOnChangesetID)
+	}
+	return r.changesetsByID(ctx, ids)
+}
+
+func (r *changesetResolver) Dependents(ctx context.Context) ([]graphqlbackend.ChangesetResolver, error) {
+	deps, err := r.store.ListChangesetDependencies(ctx, store.ListChangesetDependenciesOpts{
+		DependsOnChangesetIDs: []int64{r.changeset.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(deps))
+	for _, d := range deps {
+		ids = append(ids, d.ChangesetID)
+	}
+	return r.changesetsByID(ctx, ids)
+}
+
+// changesetsByID returns resolvers for the changesets with the given IDs.
+func (r *changesetResolver) changesetsByID(ctx context.Context, ids []int64) ([]graphqlbackend.ChangesetResolver, error) {
+	if len(ids) == 0 {
+		return []graphqlbackend.ChangesetResolver{}, nil
+	}
+
+	changesets, _, err := r.store.ListChangesets(ctx, store.ListChangesetsOpts{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := r.store.Repos().GetReposSetByIDs(ctx, changesets.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetResolver, 0, len(changesets))
+	for _, c := range changesets {
+		resolvers = append(resolvers, NewChangesetResolver(r.store, r.gitserverClient, r.logger, c, reposByID[c.RepoID]))
+	}
+	return resolvers, nil
+}
+
+func (r *changesetResolver) BlockedByDependencies(ctx context.Context) (bool, error) {
+	reason, err := r.DependenciesBlockedReason(ctx)
+	return reason != nil, err
+}
+
+func (r *changesetResolver) DependenciesBlockedReason(ctx context.Context) (*string, error) {
+	state, err := r.store.GetChangesetDependencyState(ctx, r.changeset.ID)
+	if err != nil {
+		return nil, err
+	}
+	if reason := state.BlockedReason(); reason != "" {
+		return &reason, nil
+	}
+	return nil, nil
+}
+
 func (r *changesetResolver) ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error) {
 	// We need to find out how deep in the queue this changeset is.
 	place, err := r.store.GetChangesetPlaceInSchedulerQueue(ctx, r.changeset.ID)
@@ -89,3 +89,9 @@ func (e ErrMatchingBatchChangeExists) Error() string {
 func (e ErrMatchingBatchChangeExists) Extensions() map[string]any {
 	return map[string]any{"code": "ErrMatchingBatchChangeExists"}
 }
+
+type ErrBatchStepDefinitionExists struct{ error }
+
+func (e ErrBatchStepDefinitionExists) Extensions() map[string]any {
+	return map[string]any{"code": "ErrBatchStepDefinitionExists"}
+}
@@ -37,7 +37,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
-	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
@@ -219,18 +218,19 @@ func (r *Resolver) ResolveWorkspacesForBatchSpec(ctx context.Context, args *grap
 		return nil, err
 	}
 
-	// Parse the batch spec.
-	evaluatableSpec, err := batcheslib.ParseBatchSpec([]byte(args.BatchSpec))
-	if err != nil {
-		return nil, err
-	}
-
 	// Verify the user is authenticated.
 	act := sgactor.FromContext(ctx)
 	if !act.IsAuthenticated() {
 		return nil, auth.ErrNotAuthenticated
 	}
 
+	// Parse the batch spec, resolving the step definitions its steps use in
+	// the same way as when the batch spec is created.
+	evaluatableSpec, err := service.New(r.store).ParseBatchSpec(ctx, args.BatchSpec)
+	if err != nil {
+		return nil, err
+	}
+
 	// Run the resolution.
 	resolver := service.NewWorkspaceResolver(r.store)
 	workspaces, err := resolver.ResolveWorkspacesForBatchSpec(ctx, evaluatableSpec)
@@ -1174,6 +1174,40 @@ func (r *Resolver) DeleteBatchChangesCredential(ctx context.Context, args *graph
 	return r.deleteBatchChangesUserCredential(ctx, dbID)
 }
 
+func (r *Resolver) CreateBatchStepDefinition(ctx context.Context, args *graphqlbackend.CreateBatchStepDefinitionArgs) (_ graphqlbackend.BatchStepDefinitionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchStepDefinition",
+		attribute.String("namespace", string(args.Namespace)))
+	defer tr.EndWithErr(&err)
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	var uid, oid int32
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Namespace, &uid, &oid); err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	def, err := svc.CreateBatchStepDefinition(ctx, service.CreateBatchStepDefinitionOpts{
+		RawDefinition:   args.Definition,
+		NamespaceUserID: uid,
+		NamespaceOrgID:  oid,
+	})
+	if err != nil {
+		// Render pretty error.
+		if errors.HasType[store.ErrBatchStepDefinitionExists](err) {
+			return nil, ErrBatchStepDefinitionExists{err}
+		}
+		return nil, err
+	}
+
+	return &batchStepDefinitionResolver{store: r.store, stepDefinition: def}, nil
+}
+
 func (r *Resolver) deleteBatchChangesUserCredential(ctx context.Context, credentialDBID int64) (*graphqlbackend.EmptyResponse, error) {
 	// Get existing credential.
 	cred, err := r.store.UserCredentials().GetByID(ctx, credentialDBID)
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/batches/syncer"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -172,12 +173,23 @@ func (h webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	wasMerged := cs.ExternalState == btypes.ChangesetExternalStateMerged
 	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
-	return nil
+	// Changesets that depend on this one may be published now that it's been
+	// merged.
+	if !wasMerged && cs.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := tx.EnqueueChangesetDependents(ctx, cs.ID); err != nil {
+			return err
+		}
+	}
+
+	// The webhook may also have made the changeset satisfy the auto-merge
+	// policy of its batch change, so we don't wait for the next sync.
+	return syncer.ApplyAutoMergePolicy(ctx, tx, cs, now)
 }
 
 type httpError struct {
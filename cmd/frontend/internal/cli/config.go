@@ -31,6 +31,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/rebalance"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -458,10 +459,20 @@ func (c *configurationSource) Read(ctx context.Context) (conftypes.RawUnified, e
 		return conftypes.RawUnified{}, errors.Wrap(err, "ConfStore.SiteGetLatest")
 	}
 
+	sc := serviceConnections(c.logger)
+
+	// Repos keep being routed to their previous gitserver until they have
+	// been moved to the one they are assigned to now.
+	rebalanceState, err := rebalance.NewStore(c.db.Handle()).Get(ctx)
+	if err != nil {
+		return conftypes.RawUnified{}, errors.Wrap(err, "rebalance.Store.Get")
+	}
+	sc.GitServersRebalance = rebalanceState.ServiceConnection(sc.GitServers)
+
 	return conftypes.RawUnified{
 		ID:                 site.ID,
 		Site:               site.Contents,
-		ServiceConnections: serviceConnections(c.logger),
+		ServiceConnections: sc,
 	}, nil
 }
 
@@ -26,9 +26,10 @@ type displayFilter struct {
 func newDisplayFilter(args *args, maxResults int) *displayFilter {
 	// Display is the number of results we send down. If display is < 0 we
 	// want to send everything we find before hitting a limit. Otherwise we
-	// can only send up to limit results.
+	// can only send up to limit results. Exports contain everything we find,
+	// the display limit only exists to keep the UI responsive.
 	displayLimit := args.Display
-	if displayLimit < 0 || displayLimit > maxResults {
+	if displayLimit < 0 || displayLimit > maxResults || args.Export {
 		displayLimit = maxResults
 	}
 
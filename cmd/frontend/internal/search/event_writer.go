@@ -11,17 +11,32 @@ func newEventWriter(inner *streamhttp.Writer) *eventWriter {
 	return &eventWriter{inner: inner}
 }
 
+func newExportEventWriter(export *streamhttp.ExportWriter) *eventWriter {
+	return &eventWriter{export: export}
+}
+
 // eventWriter is a type that wraps a streamhttp.Writer with typed
 // methods for each of the supported evens in a frontend stream.
+//
+// If export is set instead of inner, matches are written as rows of an
+// export. Errors and alerts are exported as rows too, other events are
+// dropped.
 type eventWriter struct {
-	inner *streamhttp.Writer
+	inner  *streamhttp.Writer
+	export *streamhttp.ExportWriter
 }
 
 func (e *eventWriter) Done() error {
+	if e.export != nil {
+		return e.export.Flush()
+	}
 	return e.inner.Event("done", map[string]any{})
 }
 
 func (e *eventWriter) Progress(current api.Progress) error {
+	if e.export != nil {
+		return nil
+	}
 	return e.inner.Event("progress", current)
 }
 
@@ -30,6 +45,9 @@ func (e *eventWriter) MatchesJSON(data []byte) error {
 }
 
 func (e *eventWriter) Filters(fs []*streaming.Filter, exhaustive bool) error {
+	if e.export != nil {
+		return nil
+	}
 	if len(fs) > 0 {
 		buf := make([]streamhttp.EventFilter, 0, len(fs))
 		for _, f := range fs {
@@ -47,11 +65,25 @@ func (e *eventWriter) Filters(fs []*streaming.Filter, exhaustive bool) error {
 	return nil
 }
 
+func (e *eventWriter) Stats(stats streamhttp.EventStats) error {
+	if e.export != nil {
+		return nil
+	}
+	return e.inner.Event("stats", stats)
+}
+
 func (e *eventWriter) Error(err error) error {
+	if e.export != nil {
+		return e.export.WriteRow(streamhttp.ExportRow{Type: "error", Content: err.Error()})
+	}
 	return e.inner.Event("error", streamhttp.EventError{Message: err.Error()})
 }
 
 func (e *eventWriter) Alert(alert *search.Alert) error {
+	if e.export != nil {
+		return e.export.WriteRow(streamhttp.ExportRow{Type: "alert", Name: alert.Title, Content: alert.Description})
+	}
+
 	var pqs []streamhttp.QueryDescription
 	for _, pq := range alert.ProposedQueries {
 		annotations := make([]streamhttp.Annotation, 0, len(pq.Annotations))
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	searchhoney "github.com/sourcegraph/sourcegraph/internal/honey/search"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
@@ -85,19 +86,39 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defer tr.End()
 	r = r.WithContext(ctx)
 
-	streamWriter, err := streamhttp.NewWriter(w)
-	if err != nil {
-		tr.SetError(err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// Log events to trace
-	streamWriter.StatHook = eventStreamTraceHook(tr.AddEvent)
+	var eventWriter *eventWriter
+	if format := r.URL.Query().Get("format"); format != "" {
+		// Matches are downloaded in a machine-readable format instead of
+		// being streamed as events.
+		exportFormat, err := streamhttp.ParseExportFormat(format)
+		if err != nil {
+			tr.SetError(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		exportWriter, err := streamhttp.NewExportWriter(w, exportFormat)
+		if err != nil {
+			tr.SetError(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		tr.SetAttributes(attribute.String("export_format", string(exportFormat)))
+		eventWriter = newExportEventWriter(exportWriter)
+	} else {
+		streamWriter, err := streamhttp.NewWriter(w)
+		if err != nil {
+			tr.SetError(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// Log events to trace
+		streamWriter.StatHook = eventStreamTraceHook(tr.AddEvent)
 
-	eventWriter := newEventWriter(streamWriter)
+		eventWriter = newEventWriter(streamWriter)
+	}
 	defer eventWriter.Done()
 
-	err = h.serveHTTP(r, tr, eventWriter)
+	err := h.serveHTTP(r, tr, eventWriter)
 	if err != nil {
 		eventWriter.Error(err)
 		tr.SetError(err)
@@ -120,6 +141,7 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr trace.Trace, eventWriter *
 		attribute.String("pattern_type", args.PatternType),
 		attribute.Int("search_mode", args.SearchMode),
 		attribute.String("source", string(source)),
+		attribute.String("stats_mode", string(args.StatsMode)),
 	)
 
 	inputs, err := h.searchClient.Plan(
@@ -147,6 +169,13 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr trace.Trace, eventWriter *
 		inputs.Features.ZoektSearchOptionsOverride = args.ZoektSearchOptionsOverride
 	}
 
+	if args.StatsMode != "" {
+		// Stats count all matches instead of the ones up to the result limit
+		// we would stream.
+		inputs.Plan = statsPlan(inputs.Plan, args.StatsMode)
+		inputs.Query = inputs.Plan.ToQ()
+	}
+
 	// displayFilter limits the matches we stream to the user. Once we have
 	// hit a display limit the search will continue, but we no longer stream
 	// the actual matches.
@@ -169,6 +198,15 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr trace.Trace, eventWriter *
 		latency = &elapsed
 	}
 
+	// stats replaces streaming matches with group-by counts if the stats mode
+	// was requested.
+	var stats *statsAggregator
+	if args.StatsMode != "" {
+		if stats, err = newStatsAggregator(ctx, h.db, args); err != nil {
+			return err
+		}
+	}
+
 	// HACK: We awkwardly call an inline function here so that we can defer the
 	// cleanups. Defers are guaranteed to run even when unrolling a panic, so
 	// we can guarantee that the goroutines spawned by `newEventHandler` are
@@ -188,6 +226,7 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr trace.Trace, eventWriter *
 			h.flushTickerInterval,
 			h.pingTickerInterval,
 			displayFilter,
+			stats,
 			args.MaxLineLen,
 			args.EnableChunkMatches,
 			logLatency,
@@ -264,6 +303,18 @@ type args struct {
 	SearchMode                 int
 	ContextLines               *int32
 	ZoektSearchOptionsOverride string
+
+	// StatsMode is the aggregation mode matches are grouped by instead of
+	// being streamed. It is empty unless the stats URL parameter is set.
+	StatsMode types.SearchAggregationMode
+
+	// StatsPathDepth is the number of directories path stats are grouped
+	// by. Zero groups by the full path.
+	StatsPathDepth int
+
+	// Export is true if matches are downloaded in an export format (format
+	// URL parameter) instead of being streamed.
+	Export bool
 }
 
 func parseURLQuery(q url.Values) (*args, error) {
@@ -315,6 +366,22 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.Errorf("search mode must be integer, got %q: %w", searchMode, err)
 	}
 
+	if stats := q.Get("stats"); stats != "" {
+		if a.StatsMode, err = parseStatsMode(stats); err != nil {
+			return nil, err
+		}
+	}
+
+	statsPathDepth := get("stats-path-depth", "0")
+	if a.StatsPathDepth, err = strconv.Atoi(statsPathDepth); err != nil || a.StatsPathDepth < 0 {
+		return nil, errors.Errorf("stats path depth must be a non-negative integer, got %q", statsPathDepth)
+	}
+
+	a.Export = q.Get("format") != ""
+	if a.StatsMode != "" && a.Export {
+		return nil, errors.New("stats cannot be exported, remove the format parameter")
+	}
+
 	return &a, nil
 }
 
@@ -389,6 +456,7 @@ func newEventHandler(
 	flushInterval time.Duration,
 	progressInterval time.Duration,
 	displayFilter *displayFilter,
+	stats *statsAggregator,
 	maxLineLen int,
 	enableChunkMatches bool,
 	logLatency func(),
@@ -411,6 +479,7 @@ func newEventHandler(
 		progress:           progress,
 		progressInterval:   progressInterval,
 		displayFilter:      displayFilter,
+		stats:              stats,
 		maxLineLen:         maxLineLen,
 		enableChunkMatches: enableChunkMatches,
 		first:              true,
@@ -456,6 +525,9 @@ type eventHandler struct {
 
 	displayFilter *displayFilter
 	first         bool
+
+	// stats is non-nil if matches are aggregated instead of streamed.
+	stats *statsAggregator
 }
 
 func (h *eventHandler) Send(event streaming.SearchEvent) {
@@ -465,6 +537,11 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 	h.progress.Update(event)
 	h.filters.Update(event)
 
+	if h.stats != nil {
+		h.sendStats(event)
+		return
+	}
+
 	// We have computed internal stats, so now we can drop/limit matches if we
 	// hit display limits.
 	h.displayFilter.Limit(&event.Results)
@@ -491,6 +568,10 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 			ChunkMatches:         h.enableChunkMatches,
 			MaxContentLineLength: h.maxLineLen,
 		})
+		if h.eventWriter.export != nil {
+			h.eventWriter.export.Append(eventMatch)
+			continue
+		}
 		h.matchesBuf.Append(eventMatch)
 	}
 
@@ -498,11 +579,45 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 	if h.first && len(event.Results) > 0 {
 		h.first = false
 		h.eventWriter.Filters(h.filters.Compute(), false)
-		h.matchesBuf.Flush()
+		h.flushMatches()
 		h.logLatency()
 	}
 }
 
+// flushMatches sends the buffered matches to the client.
+func (h *eventHandler) flushMatches() {
+	if h.eventWriter.export != nil {
+		h.eventWriter.export.Flush()
+		return
+	}
+	h.matchesBuf.Flush()
+}
+
+// sendStats counts the matches in event the actor has access to.
+func (h *eventHandler) sendStats(event streaming.SearchEvent) {
+	repoMetadata, err := getEventRepoMetadata(h.ctx, h.db, event)
+	if err != nil {
+		if !errors.IsContextCanceled(err) {
+			h.logger.Error("failed to get repo metadata", log.Error(err))
+		}
+		return
+	}
+
+	// Same as for streamed matches, we don't count matches which we cannot
+	// map to a repo the actor has access to.
+	visible := make(result.Matches, 0, len(event.Results))
+	for _, match := range event.Results {
+		repo := match.RepoName()
+		if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
+			continue
+		}
+		visible = append(visible, match)
+	}
+	event.Results = visible
+
+	h.stats.Send(event)
+}
+
 // Done cleans up any background tasks and flushes any buffered data to the stream
 func (h *eventHandler) Done() {
 	h.mu.Lock()
@@ -520,7 +635,15 @@ func (h *eventHandler) Done() {
 		!h.progress.Stats.Status.Any(search.RepoStatusLimitHit) &&
 		!h.progress.Stats.Status.Any(search.RepoStatusTimedOut)
 	h.eventWriter.Filters(h.filters.Compute(), exhaustive)
-	h.matchesBuf.Flush()
+	h.flushMatches()
+	if h.stats != nil {
+		if h.stats.errs != nil {
+			h.logger.Warn("failed to count matches for stats", log.Error(h.stats.errs))
+		}
+		if err := h.eventWriter.Stats(h.stats.Compute()); err != nil {
+			h.logger.Error("failed to write stats", log.Error(err))
+		}
+	}
 	h.eventWriter.Progress(h.progress.Final())
 }
 
@@ -546,7 +669,7 @@ func (h *eventHandler) flushTick() {
 		if h.filters.Dirty {
 			h.eventWriter.Filters(h.filters.Compute(), false)
 		}
-		h.matchesBuf.Flush()
+		h.flushMatches()
 		if h.progress.Dirty {
 			h.eventWriter.Progress(h.progress.Current())
 		}
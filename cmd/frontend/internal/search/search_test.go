@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 
 	api2 "github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	insightstypes "github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
@@ -165,6 +167,208 @@ func TestServeStream_chunkMatches(t *testing.T) {
 	require.Len(t, chunkMatches[0].Ranges, 1)
 }
 
+func TestServeStream_stats(t *testing.T) {
+	settings.MockCurrentUserFinal = &schema.Settings{}
+	t.Cleanup(func() { settings.MockCurrentUserFinal = nil })
+
+	fileMatch := func(repoID int, path string) *result.FileMatch {
+		repo := mkRepoMatch(repoID)
+		return &result.FileMatch{
+			File: result.File{
+				Repo: types.MinimalRepo{ID: repo.ID, Name: repo.Name},
+				Path: path,
+			},
+		}
+	}
+
+	plan := query.Plan{{Parameters: []query.Parameter{{Field: "count", Value: "1000"}}}}
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultReturn(&search.Inputs{Plan: plan, Query: plan.ToQ()}, nil)
+	mock.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, inputs *search.Inputs) (*search.Alert, error) {
+		// Stats count all matches, not only the ones up to the result limit.
+		if got := inputs.MaxResults(); got != query.CountAllLimit {
+			t.Errorf("expected the result limit to be removed, got %d", got)
+		}
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{
+				fileMatch(1, "cmd/main.go"),
+				fileMatch(1, "internal/server.go"),
+				fileMatch(2, "client/index.ts"),
+				// The actor cannot see repo3, so this match is not counted.
+				fileMatch(3, "main.go"),
+			},
+		})
+		return nil, nil
+	})
+
+	mockRepos := dbmocks.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api2.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			if id == 3 {
+				continue
+			}
+			out = append(out, &types.SearchedRepo{ID: id, Name: mkRepoMatch(int(id)).Name})
+		}
+		return out, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	ts := httptest.NewServer(gzipMiddleware(&streamHandler{
+		logger:              logtest.Scoped(t),
+		db:                  db,
+		flushTickerInterval: 1 * time.Millisecond,
+		pingTickerInterval:  1 * time.Millisecond,
+		searchClient:        mock,
+	}))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "?q=test&stats=language")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var (
+		matches []streamhttp.EventMatch
+		stats   *streamhttp.EventStats
+	)
+	decoder := streamhttp.FrontendStreamDecoder{
+		OnMatches: func(ev []streamhttp.EventMatch) {
+			matches = append(matches, ev...)
+		},
+		OnStats: func(ev *streamhttp.EventStats) {
+			stats = ev
+		},
+	}
+	err = decoder.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", res.StatusCode)
+	}
+	require.Empty(t, matches)
+	require.Equal(t, &streamhttp.EventStats{
+		Mode: "language",
+		Groups: []streamhttp.EventStatsGroup{
+			{Label: "Go", Count: 2},
+			{Label: "TypeScript", Count: 1},
+		},
+	}, stats)
+}
+
+func TestServeStream_export(t *testing.T) {
+	settings.MockCurrentUserFinal = &schema.Settings{}
+	t.Cleanup(func() { settings.MockCurrentUserFinal = nil })
+
+	repo := mkRepoMatch(1)
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultReturn(&search.Inputs{Query: query.Q{query.Parameter{Field: "count", Value: "1000"}}}, nil)
+	mock.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{
+				&result.FileMatch{
+					File: result.File{
+						Repo:     types.MinimalRepo{ID: repo.ID, Name: repo.Name},
+						Path:     "main.go",
+						CommitID: "deadbeef",
+					},
+					ChunkMatches: result.ChunkMatches{{
+						Content:      "func main() {",
+						ContentStart: result.Location{Line: 2},
+						Ranges: result.Ranges{{
+							Start: result.Location{Offset: 5, Line: 2, Column: 5},
+							End:   result.Location{Offset: 9, Line: 2, Column: 9},
+						}},
+					}},
+				},
+				&result.FileMatch{
+					File: result.File{
+						Repo: types.MinimalRepo{ID: repo.ID, Name: repo.Name},
+						Path: "README.md",
+					},
+				},
+			},
+		})
+		return nil, nil
+	})
+
+	mockRepos := dbmocks.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api2.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, &types.SearchedRepo{ID: id, Name: mkRepoMatch(int(id)).Name})
+		}
+		return out, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	ts := httptest.NewServer(gzipMiddleware(&streamHandler{
+		logger:              logtest.Scoped(t),
+		db:                  db,
+		flushTickerInterval: 1 * time.Millisecond,
+		pingTickerInterval:  1 * time.Millisecond,
+		searchClient:        mock,
+	}))
+	defer ts.Close()
+
+	// Exports are not truncated to the display limit.
+	res, err := http.Get(ts.URL + "?q=test&cm=t&display=1&format=csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "text/csv; charset=utf-8", res.Header.Get("Content-Type"))
+	require.Equal(t, "type,repository,commit,path,line,name,kind,author,date,url,content\n"+
+		"content,repo1,deadbeef,main.go,3,,,,,,func main() {\n"+
+		"path,repo1,,README.md,,,,,,,\n", string(b))
+
+	res, err = http.Get(ts.URL + "?q=test&format=xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestParseURLQuery_stats(t *testing.T) {
+	a, err := parseURLQuery(url.Values{"q": {"test"}, "stats": {"path"}, "stats-path-depth": {"2"}})
+	require.NoError(t, err)
+	require.Equal(t, insightstypes.PATH_AGGREGATION_MODE, a.StatsMode)
+	require.Equal(t, 2, a.StatsPathDepth)
+
+	_, err = parseURLQuery(url.Values{"q": {"test"}, "stats": {"unknown"}})
+	require.Error(t, err)
+
+	_, err = parseURLQuery(url.Values{"q": {"test"}, "stats": {"path"}, "stats-path-depth": {"-1"}})
+	require.Error(t, err)
+
+	_, err = parseURLQuery(url.Values{"q": {"test"}, "stats": {"path"}, "format": {"csv"}})
+	require.Error(t, err)
+}
+
+func TestStatsPlan(t *testing.T) {
+	test := func(input string, mode insightstypes.SearchAggregationMode) string {
+		plan, err := query.Pipeline(query.Init(input, query.SearchTypeStandard))
+		require.NoError(t, err)
+		return statsPlan(plan, mode).ToQ().String()
+	}
+
+	require.Equal(t, `(and "repo:foo" "count:99999999" "test")`, test("repo:foo count:10 test", insightstypes.LANGUAGE_AGGREGATION_MODE))
+	require.Equal(t, `(and "repo:foo" "count:99999999" "select:file.owners" "test")`, test("repo:foo test", insightstypes.OWNER_AGGREGATION_MODE))
+	require.Equal(t, `(and "repo:foo" "select:repo" "count:99999999" "test")`, test("repo:foo select:repo test", insightstypes.OWNER_AGGREGATION_MODE))
+}
+
 func TestDisplayLimit(t *testing.T) {
 	cases := []struct {
 		queryString         string
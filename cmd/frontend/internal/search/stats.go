@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/insights/aggregation"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// defaultStatsBufferSize is the number of groups we keep in memory when
+// insights.aggregations.bufferSize is not set.
+const defaultStatsBufferSize = 500
+
+// statsModes are the values accepted by the ?stats URL parameter.
+var statsModes = []types.SearchAggregationMode{
+	types.REPO_AGGREGATION_MODE,
+	types.PATH_AGGREGATION_MODE,
+	types.LANGUAGE_AGGREGATION_MODE,
+	types.AUTHOR_AGGREGATION_MODE,
+	types.OWNER_AGGREGATION_MODE,
+	types.CAPTURE_GROUP_AGGREGATION_MODE,
+	types.REPO_METADATA_AGGREGATION_MODE,
+}
+
+func parseStatsMode(s string) (types.SearchAggregationMode, error) {
+	for _, mode := range statsModes {
+		if strings.EqualFold(s, string(mode)) {
+			return mode, nil
+		}
+	}
+	return "", errors.Errorf("unsupported stats mode %q", s)
+}
+
+// statsAggregator groups matches instead of sending them to the user (?stats
+// URL parameter). It uses the same count functions as search aggregations, so
+// a group counts the same results in both places.
+//
+// statsAggregator is not thread safe, the eventHandler mutex protects it.
+type statsAggregator struct {
+	mode       types.SearchAggregationMode
+	sender     aggregation.SearchResultsAggregator
+	aggregator aggregation.LimitedAggregator
+
+	// errs are the errors reported while counting matches.
+	errs error
+}
+
+// statsPlan returns plan with the result limit of each query removed, so that
+// stats count all matches.
+//
+// Owners are only known for owner matches, so owner stats select the owners
+// of the files a query matches. Queries that already select something else
+// are left as they are and only count the owner matches they return, if any.
+func statsPlan(plan query.Plan, mode types.SearchAggregationMode) query.Plan {
+	mapped := make(query.Plan, 0, len(plan))
+	for _, b := range plan {
+		b = b.MapCountAll()
+		if mode == types.OWNER_AGGREGATION_MODE && !b.Exists(query.FieldSelect) {
+			b = b.MapParameters(append(b.Parameters, query.Parameter{Field: query.FieldSelect, Value: "file.owners"}))
+		}
+		mapped = append(mapped, b)
+	}
+	return mapped
+}
+
+func newStatsAggregator(ctx context.Context, db database.DB, args *args) (*statsAggregator, error) {
+	countFunc, err := aggregation.GetCountFuncForMode(args.Query, args.PatternType, args.StatsMode)
+	if err != nil {
+		return nil, err
+	}
+	if args.StatsMode == types.PATH_AGGREGATION_MODE && args.StatsPathDepth > 0 {
+		countFunc = aggregation.PathPrefixCountFunc(args.StatsPathDepth)
+	}
+
+	bufferSize := conf.Get().InsightsAggregationsBufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultStatsBufferSize
+	}
+
+	s := &statsAggregator{
+		mode:       args.StatsMode,
+		aggregator: aggregation.NewLimitedAggregator(bufferSize),
+	}
+	tabulator := func(amr *aggregation.AggregationMatchResult, err error) {
+		if err != nil {
+			s.errs = errors.Append(s.errs, err)
+			return
+		}
+		s.aggregator.Add(amr.Key.Group, int32(amr.Count))
+	}
+	s.sender = aggregation.NewSearchResultsAggregatorWithContext(ctx, tabulator, countFunc, db, args.StatsMode)
+	return s, nil
+}
+
+func (s *statsAggregator) Send(event streaming.SearchEvent) {
+	s.sender.Send(event)
+}
+
+// Compute returns the stats event for the matches sent so far.
+func (s *statsAggregator) Compute() streamhttp.EventStats {
+	aggregates := s.aggregator.SortAggregate()
+	groups := make([]streamhttp.EventStatsGroup, 0, len(aggregates))
+	for _, a := range aggregates {
+		groups = append(groups, streamhttp.EventStatsGroup{
+			Label: a.Label,
+			Count: int(a.Count),
+		})
+	}
+	other := s.aggregator.OtherCounts()
+	return streamhttp.EventStats{
+		Mode:             strings.ToLower(string(s.mode)),
+		Groups:           groups,
+		OtherResultCount: int(other.ResultCount),
+		OtherGroupCount:  int(other.GroupCount),
+	}
+}
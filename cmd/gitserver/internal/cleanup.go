@@ -22,6 +22,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git/gitcli"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/lfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/objectpool"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -146,6 +148,15 @@ var sgmRetries, _ = strconv.Atoi(env.Get("SRC_SGM_RETRIES", "3", "the maximum nu
 // Controls if gitserver cleanup tries to remove repos from disk which are not defined in the DB. Defaults to false.
 var removeNonExistingRepos, _ = strconv.ParseBool(env.Get("SRC_REMOVE_NON_EXISTING_REPOS", "false", "controls if gitserver cleanup tries to remove repos from disk which are not defined in the DB"))
 
+// Controls if gitserver cleanup links forks to an object pool shared with the
+// other members of their fork family. Existing pools are maintained regardless
+// of this setting, since their members depend on them.
+var enableObjectPools, _ = strconv.ParseBool(env.Get("SRC_ENABLE_GIT_OBJECT_POOLS", "false", "controls if gitserver cleanup deduplicates the objects of forks using shared object pools"))
+
+// lfsObjectMaxAge is how long a Git LFS object stays in the object cache of a
+// repository after it was last read.
+var lfsObjectMaxAge = env.MustGetDuration("SRC_GIT_LFS_OBJECT_MAX_AGE", 7*24*time.Hour, "the duration after which Git LFS objects which haven't been read are removed from the object cache of a repository")
+
 var (
 	reposRemoved = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "src_gitserver_repos_removed",
@@ -181,6 +192,10 @@ var (
 		Name: "src_gitserver_non_existing_repos_removed",
 		Help: "number of non existing repos removed during cleanup",
 	})
+	objectPoolsRemoved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_object_pools_removed",
+		Help: "number of object pools without members removed during cleanup",
+	})
 )
 
 // cleanupRepos walks the repos directory and performs maintenance tasks:
@@ -196,6 +211,7 @@ var (
 // 9. Perform sg-maintenance
 // 10. Git prune
 // 11. Set sizes of repos
+// 12. Link forks to object pools and garbage collect the pools
 func cleanupRepos(
 	ctx context.Context,
 	logger log.Logger,
@@ -253,6 +269,12 @@ func cleanupRepos(
 			return false, nil
 		}
 
+		// While gitserver shards are being rebalanced, repos are copied to
+		// their new shard before they are routed there. Keep them around.
+		if hostnameMatch(shardID, gitServerAddrs.TargetAddrForRepo(ctx, repoName)) {
+			return false, nil
+		}
+
 		wrongShardRepoCount++
 
 		// If we're on a shard not currently known, basically every repo would
@@ -365,6 +387,14 @@ func cleanupRepos(
 		return false, git.SetGitAttributes(dir)
 	}
 
+	removeStaleLFSObjects := func(backend git.GitBackend, repoName api.RepoName, dir common.GitDir) (done bool, err error) {
+		removed, err := lfs.RemoveStaleObjects(dir, lfsObjectMaxAge)
+		if removed > 0 {
+			logger.Debug("removed stale LFS objects", log.String("repo", string(repoName)), log.Int("count", removed))
+		}
+		return false, err
+	}
+
 	ensureAutoGC := func(backend git.GitBackend, repoName api.RepoName, dir common.GitDir) (done bool, err error) {
 		return false, gitSetAutoGC(ctx, backend.Config())
 	}
@@ -393,14 +423,15 @@ func cleanupRepos(
 			return false, nil
 		}
 
-		// We believe converting a Perforce depot to a Git repository is generally a
-		// very expensive operation, therefore we do not try to re-clone/redo the
-		// conversion only because it is old or slow to do "git gc".
+		// We believe converting a Perforce depot or a Subversion repository to a
+		// Git repository is generally a very expensive operation, therefore we do
+		// not try to re-clone/redo the conversion only because it is old or slow
+		// to do "git gc".
 		repoType, err := git.GetRepositoryType(ctx, backend.Config())
 		if err != nil {
 			return false, err
 		}
-		if repoType == "perforce" {
+		if repoType == "perforce" || repoType == "subversion" {
 			return false, nil
 		}
 
@@ -512,6 +543,68 @@ func cleanupRepos(
 		return false, pruneIfNeeded(rcf, repoName, dir, looseObjectsLimit)
 	}
 
+	// poolMembers records the members of each object pool we come across while
+	// walking the repos, so that we never remove a pool which is still in use.
+	poolMembers := make(map[common.GitDir]map[api.RepoName]struct{})
+	addPoolMember := func(pool common.GitDir, repoName api.RepoName) {
+		if poolMembers[pool] == nil {
+			poolMembers[pool] = make(map[api.RepoName]struct{})
+		}
+		poolMembers[pool][repoName] = struct{}{}
+	}
+
+	maintainObjectPool := func(backend git.GitBackend, repoName api.RepoName, dir common.GitDir) (done bool, err error) {
+		pool, err := objectpool.LinkedPool(dir)
+		if err != nil {
+			return false, errors.Wrap(err, "reading linked object pool")
+		}
+		if pool != "" {
+			addPoolMember(pool, repoName)
+			// Keep everything the member references reachable in the pool.
+			return false, objectpool.Sync(ctx, rcf, repoName, dir, pool)
+		}
+
+		if !enableObjectPools {
+			return false, nil
+		}
+
+		repo, err := db.Repos().GetByName(ctx, repoName)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		poolName, fork := objectpool.Name(repo)
+		if poolName == "" {
+			return false, nil
+		}
+		if partial, err := objectpool.IsPartialClone(dir); err != nil || partial {
+			return false, err
+		}
+		pool = fs.PoolDir(poolName)
+		// Pools are created for forks. The repository they were forked from
+		// only joins a pool which already exists.
+		if !fork {
+			if _, err := os.Stat(pool.Path("HEAD")); os.IsNotExist(err) {
+				return false, nil
+			}
+		}
+
+		err, unlock := lockRepoForGC(dir)
+		if err != nil {
+			logger.Debug("could not lock repository for linking object pool", log.String("repo", string(repoName)), log.Error(err))
+			return false, nil
+		}
+		defer func() { _ = unlock() }()
+
+		addPoolMember(pool, repoName)
+		if err := objectpool.Link(ctx, rcf, repoName, dir, pool); err != nil {
+			return false, errors.Wrapf(err, "linking object pool %s", poolName)
+		}
+		return false, nil
+	}
+
 	type cleanupFn struct {
 		Name string
 		Do   func(git.GitBackend, api.RepoName, common.GitDir) (bool, error)
@@ -530,6 +623,9 @@ func cleanupRepos(
 		{"remove stale locks", removeStaleLocks},
 		// We always want to have the same git attributes file at info/attributes.
 		{"ensure git attributes", ensureGitAttributes},
+		// Git LFS objects are cached in the repository when pointers are
+		// resolved. Remove the ones that haven't been read in a while.
+		{"remove stale LFS objects", removeStaleLFSObjects},
 		// Enable or disable background garbage collection depending on
 		// gitGCMode. The purpose is to avoid repository corruption which can
 		// happen if several git-gc operations are running at the same time.
@@ -566,6 +662,11 @@ func cleanupRepos(
 		})
 	}
 
+	// Move objects shared with other members of a fork family into their
+	// object pool. This runs after GC so that freshly fetched objects are
+	// already packed.
+	cleanups = append(cleanups, cleanupFn{"maintain object pool", maintainObjectPool})
+
 	// Compute the amount of space used by the repo. We do this last, because
 	// we want it to reflect the improvements that previous GC methods had.
 	cleanups = append(cleanups, cleanupFn{"compute stats", collectSize})
@@ -610,6 +711,12 @@ func cleanupRepos(
 		logger.Error("error iterating over repositories", log.Error(err))
 	}
 
+	// Pools are only cleaned up after a complete walk over the repos, since we
+	// must know all of their members.
+	if err == nil && ctx.Err() == nil {
+		cleanupObjectPools(ctx, logger, fs, rcf, poolMembers)
+	}
+
 	if len(repoToSize) > 0 {
 		_, err := db.GitserverRepos().UpdateRepoSizes(ctx, logger, shardID, repoToSize)
 		if err != nil {
@@ -620,6 +727,63 @@ func cleanupRepos(
 	logger.Info("Janitor run finished", log.String("duration", time.Since(start).String()))
 }
 
+// cleanupObjectPools garbage collects the object pools on disk and removes the
+// ones without members. members holds the members of each pool found while
+// walking the repos.
+func cleanupObjectPools(ctx context.Context, logger log.Logger, fs gitserverfs.FS, rcf *wrexec.RecordingCommandFactory, members map[common.GitDir]map[api.RepoName]struct{}) {
+	err := fs.ForEachPool(func(poolName api.RepoName, pool common.GitDir) (done bool) {
+		if ctx.Err() != nil {
+			return true
+		}
+		logger := logger.With(log.String("pool", string(poolName)))
+
+		live := members[pool]
+		if live == nil {
+			live = make(map[api.RepoName]struct{})
+		}
+		// The walk over the repos skips directories it fails to read, so we
+		// double check the members recorded in the pool itself before
+		// dropping them.
+		recorded, err := objectpool.Members(ctx, rcf, poolName, pool)
+		if err != nil {
+			logger.Error("failed to list object pool members", log.Error(err))
+			return false
+		}
+		for _, m := range recorded {
+			if _, ok := live[m]; ok {
+				continue
+			}
+			linked, err := objectpool.LinkedPool(fs.RepoDir(m))
+			if err != nil || linked == pool {
+				live[m] = struct{}{}
+			}
+		}
+
+		start := time.Now()
+		if len(live) == 0 {
+			logger.Info("removing object pool without members")
+			err = fs.RemovePool(poolName)
+			if err == nil {
+				objectPoolsRemoved.Inc()
+			}
+		} else {
+			names := make([]api.RepoName, 0, len(live))
+			for m := range live {
+				names = append(names, m)
+			}
+			err = objectpool.Maintain(ctx, rcf, poolName, pool, names)
+		}
+		if err != nil {
+			logger.Error("error cleaning up object pool", log.Error(err))
+		}
+		jobTimer.WithLabelValues(strconv.FormatBool(err == nil), "cleanup object pool").Observe(time.Since(start).Seconds())
+		return false
+	})
+	if err != nil {
+		logger.Error("error iterating over object pools", log.Error(err))
+	}
+}
+
 func checkRepoDirCorrupt(rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir) (corrupt, shouldLog bool, description string, err error) {
 	// We treat repositories missing HEAD to be corrupt. Both our cloning
 	// and fetching ensure there is a HEAD file.
@@ -878,13 +1042,20 @@ func pruneIfNeeded(rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir c
 
 func needsMaintenance(dir common.GitDir) (bool, string, error) {
 	// Bitmaps store reachability information about the set of objects in a
-	// packfile which speeds up clone and fetch operations.
-	hasBm, err := hasBitmap(dir)
+	// packfile which speeds up clone and fetch operations. Git doesn't write
+	// bitmaps for repositories borrowing objects from an object pool.
+	pool, err := objectpool.LinkedPool(dir)
 	if err != nil {
 		return false, "", err
 	}
-	if !hasBm {
-		return true, "bitmap", nil
+	if pool == "" {
+		hasBm, err := hasBitmap(dir)
+		if err != nil {
+			return false, "", err
+		}
+		if !hasBm {
+			return true, "bitmap", nil
+		}
 	}
 
 	// The commit-graph file is a supplemental data structure that accelerates
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git/gitcli"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/objectpool"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -980,6 +981,44 @@ git commit-graph write --reachable --changed-paths
 	}
 }
 
+func TestCleanupObjectPools(t *testing.T) {
+	ctx := context.Background()
+	rcf := wrexec.NewNoOpRecordingCommandFactory()
+	root := t.TempDir()
+	fs := gitserverfs.New(observation.TestContextTB(t), filepath.Join(root, "repos"))
+
+	upstream := filepath.Join(root, "upstream")
+	runCmd(t, root, "git", "init", upstream)
+	runCmd(t, upstream, "git", "commit", "--allow-empty", "-m", "initial")
+
+	link := func(repo, pool api.RepoName) {
+		dir := fs.RepoDir(repo)
+		runCmd(t, root, "git", "clone", "--bare", "--no-local", upstream, dir.Path())
+		require.NoError(t, objectpool.Link(ctx, rcf, repo, dir, fs.PoolDir(pool)))
+	}
+	link("fork-1", "pool")
+	link("fork-2", "pool")
+	link("fork-3", "orphaned-pool")
+	require.NoError(t, fs.RemoveRepo("fork-3"))
+
+	// fork-2 was not seen by the walk over the repos, but it still links to
+	// the pool.
+	cleanupObjectPools(ctx, logtest.Scoped(t), fs, rcf, map[common.GitDir]map[api.RepoName]struct{}{
+		fs.PoolDir("pool"): {"fork-1": {}},
+	})
+
+	var pools []api.RepoName
+	require.NoError(t, fs.ForEachPool(func(name api.RepoName, _ common.GitDir) bool {
+		pools = append(pools, name)
+		return false
+	}))
+	require.Equal(t, []api.RepoName{"pool"}, pools)
+
+	members, err := objectpool.Members(ctx, rcf, "pool", fs.PoolDir("pool"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []api.RepoName{"fork-1", "fork-2"}, members)
+}
+
 func TestPruneIfNeeded(t *testing.T) {
 	reposDir := t.TempDir()
 	gitDir := prepareEmptyGitRepo(t, reposDir)
@@ -0,0 +1,189 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/shardcopy"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	repoCopiedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repo_copied_from_shard",
+		Help: "number of repos successfully copied from another gitserver shard",
+	})
+	repoCopyFailedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repo_copied_from_shard_failed",
+		Help: "number of failed copies of repos from another gitserver shard",
+	})
+)
+
+// CopyRepositoryFromShard copies repo from the gitserver shard source talks
+// to, which keeps serving the repo in the meantime. This is how repos are
+// moved when gitserver shards are rebalanced, so that they don't have to be
+// cloned from the code host again. Nothing is done if repo is already cloned.
+func (s *Server) CopyRepositoryFromShard(ctx context.Context, repo api.RepoName, source proto.GitserverRepositoryServiceClient) (err error) {
+	lock, ok := s.locker.TryAcquire(repo, "copying from another shard")
+	if !ok {
+		return ErrFetchInProgress
+	}
+	defer lock.Release()
+
+	// Copies share the clone limit, as they are as expensive as clones for
+	// this shard.
+	pendingClones.Inc()
+	_, cancelCloneLimiter, err := s.cloneLimiter.Acquire(ctx)
+	pendingClones.Dec()
+	if err != nil {
+		return err
+	}
+	defer cancelCloneLimiter()
+
+	cloned, err := s.fs.RepoCloned(repo)
+	if err != nil {
+		return errors.Wrap(err, "determining cloned status")
+	}
+	if cloned {
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			repoCopyFailedCounter.Inc()
+		} else {
+			repoCopiedCounter.Inc()
+		}
+	}()
+
+	logger := s.logger.Scoped("copyRepositoryFromShard").With(log.String("repo", string(repo)))
+
+	// We may be copying a private repo so we need an internal actor.
+	ctx = actor.WithInternalActor(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
+	defer cancel()
+
+	syncer, err := s.getVCSSyncer(ctx, repo)
+	if err != nil {
+		return errors.Wrap(err, "get VCS syncer")
+	}
+
+	// Like clones, the copy is assembled in a temporary location first so
+	// that we never have incomplete repos in the repo tree.
+	tmpDir, err := s.fs.TempDir("copy-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	tmpPath := filepath.Join(tmpDir, ".git")
+	bundlePath := filepath.Join(tmpDir, "repo.bundle")
+
+	lock.SetStatus("receiving repository from other shard")
+	headRef, err := receiveBundle(ctx, source, repo, bundlePath)
+	if err != nil {
+		return errors.Wrap(err, "receiving bundle")
+	}
+
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return err
+	}
+	if err := git.MakeBareRepo(ctx, tmpPath); err != nil {
+		return err
+	}
+	lock.SetStatus("unpacking repository received from other shard")
+	if err := shardcopy.Import(ctx, s.recordingCommandFactory, repo, common.GitDir(tmpPath), bundlePath, headRef); err != nil {
+		return err
+	}
+
+	// Copies of partial clones fetch the objects their filter omitted from
+	// the promisor remote, whose URL isn't copied.
+	if status, err := partialclone.ReadStatus(common.GitDir(tmpPath)); err != nil {
+		return errors.Wrap(err, "reading partial clone status")
+	} else if status != nil {
+		remoteURL, err := s.getRemoteURL(ctx, repo)
+		if err != nil {
+			return errors.Wrap(err, "getting remote URL")
+		}
+		if err := partialclone.WriteRemoteConfig(ctx, s.recordingCommandFactory, repo, common.GitDir(tmpPath), remoteURL); err != nil {
+			return errors.Wrap(err, "configuring promisor remote")
+		}
+	}
+
+	if err := postRepoFetchActions(ctx, logger, s.fs, s.db, s.gitBackendSource(common.GitDir(tmpPath), repo), s.hostname, repo, common.GitDir(tmpPath), syncer); err != nil {
+		return err
+	}
+
+	dstPath := string(s.fs.RepoDir(repo))
+	if err := os.MkdirAll(filepath.Dir(dstPath), os.ModePerm); err != nil {
+		return err
+	}
+	if err := fileutil.RenameAndSync(tmpPath, dstPath); err != nil {
+		return err
+	}
+
+	if err := s.db.GitserverRepos().SetCloneStatus(ctx, repo, types.CloneStatusCloned, s.hostname); err != nil {
+		logger.Error("Setting clone status in DB", log.Error(err))
+	}
+
+	logger.Info("copied repo from other shard")
+
+	return nil
+}
+
+// receiveBundle writes the bundle streamed by ExportRepository to path and
+// returns the ref HEAD of the exported repository points to.
+func receiveBundle(ctx context.Context, source proto.GitserverRepositoryServiceClient, repo api.RepoName, path string) (headRef string, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := source.ExportRepository(ctx, &proto.ExportRepositoryRequest{RepoName: string(repo)})
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			if status.Code(err) == codes.NotFound {
+				return "", &gitdomain.RepoNotExistError{Repo: repo}
+			}
+			return "", err
+		}
+		if ref := msg.GetHeadRef(); ref != "" {
+			headRef = ref
+		}
+		if _, err := f.Write(msg.GetData()); err != nil {
+			return "", err
+		}
+	}
+
+	return headRef, f.Close()
+}
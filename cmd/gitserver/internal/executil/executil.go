@@ -203,6 +203,29 @@ func configureRemoteGitCommand(cmd *exec.Cmd, remoteURL *vcs.URL, tlsConf *tlsCo
 	cmd.Args = append(cmd.Args[:1], append(extraArgs, cmd.Args[1:]...)...)
 }
 
+// PersistentRemoteGitConfig returns the git config that configures commands
+// talking to remotes the way ConfigureRemoteGitCommand does, apart from
+// credentials. It is meant for the config of repositories whose remotes git
+// talks to on its own, like the promisor remotes of partial clones. Keys
+// mapped to an empty value should be unset.
+func PersistentRemoteGitConfig() map[string]string {
+	tlsConf := tlsExternal()
+	config := map[string]string{
+		"core.askPass":     "true",
+		"core.sshCommand":  "ssh -o BatchMode=yes -o ConnectTimeout=30",
+		"http.userAgent":   "git/Sourcegraph-Bot",
+		"protocol.version": "2",
+		"http.sslVerify":   "",
+		// The certificates are written to a file that only lives as long as
+		// the process, so the config has to be rewritten after restarts.
+		"http.sslCAInfo": tlsConf.SSLCAInfo,
+	}
+	if tlsConf.SSLNoVerify {
+		config["http.sslVerify"] = "false"
+	}
+	return config
+}
+
 // removeUnsupportedP4Args removes all -c arguments as `p4-fusion` command doesn't
 // support -c argument and passing this causes warning logs.
 func removeUnsupportedP4Args(args []string) []string {
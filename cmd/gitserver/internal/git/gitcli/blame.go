@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -19,14 +20,38 @@ func (g *gitCLIBackend) Blame(ctx context.Context, startCommit api.CommitID, pat
 	if err := checkSpecArgSafety(string(startCommit)); err != nil {
 		return nil, err
 	}
+	if err := checkSpecArgSafety(string(opt.ReverseFrom)); err != nil {
+		return nil, err
+	}
 
-	// Verify that the blob exists.
-	_, err := g.getBlobOID(ctx, startCommit, path)
+	// Verify that the blob exists. Reverse blame annotates the file as it was
+	// at the oldest commit.
+	blobCommit := startCommit
+	if opt.ReverseFrom != "" {
+		blobCommit = opt.ReverseFrom
+	}
+	_, err := g.getBlobOID(ctx, blobCommit, path)
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := g.NewCommand(ctx, WithArguments(buildBlameArgs(startCommit, path, opt)...))
+	var cmdOpts []CommandOptionFunc
+	ignoreRevsFile := ""
+	if opt.IgnoreRevs {
+		revs, err := g.readBlameIgnoreRevs(ctx, startCommit)
+		if err != nil {
+			return nil, err
+		}
+		if revs != nil {
+			// git blame only reads ignored revisions from a file, so we pass
+			// the contents of the file in the repository on stdin.
+			ignoreRevsFile = "/dev/stdin"
+			cmdOpts = append(cmdOpts, WithStdin(bytes.NewReader(revs)))
+		}
+	}
+
+	cmdOpts = append(cmdOpts, WithArguments(buildBlameArgs(startCommit, path, opt, ignoreRevsFile)...))
+	r, err := g.NewCommand(ctx, cmdOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +59,72 @@ func (g *gitCLIBackend) Blame(ctx context.Context, startCommit api.CommitID, pat
 	return newBlameHunkReader(r), nil
 }
 
-func buildBlameArgs(startCommit api.CommitID, path string, opt git.BlameOptions) []string {
+// blameIgnoreRevsPath is the conventional location of the file listing the
+// revisions blame should skip, as understood by GitHub and GitLab.
+const blameIgnoreRevsPath = ".git-blame-ignore-revs"
+
+// readBlameIgnoreRevs returns the revisions listed in the
+// .git-blame-ignore-revs file at commit which are commits in the repository, one
+// per line, or nil if there are none. git blame aborts if the file lists a
+// revision it can't resolve, which happens for example after history was
+// rewritten or when the file lists commits of another fork.
+func (g *gitCLIBackend) readBlameIgnoreRevs(ctx context.Context, commit api.CommitID) ([]byte, error) {
+	blobOID, err := g.getBlobOID(ctx, commit, blameIgnoreRevsPath)
+	if err != nil {
+		if os.IsNotExist(err) || err == errIsSubmodule {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	r, err := g.NewCommand(ctx, WithArguments("cat-file", "-p", string(blobOID)))
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates bytes.Buffer
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		if i := bytes.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if rev := bytes.TrimSpace(line); gitdomain.IsAbsoluteRevision(string(rev)) {
+			candidates.Write(rev)
+			candidates.WriteByte('\n')
+		}
+	}
+	if candidates.Len() == 0 {
+		return nil, nil
+	}
+
+	// cat-file prints "<oid> <type> <size>" for existing objects and
+	// "<oid> missing" for the others.
+	r, err = g.NewCommand(ctx, WithArguments("cat-file", "--batch-check"), WithStdin(&candidates))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var revs []byte
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := bytes.Fields(sc.Bytes())
+		if len(fields) == 3 && string(fields[1]) == "commit" {
+			revs = append(revs, fields[0]...)
+			revs = append(revs, '\n')
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+func buildBlameArgs(startCommit api.CommitID, path string, opt git.BlameOptions, ignoreRevsFile string) []string {
 	args := []string{"blame", "--porcelain", "--incremental"}
 	if opt.IgnoreWhitespace {
 		args = append(args, "-w")
@@ -42,7 +132,21 @@ func buildBlameArgs(startCommit api.CommitID, path string, opt git.BlameOptions)
 	if opt.Range != nil {
 		args = append(args, fmt.Sprintf("-L%d,%d", opt.Range.StartLine, opt.Range.EndLine))
 	}
-	args = append(args, string(startCommit), "--", filepath.ToSlash(path))
+	if opt.DetectMoves {
+		args = append(args, "-M")
+	}
+	if opt.DetectCopies {
+		args = append(args, "-C")
+	}
+	if ignoreRevsFile != "" {
+		args = append(args, "--ignore-revs-file="+ignoreRevsFile)
+	}
+	if opt.ReverseFrom != "" {
+		args = append(args, "--reverse", string(opt.ReverseFrom)+".."+string(startCommit))
+	} else {
+		args = append(args, string(startCommit))
+	}
+	args = append(args, "--", filepath.ToSlash(path))
 	return args
 }
 
@@ -141,6 +141,96 @@ func TestGitCLIBackend_Blame(t *testing.T) {
 	})
 }
 
+func TestGitCLIBackend_Blame_Options(t *testing.T) {
+	const (
+		line1 = "the first line is long enough for move detection"
+		line2 = "the second line is long enough for move detection"
+		line3 = "the third line is long enough for move detection"
+	)
+
+	backend := BackendWithRepoCommands(t,
+		"echo 'a\nb\nc' > foo.txt",
+		"echo '"+line1+"\n"+line2+"\n"+line3+"' > bar.txt",
+		"git add foo.txt bar.txt",
+		"git commit -m initial --author='Foo Author <foo@sourcegraph.com>'",
+		"echo 'a\nB\nc' > foo.txt",
+		"git add foo.txt",
+		"git commit -m format --author='Bar Author <bar@sourcegraph.com>'",
+		"echo '"+line3+"\n"+line1+"\n"+line2+"' > bar.txt",
+		"git add bar.txt",
+		"git commit -m move --author='Bar Author <bar@sourcegraph.com>'",
+		"echo '# Formatting' > .git-blame-ignore-revs",
+		"git rev-parse HEAD~1 >> .git-blame-ignore-revs",
+		// Revisions which don't exist in the repository are skipped.
+		"echo 'deadbeefdeadbeefdeadbeefdeadbeefdeadbeef # from another fork' >> .git-blame-ignore-revs",
+		"echo 'not-a-revision' >> .git-blame-ignore-revs",
+		"git add .git-blame-ignore-revs",
+		"git commit -m ignore --author='Bar Author <bar@sourcegraph.com>'",
+	)
+
+	ctx := context.Background()
+
+	head, err := backend.RevParseHead(ctx)
+	require.NoError(t, err)
+	initial, err := backend.ResolveRevision(ctx, "HEAD~3")
+	require.NoError(t, err)
+
+	// blameMessages returns the message of the commit each line is attributed
+	// to.
+	blameMessages := func(t *testing.T, commit api.CommitID, path string, opt git.BlameOptions) []string {
+		t.Helper()
+
+		hr, err := backend.Blame(ctx, commit, path, opt)
+		require.NoError(t, err)
+		t.Cleanup(func() { hr.Close() })
+
+		var messages []string
+		for {
+			h, err := hr.Read()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			for line := h.StartLine; line < h.EndLine; line++ {
+				for len(messages) < int(line) {
+					messages = append(messages, "")
+				}
+				messages[line-1] = h.Message
+			}
+		}
+		return messages
+	}
+
+	t.Run("ignore revs", func(t *testing.T) {
+		require.Equal(t, []string{"initial", "format", "initial"}, blameMessages(t, head, "foo.txt", git.BlameOptions{}))
+		require.Equal(t, []string{"initial", "initial", "initial"}, blameMessages(t, head, "foo.txt", git.BlameOptions{IgnoreRevs: true}))
+	})
+
+	t.Run("ignore revs without file", func(t *testing.T) {
+		require.Equal(t, []string{"initial", "format", "initial"}, blameMessages(t, "HEAD~1", "foo.txt", git.BlameOptions{IgnoreRevs: true}))
+	})
+
+	t.Run("detect moves", func(t *testing.T) {
+		require.Equal(t, []string{"move", "initial", "initial"}, blameMessages(t, head, "bar.txt", git.BlameOptions{}))
+		require.Equal(t, []string{"initial", "initial", "initial"}, blameMessages(t, head, "bar.txt", git.BlameOptions{DetectMoves: true}))
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		// b disappeared in the format commit, the other lines are still there.
+		require.Equal(t, []string{"ignore", "initial", "ignore"}, blameMessages(t, head, "foo.txt", git.BlameOptions{ReverseFrom: initial}))
+	})
+
+	t.Run("reverse file not found", func(t *testing.T) {
+		_, err := backend.Blame(ctx, head, ".git-blame-ignore-revs", git.BlameOptions{ReverseFrom: initial})
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("bad reverse input", func(t *testing.T) {
+		_, err := backend.Blame(ctx, head, "foo.txt", git.BlameOptions{ReverseFrom: "-very badarg"})
+		require.Error(t, err)
+	})
+}
+
 func TestBuildBlameArgs(t *testing.T) {
 	commit := "deadbeef"
 	path := "foo.txt"
@@ -148,7 +238,7 @@ func TestBuildBlameArgs(t *testing.T) {
 	t.Run("default options", func(t *testing.T) {
 		want := []string{"blame", "--porcelain", "--incremental", commit, "--", "foo.txt"}
 		opt := git.BlameOptions{}
-		got := buildBlameArgs(api.CommitID(commit), path, opt)
+		got := buildBlameArgs(api.CommitID(commit), path, opt, "")
 		if !equalSlice(got, want) {
 			t.Errorf("unexpected args:\ngot: %v\nwant: %v", got, want)
 		}
@@ -157,7 +247,7 @@ func TestBuildBlameArgs(t *testing.T) {
 	t.Run("with ignore whitespace", func(t *testing.T) {
 		want := []string{"blame", "--porcelain", "--incremental", "-w", commit, "--", "foo.txt"}
 		opt := git.BlameOptions{IgnoreWhitespace: true}
-		got := buildBlameArgs(api.CommitID(commit), path, opt)
+		got := buildBlameArgs(api.CommitID(commit), path, opt, "")
 		if !equalSlice(got, want) {
 			t.Errorf("unexpected args:\ngot: %v\nwant: %v", got, want)
 		}
@@ -166,7 +256,33 @@ func TestBuildBlameArgs(t *testing.T) {
 	t.Run("with line range", func(t *testing.T) {
 		want := []string{"blame", "--porcelain", "--incremental", "-L5,10", commit, "--", "foo.txt"}
 		opt := git.BlameOptions{Range: &git.BlameRange{StartLine: 5, EndLine: 10}}
-		got := buildBlameArgs(api.CommitID(commit), path, opt)
+		got := buildBlameArgs(api.CommitID(commit), path, opt, "")
+		if !equalSlice(got, want) {
+			t.Errorf("unexpected args:\ngot: %v\nwant: %v", got, want)
+		}
+	})
+	t.Run("with move and copy detection", func(t *testing.T) {
+		want := []string{"blame", "--porcelain", "--incremental", "-M", "-C", commit, "--", "foo.txt"}
+		opt := git.BlameOptions{DetectMoves: true, DetectCopies: true}
+		got := buildBlameArgs(api.CommitID(commit), path, opt, "")
+		if !equalSlice(got, want) {
+			t.Errorf("unexpected args:\ngot: %v\nwant: %v", got, want)
+		}
+	})
+
+	t.Run("with ignore revs file", func(t *testing.T) {
+		want := []string{"blame", "--porcelain", "--incremental", "--ignore-revs-file=/dev/stdin", commit, "--", "foo.txt"}
+		opt := git.BlameOptions{IgnoreRevs: true}
+		got := buildBlameArgs(api.CommitID(commit), path, opt, "/dev/stdin")
+		if !equalSlice(got, want) {
+			t.Errorf("unexpected args:\ngot: %v\nwant: %v", got, want)
+		}
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		want := []string{"blame", "--porcelain", "--incremental", "--reverse", "cafebabe.." + commit, "--", "foo.txt"}
+		opt := git.BlameOptions{ReverseFrom: "cafebabe"}
+		got := buildBlameArgs(api.CommitID(commit), path, opt, "")
 		if !equalSlice(got, want) {
 			t.Errorf("unexpected args:\ngot: %v\nwant: %v", got, want)
 		}
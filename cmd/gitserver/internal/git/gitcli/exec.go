@@ -15,7 +15,7 @@ var (
 		"show":      append([]string{}, gitCommonAllowlist...),
 		"remote":    {"-v"},
 		"diff-tree": append([]string{"--root"}, gitCommonAllowlist...),
-		"blame":     {"--root", "--incremental", "-w", "-p", "--porcelain", "--"},
+		"blame":     {"--root", "--incremental", "-w", "-p", "--porcelain", "-M", "-C", "--reverse", "--"},
 		"branch":    {"-r", "-a", "--contains", "--merged", "--format"},
 
 		"rev-parse":    {"--abbrev-ref", "--symbolic-full-name", "--glob", "--exclude"},
@@ -30,7 +30,7 @@ var (
 		"merge-base":   {"--octopus", "--"},
 		"show-ref":     {"--heads"},
 		"shortlog":     {"--summary", "--numbered", "--email", "--no-merges", "--after", "--before"},
-		"cat-file":     {"-p", "-t"},
+		"cat-file":     {"-p", "-t", "--batch-check"},
 		"lfs":          {},
 
 		// Commands used by GitConfigStore:
@@ -140,6 +140,12 @@ func IsAllowedGitCmd(logger log.Logger, args []string) bool {
 				continue // this arg is OK
 			}
 
+			// For `git blame`, allow reading ignored revisions from stdin,
+			// but not from arbitrary files.
+			if cmd == "blame" && arg == "--ignore-revs-file=/dev/stdin" {
+				continue // this arg is OK
+			}
+
 			// Special case numeric arguments like `git log -20`.
 			if _, err := strconv.Atoi(arg[1:]); err == nil {
 				continue // this arg is OK
@@ -26,12 +26,17 @@ func TestIsAllowedGitCmd(t *testing.T) {
 		{"commit", "--file=-"},
 		{"push", "--force", "git@github.com:repo/name", "f22cfd066432e382c24f1eaa867444671e23a136:refs/heads/a-branch"},
 		{"update-ref", "--"},
+
+		// Blame.
+		{"blame", "--porcelain", "--incremental", "-M", "-C", "--ignore-revs-file=/dev/stdin", "--reverse", "a..b", "--", "foo.txt"},
 	}
 	notAllowed := [][]string{
 		{"commit", "-F", "/etc/passwd"},
 		{"commit", "--file=/absolute/path"},
 		{"commit", "-F", "relative/passwd"},
 		{"commit", "--file=relative/path"},
+		{"blame", "--ignore-revs-file=/etc/passwd", "HEAD", "--", "foo.txt"},
+		{"blame", "--ignore-revs-file", "/dev/stdin", "HEAD", "--", "foo.txt"},
 	}
 
 	logger := logtest.NoOp(t)
@@ -384,6 +384,9 @@ func (g *gitCLIBackend) lsTree(ctx context.Context, commit api.CommitID, path st
 		commit:   commit,
 		path:     path,
 		r:        r,
+		gitModules: sync.OnceValues(func() (config.Config, error) {
+			return g.gitModulesConfig(ctx, commit)
+		}),
 	}, nil
 }
 
@@ -396,6 +399,10 @@ type readDirIterator struct {
 	path     string
 	fdsSeen  int
 	r        io.ReadCloser
+
+	// gitModules lazily loads the .gitmodules configuration, which is only
+	// needed if the listing contains submodules.
+	gitModules func() (config.Config, error)
 }
 
 func (it *readDirIterator) Next() (fs.FileInfo, error) {
@@ -442,10 +449,6 @@ func (it *readDirIterator) Next() (fs.FileInfo, error) {
 			return nil, err
 		}
 
-		loadModConf := sync.OnceValues(func() (config.Config, error) {
-			return it.g.gitModulesConfig(it.ctx, it.commit)
-		})
-
 		mode := os.FileMode(modeVal)
 		switch string(typ) {
 		case "blob":
@@ -458,18 +461,17 @@ func (it *readDirIterator) Next() (fs.FileInfo, error) {
 		case "commit":
 			mode = gitdomain.ModeSubmodule
 
-			modconf, err := loadModConf()
+			modconf, err := it.gitModules()
 			if err != nil {
 				return nil, err
 			}
 
-			submodule := gitdomain.Submodule{
-				URL:      modconf.Section("submodule").Subsection(name).Option("url"),
-				Path:     modconf.Section("submodule").Subsection(name).Option("path"),
+			sub := submoduleConfig(modconf, name)
+			sys = gitdomain.Submodule{
+				URL:      sub.Option("url"),
+				Path:     sub.Option("path"),
 				CommitID: api.CommitID(oid.String()),
 			}
-
-			sys = submodule
 		case "tree":
 			mode = mode | os.ModeDir
 		}
@@ -557,6 +559,19 @@ func (g *gitCLIBackend) gitModulesConfig(ctx context.Context, commit api.CommitI
 	return cfg, nil
 }
 
+// submoduleConfig returns the .gitmodules section of the submodule at path.
+// Sections are keyed by the submodule name, which usually but not necessarily
+// is the same as its path.
+func submoduleConfig(modconf config.Config, path string) *config.Subsection {
+	section := modconf.Section("submodule")
+	for _, sub := range section.Subsections {
+		if sub.Option("path") == path {
+			return sub
+		}
+	}
+	return section.Subsection(path)
+}
+
 // rel strips the leading "/" prefix from the path string, effectively turning
 // an absolute path into one relative to the root directory. A path that is just
 // "/" is treated specially, returning just ".".
@@ -866,6 +866,23 @@ func TestGitCLIBackend_ReadDir(t *testing.T) {
 	})
 }
 
+func TestSubmoduleConfig(t *testing.T) {
+	var modconf config.Config
+	err := config.NewDecoder(strings.NewReader(`[submodule "lib"]
+	path = vendor/lib
+	url = https://github.com/sourcegraph/lib
+[submodule "tools"]
+	path = tools
+	url = ../tools.git
+`)).Decode(&modconf)
+	require.NoError(t, err)
+
+	// Sections are looked up by path, not by name.
+	require.Equal(t, "https://github.com/sourcegraph/lib", submoduleConfig(modconf, "vendor/lib").Option("url"))
+	require.Equal(t, "../tools.git", submoduleConfig(modconf, "tools").Option("url"))
+	require.Equal(t, "", submoduleConfig(modconf, "missing").Option("url"))
+}
+
 func TestLogPartsPerCommitInSync(t *testing.T) {
 	require.Equal(t, partsPerCommit-1, strings.Count(logFormatWithoutRefs, "%x00"))
 }
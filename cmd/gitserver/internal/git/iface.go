@@ -280,6 +280,20 @@ type GitConfigBackend interface {
 type BlameOptions struct {
 	IgnoreWhitespace bool
 	Range            *BlameRange
+	// IgnoreRevs skips the commits listed in the .git-blame-ignore-revs file
+	// of the repository at the start commit.
+	IgnoreRevs bool
+	// DetectMoves attributes lines moved or copied within the file to the
+	// commit they were originally written in (git blame -M).
+	DetectMoves bool
+	// DetectCopies attributes lines moved or copied from other files modified
+	// in the same commit to the commit they were originally written in
+	// (git blame -C).
+	DetectCopies bool
+	// ReverseFrom, if set, walks history forward from ReverseFrom to the
+	// start commit. The file is annotated as of ReverseFrom, and each hunk
+	// reports the last commit in which its lines were still present.
+	ReverseFrom api.CommitID
 }
 
 type BlameRange struct {
@@ -326,6 +340,9 @@ type ListRefsOpts struct {
 	PointsAtCommit []api.CommitID
 	// If set, only return refs that contain the given commit shas.
 	Contains []api.CommitID
+	// If true, the signatures of annotated tags are verified. Backends which
+	// don't verify signatures ignore it.
+	VerifyTagSignatures bool
 }
 
 // ChangedFilesIterator iterates over changed files. The iterator must be closed
@@ -0,0 +1,409 @@
+// Package native implements a GitBackend that serves hot read operations by
+// reading the object database in-process, instead of forking a git process
+// for every call. It reads pack indexes, packfiles, loose objects,
+// commit-graphs and refs directly, and keeps files memory-mapped across
+// requests in a PackCache.
+//
+// Only a subset of operations is implemented. Everything else, and every
+// request the native backend can't answer definitively, is served by the
+// wrapped CLI backend.
+package native
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var operationsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_native_backend_operations_total",
+	Help: "Operations handled by the native git backend, by whether they were served natively or fell back to the git CLI.",
+}, []string{"op", "result"})
+
+// Operations selects the GitBackend methods served by the native backend.
+type Operations struct {
+	ReadFile        bool
+	Stat            bool
+	ReadDir         bool
+	ResolveRevision bool
+}
+
+// Any reports whether any operation is enabled.
+func (o Operations) Any() bool {
+	return o.ReadFile || o.Stat || o.ReadDir || o.ResolveRevision
+}
+
+// ParseOperations parses a comma-separated list of GitBackend method names,
+// e.g. "ReadFile,Stat". The special value "all" enables every operation the
+// native backend supports.
+func ParseOperations(s string) (Operations, error) {
+	var ops Operations
+	for _, name := range strings.Split(s, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "all":
+			ops = Operations{ReadFile: true, Stat: true, ReadDir: true, ResolveRevision: true}
+		case "readfile":
+			ops.ReadFile = true
+		case "stat":
+			ops.Stat = true
+		case "readdir":
+			ops.ReadDir = true
+		case "resolverevision":
+			ops.ResolveRevision = true
+		default:
+			return Operations{}, errors.Newf("unknown native git backend operation %q", name)
+		}
+	}
+	return ops, nil
+}
+
+// NewBackend wraps backend so that the given operations are served by reading
+// the repository in-process. If no operations are enabled, backend is
+// returned as is.
+func NewBackend(logger log.Logger, backend git.GitBackend, cache *PackCache, dir common.GitDir, repoName api.RepoName, ops Operations) git.GitBackend {
+	if !ops.Any() {
+		return backend
+	}
+	return &nativeBackend{
+		GitBackend: backend,
+		logger:     logger.Scoped("native").With(log.String("repo", string(repoName))),
+		cache:      cache,
+		dir:        dir,
+		repoName:   repoName,
+		ops:        ops,
+	}
+}
+
+type nativeBackend struct {
+	git.GitBackend
+
+	logger   log.Logger
+	cache    *PackCache
+	dir      common.GitDir
+	repoName api.RepoName
+	ops      Operations
+}
+
+// served reports whether the result of a native operation can be returned to
+// the caller. Otherwise, the operation is retried with the CLI backend.
+func (b *nativeBackend) served(op string, err error) bool {
+	if err == nil || isNotExist(err) {
+		operationsCounter.WithLabelValues(op, "native").Inc()
+		return true
+	}
+	if errors.Is(err, errUnsupported) {
+		operationsCounter.WithLabelValues(op, "fallback_unsupported").Inc()
+		return false
+	}
+	// Anything else means the repository is in a state we don't understand.
+	// git might still be able to make sense of it.
+	b.logger.Warn("native git backend failed, falling back to git CLI", log.String("op", op), log.Error(err))
+	operationsCounter.WithLabelValues(op, "fallback_error").Inc()
+	return false
+}
+
+func isNotExist(err error) bool {
+	var pathErr *os.PathError
+	return errors.As(err, &pathErr) && errors.Is(pathErr.Err, os.ErrNotExist)
+}
+
+func (b *nativeBackend) ReadFile(ctx context.Context, commit api.CommitID, path string) (io.ReadCloser, error) {
+	if !b.ops.ReadFile {
+		return b.GitBackend.ReadFile(ctx, commit, path)
+	}
+	r, err := b.readFile(commit, path)
+	if b.served("ReadFile", err) {
+		return r, err
+	}
+	return b.GitBackend.ReadFile(ctx, commit, path)
+}
+
+func (b *nativeBackend) readFile(commit api.CommitID, path string) (_ io.ReadCloser, err error) {
+	if !gitdomain.IsAbsoluteRevision(string(commit)) || !isLiteralPath(path) {
+		return nil, errUnsupported
+	}
+
+	repo, err := openRepository(b.cache, b.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			repo.close()
+		}
+	}()
+
+	entry, ok, err := repo.lookupCommitPath(commit, path)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	switch {
+	case entry.isSubmodule():
+		repo.close()
+		return io.NopCloser(strings.NewReader("")), nil
+	case entry.isTree():
+		// git cat-file prints a listing of the tree, which we don't replicate.
+		return nil, errors.Wrapf(errUnsupported, "%q is a directory", path)
+	}
+
+	r, err := repo.openBlob(entry.oid)
+	if err != nil {
+		return nil, err
+	}
+	return &repoReadCloser{ReadCloser: r, repo: repo}, nil
+}
+
+// repoReadCloser keeps the repository, and with it the mapped packs, open
+// until the reader is closed.
+type repoReadCloser struct {
+	io.ReadCloser
+	repo *repository
+}
+
+func (r *repoReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.repo.close()
+	return err
+}
+
+func (b *nativeBackend) Stat(ctx context.Context, commit api.CommitID, path string) (fs.FileInfo, error) {
+	if !b.ops.Stat {
+		return b.GitBackend.Stat(ctx, commit, path)
+	}
+	fi, err := b.stat(commit, path)
+	if b.served("Stat", err) {
+		return fi, err
+	}
+	return b.GitBackend.Stat(ctx, commit, path)
+}
+
+func (b *nativeBackend) stat(commit api.CommitID, p string) (fs.FileInfo, error) {
+	p = filepath.ToSlash(filepath.Clean(rel(p)))
+	if p == "." {
+		p = ""
+	}
+	if !gitdomain.IsAbsoluteRevision(string(commit)) || (p != "" && !isLiteralPath(p)) {
+		return nil, errUnsupported
+	}
+
+	repo, err := openRepository(b.cache, b.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer repo.close()
+
+	oid, err := decodeOID(string(commit))
+	if err != nil {
+		return nil, err
+	}
+	root, err := repo.rootTree(oid)
+	if err != nil {
+		return nil, err
+	}
+	if p == "" {
+		return rootFileInfo(root), nil
+	}
+
+	entry, ok, err := repo.lookupPath(root, p)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &os.PathError{Op: "ls-tree", Path: p, Err: os.ErrNotExist}
+	}
+	return repo.fileInfo(newGitModules(repo, root), entry, p)
+}
+
+func (b *nativeBackend) ReadDir(ctx context.Context, commit api.CommitID, path string, recursive bool) (git.ReadDirIterator, error) {
+	if !b.ops.ReadDir {
+		return b.GitBackend.ReadDir(ctx, commit, path, recursive)
+	}
+	it, err := b.readDir(commit, path, recursive)
+	if !b.served("ReadDir", err) {
+		return b.GitBackend.ReadDir(ctx, commit, path, recursive)
+	}
+	return &fallbackReadDirIterator{
+		native: it,
+		fallback: func() (git.ReadDirIterator, error) {
+			return b.GitBackend.ReadDir(ctx, commit, path, recursive)
+		},
+		served: b.served,
+	}, nil
+}
+
+func (b *nativeBackend) readDir(commit api.CommitID, p string, recursive bool) (_ *readDirIterator, err error) {
+	// The CLI backend reports the path it listed with a trailing slash.
+	dir, listedPath := "", ""
+	if p != "" {
+		dir = filepath.ToSlash(filepath.Clean(rel(p)))
+		listedPath = dir + "/"
+		if dir == "." {
+			dir = ""
+		}
+	}
+	if !gitdomain.IsAbsoluteRevision(string(commit)) || (dir != "" && !isCleanPath(dir)) {
+		return nil, errUnsupported
+	}
+
+	repo, err := openRepository(b.cache, b.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			repo.close()
+		}
+	}()
+
+	oid, err := decodeOID(string(commit))
+	if err != nil {
+		return nil, err
+	}
+	root, err := repo.rootTree(oid)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &readDirIterator{
+		repo:      repo,
+		modules:   newGitModules(repo, root),
+		recursive: recursive,
+	}
+
+	// Walk down to the listed tree. git ls-tree -r -t also lists the trees
+	// leading up to it, and a submodule matches the path even with a
+	// trailing slash.
+	tree, prefix, found := root, "", true
+	for rest := dir; rest != ""; {
+		var name string
+		name, rest, _ = strings.Cut(rest, "/")
+		data, err := repo.readTree(tree)
+		if err != nil {
+			return nil, err
+		}
+		e, ok, err := findTreeEntry(data, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok && e.isSubmodule() && rest == "" {
+			fi, err := repo.fileInfo(it.modules, e, prefix+name)
+			if err != nil {
+				return nil, err
+			}
+			it.pending = append(it.pending, fi)
+		}
+		if !ok || !e.isTree() {
+			found = false
+			break
+		}
+		if recursive {
+			fi, err := repo.fileInfo(it.modules, e, prefix+name)
+			if err != nil {
+				return nil, err
+			}
+			it.pending = append(it.pending, fi)
+		}
+		tree, prefix = e.oid, prefix+name+"/"
+	}
+
+	if found {
+		if err := it.push(tree, prefix); err != nil {
+			return nil, err
+		}
+	} else if len(it.pending) == 0 {
+		// Like the CLI backend, we only report this once iterating.
+		it.err = &os.PathError{Op: "git ls-tree", Path: listedPath, Err: os.ErrNotExist}
+	}
+	return it, nil
+}
+
+func (b *nativeBackend) ResolveRevision(ctx context.Context, spec string) (api.CommitID, error) {
+	if !b.ops.ResolveRevision {
+		return b.GitBackend.ResolveRevision(ctx, spec)
+	}
+	commit, err := b.resolveRevision(spec)
+	if b.served("ResolveRevision", err) {
+		return commit, err
+	}
+	return b.GitBackend.ResolveRevision(ctx, spec)
+}
+
+func (b *nativeBackend) resolveRevision(spec string) (api.CommitID, error) {
+	if spec == "" {
+		spec = "HEAD"
+	}
+
+	repo, err := openRepository(b.cache, b.dir)
+	if err != nil {
+		return "", err
+	}
+	defer repo.close()
+
+	oid, err := repo.resolveRevision(spec)
+	if err != nil {
+		return "", err
+	}
+	// Like git rev-parse, we trust HEAD to point to an existing commit. For
+	// everything else we make sure the object exists and peel it.
+	if spec != "HEAD" {
+		if oid, err = repo.peelToCommit(oid); err != nil {
+			return "", err
+		}
+	}
+	return api.CommitID(oid.String()), nil
+}
+
+// lookupCommitPath returns the entry at path in the tree of the given commit.
+func (r *repository) lookupCommitPath(commit api.CommitID, path string) (treeEntry, bool, error) {
+	oid, err := decodeOID(string(commit))
+	if err != nil {
+		return treeEntry{}, false, err
+	}
+	root, err := r.rootTree(oid)
+	if err != nil {
+		return treeEntry{}, false, err
+	}
+	return r.lookupPath(root, path)
+}
+
+// isCleanPath reports whether p is a canonical path relative to the root of
+// the repository.
+func isCleanPath(p string) bool {
+	return p != "" && p != "." && path.Clean(p) == p &&
+		!strings.HasPrefix(p, "/") && p != ".." && !strings.HasPrefix(p, "../")
+}
+
+// isLiteralPath reports whether p is a clean path that git matches
+// literally when passed as a pathspec, ie. it doesn't use any pathspec magic
+// or glob characters.
+func isLiteralPath(p string) bool {
+	return isCleanPath(p) && !strings.HasPrefix(p, ":") && !strings.ContainsAny(p, "*?[\\")
+}
+
+// rel strips the leading "/" prefix from the path string, effectively turning
+// an absolute path into one relative to the root directory. A path that is just
+// "/" is treated specially, returning just ".".
+func rel(path string) string {
+	if path == "/" {
+		return "."
+	}
+	return strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,480 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git/gitcli"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func repoWithCommands(t testing.TB, cmds ...string) common.GitDir {
+	reposDir := t.TempDir()
+
+	// Make a new bare repo on disk.
+	p := filepath.Join(reposDir, "repo")
+	require.NoError(t, os.MkdirAll(p, os.ModePerm))
+	dir := common.GitDir(filepath.Join(p, ".git"))
+
+	// Prepare repo state:
+	for _, cmd := range append(
+		append([]string{"git init --initial-branch=master ."}, cmds...),
+		// Promote the repo to a bare repo.
+		"git config --bool core.bare true",
+	) {
+		out, err := gitserver.CreateGitCommand(p, "bash", "-c", cmd).CombinedOutput()
+		if err != nil {
+			t.Fatalf("Failed to run git command %v. Output was:\n\n%s", cmd, out)
+		}
+	}
+
+	return dir
+}
+
+// testRepoCommands create a repository with packed and loose objects, deltas,
+// packed and loose refs, a commit-graph, and all kinds of tree entries.
+var testRepoCommands = []string{
+	"mkdir -p dir/sub && echo hello > README.md && echo nested > dir/sub/file.txt",
+	"printf '#!/bin/sh\\n' > run.sh && chmod +x run.sh && ln -s README.md link",
+	"seq 1 2000 > big.txt",
+	"git add -A && git commit -m one",
+	"seq 1 2001 > big.txt && echo world >> README.md",
+	"printf '[submodule \"submod\"]\\n\\tpath = submod\\n\\turl = https://example.com/submod\\n' > .gitmodules",
+	"git update-index --add --cacheinfo 160000,c8bb0d84d1d2d1dba3a0e2e7fbec2d67d9b5d3d1,submod",
+	"git add -A && git commit -m two",
+	"git tag -a v1 -m v1 && git tag lw HEAD~1 && git branch feature/x HEAD~1",
+	"git repack -adf --window=50 && git pack-refs --all && git commit-graph write --reachable",
+	// Objects and refs written after packing stay loose.
+	"seq 1 2002 > big.txt && git add big.txt && git commit -m three",
+	"git branch loose HEAD~1",
+}
+
+type testBackends struct {
+	cli git.GitBackend
+	// strict serves all operations natively and fails when falling back.
+	strict git.GitBackend
+	mock   *git.MockGitBackend
+	// native falls back to the CLI backend.
+	native git.GitBackend
+}
+
+func newTestBackends(t *testing.T, dir common.GitDir) testBackends {
+	logger := logtest.Scoped(t)
+	cache, err := NewPackCache(100)
+	require.NoError(t, err)
+	all, err := ParseOperations("all")
+	require.NoError(t, err)
+
+	cli := gitcli.NewBackend(logger, wrexec.NewNoOpRecordingCommandFactory(), dir, api.RepoName(t.Name()))
+	mock := git.NewMockGitBackend()
+	return testBackends{
+		cli:    cli,
+		strict: NewBackend(logger, mock, cache, dir, api.RepoName(t.Name()), all),
+		mock:   mock,
+		native: NewBackend(logger, cli, cache, dir, api.RepoName(t.Name()), all),
+	}
+}
+
+func (b testBackends) requireNoFallback(t *testing.T) {
+	t.Helper()
+	require.Empty(t, b.mock.ReadFileFunc.History())
+	require.Empty(t, b.mock.StatFunc.History())
+	require.Empty(t, b.mock.ReadDirFunc.History())
+	require.Empty(t, b.mock.ResolveRevisionFunc.History())
+}
+
+func revParse(t *testing.T, b git.GitBackend, spec string) api.CommitID {
+	t.Helper()
+	commit, err := b.ResolveRevision(context.Background(), spec)
+	require.NoError(t, err)
+	return commit
+}
+
+func readAll(t *testing.T, r io.ReadCloser, err error) string {
+	t.Helper()
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	return string(content)
+}
+
+func requireSameFileInfo(t *testing.T, want, have fs.FileInfo) {
+	t.Helper()
+	require.Equal(t, want.Name(), have.Name())
+	require.Equal(t, want.Mode(), have.Mode(), want.Name())
+	require.Equal(t, want.Size(), have.Size(), want.Name())
+	if sub, ok := want.Sys().(gitdomain.Submodule); ok {
+		require.Equal(t, sub, have.Sys(), want.Name())
+		return
+	}
+	type oidGetter interface{ OID() gitdomain.OID }
+	require.Equal(t, want.Sys().(oidGetter).OID(), have.Sys().(oidGetter).OID(), want.Name())
+}
+
+func listDir(it git.ReadDirIterator, err error) ([]fs.FileInfo, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var fis []fs.FileInfo
+	for {
+		fi, err := it.Next()
+		if err == io.EOF {
+			return fis, nil
+		}
+		if err != nil {
+			return fis, err
+		}
+		fis = append(fis, fi)
+	}
+}
+
+func TestNativeBackend_ResolveRevision(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBackends(t, repoWithCommands(t, testRepoCommands...))
+
+	for _, spec := range []string{
+		"",
+		"HEAD",
+		"master",
+		"refs/heads/master",
+		"heads/master",
+		// Packed refs.
+		"feature/x",
+		"refs/heads/feature/x",
+		"v1",
+		"refs/tags/v1",
+		"lw",
+		// Loose ref written after packing.
+		"loose",
+		string(revParse(t, b.cli, "HEAD~1")),
+		string(revParse(t, b.cli, "HEAD~2")),
+	} {
+		t.Run(spec, func(t *testing.T) {
+			have, err := b.strict.ResolveRevision(ctx, spec)
+			require.NoError(t, err)
+			require.Equal(t, revParse(t, b.cli, spec), have)
+			b.requireNoFallback(t)
+		})
+	}
+
+	t.Run("fallback", func(t *testing.T) {
+		for _, spec := range []string{"HEAD~1", "master^", "v1^{commit}", string(revParse(t, b.cli, "HEAD"))[:7]} {
+			require.Equal(t, revParse(t, b.cli, spec), revParse(t, b.native, spec), spec)
+		}
+
+		root, err := b.cli.Stat(ctx, revParse(t, b.cli, "HEAD"), "")
+		require.NoError(t, err)
+		tree := root.Sys().(interface{ OID() gitdomain.OID }).OID().String()
+
+		for _, spec := range []string{"nonexistent", "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", tree} {
+			_, err := b.native.ResolveRevision(ctx, spec)
+			require.True(t, errors.HasType[*gitdomain.RevisionNotFoundError](err), "%s: %v", spec, err)
+		}
+	})
+
+	t.Run("empty repository", func(t *testing.T) {
+		b := newTestBackends(t, repoWithCommands(t))
+		_, err := b.native.ResolveRevision(ctx, "HEAD")
+		require.True(t, errors.HasType[*gitdomain.RevisionNotFoundError](err), "%v", err)
+	})
+}
+
+func TestNativeBackend_ReadFile(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBackends(t, repoWithCommands(t, testRepoCommands...))
+
+	for _, rev := range []string{"HEAD", "HEAD~1", "HEAD~2", "v1"} {
+		commit := revParse(t, b.cli, rev)
+		for _, path := range []string{"README.md", "big.txt", "dir/sub/file.txt", "run.sh", "link", "submod", ".gitmodules"} {
+			t.Run(rev+"/"+path, func(t *testing.T) {
+				want, wantErr := b.cli.ReadFile(ctx, commit, path)
+				have, haveErr := b.strict.ReadFile(ctx, commit, path)
+				b.requireNoFallback(t)
+				if wantErr != nil {
+					require.True(t, os.IsNotExist(wantErr), "%v", wantErr)
+					require.True(t, os.IsNotExist(haveErr), "%v", haveErr)
+					return
+				}
+				require.Equal(t, readAll(t, want, wantErr), readAll(t, have, haveErr))
+			})
+		}
+	}
+
+	t.Run("missing paths", func(t *testing.T) {
+		commit := revParse(t, b.cli, "HEAD")
+		for _, path := range []string{"nonexistent", "dir/nonexistent", "README.md/foo", "dir/sub/file.txt/foo"} {
+			_, err := b.strict.ReadFile(ctx, commit, path)
+			require.True(t, os.IsNotExist(err), "%s: %v", path, err)
+		}
+		b.requireNoFallback(t)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		_, err := b.native.ReadFile(ctx, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", "README.md")
+		require.True(t, errors.HasType[*gitdomain.RevisionNotFoundError](err), "%v", err)
+
+		_, err = b.native.ReadFile(ctx, "HEAD", "README.md")
+		require.Error(t, err)
+	})
+}
+
+func TestNativeBackend_Alternates(t *testing.T) {
+	ctx := context.Background()
+	pool := repoWithCommands(t, testRepoCommands...)
+
+	// The fork borrows all objects of the pool and has a commit of its own.
+	fork := common.GitDir(filepath.Join(t.TempDir(), "fork.git"))
+	for _, cmd := range []string{
+		fmt.Sprintf("git clone --quiet --shared --mirror %s %s", pool, fork),
+		fmt.Sprintf("git --git-dir=%s update-ref refs/heads/fork $(git --git-dir=%s commit-tree -p HEAD -m four HEAD^{tree})", fork, fork),
+	} {
+		out, err := gitserver.CreateGitCommand(t.TempDir(), "bash", "-c", cmd).CombinedOutput()
+		require.NoError(t, err, "%s", out)
+	}
+	_, err := os.Stat(fork.Path("objects", "info", "alternates"))
+	require.NoError(t, err)
+
+	b := newTestBackends(t, fork)
+	for _, rev := range []string{"fork", "HEAD", "v1", string(revParse(t, b.cli, "HEAD~1"))} {
+		t.Run(rev, func(t *testing.T) {
+			want := revParse(t, b.cli, rev)
+			require.Equal(t, want, revParse(t, b.strict, rev))
+			for _, path := range []string{"README.md", "big.txt", "dir/sub/file.txt"} {
+				wantR, wantErr := b.cli.ReadFile(ctx, want, path)
+				haveR, haveErr := b.strict.ReadFile(ctx, want, path)
+				require.Equal(t, readAll(t, wantR, wantErr), readAll(t, haveR, haveErr))
+			}
+			b.requireNoFallback(t)
+		})
+	}
+}
+
+func TestNativeBackend_Stat(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBackends(t, repoWithCommands(t, testRepoCommands...))
+
+	for _, rev := range []string{"HEAD", "HEAD~2"} {
+		commit := revParse(t, b.cli, rev)
+		for _, path := range []string{"", ".", "/", "README.md", "/README.md", "big.txt", "dir", "dir/", "dir/sub/file.txt", "run.sh", "link", "submod"} {
+			t.Run(rev+"/"+path, func(t *testing.T) {
+				want, wantErr := b.cli.Stat(ctx, commit, path)
+				have, haveErr := b.strict.Stat(ctx, commit, path)
+				b.requireNoFallback(t)
+				if wantErr != nil {
+					require.True(t, os.IsNotExist(wantErr), "%v", wantErr)
+					require.True(t, os.IsNotExist(haveErr), "%v", haveErr)
+					return
+				}
+				require.NoError(t, haveErr)
+				requireSameFileInfo(t, want, have)
+			})
+		}
+	}
+
+	t.Run("missing paths", func(t *testing.T) {
+		commit := revParse(t, b.cli, "HEAD")
+		for _, path := range []string{"nonexistent", "dir/nonexistent", "README.md/foo"} {
+			_, err := b.strict.Stat(ctx, commit, path)
+			require.True(t, os.IsNotExist(err), "%s: %v", path, err)
+		}
+		b.requireNoFallback(t)
+	})
+}
+
+func TestNativeBackend_ReadDir(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBackends(t, repoWithCommands(t, testRepoCommands...))
+
+	for _, rev := range []string{"HEAD", "HEAD~2"} {
+		commit := revParse(t, b.cli, rev)
+		for _, path := range []string{"", "/", "dir", "dir/", "dir/sub", "dir/sub/file.txt", "dir/nonexistent", "nonexistent", "README.md", "submod"} {
+			for _, recursive := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s/%s/recursive=%t", rev, path, recursive), func(t *testing.T) {
+					want, wantErr := listDir(b.cli.ReadDir(ctx, commit, path, recursive))
+					have, haveErr := listDir(b.strict.ReadDir(ctx, commit, path, recursive))
+					b.requireNoFallback(t)
+					if wantErr != nil {
+						require.True(t, os.IsNotExist(wantErr), "%v", wantErr)
+						require.Equal(t, wantErr.Error(), haveErr.Error())
+						return
+					}
+					require.NoError(t, haveErr)
+					require.Equal(t, len(want), len(have))
+					for i := range want {
+						requireSameFileInfo(t, want[i], have[i])
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestNativeBackend_FallbackMidListing(t *testing.T) {
+	ctx := context.Background()
+	dir := repoWithCommands(t, testRepoCommands...)
+	b := newTestBackends(t, dir)
+	commit := revParse(t, b.cli, "HEAD")
+
+	want, err := listDir(b.cli.ReadDir(ctx, commit, "", true))
+	require.NoError(t, err)
+
+	it, err := b.native.ReadDir(ctx, commit, "", true)
+	require.NoError(t, err)
+	var have []fs.FileInfo
+	for i := 0; i < 2; i++ {
+		fi, err := it.Next()
+		require.NoError(t, err)
+		have = append(have, fi)
+	}
+
+	// Make all objects disappear for the native backend, so that it fails
+	// while listing.
+	native := it.(*fallbackReadDirIterator).native
+	native.repo.dir = common.GitDir(t.TempDir())
+	native.repo.objectDirs = []string{native.repo.dir.Path("objects")}
+	native.repo.packs = nil
+	native.repo.graphs = nil
+	baseCache.Purge()
+
+	rest, err := listDir(it, nil)
+	require.NoError(t, err)
+	require.NotNil(t, it.(*fallbackReadDirIterator).cli)
+	have = append(have, rest...)
+	require.Equal(t, len(want), len(have))
+	for i := range want {
+		requireSameFileInfo(t, want[i], have[i])
+	}
+}
+
+func TestParseOperations(t *testing.T) {
+	ops, err := ParseOperations("ReadFile, stat")
+	require.NoError(t, err)
+	require.Equal(t, Operations{ReadFile: true, Stat: true}, ops)
+
+	ops, err = ParseOperations("")
+	require.NoError(t, err)
+	require.False(t, ops.Any())
+
+	_, err = ParseOperations("ReadFile,Blame")
+	require.Error(t, err)
+}
+
+// benchmarkRepoCommands create a repository with a few hundred files in
+// nested directories, packed with deltas like a repository cloned by
+// gitserver.
+var benchmarkRepoCommands = []string{
+	"for d in $(seq 1 10); do mkdir -p dir$d/sub; for f in $(seq 1 20); do seq 1 $((d*f*10)) > dir$d/sub/file$f.txt; done; done",
+	"git add -A && git commit -m one",
+	"for d in $(seq 1 10); do echo changed >> dir$d/sub/file1.txt; done",
+	"git add -A && git commit -m two",
+	"git repack -adf && git pack-refs --all && git commit-graph write --reachable",
+}
+
+func newBenchmarkBackends(b *testing.B) (cli, native git.GitBackend, commit api.CommitID) {
+	logger := log.NoOp()
+	dir := repoWithCommands(b, benchmarkRepoCommands...)
+	cache, err := NewPackCache(100)
+	require.NoError(b, err)
+	all, err := ParseOperations("all")
+	require.NoError(b, err)
+
+	cli = gitcli.NewBackend(logger, wrexec.NewNoOpRecordingCommandFactory(), dir, "repo")
+	native = NewBackend(logger, cli, cache, dir, "repo", all)
+	commit, err = cli.ResolveRevision(context.Background(), "HEAD")
+	require.NoError(b, err)
+	return cli, native, commit
+}
+
+func benchmarkBackends(b *testing.B, fn func(b *testing.B, backend git.GitBackend, commit api.CommitID)) {
+	cli, native, commit := newBenchmarkBackends(b)
+	b.Run("cli", func(b *testing.B) { fn(b, cli, commit) })
+	b.Run("native", func(b *testing.B) { fn(b, native, commit) })
+}
+
+func BenchmarkReadFile(b *testing.B) {
+	benchmarkBackends(b, func(b *testing.B, backend git.GitBackend, commit api.CommitID) {
+		ctx := context.Background()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r, err := backend.ReadFile(ctx, commit, fmt.Sprintf("dir%d/sub/file1.txt", i%10+1))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := io.Copy(io.Discard, r); err != nil {
+				b.Fatal(err)
+			}
+			r.Close()
+		}
+	})
+}
+
+func BenchmarkStat(b *testing.B) {
+	benchmarkBackends(b, func(b *testing.B, backend git.GitBackend, commit api.CommitID) {
+		ctx := context.Background()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := backend.Stat(ctx, commit, fmt.Sprintf("dir%d/sub/file%d.txt", i%10+1, i%20+1)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkReadDir(b *testing.B) {
+	for _, recursive := range []bool{false, true} {
+		b.Run(fmt.Sprintf("recursive=%t", recursive), func(b *testing.B) {
+			benchmarkBackends(b, func(b *testing.B, backend git.GitBackend, commit api.CommitID) {
+				ctx := context.Background()
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					it, err := backend.ReadDir(ctx, commit, "", recursive)
+					if err != nil {
+						b.Fatal(err)
+					}
+					for {
+						if _, err := it.Next(); err == io.EOF {
+							break
+						} else if err != nil {
+							b.Fatal(err)
+						}
+					}
+					it.Close()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkResolveRevision(b *testing.B) {
+	for _, spec := range []string{"HEAD", "master", "refs/heads/master"} {
+		b.Run(strings.ReplaceAll(spec, "/", "_"), func(b *testing.B) {
+			benchmarkBackends(b, func(b *testing.B, backend git.GitBackend, commit api.CommitID) {
+				ctx := context.Background()
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := backend.ResolveRevision(ctx, spec); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package native
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	mappedFilesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_native_mapped_files",
+		Help: "Number of files currently memory-mapped by the native git backend.",
+	})
+	mappedBytesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_native_mapped_bytes",
+		Help: "Number of bytes currently memory-mapped by the native git backend.",
+	})
+)
+
+// PackCache keeps packfiles, pack indexes, commit-graphs and packed-refs files
+// memory-mapped across requests, so that hot repositories don't have to be
+// mapped again for every operation. It is safe for concurrent use and is
+// shared by all repositories on a gitserver.
+type PackCache struct {
+	mu    sync.Mutex
+	files *lru.Cache[fileKey, *mappedFile]
+}
+
+// NewPackCache returns a PackCache that keeps at most size files mapped while
+// they are not in use.
+func NewPackCache(size int) (*PackCache, error) {
+	c := &PackCache{}
+	files, err := lru.NewWithEvict(size, func(_ fileKey, f *mappedFile) {
+		// Called with c.mu held.
+		f.evicted = true
+		if f.refs == 0 {
+			f.unmap()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.files = files
+	return c, nil
+}
+
+// fileKey identifies a version of a file on disk. Git never modifies files in
+// the object database in place, but commit-graphs and packed-refs are replaced
+// by renaming a new file over the old one, so the path alone is not enough.
+type fileKey struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+type mappedFile struct {
+	cache *PackCache
+	data  []byte
+
+	// refs and evicted are protected by cache.mu.
+	refs    int
+	evicted bool
+}
+
+// acquire returns the mapped contents of the file at path. The returned file
+// must be released when the caller is done reading from it. If the file does
+// not exist, an error satisfying os.IsNotExist is returned.
+func (c *PackCache) acquire(path string) (*mappedFile, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() == 0 {
+		return nil, errors.Newf("cannot map empty file %q", path)
+	}
+	key := fileKey{path: path, size: fi.Size(), modTime: fi.ModTime()}
+
+	c.mu.Lock()
+	if f, ok := c.files.Get(key); ok {
+		f.refs++
+		c.mu.Unlock()
+		return f, nil
+	}
+	c.mu.Unlock()
+
+	data, err := mapFile(path, fi.Size())
+	if err != nil {
+		return nil, err
+	}
+	f := &mappedFile{cache: c, data: data, refs: 1}
+	mappedFilesGauge.Inc()
+	mappedBytesGauge.Add(float64(len(data)))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Another request might have mapped the same file in the meantime. Prefer
+	// the cached mapping and discard ours.
+	if existing, ok := c.files.Get(key); ok {
+		existing.refs++
+		f.unmap()
+		return existing, nil
+	}
+	c.files.Add(key, f)
+	return f, nil
+}
+
+// release marks the file as no longer used by the caller. Files that have
+// been evicted from the cache are unmapped once their last user releases
+// them.
+func (f *mappedFile) release() {
+	f.cache.mu.Lock()
+	defer f.cache.mu.Unlock()
+	f.refs--
+	if f.evicted && f.refs == 0 {
+		f.unmap()
+	}
+}
+
+func (f *mappedFile) unmap() {
+	if f.data == nil {
+		return
+	}
+	mappedFilesGauge.Dec()
+	mappedBytesGauge.Sub(float64(len(f.data)))
+	// Unmapping only fails for invalid arguments, which would be a bug.
+	_ = unmap(f.data)
+	f.data = nil
+}
+
+func mapFile(path string, size int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	// The mapping stays valid after the file is closed.
+	defer f.Close()
+	data, err := mmap(f, size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "mapping %q", path)
+	}
+	return data, nil
+}
@@ -0,0 +1,153 @@
+package native
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// commitGraph is a memory-mapped commit-graph file, which lets us look up the
+// tree of a commit without inflating the commit object. See
+// https://git-scm.com/docs/gitformat-commit-graph.
+type commitGraph struct {
+	file  *mappedFile
+	oidf  []byte
+	oidl  []byte
+	cdat  []byte
+	count uint32
+}
+
+const (
+	commitGraphHeaderSize = 8
+	commitGraphChunkSize  = 12
+	commitGraphDataSize   = oidSize + 16
+)
+
+// openCommitGraphs maps the commit-graph of the repository, which is either a
+// single file or a chain of incremental files. Repositories without a
+// commit-graph return no graphs and no error.
+func openCommitGraphs(cache *PackCache, objectsDir string) ([]*commitGraph, error) {
+	var paths []string
+
+	chain, err := os.Open(filepath.Join(objectsDir, "info", "commit-graphs", "commit-graph-chain"))
+	switch {
+	case err == nil:
+		defer chain.Close()
+		sc := bufio.NewScanner(chain)
+		for sc.Scan() {
+			if line := sc.Text(); line != "" {
+				paths = append(paths, filepath.Join(objectsDir, "info", "commit-graphs", "graph-"+line+".graph"))
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	case os.IsNotExist(err):
+		paths = append(paths, filepath.Join(objectsDir, "info", "commit-graph"))
+	default:
+		return nil, err
+	}
+
+	var graphs []*commitGraph
+	for _, path := range paths {
+		f, err := cache.acquire(path)
+		if err != nil {
+			closeCommitGraphs(graphs)
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		g := &commitGraph{file: f}
+		if err := g.parse(); err != nil {
+			f.release()
+			closeCommitGraphs(graphs)
+			return nil, errors.Wrapf(err, "invalid commit-graph %q", path)
+		}
+		graphs = append(graphs, g)
+	}
+	return graphs, nil
+}
+
+func closeCommitGraphs(graphs []*commitGraph) {
+	for _, g := range graphs {
+		g.file.release()
+	}
+}
+
+func (g *commitGraph) parse() error {
+	data := g.file.data
+	if len(data) < commitGraphHeaderSize || !bytes.Equal(data[:4], []byte("CGPH")) {
+		return errors.New("missing commit-graph signature")
+	}
+	if data[4] != 1 {
+		return errors.Newf("unsupported commit-graph version %d", data[4])
+	}
+	if data[5] != 1 {
+		// Hash version 2 is SHA-256.
+		return errors.Newf("unsupported commit-graph hash version %d", data[5])
+	}
+
+	numChunks := int(data[6])
+	lookup := data[commitGraphHeaderSize:]
+	if len(lookup) < (numChunks+1)*commitGraphChunkSize {
+		return errors.New("commit-graph chunk table is truncated")
+	}
+	for i := 0; i < numChunks; i++ {
+		entry := lookup[i*commitGraphChunkSize:]
+		next := lookup[(i+1)*commitGraphChunkSize:]
+		start := binary.BigEndian.Uint64(entry[4:])
+		end := binary.BigEndian.Uint64(next[4:])
+		if start > end || end > uint64(len(data)) {
+			return errors.New("commit-graph chunk out of bounds")
+		}
+		chunk := data[start:end]
+		switch string(entry[:4]) {
+		case "OIDF":
+			g.oidf = chunk
+		case "OIDL":
+			g.oidl = chunk
+		case "CDAT":
+			g.cdat = chunk
+		}
+	}
+
+	if len(g.oidf) != 256*4 {
+		return errors.New("commit-graph has no valid OID fanout")
+	}
+	g.count = binary.BigEndian.Uint32(g.oidf[255*4:])
+	if len(g.oidl) != int(g.count)*oidSize || len(g.cdat) != int(g.count)*commitGraphDataSize {
+		return errors.New("commit-graph OID lookup and commit data do not match fanout")
+	}
+	return nil
+}
+
+// commitTree returns the tree of the given commit, if the commit is part of
+// the graph.
+func (g *commitGraph) commitTree(oid gitdomain.OID) (gitdomain.OID, bool) {
+	var lo uint32
+	if oid[0] > 0 {
+		lo = binary.BigEndian.Uint32(g.oidf[(int(oid[0])-1)*4:])
+	}
+	hi := binary.BigEndian.Uint32(g.oidf[int(oid[0])*4:])
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		switch bytes.Compare(g.oidl[mid*oidSize:(mid+1)*oidSize], oid[:]) {
+		case 0:
+			var tree gitdomain.OID
+			copy(tree[:], g.cdat[mid*commitGraphDataSize:])
+			return tree, true
+		case -1:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return gitdomain.OID{}, false
+}
@@ -0,0 +1,110 @@
+package native
+
+import (
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var errInvalidDelta = errors.New("invalid delta")
+
+// deltaHeader returns the sizes of the base and result objects encoded at
+// the start of a delta, and the remaining delta instructions.
+func deltaHeader(delta []byte) (baseSize, resultSize int64, rest []byte, err error) {
+	baseSize, n := deltaVarint(delta)
+	if n == 0 {
+		return 0, 0, nil, errInvalidDelta
+	}
+	delta = delta[n:]
+	resultSize, n = deltaVarint(delta)
+	if n == 0 {
+		return 0, 0, nil, errInvalidDelta
+	}
+	return baseSize, resultSize, delta[n:], nil
+}
+
+// applyDelta reconstructs an object from its base and a delta as produced by
+// git-pack-objects. See
+// https://git-scm.com/docs/pack-format#_deltified_representation.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	baseSize, resultSize, delta, err := deltaHeader(delta)
+	if err != nil {
+		return nil, err
+	}
+	if baseSize != int64(len(base)) {
+		return nil, errors.Wrapf(errInvalidDelta, "base size %d does not match delta base size %d", len(base), baseSize)
+	}
+	if resultSize > maxObjectSize {
+		return nil, errObjectTooLarge
+	}
+
+	result := make([]byte, 0, resultSize)
+	for len(delta) > 0 {
+		cmd := delta[0]
+		delta = delta[1:]
+
+		switch {
+		case cmd&0x80 != 0:
+			// Copy from base. The low 4 bits select which offset bytes
+			// follow, the next 3 bits which size bytes follow.
+			var offset, size uint64
+			for i := uint(0); i < 4; i++ {
+				if cmd&(1<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errInvalidDelta
+					}
+					offset |= uint64(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			for i := uint(0); i < 3; i++ {
+				if cmd&(0x10<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errInvalidDelta
+					}
+					size |= uint64(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			if size == 0 {
+				size = 0x10000
+			}
+			if offset+size > uint64(len(base)) || uint64(len(result))+size > uint64(resultSize) {
+				return nil, errInvalidDelta
+			}
+			result = append(result, base[offset:offset+size]...)
+
+		case cmd != 0:
+			// Insert the next cmd bytes of the delta.
+			size := int(cmd)
+			if size > len(delta) || int64(len(result)+size) > resultSize {
+				return nil, errInvalidDelta
+			}
+			result = append(result, delta[:size]...)
+			delta = delta[size:]
+
+		default:
+			// Opcode 0 is reserved.
+			return nil, errInvalidDelta
+		}
+	}
+
+	if int64(len(result)) != resultSize {
+		return nil, errors.Wrapf(errInvalidDelta, "result size %d does not match expected size %d", len(result), resultSize)
+	}
+	return result, nil
+}
+
+// deltaVarint decodes the little-endian base 128 size encoding used in delta
+// headers. It returns the number of bytes read, or 0 if buf is truncated.
+func deltaVarint(buf []byte) (int64, int) {
+	var v int64
+	for i, b := range buf {
+		if i == 9 {
+			return 0, 0
+		}
+		v |= int64(b&0x7f) << (7 * uint(i))
+		if b&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
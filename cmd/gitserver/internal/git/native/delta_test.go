@@ -0,0 +1,37 @@
+package native
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("the quick brown fox jumps over the lazy dog")
+
+	// Copy "the quick ", insert "red", copy " fox jumps over the lazy dog".
+	delta := []byte{
+		byte(len(base)), // base size
+		41,              // result size
+		0x90, 10,        // copy offset 0, size 10
+		3, 'r', 'e', 'd', // insert
+		0x91, 15, 28, // copy offset 15, size 28
+	}
+	result, err := applyDelta(base, delta)
+	require.NoError(t, err)
+	require.Equal(t, "the quick red fox jumps over the lazy dog", string(result))
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, delta := range map[string][]byte{
+			"base size mismatch": {1, 1, 1, 'a'},
+			"result too short":   {byte(len(base)), 5, 1, 'a'},
+			"copy out of bounds": {byte(len(base)), 10, 0x91, 40, 10},
+			"truncated insert":   {byte(len(base)), 5, 5, 'a'},
+			"reserved opcode":    {byte(len(base)), 1, 0},
+			"truncated header":   {0x80},
+		} {
+			_, err := applyDelta(base, delta)
+			require.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package native
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// looseObject is a reader for a loose object, positioned after its header.
+type looseObject struct {
+	typ  objectType
+	size int64
+
+	f *os.File
+	z io.ReadCloser
+	r io.Reader
+}
+
+// openLoose opens the loose object at path and parses its header. See
+// https://git-scm.com/book/en/v2/Git-Internals-Git-Objects#_object_storage.
+func openLoose(path string) (_ *looseObject, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+		}
+	}()
+
+	z, err := newZlibReader(bufio.NewReader(f))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading loose object %q", path)
+	}
+	br := bufio.NewReaderSize(z, 512)
+
+	// The header is "<type> <size>\x00".
+	header, err := br.ReadSlice(0)
+	if err != nil {
+		putZlibReader(z)
+		return nil, errors.Wrapf(err, "reading loose object header %q", path)
+	}
+	typName, sizeStr, ok := bytes.Cut(header[:len(header)-1], []byte{' '})
+	typ, typOK := parseObjectType(typName)
+	size, sizeErr := strconv.ParseInt(string(sizeStr), 10, 64)
+	if !ok || !typOK || sizeErr != nil || size < 0 {
+		putZlibReader(z)
+		return nil, errors.Newf("invalid loose object header %q in %q", header, path)
+	}
+
+	return &looseObject{
+		typ:  typ,
+		size: size,
+		f:    f,
+		z:    z,
+		r:    io.LimitReader(br, size),
+	}, nil
+}
+
+func (o *looseObject) Read(p []byte) (int, error) {
+	return o.r.Read(p)
+}
+
+func (o *looseObject) Close() error {
+	putZlibReader(o.z)
+	return o.f.Close()
+}
+
+// readAll reads the contents of the object and closes it.
+func (o *looseObject) readAll() ([]byte, error) {
+	defer o.Close()
+	if o.size > maxObjectSize {
+		return nil, errObjectTooLarge
+	}
+	buf := make([]byte, o.size)
+	if _, err := io.ReadFull(o.r, buf); err != nil {
+		return nil, errors.Wrap(err, "inflating loose object")
+	}
+	return buf, nil
+}
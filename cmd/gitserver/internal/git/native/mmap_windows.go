@@ -0,0 +1,16 @@
+package native
+
+import (
+	"os"
+
+	mmapgo "github.com/edsrzf/mmap-go"
+)
+
+func mmap(f *os.File, _ int64) ([]byte, error) {
+	return mmapgo.Map(f, mmapgo.RDONLY, 0)
+}
+
+func unmap(data []byte) error {
+	m := mmapgo.MMap(data)
+	return m.Unmap()
+}
@@ -0,0 +1,195 @@
+package native
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// objectType is the type of an object as encoded in packfiles.
+type objectType int8
+
+const (
+	objectCommit   objectType = 1
+	objectTree     objectType = 2
+	objectBlob     objectType = 3
+	objectTag      objectType = 4
+	objectOfsDelta objectType = 6
+	objectRefDelta objectType = 7
+)
+
+func (t objectType) String() string {
+	switch t {
+	case objectCommit:
+		return "commit"
+	case objectTree:
+		return "tree"
+	case objectBlob:
+		return "blob"
+	case objectTag:
+		return "tag"
+	case objectOfsDelta:
+		return "ofs-delta"
+	case objectRefDelta:
+		return "ref-delta"
+	}
+	return "unknown"
+}
+
+func parseObjectType(s []byte) (objectType, bool) {
+	switch string(s) {
+	case "commit":
+		return objectCommit, true
+	case "tree":
+		return objectTree, true
+	case "blob":
+		return objectBlob, true
+	case "tag":
+		return objectTag, true
+	}
+	return 0, false
+}
+
+// maxObjectSize is the size above which objects are not materialized in
+// memory. Larger objects are left to the CLI backend, which streams them.
+const maxObjectSize = 256 * 1024 * 1024
+
+var errObjectTooLarge = errors.Wrap(errUnsupported, "object too large")
+
+// zlibReaders pools zlib readers, whose decompression state is comparatively
+// expensive to allocate.
+var zlibReaders sync.Pool
+
+func newZlibReader(r io.Reader) (io.ReadCloser, error) {
+	if z, ok := zlibReaders.Get().(io.ReadCloser); ok {
+		if err := z.(zlib.Resetter).Reset(r, nil); err != nil {
+			return nil, err
+		}
+		return z, nil
+	}
+	return zlib.NewReader(r)
+}
+
+func putZlibReader(z io.ReadCloser) {
+	zlibReaders.Put(z)
+}
+
+// inflate decompresses the zlib stream at the start of data, which is known
+// to decompress to size bytes.
+func inflate(data []byte, size int64) ([]byte, error) {
+	if size > maxObjectSize {
+		return nil, errObjectTooLarge
+	}
+	z, err := newZlibReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer putZlibReader(z)
+
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(z, buf); err != nil {
+		return nil, errors.Wrap(err, "inflating object")
+	}
+	return buf, nil
+}
+
+// inflatePrefix decompresses at most n bytes from the start of the zlib stream
+// at the start of data.
+func inflatePrefix(data []byte, n int) ([]byte, error) {
+	z, err := newZlibReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer putZlibReader(z)
+
+	buf := make([]byte, n)
+	read, err := io.ReadFull(z, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, errors.Wrap(err, "inflating object")
+	}
+	return buf[:read], nil
+}
+
+// streamReader streams the decompressed contents of an object and calls
+// release when closed.
+type streamReader struct {
+	z       io.ReadCloser
+	r       io.Reader
+	release func()
+	closed  bool
+}
+
+func newStreamReader(data []byte, size int64, release func()) (*streamReader, error) {
+	z, err := newZlibReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &streamReader{
+		z: z,
+		// Objects are followed by other data in packfiles, so we need to
+		// stop at the object size rather than at the end of the input.
+		r:       io.LimitReader(z, size),
+		release: release,
+	}, nil
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return s.r.Read(p)
+}
+
+func (s *streamReader) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	putZlibReader(s.z)
+	s.release()
+	return nil
+}
+
+// parseCommitTree returns the tree of a raw commit object.
+func parseCommitTree(data []byte) (gitdomain.OID, error) {
+	line, _, _ := bytes.Cut(data, []byte{'\n'})
+	hexTree, ok := bytes.CutPrefix(line, []byte("tree "))
+	if !ok {
+		return gitdomain.OID{}, errors.New("commit object has no tree")
+	}
+	return decodeOID(hexTree)
+}
+
+// parseTagTarget returns the object and type referenced by a raw tag object.
+func parseTagTarget(data []byte) (gitdomain.OID, objectType, error) {
+	var (
+		target    gitdomain.OID
+		typ       objectType
+		hasTarget bool
+	)
+	for len(data) > 0 {
+		var line []byte
+		line, data, _ = bytes.Cut(data, []byte{'\n'})
+		if len(line) == 0 {
+			// End of the header.
+			break
+		}
+		if v, ok := bytes.CutPrefix(line, []byte("object ")); ok {
+			oid, err := decodeOID(v)
+			if err != nil {
+				return target, 0, err
+			}
+			target, hasTarget = oid, true
+		} else if v, ok := bytes.CutPrefix(line, []byte("type ")); ok {
+			typ, _ = parseObjectType(v)
+		}
+	}
+	if !hasTarget || typ == 0 {
+		return target, 0, errors.New("malformed tag object")
+	}
+	return target, typ, nil
+}
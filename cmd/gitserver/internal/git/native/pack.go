@@ -0,0 +1,213 @@
+package native
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// pack is a packfile together with its index, both memory-mapped. See
+// https://git-scm.com/docs/pack-format.
+type pack struct {
+	path  string
+	idx   *mappedFile
+	data  *mappedFile
+	count uint32
+}
+
+const (
+	idxHeaderSize = 8
+	idxFanoutSize = 256 * 4
+	packHeaderLen = 12
+	oidSize       = 20
+)
+
+var idxMagic = []byte{0xff, 't', 'O', 'c'}
+
+// openPack maps the pack index at idxPath and the packfile next to it.
+func openPack(cache *PackCache, idxPath string) (*pack, error) {
+	idx, err := cache.acquire(idxPath)
+	if err != nil {
+		return nil, err
+	}
+	packPath := strings.TrimSuffix(idxPath, ".idx") + ".pack"
+	data, err := cache.acquire(packPath)
+	if err != nil {
+		idx.release()
+		return nil, err
+	}
+
+	p := &pack{path: packPath, idx: idx, data: data}
+	if err := p.validate(); err != nil {
+		p.close()
+		return nil, errors.Wrapf(err, "invalid pack %q", packPath)
+	}
+	return p, nil
+}
+
+func (p *pack) validate() error {
+	idx := p.idx.data
+	if len(idx) < idxHeaderSize+idxFanoutSize || !bytes.Equal(idx[:4], idxMagic) {
+		// Version 1 indexes have no header. They haven't been written by
+		// default since git 1.5.2 so we don't bother supporting them.
+		return errors.Wrap(errUnsupported, "pack index is not version 2")
+	}
+	if v := binary.BigEndian.Uint32(idx[4:8]); v != 2 {
+		return errors.Wrapf(errUnsupported, "pack index version %d", v)
+	}
+	p.count = p.fanout(255)
+	// Names, CRCs, and offsets, followed by the pack and index checksums.
+	// The table of large offsets is checked on access.
+	if int64(len(idx)) < int64(idxHeaderSize+idxFanoutSize)+int64(p.count)*(oidSize+4+4)+2*oidSize {
+		return errors.New("pack index is truncated")
+	}
+
+	data := p.data.data
+	if len(data) < packHeaderLen || !bytes.Equal(data[:4], []byte("PACK")) {
+		return errors.New("not a packfile")
+	}
+	if v := binary.BigEndian.Uint32(data[4:8]); v != 2 && v != 3 {
+		return errors.Wrapf(errUnsupported, "packfile version %d", v)
+	}
+	if n := binary.BigEndian.Uint32(data[8:12]); n != p.count {
+		return errors.Newf("packfile has %d objects, index has %d", n, p.count)
+	}
+	return nil
+}
+
+func (p *pack) close() {
+	p.idx.release()
+	p.data.release()
+}
+
+func (p *pack) fanout(b int) uint32 {
+	off := idxHeaderSize + 4*b
+	return binary.BigEndian.Uint32(p.idx.data[off:])
+}
+
+func (p *pack) name(i uint32) []byte {
+	off := idxHeaderSize + idxFanoutSize + int64(i)*oidSize
+	return p.idx.data[off : off+oidSize]
+}
+
+// find returns the offset of the object with the given ID in the packfile.
+func (p *pack) find(oid gitdomain.OID) (int64, bool, error) {
+	var lo uint32
+	if oid[0] > 0 {
+		lo = p.fanout(int(oid[0]) - 1)
+	}
+	hi := p.fanout(int(oid[0]))
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		switch bytes.Compare(p.name(mid), oid[:]) {
+		case 0:
+			off, err := p.offset(mid)
+			return off, err == nil, err
+		case -1:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false, nil
+}
+
+func (p *pack) offset(i uint32) (int64, error) {
+	idx := p.idx.data
+	offsets := int64(idxHeaderSize+idxFanoutSize) + int64(p.count)*(oidSize+4)
+	off := binary.BigEndian.Uint32(idx[offsets+int64(i)*4:])
+	if off&0x80000000 == 0 {
+		return int64(off), nil
+	}
+
+	// Offsets beyond 2GiB are stored in a separate table of 64-bit offsets.
+	large := offsets + int64(p.count)*4 + int64(off&0x7fffffff)*8
+	if large+8 > int64(len(idx))-2*oidSize {
+		return 0, errors.New("pack index large offset out of bounds")
+	}
+	return int64(binary.BigEndian.Uint64(idx[large:])), nil
+}
+
+// packEntry describes an object stored in a packfile.
+type packEntry struct {
+	typ objectType
+	// size is the inflated size of the object data. For deltas, this is
+	// the size of the delta, not of the resulting object.
+	size int64
+	// dataOffset is the offset of the compressed object data.
+	dataOffset int64
+	// baseOffset is the offset of the base object of offset deltas.
+	baseOffset int64
+	// baseOID is the ID of the base object of reference deltas.
+	baseOID gitdomain.OID
+}
+
+// entry parses the header of the object at offset.
+func (p *pack) entry(offset int64) (packEntry, error) {
+	data := p.data.data
+	// The trailing checksum is never part of an object.
+	end := int64(len(data)) - oidSize
+	if offset < packHeaderLen || offset >= end {
+		return packEntry{}, errors.Newf("pack offset %d out of bounds", offset)
+	}
+
+	pos := offset
+	c := data[pos]
+	pos++
+	e := packEntry{typ: objectType((c >> 4) & 0x7), size: int64(c & 0x0f)}
+	for shift := uint(4); c&0x80 != 0; shift += 7 {
+		if pos >= end || shift > 56 {
+			return packEntry{}, errors.Newf("invalid object header at pack offset %d", offset)
+		}
+		c = data[pos]
+		pos++
+		e.size |= int64(c&0x7f) << shift
+	}
+
+	switch e.typ {
+	case objectCommit, objectTree, objectBlob, objectTag:
+	case objectOfsDelta:
+		// The negative offset to the base is encoded in a big-endian base
+		// 128 encoding where each continuation adds one, so that every
+		// value has a single encoding.
+		if pos >= end {
+			return packEntry{}, errors.Newf("invalid delta header at pack offset %d", offset)
+		}
+		c = data[pos]
+		pos++
+		rel := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			if pos >= end || rel > (1<<56) {
+				return packEntry{}, errors.Newf("invalid delta header at pack offset %d", offset)
+			}
+			c = data[pos]
+			pos++
+			rel = ((rel + 1) << 7) | int64(c&0x7f)
+		}
+		e.baseOffset = offset - rel
+		if rel <= 0 || e.baseOffset < packHeaderLen {
+			return packEntry{}, errors.Newf("invalid delta base offset at pack offset %d", offset)
+		}
+	case objectRefDelta:
+		if pos+oidSize > end {
+			return packEntry{}, errors.Newf("invalid delta header at pack offset %d", offset)
+		}
+		copy(e.baseOID[:], data[pos:pos+oidSize])
+		pos += oidSize
+	default:
+		return packEntry{}, errors.Newf("invalid object type %d at pack offset %d", e.typ, offset)
+	}
+
+	e.dataOffset = pos
+	return e, nil
+}
+
+// compressed returns the compressed data of the entry. The slice extends to
+// the end of the pack, as the compressed size is not stored.
+func (p *pack) compressed(e packEntry) []byte {
+	return p.data.data[e.dataOffset : len(p.data.data)-oidSize]
+}
@@ -0,0 +1,231 @@
+package native
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/format/config"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type objectInfo gitdomain.OID
+
+func (oid objectInfo) OID() gitdomain.OID { return gitdomain.OID(oid) }
+
+func rootFileInfo(tree gitdomain.OID) fs.FileInfo {
+	return &fileutil.FileInfo{Mode_: os.ModeDir, Sys_: objectInfo(tree)}
+}
+
+// fileInfo returns the file info for a tree entry, in the same shape as the
+// CLI backend derives it from git ls-tree --long.
+func (r *repository) fileInfo(modules func() (config.Config, error), e treeEntry, name string) (fs.FileInfo, error) {
+	mode := os.FileMode(e.mode)
+	var (
+		size int64
+		sys  any = objectInfo(e.oid)
+	)
+	switch {
+	case e.isTree():
+		mode = mode | os.ModeDir
+	case e.isSubmodule():
+		mode = gitdomain.ModeSubmodule
+		modconf, err := modules()
+		if err != nil {
+			return nil, err
+		}
+		sub := submoduleConfig(modconf, name)
+		sys = gitdomain.Submodule{
+			URL:      sub.Option("url"),
+			Path:     sub.Option("path"),
+			CommitID: api.CommitID(e.oid.String()),
+		}
+	default:
+		if mode&gitdomain.ModeSymlink != 0 {
+			mode = os.ModeSymlink
+		} else {
+			// Regular file.
+			mode = mode | 0o644
+		}
+		var err error
+		if size, err = r.objectSize(e.oid); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fileutil.FileInfo{
+		Name_: name, // full path relative to root (not just basename)
+		Mode_: mode,
+		Size_: size,
+		Sys_:  sys,
+	}, nil
+}
+
+// newGitModules returns a function that lazily loads the .gitmodules
+// configuration at the given root tree, which is only needed if a listing
+// contains submodules.
+func newGitModules(r *repository, root gitdomain.OID) func() (config.Config, error) {
+	return sync.OnceValues(func() (config.Config, error) {
+		entry, ok, err := r.lookupPath(root, ".gitmodules")
+		if err != nil {
+			return config.Config{}, err
+		}
+		if !ok || entry.isTree() || entry.isSubmodule() {
+			return config.Config{}, nil
+		}
+		typ, modfile, err := r.readObject(entry.oid)
+		if err != nil {
+			return config.Config{}, err
+		}
+		if typ != objectBlob {
+			return config.Config{}, errors.Newf("object %s is a %s, not a blob", entry.oid, typ)
+		}
+
+		var cfg config.Config
+		if err := config.NewDecoder(bytes.NewReader(modfile)).Decode(&cfg); err != nil {
+			return config.Config{}, errors.Wrap(err, "error parsing .gitmodules")
+		}
+		return cfg, nil
+	})
+}
+
+// submoduleConfig returns the .gitmodules section of the submodule at path.
+// Sections are keyed by the submodule name, which usually but not necessarily
+// is the same as its path.
+func submoduleConfig(modconf config.Config, path string) *config.Subsection {
+	section := modconf.Section("submodule")
+	for _, sub := range section.Subsections {
+		if sub.Option("path") == path {
+			return sub
+		}
+	}
+	return section.Subsection(path)
+}
+
+// readDirIterator lists trees in the same order as git ls-tree. Recursive
+// listings visit a tree entry before its contents, like git ls-tree -r -t.
+type readDirIterator struct {
+	repo      *repository
+	modules   func() (config.Config, error)
+	recursive bool
+
+	// pending are entries returned before the contents of the listed tree.
+	pending []fs.FileInfo
+	stack   []readDirFrame
+	err     error
+}
+
+type readDirFrame struct {
+	entries []treeEntry
+	prefix  string
+}
+
+func (it *readDirIterator) push(tree gitdomain.OID, prefix string) error {
+	data, err := it.repo.readTree(tree)
+	if err != nil {
+		return err
+	}
+	var entries []treeEntry
+	if err := forEachTreeEntry(data, func(e treeEntry) bool {
+		entries = append(entries, e)
+		return true
+	}); err != nil {
+		return err
+	}
+	it.stack = append(it.stack, readDirFrame{entries: entries, prefix: prefix})
+	return nil
+}
+
+func (it *readDirIterator) Next() (fs.FileInfo, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if len(it.pending) > 0 {
+		fi := it.pending[0]
+		it.pending = it.pending[1:]
+		return fi, nil
+	}
+	for len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if len(top.entries) == 0 {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		e := top.entries[0]
+		top.entries = top.entries[1:]
+		name := top.prefix + e.name
+
+		fi, err := it.repo.fileInfo(it.modules, e, name)
+		if err != nil {
+			it.err = err
+			return nil, err
+		}
+		if it.recursive && e.isTree() {
+			// top is invalidated by push.
+			if err := it.push(e.oid, name+"/"); err != nil {
+				it.err = err
+				return nil, err
+			}
+		}
+		return fi, nil
+	}
+	return nil, io.EOF
+}
+
+func (it *readDirIterator) Close() error {
+	it.repo.close()
+	return nil
+}
+
+// fallbackReadDirIterator switches to the CLI backend if the native iterator
+// fails mid-listing. Both list entries in the same order, so the entries
+// already returned are skipped.
+type fallbackReadDirIterator struct {
+	native   *readDirIterator
+	fallback func() (git.ReadDirIterator, error)
+	served   func(op string, err error) bool
+
+	cli  git.ReadDirIterator
+	seen int
+}
+
+func (it *fallbackReadDirIterator) Next() (fs.FileInfo, error) {
+	if it.cli != nil {
+		return it.cli.Next()
+	}
+
+	fi, err := it.native.Next()
+	if err == nil {
+		it.seen++
+		return fi, nil
+	}
+	if err == io.EOF || it.served("ReadDir", err) {
+		return nil, err
+	}
+
+	it.native.Close()
+	if it.cli, err = it.fallback(); err != nil {
+		it.cli = nil
+		return nil, err
+	}
+	for i := 0; i < it.seen; i++ {
+		if _, err := it.cli.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return it.cli.Next()
+}
+
+func (it *fallbackReadDirIterator) Close() error {
+	if it.cli != nil {
+		return it.cli.Close()
+	}
+	return it.native.Close()
+}
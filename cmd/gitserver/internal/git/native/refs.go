@@ -0,0 +1,206 @@
+package native
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxSymrefDepth bounds the number of symbolic refs we follow, like git does.
+const maxSymrefDepth = 5
+
+// resolveRevision resolves a revspec that is either an object ID or a ref
+// name, using the same rules as git-rev-parse. Revspecs using any other
+// syntax return errUnsupported. If no ref matches, errUnsupported is returned
+// as well, since git would go on to try the revspec as an abbreviated object
+// ID.
+func (r *repository) resolveRevision(spec string) (gitdomain.OID, error) {
+	if gitdomain.IsAbsoluteRevision(spec) {
+		return decodeOID(spec)
+	}
+	if !isPlainRefName(spec) {
+		return gitdomain.OID{}, errors.Wrapf(errUnsupported, "revspec %q", spec)
+	}
+
+	// See "<refname>" in https://git-scm.com/docs/gitrevisions.
+	candidates := []string{
+		spec,
+		"refs/" + spec,
+		"refs/tags/" + spec,
+		"refs/heads/" + spec,
+		"refs/remotes/" + spec,
+		"refs/remotes/" + spec + "/HEAD",
+	}
+	for i, name := range candidates {
+		if i == 0 && spec != "HEAD" && !strings.HasPrefix(spec, "refs/") {
+			// git also looks up other names directly in the git directory,
+			// e.g. FETCH_HEAD, which has a format of its own. We leave
+			// those to git.
+			if _, err := os.Stat(r.dir.Path(filepath.FromSlash(spec))); err == nil {
+				return gitdomain.OID{}, errors.Wrapf(errUnsupported, "revspec %q", spec)
+			}
+			continue
+		}
+		oid, ok, err := r.resolveRef(name)
+		if err != nil || ok {
+			return oid, err
+		}
+	}
+	return gitdomain.OID{}, errors.Wrapf(errUnsupported, "no ref matches revspec %q", spec)
+}
+
+// isPlainRefName reports whether name can only be interpreted as a ref name,
+// ie. it uses none of the revision syntax described in gitrevisions(7).
+func isPlainRefName(name string) bool {
+	if name == "" || name == "@" ||
+		strings.HasPrefix(name, "-") || strings.HasPrefix(name, "/") ||
+		strings.HasSuffix(name, "/") || strings.HasSuffix(name, ".") || strings.HasSuffix(name, ".lock") ||
+		strings.Contains(name, "..") || strings.Contains(name, "//") || strings.Contains(name, "/.") ||
+		strings.Contains(name, "@{") || strings.HasPrefix(name, ".") {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c <= ' ' || c == 0x7f || strings.IndexByte("~^:?*[\\", c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveRef returns the object the fully qualified ref points to, following
+// symbolic refs.
+func (r *repository) resolveRef(name string) (gitdomain.OID, bool, error) {
+	if _, err := os.Stat(r.dir.Path("reftable")); err == nil {
+		return gitdomain.OID{}, false, errors.Wrap(errUnsupported, "repository uses reftable")
+	}
+
+	for i := 0; i < maxSymrefDepth; i++ {
+		content, err := os.ReadFile(r.dir.Path(filepath.FromSlash(name)))
+		if err != nil {
+			// A directory or a file in place of a parent directory means
+			// the ref only exists as a prefix of other refs.
+			if !os.IsNotExist(err) && !errors.Is(err, syscall.EISDIR) && !errors.Is(err, syscall.ENOTDIR) {
+				return gitdomain.OID{}, false, err
+			}
+			// Only regular refs can be packed.
+			return r.resolvePackedRef(name)
+		}
+
+		content = bytes.TrimSpace(content)
+		if target, ok := bytes.CutPrefix(content, []byte("ref: ")); ok {
+			name = string(target)
+			continue
+		}
+		oid, err := decodeOID(content)
+		if err != nil {
+			return gitdomain.OID{}, false, errors.Wrapf(err, "invalid ref %q", name)
+		}
+		return oid, true, nil
+	}
+	return gitdomain.OID{}, false, errors.Newf("symbolic ref %q is nested too deeply", name)
+}
+
+// resolvePackedRef looks up a ref in the packed-refs file. See
+// https://git-scm.com/docs/git-pack-refs.
+func (r *repository) resolvePackedRef(name string) (gitdomain.OID, bool, error) {
+	if !r.packedRefsLoaded {
+		r.packedRefsLoaded = true
+		f, err := r.cache.acquire(r.dir.Path("packed-refs"))
+		if err != nil && !os.IsNotExist(err) {
+			return gitdomain.OID{}, false, err
+		}
+		r.packedRefs = f
+	}
+	if r.packedRefs == nil {
+		return gitdomain.OID{}, false, nil
+	}
+
+	hexOID, ok := findPackedRef(r.packedRefs.data, name)
+	if !ok {
+		return gitdomain.OID{}, false, nil
+	}
+	oid, err := decodeOID(hexOID)
+	if err != nil {
+		return gitdomain.OID{}, false, errors.Wrapf(err, "invalid packed ref %q", name)
+	}
+	return oid, true, nil
+}
+
+// findPackedRef returns the object ID of the named ref in the contents of a
+// packed-refs file. Records are "<oid> <refname>" lines, each optionally
+// followed by a "^<oid>" line with the peeled value of a tag.
+func findPackedRef(data []byte, name string) ([]byte, bool) {
+	start := 0
+	sorted := false
+	if header, ok := bytes.CutPrefix(data, []byte("# pack-refs with:")); ok {
+		line, _, _ := bytes.Cut(header, []byte{'\n'})
+		sorted = bytes.Contains(line, []byte(" sorted ")) || bytes.HasSuffix(line, []byte(" sorted"))
+		start = len(data) - len(header) + len(line) + 1
+		if start > len(data) {
+			start = len(data)
+		}
+	}
+
+	if !sorted {
+		for pos := start; pos < len(data); {
+			line, next := packedRefLine(data, pos)
+			if oid, ref, ok := parsePackedRef(line); ok && ref == name {
+				return oid, true
+			}
+			pos = next
+		}
+		return nil, false
+	}
+
+	// Binary search for the record. lo always points at the start of a
+	// record, hi at the start of a record or the end of the data.
+	lo, hi := start, len(data)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ls := lo + bytes.LastIndexByte(data[lo:mid], '\n') + 1
+		if data[ls] == '^' {
+			// A peeled line belongs to the record before it.
+			ls = lo + bytes.LastIndexByte(data[lo:ls-1], '\n') + 1
+		}
+		line, next := packedRefLine(data, ls)
+		oid, ref, ok := parsePackedRef(line)
+		if !ok {
+			return nil, false
+		}
+		switch {
+		case ref == name:
+			return oid, true
+		case ref < name:
+			for next < hi && data[next] == '^' {
+				_, next = packedRefLine(data, next)
+			}
+			lo = next
+		default:
+			hi = ls
+		}
+	}
+	return nil, false
+}
+
+// packedRefLine returns the line starting at pos without its line ending, and
+// the position of the next line.
+func packedRefLine(data []byte, pos int) ([]byte, int) {
+	end := bytes.IndexByte(data[pos:], '\n')
+	if end < 0 {
+		return data[pos:], len(data)
+	}
+	return bytes.TrimSuffix(data[pos:pos+end], []byte{'\r'}), pos + end + 1
+}
+
+func parsePackedRef(line []byte) (oid []byte, ref string, ok bool) {
+	if len(line) == 0 || line[0] == '^' || line[0] == '#' {
+		return nil, "", false
+	}
+	oid, refName, ok := bytes.Cut(line, []byte{' '})
+	return oid, string(refName), ok
+}
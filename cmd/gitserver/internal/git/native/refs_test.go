@@ -0,0 +1,54 @@
+package native
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPackedRef(t *testing.T) {
+	const (
+		oidA = "1111111111111111111111111111111111111111"
+		oidB = "2222222222222222222222222222222222222222"
+		oidC = "3333333333333333333333333333333333333333"
+		oidD = "4444444444444444444444444444444444444444"
+	)
+	records := oidA + " refs/heads/feature/x\n" +
+		oidB + " refs/heads/master\n" +
+		oidC + " refs/tags/v1\n" +
+		"^" + oidA + "\n" +
+		oidD + " refs/tags/v2\n"
+
+	for name, data := range map[string]string{
+		"sorted":    "# pack-refs with: peeled fully-peeled sorted \n" + records,
+		"unsorted":  "# pack-refs with: peeled \n" + records,
+		"no header": records,
+	} {
+		t.Run(name, func(t *testing.T) {
+			for ref, want := range map[string]string{
+				"refs/heads/feature/x": oidA,
+				"refs/heads/master":    oidB,
+				"refs/tags/v1":         oidC,
+				"refs/tags/v2":         oidD,
+			} {
+				have, ok := findPackedRef([]byte(data), ref)
+				require.True(t, ok, ref)
+				require.Equal(t, want, string(have), ref)
+			}
+
+			for _, ref := range []string{"refs/heads/feature", "refs/heads/main", "refs/tags/v0", "refs/tags/v3", "^" + oidA} {
+				_, ok := findPackedRef([]byte(data), ref)
+				require.False(t, ok, ref)
+			}
+		})
+	}
+}
+
+func TestIsPlainRefName(t *testing.T) {
+	for _, name := range []string{"HEAD", "master", "feature/x", "refs/heads/master", "v1.0.0", "deadbeef"} {
+		require.True(t, isPlainRefName(name), name)
+	}
+	for _, name := range []string{"", "@", "HEAD~1", "master^", "v1^{commit}", "master@{1}", "a..b", "-master", "HEAD:README.md", "feature/", "refs/heads/x.lock", "foo bar"} {
+		require.False(t, isPlainRefName(name), name)
+	}
+}
@@ -0,0 +1,511 @@
+package native
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// errUnsupported is returned for requests the native backend cannot answer
+// definitively. These are served by the CLI backend instead.
+var errUnsupported = errors.New("not supported by the native git backend")
+
+// errObjectNotFound is returned when an object is neither packed nor loose.
+// The object might still be available to git, for example through a promisor
+// remote, so it is treated like errUnsupported.
+var errObjectNotFound = errors.Wrap(errUnsupported, "object not found")
+
+const (
+	// maxDeltaChain bounds the length of delta chains we follow. git
+	// defaults to chains of at most 50 deltas.
+	maxDeltaChain = 10000
+	// maxPeelDepth bounds the number of tags we follow to reach a commit.
+	maxPeelDepth = 100
+)
+
+// repository reads objects and refs of a single repository. It holds
+// references to mapped files until it is closed.
+type repository struct {
+	dir   common.GitDir
+	cache *PackCache
+	// objectDirs are the object directories of the repository followed by
+	// those it borrows objects from through alternates.
+	objectDirs []string
+
+	packs         []*pack
+	packsLoaded   bool
+	packsReloaded bool
+
+	graphs       []*commitGraph
+	graphsLoaded bool
+
+	packedRefs       *mappedFile
+	packedRefsLoaded bool
+}
+
+func openRepository(cache *PackCache, dir common.GitDir) (*repository, error) {
+	objectDirs, err := readAlternates(dir.Path("objects"))
+	if err != nil {
+		return nil, err
+	}
+	return &repository{dir: dir, cache: cache, objectDirs: objectDirs}, nil
+}
+
+// maxAlternateDepth is the number of alternates we follow transitively, the
+// same limit git applies.
+const maxAlternateDepth = 5
+
+// readAlternates returns objectsDir followed by the object directories listed
+// in its objects/info/alternates, transitively. gitserver links forks to object
+// pools through alternates.
+func readAlternates(objectsDir string) ([]string, error) {
+	dirs := []string{objectsDir}
+	seen := map[string]struct{}{objectsDir: {}}
+	var visit func(dir string, depth int) error
+	visit = func(dir string, depth int) error {
+		b, err := os.ReadFile(filepath.Join(dir, "info", "alternates"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if depth >= maxAlternateDepth {
+			// git ignores alternates nested this deeply. Objects we can't
+			// find are left to the CLI backend.
+			return nil
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if strings.HasPrefix(line, "\"") {
+				// git allows quoting paths with unusual characters.
+				return errors.Wrap(errUnsupported, "repository has quoted alternates")
+			}
+			if !filepath.IsAbs(line) {
+				// Relative alternates are relative to the objects directory.
+				line = filepath.Join(dir, line)
+			}
+			line = filepath.Clean(line)
+			if _, ok := seen[line]; ok {
+				continue
+			}
+			seen[line] = struct{}{}
+			dirs = append(dirs, line)
+			if err := visit(line, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(objectsDir, 0); err != nil {
+		return nil, err
+	}
+	return dirs, nil
+}
+
+func (r *repository) close() {
+	for _, p := range r.packs {
+		p.close()
+	}
+	r.packs = nil
+	closeCommitGraphs(r.graphs)
+	r.graphs = nil
+	if r.packedRefs != nil {
+		r.packedRefs.release()
+		r.packedRefs = nil
+	}
+}
+
+// loadPacks maps all packs of the repository and its alternates that aren't
+// mapped yet.
+func (r *repository) loadPacks() error {
+	loaded := make(map[string]struct{}, len(r.packs))
+	for _, p := range r.packs {
+		loaded[p.path] = struct{}{}
+	}
+	for _, objectDir := range r.objectDirs {
+		if err := r.loadPacksFrom(filepath.Join(objectDir, "pack"), loaded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) loadPacksFrom(packDir string, loaded map[string]struct{}) error {
+	entries, err := os.ReadDir(packDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".idx") {
+			continue
+		}
+		idxPath := filepath.Join(packDir, e.Name())
+		if _, ok := loaded[strings.TrimSuffix(idxPath, ".idx")+".pack"]; ok {
+			continue
+		}
+		p, err := openPack(r.cache, idxPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Removed by a concurrent repack, or not fully written yet.
+				continue
+			}
+			return err
+		}
+		r.packs = append(r.packs, p)
+	}
+	return nil
+}
+
+// location is where an object is stored. Either pack or loose is set.
+type location struct {
+	pack   *pack
+	offset int64
+	loose  string
+}
+
+func (r *repository) locate(oid gitdomain.OID) (location, error) {
+	if !r.packsLoaded {
+		if err := r.loadPacks(); err != nil {
+			return location{}, err
+		}
+		r.packsLoaded = true
+	}
+	if loc, ok, err := r.findPacked(oid); ok || err != nil {
+		return loc, err
+	}
+
+	hexOID := oid.String()
+	for _, objectDir := range r.objectDirs {
+		loose := filepath.Join(objectDir, hexOID[:2], hexOID[2:])
+		if _, err := os.Stat(loose); err == nil {
+			return location{loose: loose}, nil
+		} else if !os.IsNotExist(err) {
+			return location{}, err
+		}
+	}
+
+	// A concurrent repack might have moved the object from a pack we haven't
+	// seen yet or from a loose object into a new pack.
+	if !r.packsReloaded {
+		r.packsReloaded = true
+		if err := r.loadPacks(); err != nil {
+			return location{}, err
+		}
+		if loc, ok, err := r.findPacked(oid); ok || err != nil {
+			return loc, err
+		}
+	}
+
+	return location{}, errors.Wrapf(errObjectNotFound, "%s", oid)
+}
+
+func (r *repository) findPacked(oid gitdomain.OID) (location, bool, error) {
+	for _, p := range r.packs {
+		offset, ok, err := p.find(oid)
+		if err != nil {
+			return location{}, false, err
+		}
+		if ok {
+			return location{pack: p, offset: offset}, true, nil
+		}
+	}
+	return location{}, false, nil
+}
+
+// readObject returns the type and contents of the given object. The returned
+// data may be shared and must not be modified.
+func (r *repository) readObject(oid gitdomain.OID) (objectType, []byte, error) {
+	loc, err := r.locate(oid)
+	if err != nil {
+		return 0, nil, err
+	}
+	if loc.pack == nil {
+		o, err := openLoose(loc.loose)
+		if err != nil {
+			return 0, nil, err
+		}
+		data, err := o.readAll()
+		return o.typ, data, err
+	}
+	return r.readPacked(loc.pack, loc.offset)
+}
+
+type baseCacheKey struct {
+	pack   string
+	offset int64
+}
+
+type cachedObject struct {
+	typ  objectType
+	data []byte
+}
+
+// maxCachedBaseSize is the size up to which delta bases are cached.
+const maxCachedBaseSize = 1024 * 1024
+
+// baseCache caches objects that deltas are applied to. Trees in particular
+// tend to be stored as long delta chains against each other, so without it
+// walking a path would resolve the same bases over and over again.
+var baseCache, _ = lru.New[baseCacheKey, cachedObject](4096)
+
+// readPacked returns the type and contents of the object at offset in p,
+// resolving deltas. The returned data may be shared and must not be modified.
+func (r *repository) readPacked(p *pack, offset int64) (objectType, []byte, error) {
+	type delta struct {
+		pack   *pack
+		offset int64
+		entry  packEntry
+	}
+	var (
+		chain []delta
+		typ   objectType
+		base  []byte
+	)
+
+	// Walk down the delta chain until we reach a full object.
+	for {
+		if len(chain) > maxDeltaChain {
+			return 0, nil, errors.Newf("delta chain at offset %d in %q is too long", offset, p.path)
+		}
+		if cached, ok := baseCache.Get(baseCacheKey{pack: p.path, offset: offset}); ok {
+			typ, base = cached.typ, cached.data
+			break
+		}
+
+		e, err := p.entry(offset)
+		if err != nil {
+			return 0, nil, err
+		}
+		if e.typ == objectOfsDelta {
+			chain = append(chain, delta{pack: p, offset: offset, entry: e})
+			offset = e.baseOffset
+			continue
+		}
+		if e.typ == objectRefDelta {
+			chain = append(chain, delta{pack: p, offset: offset, entry: e})
+			loc, err := r.locate(e.baseOID)
+			if err != nil {
+				return 0, nil, err
+			}
+			if loc.pack == nil {
+				o, err := openLoose(loc.loose)
+				if err != nil {
+					return 0, nil, err
+				}
+				if base, err = o.readAll(); err != nil {
+					return 0, nil, err
+				}
+				typ = o.typ
+				break
+			}
+			p, offset = loc.pack, loc.offset
+			continue
+		}
+
+		typ = e.typ
+		if base, err = inflate(p.compressed(e), e.size); err != nil {
+			return 0, nil, err
+		}
+		if len(chain) > 0 {
+			cacheBase(p, offset, typ, base)
+		}
+		break
+	}
+
+	// Apply the deltas from the innermost to the outermost.
+	for i := len(chain) - 1; i >= 0; i-- {
+		d := chain[i]
+		data, err := inflate(d.pack.compressed(d.entry), d.entry.size)
+		if err != nil {
+			return 0, nil, err
+		}
+		if base, err = applyDelta(base, data); err != nil {
+			return 0, nil, errors.Wrapf(err, "applying delta at offset %d in %q", d.offset, d.pack.path)
+		}
+		if i > 0 {
+			cacheBase(d.pack, d.offset, typ, base)
+		}
+	}
+	return typ, base, nil
+}
+
+func cacheBase(p *pack, offset int64, typ objectType, data []byte) {
+	if len(data) <= maxCachedBaseSize {
+		baseCache.Add(baseCacheKey{pack: p.path, offset: offset}, cachedObject{typ: typ, data: data})
+	}
+}
+
+// objectSize returns the size of the given object without reading its
+// contents where possible.
+func (r *repository) objectSize(oid gitdomain.OID) (int64, error) {
+	loc, err := r.locate(oid)
+	if err != nil {
+		return 0, err
+	}
+	if loc.pack == nil {
+		o, err := openLoose(loc.loose)
+		if err != nil {
+			return 0, err
+		}
+		defer o.Close()
+		return o.size, nil
+	}
+
+	e, err := loc.pack.entry(loc.offset)
+	if err != nil {
+		return 0, err
+	}
+	if e.typ != objectOfsDelta && e.typ != objectRefDelta {
+		return e.size, nil
+	}
+	// The size of the resulting object is stored at the start of the delta,
+	// after the size of the base. Each is encoded in at most 10 bytes.
+	header, err := inflatePrefix(loc.pack.compressed(e), 20)
+	if err != nil {
+		return 0, err
+	}
+	_, size, _, err := deltaHeader(header)
+	return size, err
+}
+
+// openBlob returns a reader for the contents of the given blob. Blobs that
+// are stored as a full object are streamed, deltified blobs are resolved in
+// memory.
+func (r *repository) openBlob(oid gitdomain.OID) (io.ReadCloser, error) {
+	loc, err := r.locate(oid)
+	if err != nil {
+		return nil, err
+	}
+
+	if loc.pack == nil {
+		o, err := openLoose(loc.loose)
+		if err != nil {
+			return nil, err
+		}
+		if o.typ != objectBlob {
+			o.Close()
+			return nil, errors.Newf("object %s is a %s, not a blob", oid, o.typ)
+		}
+		return o, nil
+	}
+
+	e, err := loc.pack.entry(loc.offset)
+	if err != nil {
+		return nil, err
+	}
+	if e.typ == objectBlob {
+		// The pack stays mapped until the repository is closed.
+		return newStreamReader(loc.pack.compressed(e), e.size, func() {})
+	}
+
+	typ, data, err := r.readPacked(loc.pack, loc.offset)
+	if err != nil {
+		return nil, err
+	}
+	if typ != objectBlob {
+		return nil, errors.Newf("object %s is a %s, not a blob", oid, typ)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// commitGraphTree returns the tree of the given commit from the commit-graph,
+// if the repository has one and it contains the commit.
+func (r *repository) commitGraphTree(oid gitdomain.OID) (gitdomain.OID, bool) {
+	if !r.graphsLoaded {
+		r.graphsLoaded = true
+		// The commit-graph is an optimization. If it is missing or
+		// invalid, we read commits from the object database instead.
+		for _, objectDir := range r.objectDirs {
+			graphs, _ := openCommitGraphs(r.cache, objectDir)
+			r.graphs = append(r.graphs, graphs...)
+		}
+	}
+	for _, g := range r.graphs {
+		if tree, ok := g.commitTree(oid); ok {
+			return tree, true
+		}
+	}
+	return gitdomain.OID{}, false
+}
+
+// rootTree returns the tree of a tree-ish object, peeling tags and commits.
+func (r *repository) rootTree(oid gitdomain.OID) (gitdomain.OID, error) {
+	for i := 0; i < maxPeelDepth; i++ {
+		if tree, ok := r.commitGraphTree(oid); ok {
+			return tree, nil
+		}
+
+		typ, data, err := r.readObject(oid)
+		if err != nil {
+			return gitdomain.OID{}, err
+		}
+		switch typ {
+		case objectCommit:
+			return parseCommitTree(data)
+		case objectTree:
+			return oid, nil
+		case objectTag:
+			if oid, _, err = parseTagTarget(data); err != nil {
+				return gitdomain.OID{}, err
+			}
+		default:
+			// git reports blobs as missing revisions, which is left to the
+			// CLI backend.
+			return gitdomain.OID{}, errors.Wrapf(errUnsupported, "object %s is a %s", oid, typ)
+		}
+	}
+	return gitdomain.OID{}, errors.Newf("too many nested tags at %s", oid)
+}
+
+// peelToCommit follows tags until it reaches a commit.
+func (r *repository) peelToCommit(oid gitdomain.OID) (gitdomain.OID, error) {
+	for i := 0; i < maxPeelDepth; i++ {
+		if _, ok := r.commitGraphTree(oid); ok {
+			return oid, nil
+		}
+
+		typ, data, err := r.readObject(oid)
+		if err != nil {
+			return gitdomain.OID{}, err
+		}
+		switch typ {
+		case objectCommit:
+			return oid, nil
+		case objectTag:
+			if oid, _, err = parseTagTarget(data); err != nil {
+				return gitdomain.OID{}, err
+			}
+		default:
+			return gitdomain.OID{}, errors.Wrapf(errUnsupported, "object %s is a %s", oid, typ)
+		}
+	}
+	return gitdomain.OID{}, errors.Newf("too many nested tags at %s", oid)
+}
+
+func decodeOID[T string | []byte](s T) (gitdomain.OID, error) {
+	var oid gitdomain.OID
+	if len(s) != 2*oidSize {
+		return oid, errors.Newf("invalid object ID %q", s)
+	}
+	if _, err := hex.Decode(oid[:], []byte(s)); err != nil {
+		return oid, errors.Newf("invalid object ID %q", s)
+	}
+	return oid, nil
+}
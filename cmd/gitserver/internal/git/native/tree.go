@@ -0,0 +1,104 @@
+package native
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Modes of tree entries, as stored in tree objects.
+const (
+	modeTree      = 0o40000
+	modeSymlink   = 0o120000
+	modeSubmodule = 0o160000
+)
+
+type treeEntry struct {
+	mode uint32
+	name string
+	oid  gitdomain.OID
+}
+
+func (e treeEntry) isTree() bool      { return e.mode == modeTree }
+func (e treeEntry) isSubmodule() bool { return e.mode == modeSubmodule }
+
+// forEachTreeEntry calls fn for every entry of the raw tree object, in the
+// order they are stored. Iteration stops early if fn returns false.
+func forEachTreeEntry(data []byte, fn func(treeEntry) bool) error {
+	for len(data) > 0 {
+		// Entries are "<octal mode> <name>\x00<binary oid>".
+		sp := bytes.IndexByte(data, ' ')
+		if sp <= 0 {
+			return errors.New("invalid tree entry mode")
+		}
+		mode, err := strconv.ParseUint(string(data[:sp]), 8, 32)
+		if err != nil {
+			return errors.Wrap(err, "invalid tree entry mode")
+		}
+		data = data[sp+1:]
+
+		nul := bytes.IndexByte(data, 0)
+		if nul <= 0 || len(data) < nul+1+oidSize {
+			return errors.New("invalid tree entry")
+		}
+		e := treeEntry{mode: uint32(mode), name: string(data[:nul])}
+		copy(e.oid[:], data[nul+1:])
+		data = data[nul+1+oidSize:]
+
+		if !fn(e) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// findTreeEntry returns the entry with the given name in the raw tree object.
+func findTreeEntry(data []byte, name string) (entry treeEntry, found bool, err error) {
+	err = forEachTreeEntry(data, func(e treeEntry) bool {
+		if e.name == name {
+			entry, found = e, true
+			return false
+		}
+		return true
+	})
+	return entry, found, err
+}
+
+// lookupPath returns the entry at the slash-separated path relative to the
+// tree root.
+func (r *repository) lookupPath(root gitdomain.OID, path string) (treeEntry, bool, error) {
+	entry := treeEntry{mode: modeTree, oid: root}
+	for path != "" {
+		if !entry.isTree() {
+			return treeEntry{}, false, nil
+		}
+		var name string
+		name, path, _ = strings.Cut(path, "/")
+
+		data, err := r.readTree(entry.oid)
+		if err != nil {
+			return treeEntry{}, false, err
+		}
+		e, ok, err := findTreeEntry(data, name)
+		if err != nil || !ok {
+			return treeEntry{}, false, err
+		}
+		entry = e
+	}
+	return entry, true, nil
+}
+
+// readTree returns the contents of the given tree object.
+func (r *repository) readTree(oid gitdomain.OID) ([]byte, error) {
+	typ, data, err := r.readObject(oid)
+	if err != nil {
+		return nil, err
+	}
+	if typ != objectTree {
+		return nil, errors.Newf("object %s is a %s, not a tree", oid, typ)
+	}
+	return data, nil
+}
@@ -34,6 +34,11 @@ type FS interface {
 	RepoCloned(api.RepoName) (bool, error)
 	RemoveRepo(api.RepoName) error
 	ForEachRepo(func(api.RepoName, common.GitDir) (done bool)) error
+	// PoolDir returns the GIT_DIR of the object pool shared by the members
+	// of the fork family with the given name.
+	PoolDir(name api.RepoName) common.GitDir
+	RemovePool(name api.RepoName) error
+	ForEachPool(func(api.RepoName, common.GitDir) (done bool)) error
 	DiskUsage() (diskusage.DiskUsage, error)
 	CanonicalPath(common.GitDir) string
 }
@@ -106,8 +111,38 @@ func (r *realGitserverFS) RemoveRepo(name api.RepoName) error {
 // iterateGitDirs walks over the reposDir on disk and calls walkFn for each of the
 // git directories found on disk.
 func (r *realGitserverFS) ForEachRepo(visit func(api.RepoName, common.GitDir) bool) error {
-	return BestEffortWalk(r.reposDir, func(dir string, fi fs.DirEntry) error {
-		if ignorePath(r.reposDir, dir) {
+	return forEachGitDir(r.reposDir, visit)
+}
+
+func (r *realGitserverFS) PoolDir(name api.RepoName) common.GitDir {
+	poolsDir := filepath.Join(r.reposDir, poolsDirName)
+	dir := repoDirFromName(poolsDir, name)
+	// dir is expected to be cleaned, ie. it doesn't allow `..`.
+	if !strings.HasPrefix(dir.Path(), poolsDir) {
+		panic("dir is outside of pools dir")
+	}
+	return dir
+}
+
+func (r *realGitserverFS) RemovePool(name api.RepoName) error {
+	return removeRepoDirectory(r.logger, r.reposDir, r.PoolDir(name))
+}
+
+// ForEachPool walks over the object pools on disk and calls visit for each of
+// them.
+func (r *realGitserverFS) ForEachPool(visit func(api.RepoName, common.GitDir) bool) error {
+	poolsDir := filepath.Join(r.reposDir, poolsDirName)
+	if _, err := os.Stat(poolsDir); os.IsNotExist(err) {
+		return nil
+	}
+	return forEachGitDir(poolsDir, visit)
+}
+
+// forEachGitDir walks over root and calls visit for each of the git
+// directories found below it.
+func forEachGitDir(root string, visit func(api.RepoName, common.GitDir) bool) error {
+	return BestEffortWalk(root, func(dir string, fi fs.DirEntry) error {
+		if ignorePath(root, dir) {
 			if fi.IsDir() {
 				return filepath.SkipDir
 			}
@@ -122,7 +157,7 @@ func (r *realGitserverFS) ForEachRepo(visit func(api.RepoName, common.GitDir) bo
 		// We are sure this is a GIT_DIR after the above check
 		gitDir := common.GitDir(dir)
 
-		if done := visit(r.ResolveRepoName(gitDir), gitDir); done {
+		if done := visit(repoNameFromDir(root, gitDir), gitDir); done {
 			return filepath.SkipAll
 		}
 
@@ -201,6 +236,10 @@ const tempDirName = ".tmp"
 // and where it will store cache data.
 const p4HomeName = ".p4home"
 
+// poolsDirName is the name used for the directory under ReposDir which holds
+// the object pools shared by fork families.
+const poolsDirName = ".pools"
+
 func repoDirFromName(reposDir string, name api.RepoName) common.GitDir {
 	p := string(protocol.NormalizeRepo(name))
 	return common.GitDir(filepath.Join(reposDir, filepath.FromSlash(p), ".git"))
@@ -232,12 +271,12 @@ func tempDir(reposDir, prefix string) (name string, err error) {
 }
 
 func ignorePath(reposDir string, path string) bool {
-	// We ignore any path which starts with .tmp or .p4home in ReposDir
+	// We ignore any path which starts with .tmp, .p4home or .pools in ReposDir
 	if filepath.Dir(path) != reposDir {
 		return false
 	}
 	base := filepath.Base(path)
-	return strings.HasPrefix(base, tempDirName) || strings.HasPrefix(base, p4HomeName)
+	return strings.HasPrefix(base, tempDirName) || strings.HasPrefix(base, p4HomeName) || strings.HasPrefix(base, poolsDirName)
 }
 
 // removeRepoDirectory atomically removes a directory from reposDir.
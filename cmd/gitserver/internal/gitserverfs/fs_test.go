@@ -1,12 +1,14 @@
 package gitserverfs
 
 import (
+	"os"
 	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -48,6 +50,39 @@ func TestGitserverFS_RepoDir(t *testing.T) {
 	}
 }
 
+func TestGitserverFS_ForEachPool(t *testing.T) {
+	reposDir := t.TempDir()
+	fs := New(observation.TestContextTB(t), reposDir)
+
+	for _, dir := range []common.GitDir{
+		fs.RepoDir("github.com/sourcegraph/sourcegraph"),
+		fs.PoolDir("github.com/sourcegraph/sourcegraph"),
+	} {
+		require.NoError(t, os.MkdirAll(dir.Path(), os.ModePerm))
+	}
+
+	var repos, pools []api.RepoName
+	require.NoError(t, fs.ForEachRepo(func(name api.RepoName, _ common.GitDir) bool {
+		repos = append(repos, name)
+		return false
+	}))
+	require.NoError(t, fs.ForEachPool(func(name api.RepoName, dir common.GitDir) bool {
+		assert.Equal(t, filepath.Join(reposDir, poolsDirName, "github.com/sourcegraph/sourcegraph/.git"), dir.Path())
+		pools = append(pools, name)
+		return false
+	}))
+	assert.Equal(t, []api.RepoName{"github.com/sourcegraph/sourcegraph"}, repos)
+	assert.Equal(t, []api.RepoName{"github.com/sourcegraph/sourcegraph"}, pools)
+
+	require.NoError(t, fs.RemovePool("github.com/sourcegraph/sourcegraph"))
+	pools = nil
+	require.NoError(t, fs.ForEachPool(func(name api.RepoName, _ common.GitDir) bool {
+		pools = append(pools, name)
+		return false
+	}))
+	assert.Empty(t, pools)
+}
+
 func TestIgnorePath(t *testing.T) {
 	reposDir := "/data/repos"
 
@@ -57,6 +92,7 @@ func TestIgnorePath(t *testing.T) {
 	}{
 		{path: filepath.Join(reposDir, tempDirName), shouldIgnore: true},
 		{path: filepath.Join(reposDir, p4HomeName), shouldIgnore: true},
+		{path: filepath.Join(reposDir, poolsDirName), shouldIgnore: true},
 		// Double check handling of trailing space
 		{path: filepath.Join(reposDir, p4HomeName+"   "), shouldIgnore: true},
 		{path: filepath.Join(reposDir, "sourcegraph/sourcegraph"), shouldIgnore: false},
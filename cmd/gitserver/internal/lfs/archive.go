@@ -0,0 +1,191 @@
+package lfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+)
+
+func (b *lfsBackend) ArchiveReader(ctx context.Context, format git.ArchiveFormat, treeish string, paths []string) (io.ReadCloser, error) {
+	opts := b.options(ctx)
+	if opts == nil || !b.mayContainPointers(ctx, treeish) {
+		return b.GitBackend.ArchiveReader(ctx, format, treeish, paths)
+	}
+
+	// We always ask git for a tar archive since it is trivial to stream and
+	// rewrite, and convert it to the requested format ourselves.
+	r, err := b.GitBackend.ArchiveReader(ctx, git.ArchiveFormatTar, treeish, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := b.rewriteArchive(ctx, opts, format, r, pw)
+		if closeErr := r.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// mayContainPointers returns false if no .gitattributes file in treeish assigns
+// the LFS filter to any path, in which case the archive is passed through as
+// is. If in doubt, it returns true.
+func (b *lfsBackend) mayContainPointers(ctx context.Context, treeish string) bool {
+	commit, err := b.GitBackend.ResolveRevision(ctx, treeish)
+	if err != nil {
+		return true
+	}
+	it, err := b.GitBackend.ReadDir(ctx, commit, "", true)
+	if err != nil {
+		return true
+	}
+	defer it.Close()
+
+	for {
+		fi, err := it.Next()
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			return true
+		}
+		if path.Base(fi.Name()) != ".gitattributes" || !fi.Mode().IsRegular() {
+			continue
+		}
+		r, err := b.GitBackend.ReadFile(ctx, commit, fi.Name())
+		if err != nil {
+			return true
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil || bytes.Contains(content, []byte("filter=lfs")) {
+			return true
+		}
+	}
+}
+
+// rewriteArchive copies the tar archive read from r to w in the given format,
+// replacing LFS pointers with the objects they point to.
+func (b *lfsBackend) rewriteArchive(ctx context.Context, opts *Options, format git.ArchiveFormat, r io.Reader, w io.Writer) error {
+	var aw archiveWriter
+	if format == git.ArchiveFormatZip {
+		aw = &zipArchiveWriter{w: zip.NewWriter(w)}
+	} else {
+		aw = &tarArchiveWriter{w: tar.NewWriter(w)}
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return aw.Close()
+		}
+		if err != nil {
+			return err
+		}
+
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > MaxPointerSize {
+			if err := aw.WriteEntry(hdr, tr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		p, ok := ParsePointer(content)
+		if !ok {
+			if err := aw.WriteEntry(hdr, bytes.NewReader(content)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		obj, err := b.store.Open(ctx, opts, p)
+		if err != nil {
+			b.logger.Warn("failed to resolve LFS pointer, serving pointer instead", log.String("path", hdr.Name), log.Error(err))
+			if err := aw.WriteEntry(hdr, bytes.NewReader(content)); err != nil {
+				return err
+			}
+			continue
+		}
+		hdr.Size = p.Size
+		err = aw.WriteEntry(hdr, obj)
+		obj.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// archiveWriter writes entries read from a tar archive into an archive of a
+// possibly different format.
+type archiveWriter interface {
+	WriteEntry(hdr *tar.Header, content io.Reader) error
+	Close() error
+}
+
+type tarArchiveWriter struct {
+	w *tar.Writer
+}
+
+func (a *tarArchiveWriter) WriteEntry(hdr *tar.Header, content io.Reader) error {
+	if err := a.w.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.Copy(a.w, content)
+	return err
+}
+
+func (a *tarArchiveWriter) Close() error {
+	return a.w.Close()
+}
+
+// zipArchiveWriter mirrors the output of git archive --format=zip -0.
+type zipArchiveWriter struct {
+	w *zip.Writer
+}
+
+func (a *zipArchiveWriter) WriteEntry(hdr *tar.Header, content io.Reader) error {
+	switch hdr.Typeflag {
+	case tar.TypeXGlobalHeader:
+		// git archive stores the commit ID in the pax global header of tar
+		// archives and in the comment of zip archives.
+		return a.w.SetComment(hdr.PAXRecords["comment"])
+	case tar.TypeSymlink:
+		content = strings.NewReader(hdr.Linkname)
+	case tar.TypeReg, tar.TypeDir:
+	default:
+		return nil
+	}
+
+	fh := &zip.FileHeader{
+		Name:     hdr.Name,
+		Method:   zip.Store,
+		Modified: hdr.ModTime,
+	}
+	fh.SetMode(hdr.FileInfo().Mode())
+	w, err := a.w.CreateHeader(fh)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, content)
+	return err
+}
+
+func (a *zipArchiveWriter) Close() error {
+	return a.w.Close()
+}
@@ -0,0 +1,91 @@
+package lfs
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// NewBackend wraps backend so that file contents returned from ReadFile and
+// ArchiveReader have Git LFS pointers replaced by the objects they point to.
+// Pointers are only resolved if source returns options for the repository.
+func NewBackend(logger log.Logger, backend git.GitBackend, dir common.GitDir, repoName api.RepoName, source OptionsSource) git.GitBackend {
+	return &lfsBackend{
+		GitBackend: backend,
+		logger:     logger.Scoped("lfs").With(log.String("repo", string(repoName))),
+		repoName:   repoName,
+		source:     source,
+		store:      &store{dir: dir, doer: httpcli.UncachedExternalDoer},
+	}
+}
+
+type lfsBackend struct {
+	git.GitBackend
+
+	logger   log.Logger
+	repoName api.RepoName
+	source   OptionsSource
+	store    *store
+
+	optionsOnce sync.Once
+	opts        *Options
+}
+
+// options returns the LFS options for the repository, or nil if pointers
+// should not be resolved.
+func (b *lfsBackend) options(ctx context.Context) *Options {
+	b.optionsOnce.Do(func() {
+		opts, err := b.source(ctx, b.repoName)
+		if err != nil {
+			b.logger.Warn("failed to load LFS options", log.Error(err))
+			return
+		}
+		b.opts = opts
+	})
+	return b.opts
+}
+
+func (b *lfsBackend) ReadFile(ctx context.Context, commit api.CommitID, path string) (io.ReadCloser, error) {
+	r, err := b.GitBackend.ReadFile(ctx, commit, path)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReaderSize(r, MaxPointerSize+1)
+	head, err := br.Peek(MaxPointerSize + 1)
+	// Anything that isn't a complete read of a small blob is either too large
+	// to be a pointer or an error the caller will see when reading.
+	if err != io.EOF {
+		return &readCloser{Reader: br, Closer: r}, nil
+	}
+	p, ok := ParsePointer(head)
+	if !ok {
+		return &readCloser{Reader: br, Closer: r}, nil
+	}
+
+	opts := b.options(ctx)
+	if opts == nil {
+		return &readCloser{Reader: br, Closer: r}, nil
+	}
+
+	obj, err := b.store.Open(ctx, opts, p)
+	if err != nil {
+		b.logger.Warn("failed to resolve LFS pointer, serving pointer instead", log.String("path", path), log.Error(err))
+		return &readCloser{Reader: br, Closer: r}, nil
+	}
+	r.Close()
+	return obj, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,314 @@
+package lfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const lfsContent = "this is the actual content of a large file\n"
+
+func lfsPointer(content string) (Pointer, string) {
+	sum := sha256.Sum256([]byte(content))
+	p := Pointer{OID: hex.EncodeToString(sum[:]), Size: int64(len(content))}
+	return p, fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", p.OID, p.Size)
+}
+
+// newLFSServer returns a server implementing the LFS batch API for a single
+// repository "repo" serving content. The returned counter tracks the number of
+// objects downloaded.
+func newLFSServer(t *testing.T, content string) (*httptest.Server, *atomic.Int32) {
+	p, _ := lfsPointer(content)
+	var downloads atomic.Int32
+
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/repo.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "user" || pass != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req batchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "download", req.Operation)
+		require.Len(t, req.Objects, 1)
+
+		w.Header().Set("Content-Type", lfsMediaType)
+		if req.Objects[0] != (batchObject{OID: p.OID, Size: p.Size}) {
+			fmt.Fprintf(w, `{"objects":[{"oid":%q,"size":%d,"error":{"code":404,"message":"Object does not exist"}}]}`, req.Objects[0].OID, req.Objects[0].Size)
+			return
+		}
+		fmt.Fprintf(w, `{"objects":[{"oid":%q,"size":%d,"actions":{"download":{"href":%q}}}]}`, p.OID, p.Size, srv.URL+"/objects/"+p.OID)
+	})
+	mux.HandleFunc("/objects/"+p.OID, func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "user" || pass != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		downloads.Add(1)
+		io.WriteString(w, content)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv, &downloads
+}
+
+func newTestBackend(t *testing.T, inner git.GitBackend, opts *Options) git.GitBackend {
+	b := NewBackend(logtest.Scoped(t), inner, common.GitDir(t.TempDir()), "repo", func(context.Context, api.RepoName) (*Options, error) {
+		return opts, nil
+	})
+	b.(*lfsBackend).store.doer = httpcli.TestExternalDoer
+	return b
+}
+
+func TestLFSBackend_ReadFile(t *testing.T) {
+	srv, downloads := newLFSServer(t, lfsContent)
+	_, pointer := lfsPointer(lfsContent)
+
+	inner := git.NewMockGitBackend()
+	inner.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ api.CommitID, path string) (io.ReadCloser, error) {
+		switch path {
+		case "large.bin":
+			return io.NopCloser(strings.NewReader(pointer)), nil
+		case "broken.bin":
+			_, brokenPointer := lfsPointer("unknown")
+			return io.NopCloser(strings.NewReader(brokenPointer)), nil
+		default:
+			return io.NopCloser(strings.NewReader("package main\n")), nil
+		}
+	})
+
+	readFile := func(t *testing.T, b git.GitBackend, path string) string {
+		r, err := b.ReadFile(context.Background(), "deadbeef", path)
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	t.Run("resolves pointers", func(t *testing.T) {
+		b := newTestBackend(t, inner, &Options{RemoteURL: strings.Replace(srv.URL, "http://", "http://user:token@", 1) + "/repo"})
+
+		require.Equal(t, lfsContent, readFile(t, b, "large.bin"))
+		require.Equal(t, "package main\n", readFile(t, b, "main.go"))
+
+		// The second read is served from the local object cache.
+		require.Equal(t, lfsContent, readFile(t, b, "large.bin"))
+		require.Equal(t, int32(1), downloads.Load())
+	})
+
+	t.Run("serves pointer if object cannot be fetched", func(t *testing.T) {
+		b := newTestBackend(t, inner, &Options{RemoteURL: strings.Replace(srv.URL, "http://", "http://user:token@", 1) + "/repo"})
+
+		_, brokenPointer := lfsPointer("unknown")
+		require.Equal(t, brokenPointer, readFile(t, b, "broken.bin"))
+	})
+
+	t.Run("serves pointer if object is too large", func(t *testing.T) {
+		b := newTestBackend(t, inner, &Options{RemoteURL: srv.URL + "/repo", MaxObjectSize: 4})
+
+		require.Equal(t, pointer, readFile(t, b, "large.bin"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		b := newTestBackend(t, inner, nil)
+
+		require.Equal(t, pointer, readFile(t, b, "large.bin"))
+	})
+}
+
+func TestLFSBackend_ArchiveReader(t *testing.T) {
+	srv, _ := newLFSServer(t, lfsContent)
+	_, pointer := lfsPointer(lfsContent)
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": "deadbeef"}}))
+	for _, f := range []struct{ name, content string }{{"large.bin", pointer}, {"main.go", "package main\n"}} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: f.name, Size: int64(len(f.content)), Mode: 0o644, ModTime: modTime}))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	const attributes = "*.bin filter=lfs diff=lfs merge=lfs -text\n"
+	inner := git.NewMockGitBackend()
+	inner.ArchiveReaderFunc.SetDefaultHook(func(_ context.Context, format git.ArchiveFormat, _ string, _ []string) (io.ReadCloser, error) {
+		require.Equal(t, git.ArchiveFormatTar, format)
+		return io.NopCloser(bytes.NewReader(archive.Bytes())), nil
+	})
+	inner.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+	inner.ReadDirFunc.SetDefaultHook(func(context.Context, api.CommitID, string, bool) (git.ReadDirIterator, error) {
+		return newReadDirIterator(".gitattributes", "large.bin", "main.go"), nil
+	})
+	inner.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ api.CommitID, path string) (io.ReadCloser, error) {
+		require.Equal(t, ".gitattributes", path)
+		return io.NopCloser(strings.NewReader(attributes)), nil
+	})
+
+	b := newTestBackend(t, inner, &Options{RemoteURL: strings.Replace(srv.URL, "http://", "http://user:token@", 1) + "/repo"})
+	want := map[string]string{"large.bin": lfsContent, "main.go": "package main\n"}
+
+	t.Run("tar", func(t *testing.T) {
+		r, err := b.ArchiveReader(context.Background(), git.ArchiveFormatTar, "deadbeef", nil)
+		require.NoError(t, err)
+		defer r.Close()
+
+		got := map[string]string{}
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if hdr.Typeflag == tar.TypeXGlobalHeader {
+				require.Equal(t, "deadbeef", hdr.PAXRecords["comment"])
+				continue
+			}
+			content, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			got[hdr.Name] = string(content)
+		}
+		require.Equal(t, want, got)
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		r, err := b.ArchiveReader(context.Background(), git.ArchiveFormatZip, "deadbeef", nil)
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Equal(t, "deadbeef", zr.Comment)
+
+		got := map[string]string{}
+		for _, f := range zr.File {
+			require.Equal(t, modTime, f.Modified.UTC())
+			fr, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(fr)
+			require.NoError(t, err)
+			got[f.Name] = string(content)
+		}
+		require.Equal(t, want, got)
+	})
+	t.Run("passes archives without LFS attributes through", func(t *testing.T) {
+		inner := git.NewMockGitBackendFrom(inner)
+		inner.ReadDirFunc.SetDefaultHook(func(context.Context, api.CommitID, string, bool) (git.ReadDirIterator, error) {
+			return newReadDirIterator("main.go", "sub/.gitattributes"), nil
+		})
+		inner.ReadFileFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("*.go diff=golang\n")), nil)
+		inner.ArchiveReaderFunc.SetDefaultHook(func(_ context.Context, format git.ArchiveFormat, _ string, _ []string) (io.ReadCloser, error) {
+			require.Equal(t, git.ArchiveFormatZip, format)
+			return io.NopCloser(strings.NewReader("zip")), nil
+		})
+		b := newTestBackend(t, inner, &Options{RemoteURL: srv.URL + "/repo"})
+
+		r, err := b.ArchiveReader(context.Background(), git.ArchiveFormatZip, "deadbeef", nil)
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "zip", string(data))
+	})
+}
+
+// newReadDirIterator returns an iterator over regular files with the given
+// names.
+func newReadDirIterator(names ...string) git.ReadDirIterator {
+	it := git.NewMockReadDirIterator()
+	it.NextFunc.SetDefaultHook(func() (fs.FileInfo, error) {
+		if len(names) == 0 {
+			return nil, io.EOF
+		}
+		fi := &fileutil.FileInfo{Name_: names[0], Mode_: 0o644}
+		names = names[1:]
+		return fi, nil
+	})
+	return it
+}
+
+func TestNewCachedOptionsSource(t *testing.T) {
+	var calls int
+	source := NewCachedOptionsSource(func(_ context.Context, repo api.RepoName) (*Options, error) {
+		calls++
+		if repo == "broken" {
+			return nil, errors.New("boom")
+		}
+		return &Options{RemoteURL: string(repo)}, nil
+	}, 10, time.Minute)
+
+	for range 2 {
+		opts, err := source(context.Background(), "repo")
+		require.NoError(t, err)
+		require.Equal(t, &Options{RemoteURL: "repo"}, opts)
+	}
+	require.Equal(t, 1, calls)
+
+	// Errors are not cached.
+	for range 2 {
+		_, err := source(context.Background(), "broken")
+		require.Error(t, err)
+	}
+	require.Equal(t, 3, calls)
+}
+
+func TestRemoveStaleObjects(t *testing.T) {
+	dir := common.GitDir(t.TempDir())
+	s := &store{dir: dir}
+
+	fresh, _ := lfsPointer("fresh")
+	stale, _ := lfsPointer("stale")
+	require.NoError(t, s.write(fresh, strings.NewReader("fresh")))
+	require.NoError(t, s.write(stale, strings.NewReader("stale")))
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(s.objectPath(stale.OID), old, old))
+
+	removed, err := RemoveStaleObjects(dir, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+
+	_, err = os.Stat(s.objectPath(fresh.OID))
+	require.NoError(t, err)
+	_, err = os.Stat(s.objectPath(stale.OID))
+	require.True(t, os.IsNotExist(err))
+	// Directories left empty are removed too.
+	_, err = os.Stat(filepath.Dir(s.objectPath(stale.OID)))
+	require.True(t, os.IsNotExist(err))
+
+	// Repositories without LFS objects are fine.
+	removed, err = RemoveStaleObjects(common.GitDir(t.TempDir()), time.Hour)
+	require.NoError(t, err)
+	require.Zero(t, removed)
+}
@@ -0,0 +1,67 @@
+// Package lfs resolves Git LFS pointer files to the object content they point
+// to. Repositories are cloned with GIT_LFS_SKIP_SMUDGE=1, so the git objects we
+// store only ever contain the pointers.
+package lfs
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+)
+
+// MaxPointerSize is the maximum size of a Git LFS pointer file. Blobs larger
+// than this are never considered to be pointers.
+//
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md#the-pointer.
+const MaxPointerSize = 1024
+
+const pointerVersionLine = "version https://git-lfs.github.com/spec/v1"
+
+var sha256OIDPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Pointer describes the LFS object a pointer file refers to.
+type Pointer struct {
+	// OID is the hex encoded sha256 of the object content.
+	OID string
+	// Size is the size of the object content in bytes.
+	Size int64
+}
+
+// ParsePointer parses content as a Git LFS pointer file. The second return
+// value is false if content is not a valid pointer.
+func ParsePointer(content []byte) (Pointer, bool) {
+	if len(content) > MaxPointerSize || !bytes.HasPrefix(content, []byte(pointerVersionLine+"\n")) {
+		return Pointer{}, false
+	}
+
+	var (
+		p       Pointer
+		hasSize bool
+	)
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		key, value, ok := bytes.Cut(line, []byte(" "))
+		if !ok {
+			continue
+		}
+		switch string(key) {
+		case "oid":
+			oid, ok := bytes.CutPrefix(value, []byte("sha256:"))
+			if !ok || !sha256OIDPattern.Match(oid) {
+				return Pointer{}, false
+			}
+			p.OID = string(oid)
+		case "size":
+			size, err := strconv.ParseInt(string(value), 10, 64)
+			if err != nil || size < 0 {
+				return Pointer{}, false
+			}
+			p.Size = size
+			hasSize = true
+		}
+	}
+
+	if p.OID == "" || !hasSize {
+		return Pointer{}, false
+	}
+	return p, true
+}
@@ -0,0 +1,331 @@
+package lfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultMaxObjectSize is the size limit applied to LFS objects when the code
+// host connection doesn't configure one.
+const DefaultMaxObjectSize = 10 * 1024 * 1024
+
+// Options configures LFS pointer resolution for a repository.
+type Options struct {
+	// RemoteURL is the clone URL of the repository, including credentials. The
+	// LFS server endpoint is derived from it.
+	RemoteURL string
+	// MaxObjectSize is the maximum size of an object that will be fetched.
+	// Pointers to larger objects are served as is.
+	MaxObjectSize int64
+}
+
+// OptionsSource returns the LFS options for the given repository. A nil
+// result means LFS pointers are not resolved for the repository.
+type OptionsSource func(ctx context.Context, repo api.RepoName) (*Options, error)
+
+// NewCachedOptionsSource wraps source so that the options of a repository are
+// looked up at most once every ttl. Errors are not cached.
+func NewCachedOptionsSource(source OptionsSource, size int, ttl time.Duration) OptionsSource {
+	cache := expirable.NewLRU[api.RepoName, *Options](size, nil, ttl)
+	return func(ctx context.Context, repo api.RepoName) (*Options, error) {
+		if opts, ok := cache.Get(repo); ok {
+			return opts, nil
+		}
+		opts, err := source(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		cache.Add(repo, opts)
+		return opts, nil
+	}
+}
+
+var errObjectTooLarge = errors.New("LFS object exceeds the maximum object size")
+
+const lfsMediaType = "application/vnd.git-lfs+json"
+
+// store reads LFS objects from the local object cache in the repository's
+// GIT_DIR, fetching them from the LFS server on a cache miss. The layout
+// matches the one used by git-lfs itself.
+type store struct {
+	dir  common.GitDir
+	doer httpcli.Doer
+}
+
+func (s *store) objectPath(oid string) string {
+	return s.dir.Path("lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// Open returns a reader for the content of the object p points to.
+func (s *store) Open(ctx context.Context, opts *Options, p Pointer) (io.ReadCloser, error) {
+	maxSize := opts.MaxObjectSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxObjectSize
+	}
+	if p.Size > maxSize {
+		return nil, errObjectTooLarge
+	}
+
+	f, err := os.Open(s.objectPath(p.OID))
+	if err == nil {
+		touchObject(f)
+		return f, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := s.fetch(ctx, opts.RemoteURL, p); err != nil {
+		return nil, errors.Wrapf(err, "fetching LFS object %s", p.OID)
+	}
+	return os.Open(s.objectPath(p.OID))
+}
+
+// objectTouchInterval is how often the modification time of an object in the
+// cache is updated when it is read. RemoveStaleObjects removes the objects that
+// haven't been read for a while.
+const objectTouchInterval = time.Hour
+
+// touchObject marks the cached object f as recently used.
+func touchObject(f *os.File) {
+	fi, err := f.Stat()
+	if err != nil || time.Since(fi.ModTime()) < objectTouchInterval {
+		return
+	}
+	now := time.Now()
+	_ = os.Chtimes(f.Name(), now, now)
+}
+
+// RemoveStaleObjects removes the objects in the LFS object cache of dir which
+// haven't been read for maxAge, as well as leftovers of interrupted fetches. It
+// returns the number of removed files.
+func RemoveStaleObjects(dir common.GitDir, maxAge time.Duration) (int, error) {
+	root := dir.Path("lfs", "objects")
+	var (
+		removed int
+		dirs    []string
+	)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path != root {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if time.Since(fi.ModTime()) < maxAge {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		removed++
+		return nil
+	})
+	// Remove the directories that are now empty, deepest first. Removing a
+	// directory which still has objects fails, which is fine.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = os.Remove(dirs[i])
+	}
+	return removed, err
+}
+
+type batchRequest struct {
+	Operation string        `json:"operation"`
+	Transfers []string      `json:"transfers"`
+	Objects   []batchObject `json:"objects"`
+}
+
+type batchObject struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type batchResponse struct {
+	Objects []struct {
+		batchObject
+		Actions struct {
+			Download *struct {
+				Href   string            `json:"href"`
+				Header map[string]string `json:"header"`
+			} `json:"download"`
+		} `json:"actions"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// fetch downloads the object p points to using the LFS batch API and stores it
+// in the object cache.
+//
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md.
+func (s *store) fetch(ctx context.Context, remoteURL string, p Pointer) error {
+	endpoint, user, err := batchEndpoint(remoteURL)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(batchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   []batchObject{{OID: p.OID, Size: p.Size}},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	setBasicAuth(req, user)
+
+	resp, err := s.doer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("LFS batch request failed with status %d", resp.StatusCode)
+	}
+
+	var batch batchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return errors.Wrap(err, "decoding LFS batch response")
+	}
+	if len(batch.Objects) != 1 || batch.Objects[0].OID != p.OID {
+		return errors.New("LFS batch response does not contain the requested object")
+	}
+	obj := batch.Objects[0]
+	if obj.Error != nil {
+		return errors.Errorf("LFS server error %d: %s", obj.Error.Code, obj.Error.Message)
+	}
+	if obj.Actions.Download == nil {
+		return errors.New("LFS batch response has no download action")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, obj.Actions.Download.Href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range obj.Actions.Download.Header {
+		req.Header.Set(k, v)
+	}
+	// Objects served by the code host itself usually need the same credentials
+	// as the batch request. Objects served from elsewhere (e.g. a presigned
+	// blob storage URL) carry their own authorization.
+	if req.Header.Get("Authorization") == "" && req.URL.Host == endpoint.Host {
+		setBasicAuth(req, user)
+	}
+
+	resp, err = s.doer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("LFS object download failed with status %d", resp.StatusCode)
+	}
+
+	return s.write(p, resp.Body)
+}
+
+// write verifies that r yields the content of p and atomically moves it into
+// the object cache.
+func (s *store) write(p Pointer, r io.Reader) (err error) {
+	path := s.objectPath(p.OID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), p.OID+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(r, p.Size+1))
+	if err != nil {
+		return err
+	}
+	if n != p.Size {
+		return errors.Errorf("LFS object has size %d, expected %d", n, p.Size)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != p.OID {
+		return errors.Errorf("LFS object has oid %s, expected %s", got, p.OID)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// batchEndpoint derives the LFS batch API endpoint from a clone URL. Any
+// credentials in the clone URL are returned separately.
+func batchEndpoint(remoteURL string) (*url.URL, *url.Userinfo, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing remote URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, nil, errors.Errorf("unsupported remote URL scheme %q for LFS", u.Scheme)
+	}
+
+	user := u.User
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, ".git") {
+		u.Path += ".git"
+	}
+	u.Path += "/info/lfs/objects/batch"
+	u.RawPath = ""
+	return u, user, nil
+}
+
+func setBasicAuth(req *http.Request, user *url.Userinfo) {
+	if user == nil {
+		return
+	}
+	password, _ := user.Password()
+	req.SetBasicAuth(user.Username(), password)
+}
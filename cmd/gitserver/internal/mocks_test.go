@@ -14,6 +14,7 @@ import (
 
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	protocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	v1 "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
 )
 
 // MockRepositoryLock is a mock implementation of the RepositoryLock
@@ -666,6 +667,9 @@ func (c RepositoryLockerTryAcquireFuncCall) Results() []interface{} {
 // package github.com/sourcegraph/sourcegraph/cmd/gitserver/internal) used
 // for unit testing.
 type MockService struct {
+	// CopyRepositoryFromShardFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyRepositoryFromShard.
+	CopyRepositoryFromShardFunc *ServiceCopyRepositoryFromShardFunc
 	// CreateCommitFromPatchFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateCommitFromPatch.
 	CreateCommitFromPatchFunc *ServiceCreateCommitFromPatchFunc
@@ -687,6 +691,11 @@ type MockService struct {
 // return zero values for all results, unless overwritten.
 func NewMockService() *MockService {
 	return &MockService{
+		CopyRepositoryFromShardFunc: &ServiceCopyRepositoryFromShardFunc{
+			defaultHook: func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) (r0 error) {
+				return
+			},
+		},
 		CreateCommitFromPatchFunc: &ServiceCreateCommitFromPatchFunc{
 			defaultHook: func(context.Context, protocol.CreateCommitFromPatchRequest, io.Reader) (r0 protocol.CreateCommitFromPatchResponse) {
 				return
@@ -719,6 +728,11 @@ func NewMockService() *MockService {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockService() *MockService {
 	return &MockService{
+		CopyRepositoryFromShardFunc: &ServiceCopyRepositoryFromShardFunc{
+			defaultHook: func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error {
+				panic("unexpected invocation of MockService.CopyRepositoryFromShard")
+			},
+		},
 		CreateCommitFromPatchFunc: &ServiceCreateCommitFromPatchFunc{
 			defaultHook: func(context.Context, protocol.CreateCommitFromPatchRequest, io.Reader) protocol.CreateCommitFromPatchResponse {
 				panic("unexpected invocation of MockService.CreateCommitFromPatch")
@@ -751,6 +765,7 @@ func NewStrictMockService() *MockService {
 // github.com/sourcegraph/sourcegraph/cmd/gitserver/internal). It is
 // redefined here as it is unexported in the source package.
 type surrogateMockService interface {
+	CopyRepositoryFromShard(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error
 	CreateCommitFromPatch(context.Context, protocol.CreateCommitFromPatchRequest, io.Reader) protocol.CreateCommitFromPatchResponse
 	EnsureRevision(context.Context, api.RepoName, string) bool
 	FetchRepository(context.Context, api.RepoName) (time.Time, time.Time, error)
@@ -762,6 +777,9 @@ type surrogateMockService interface {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockServiceFrom(i surrogateMockService) *MockService {
 	return &MockService{
+		CopyRepositoryFromShardFunc: &ServiceCopyRepositoryFromShardFunc{
+			defaultHook: i.CopyRepositoryFromShard,
+		},
 		CreateCommitFromPatchFunc: &ServiceCreateCommitFromPatchFunc{
 			defaultHook: i.CreateCommitFromPatch,
 		},
@@ -780,6 +798,116 @@ func NewMockServiceFrom(i surrogateMockService) *MockService {
 	}
 }
 
+// ServiceCopyRepositoryFromShardFunc describes the behavior when the
+// CopyRepositoryFromShard method of the parent MockService instance is
+// invoked.
+type ServiceCopyRepositoryFromShardFunc struct {
+	defaultHook func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error
+	hooks       []func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error
+	history     []ServiceCopyRepositoryFromShardFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyRepositoryFromShard delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockService) CopyRepositoryFromShard(v0 context.Context, v1 api.RepoName, v2 v1.GitserverRepositoryServiceClient) error {
+	r0 := m.CopyRepositoryFromShardFunc.nextHook()(v0, v1, v2)
+	m.CopyRepositoryFromShardFunc.appendCall(ServiceCopyRepositoryFromShardFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// CopyRepositoryFromShard method of the parent MockService instance is
+// invoked and the hook queue is empty.
+func (f *ServiceCopyRepositoryFromShardFunc) SetDefaultHook(hook func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyRepositoryFromShard method of the parent MockService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ServiceCopyRepositoryFromShardFunc) PushHook(hook func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ServiceCopyRepositoryFromShardFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ServiceCopyRepositoryFromShardFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error {
+		return r0
+	})
+}
+
+func (f *ServiceCopyRepositoryFromShardFunc) nextHook() func(context.Context, api.RepoName, v1.GitserverRepositoryServiceClient) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ServiceCopyRepositoryFromShardFunc) appendCall(r0 ServiceCopyRepositoryFromShardFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ServiceCopyRepositoryFromShardFuncCall
+// objects describing the invocations of this function.
+func (f *ServiceCopyRepositoryFromShardFunc) History() []ServiceCopyRepositoryFromShardFuncCall {
+	f.mutex.Lock()
+	history := make([]ServiceCopyRepositoryFromShardFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ServiceCopyRepositoryFromShardFuncCall is an object that describes an
+// invocation of method CopyRepositoryFromShard on an instance of
+// MockService.
+type ServiceCopyRepositoryFromShardFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 v1.GitserverRepositoryServiceClient
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ServiceCopyRepositoryFromShardFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ServiceCopyRepositoryFromShardFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ServiceCreateCommitFromPatchFunc describes the behavior when the
 // CreateCommitFromPatch method of the parent MockService instance is
 // invoked.
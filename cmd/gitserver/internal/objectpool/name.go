@@ -0,0 +1,56 @@
+package objectpool
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Name returns the name of the object pool shared by the fork family repo
+// belongs to, and whether repo is a fork. Forks are grouped by the repository
+// they were forked from, which is also the pool name of that repository
+// itself. An empty name is returned if the code host metadata doesn't tell us
+// which family repo belongs to.
+//
+// Only the immediate parent of a fork is known, so forks of forks end up in
+// the pool of their parent rather than the one of the root of the family.
+func Name(repo *types.Repo) (name api.RepoName, fork bool) {
+	var own, parent string
+	switch m := repo.Metadata.(type) {
+	case *github.Repository:
+		own = m.NameWithOwner
+		if m.Parent != nil {
+			parent = m.Parent.NameWithOwner
+		}
+	case *gitlab.Project:
+		own = m.PathWithNamespace
+		if m.ForkedFromProject != nil {
+			parent = m.ForkedFromProject.PathWithNamespace
+		}
+	default:
+		return "", false
+	}
+
+	u, err := url.Parse(repo.ExternalRepo.ServiceID)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+
+	if parent != "" {
+		return poolName(u.Host, parent), true
+	}
+	if own != "" {
+		return poolName(u.Host, own), false
+	}
+	return "", false
+}
+
+func poolName(host, p string) api.RepoName {
+	// Both GitHub and GitLab treat repository paths case-insensitively.
+	return api.RepoName(strings.ToLower(path.Join(host, p)))
+}
@@ -0,0 +1,67 @@
+package objectpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestName(t *testing.T) {
+	githubRepo := func(metadata *github.Repository) *types.Repo {
+		r := &types.Repo{Metadata: metadata}
+		r.ExternalRepo.ServiceID = "https://github.com/"
+		return r
+	}
+	gitlabRepo := func(metadata *gitlab.Project) *types.Repo {
+		r := &types.Repo{Metadata: metadata}
+		r.ExternalRepo.ServiceID = "https://gitlab.example.com/"
+		return r
+	}
+
+	for _, tc := range []struct {
+		name     string
+		repo     *types.Repo
+		wantName api.RepoName
+		wantFork bool
+	}{
+		{
+			name:     "github upstream",
+			repo:     githubRepo(&github.Repository{NameWithOwner: "sourcegraph/Sourcegraph"}),
+			wantName: "github.com/sourcegraph/sourcegraph",
+		},
+		{
+			name: "github fork",
+			repo: githubRepo(&github.Repository{
+				NameWithOwner: "someone/sourcegraph",
+				IsFork:        true,
+				Parent:        &github.ParentRepository{NameWithOwner: "sourcegraph/sourcegraph"},
+			}),
+			wantName: "github.com/sourcegraph/sourcegraph",
+			wantFork: true,
+		},
+		{
+			name: "gitlab fork",
+			repo: gitlabRepo(&gitlab.Project{
+				ProjectCommon:     gitlab.ProjectCommon{PathWithNamespace: "someone/project"},
+				ForkedFromProject: &gitlab.ProjectCommon{PathWithNamespace: "group/subgroup/project"},
+			}),
+			wantName: "gitlab.example.com/group/subgroup/project",
+			wantFork: true,
+		},
+		{
+			name: "unsupported code host",
+			repo: &types.Repo{Name: "example.com/repo"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			name, fork := Name(tc.repo)
+			require.Equal(t, tc.wantName, name)
+			require.Equal(t, tc.wantFork, fork)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Package objectpool deduplicates the objects of repositories belonging to
+// the same fork family.
+//
+// Members of a family borrow objects from a shared object pool repository
+// through objects/info/alternates. The pool fetches the refs of every member
+// into refs/members/<member>/, which keeps everything the members need
+// reachable in the pool. Members then only store the objects the pool doesn't
+// have.
+package objectpool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const membersRefPrefix = "refs/members/"
+
+// memberRefPrefix returns the ref namespace member's refs are fetched into.
+// Repository names can contain characters which aren't valid in refs, so they
+// are hex encoded.
+func memberRefPrefix(member api.RepoName) string {
+	return membersRefPrefix + hex.EncodeToString([]byte(member)) + "/"
+}
+
+// LinkedPool returns the GIT_DIR of the object pool member borrows objects
+// from, or an empty string if member isn't linked to a pool.
+func LinkedPool(member common.GitDir) (common.GitDir, error) {
+	b, err := os.ReadFile(member.Path("objects", "info", "alternates"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Alternates point at the objects directory of the pool.
+		return common.GitDir(filepath.Dir(line)), nil
+	}
+	return "", sc.Err()
+}
+
+// IsPartialClone returns true if member is a partial clone. Partial clones
+// don't join pools: the pool would have to fetch the objects the filter
+// omitted from member, which doesn't have them, and so fetch all of them from
+// the code host.
+func IsPartialClone(member common.GitDir) (bool, error) {
+	status, err := partialclone.ReadStatus(member)
+	if err != nil {
+		return false, errors.Wrap(err, "reading partial clone status")
+	}
+	return status != nil, nil
+}
+
+// Link makes member borrow objects from pool, creating the pool if it doesn't
+// exist yet. The objects of member are copied into the pool first, and member
+// is then repacked without the objects now available from the pool. Partial
+// clones are not linked.
+//
+// The caller must make sure no garbage collection runs in member concurrently.
+func Link(ctx context.Context, rcf *wrexec.RecordingCommandFactory, memberName api.RepoName, member, pool common.GitDir) error {
+	if partial, err := IsPartialClone(member); err != nil || partial {
+		return err
+	}
+
+	if _, err := os.Stat(pool.Path("HEAD")); os.IsNotExist(err) {
+		if err := os.MkdirAll(pool.Path(), os.ModePerm); err != nil {
+			return errors.Wrap(err, "creating object pool")
+		}
+		if err := git.MakeBareRepo(ctx, pool.Path()); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	// The pool must have all objects of member before member stops storing
+	// them itself.
+	if err := Sync(ctx, rcf, memberName, member, pool); err != nil {
+		return err
+	}
+
+	if err := writeAlternates(member, pool); err != nil {
+		return errors.Wrap(err, "writing alternates")
+	}
+
+	// -l omits objects borrowed from the pool.
+	return run(ctx, rcf, memberName, member, "repack", "-a", "-d", "-l")
+}
+
+// Sync fetches the refs of member into the pool, so that all objects member
+// currently references are kept in the pool. It does nothing for members that
+// became partial clones after they were linked, which keep the objects they
+// borrow from the pool, but store all new objects themselves.
+func Sync(ctx context.Context, rcf *wrexec.RecordingCommandFactory, memberName api.RepoName, member, pool common.GitDir) error {
+	if partial, err := IsPartialClone(member); err != nil || partial {
+		return err
+	}
+
+	prefix := memberRefPrefix(memberName)
+	return run(ctx, rcf, memberName, pool,
+		"fetch", "--quiet", "--prune", "--no-tags", "--no-write-fetch-head",
+		member.Path(), "+refs/*:"+prefix+"*",
+	)
+}
+
+// Members returns the repositories which have refs in the pool.
+func Members(ctx context.Context, rcf *wrexec.RecordingCommandFactory, poolName api.RepoName, pool common.GitDir) ([]api.RepoName, error) {
+	refs, err := output(ctx, rcf, poolName, pool, "for-each-ref", "--format=%(refname)", membersRefPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	var members []api.RepoName
+	for _, ref := range strings.Fields(string(refs)) {
+		encoded, _, _ := strings.Cut(strings.TrimPrefix(ref, membersRefPrefix), "/")
+		if _, ok := seen[encoded]; ok {
+			continue
+		}
+		seen[encoded] = struct{}{}
+
+		name, err := hex.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		members = append(members, api.RepoName(name))
+	}
+	return members, nil
+}
+
+// Maintain removes the refs of repositories which are no longer members of the
+// pool and garbage collects the pool.
+//
+// Unreachable objects are never pruned from a pool: a member can start to
+// reference an object of the pool at any time, for example when a fetch finds
+// it in the pool, long before its refs are synced into the pool again. Pools
+// only shrink by being removed once they have no members left.
+func Maintain(ctx context.Context, rcf *wrexec.RecordingCommandFactory, poolName api.RepoName, pool common.GitDir, members []api.RepoName) error {
+	current, err := Members(ctx, rcf, poolName, pool)
+	if err != nil {
+		return err
+	}
+
+	live := make(map[api.RepoName]struct{}, len(members))
+	for _, m := range members {
+		live[m] = struct{}{}
+	}
+	for _, m := range current {
+		if _, ok := live[m]; ok {
+			continue
+		}
+		refs, err := output(ctx, rcf, poolName, pool, "for-each-ref", "--format=delete %(refname)", memberRefPrefix(m))
+		if err != nil {
+			return err
+		}
+		cmd := exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+		pool.Set(cmd)
+		cmd.Stdin = bytes.NewReader(refs)
+		if out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), poolName, cmd).CombinedOutput(); err != nil {
+			return errors.Wrapf(executil.WrapCmdError(cmd, err), "removing refs of former member %s: %s", m, out)
+		}
+	}
+
+	return run(ctx, rcf, poolName, pool, "-c", "gc.autoDetach=false", "gc", "--prune=never")
+}
+
+// writeAlternates atomically points the alternates of member at the objects
+// of pool.
+func writeAlternates(member, pool common.GitDir) error {
+	path := member.Path("objects", "info", "alternates")
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(pool.Path("objects")+"\n"), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func run(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, args ...string) error {
+	_, err := output(ctx, rcf, repo, dir, args...)
+	return err
+}
+
+func output(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).Output()
+	if err != nil {
+		return nil, executil.WrapCmdError(cmd, err)
+	}
+	return out, nil
+}
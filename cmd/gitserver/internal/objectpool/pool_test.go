@@ -0,0 +1,130 @@
+package objectpool
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+)
+
+func TestLink(t *testing.T) {
+	ctx := context.Background()
+	rcf := wrexec.NewNoOpRecordingCommandFactory()
+	root := t.TempDir()
+
+	upstream := filepath.Join(root, "upstream")
+	gitCmd(t, "", "init", upstream)
+	for _, f := range []string{"a", "b", "c"} {
+		require.NoError(t, os.WriteFile(filepath.Join(upstream, f), []byte(f), 0o644))
+		gitCmd(t, upstream, "add", f)
+		gitCmd(t, upstream, "commit", "-m", f)
+	}
+
+	clone := func(name string) common.GitDir {
+		dir := filepath.Join(root, "repos", name, ".git")
+		gitCmd(t, "", "clone", "--bare", "--no-local", upstream, dir)
+		return common.GitDir(dir)
+	}
+	fork1, fork2 := clone("fork1"), clone("fork2")
+	pool := common.GitDir(filepath.Join(root, "pools", "upstream", ".git"))
+
+	linked, err := LinkedPool(fork1)
+	require.NoError(t, err)
+	require.Empty(t, linked)
+
+	require.NoError(t, Link(ctx, rcf, "fork1", fork1, pool))
+	require.NoError(t, Link(ctx, rcf, "fork2", fork2, pool))
+
+	for _, fork := range []common.GitDir{fork1, fork2} {
+		linked, err := LinkedPool(fork)
+		require.NoError(t, err)
+		require.Equal(t, pool, linked)
+
+		// All objects now live in the pool, and the fork is still intact.
+		require.Equal(t, "0", countObjects(t, fork)["in-pack"])
+		gitCmd(t, string(fork), "fsck", "--connectivity-only")
+	}
+
+	members, err := Members(ctx, rcf, "pool", pool)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []api.RepoName{"fork1", "fork2"}, members)
+
+	t.Run("maintain drops former members", func(t *testing.T) {
+		require.NoError(t, Maintain(ctx, rcf, "pool", pool, []api.RepoName{"fork2"}))
+
+		members, err := Members(ctx, rcf, "pool", pool)
+		require.NoError(t, err)
+		require.Equal(t, []api.RepoName{"fork2"}, members)
+
+		// Objects are never pruned from the pool, so fork1 isn't corrupted
+		// even though it's still linked.
+		gitCmd(t, string(fork1), "fsck", "--connectivity-only")
+		gitCmd(t, string(fork2), "fsck", "--connectivity-only")
+	})
+}
+
+func TestLink_PartialClone(t *testing.T) {
+	ctx := context.Background()
+	rcf := wrexec.NewNoOpRecordingCommandFactory()
+	root := t.TempDir()
+
+	upstream := filepath.Join(root, "upstream")
+	gitCmd(t, "", "init", upstream)
+	gitCmd(t, upstream, "config", "uploadpack.allowFilter", "true")
+	require.NoError(t, os.WriteFile(filepath.Join(upstream, "a"), []byte("a"), 0o644))
+	gitCmd(t, upstream, "add", "a")
+	gitCmd(t, upstream, "commit", "-m", "a")
+
+	fork := common.GitDir(filepath.Join(root, "repos", "fork", ".git"))
+	gitCmd(t, "", "clone", "--bare", "--filter=blob:none", "file://"+upstream, string(fork))
+	pool := common.GitDir(filepath.Join(root, "pools", "upstream", ".git"))
+
+	partial, err := IsPartialClone(fork)
+	require.NoError(t, err)
+	require.True(t, partial)
+
+	// Partial clones are neither linked nor synced, so the pool isn't even
+	// created.
+	require.NoError(t, Link(ctx, rcf, "fork", fork, pool))
+	require.NoError(t, Sync(ctx, rcf, "fork", fork, pool))
+	linked, err := LinkedPool(fork)
+	require.NoError(t, err)
+	require.Empty(t, linked)
+	_, err = os.Stat(pool.Path())
+	require.True(t, os.IsNotExist(err), "%v", err)
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_GLOBAL=",
+		"GIT_CONFIG_SYSTEM=",
+		"GIT_AUTHOR_NAME=a",
+		"GIT_AUTHOR_EMAIL=a@a.com",
+		"GIT_COMMITTER_NAME=a",
+		"GIT_COMMITTER_EMAIL=a@a.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), out)
+	return string(out)
+}
+
+func countObjects(t *testing.T, dir common.GitDir) map[string]string {
+	t.Helper()
+	counts := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(gitCmd(t, string(dir), "count-objects", "-v")), "\n") {
+		k, v, _ := strings.Cut(line, ": ")
+		counts[k] = v
+	}
+	return counts
+}
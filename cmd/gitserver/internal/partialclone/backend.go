@@ -0,0 +1,136 @@
+package partialclone
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var fetchedObjectsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_partial_clone_fetched_objects_total",
+	Help: "Objects missing from partial clones that were fetched on demand, by operation and whether the fetch succeeded.",
+}, []string{"op", "result"})
+
+// RemoteURLSource returns the remote URL of the given repository.
+type RemoteURLSource func(ctx context.Context, repo api.RepoName) (*vcs.URL, error)
+
+// fetches deduplicates concurrent fetches of the same objects, which happen
+// when many clients read the same file shortly after it changed.
+var fetches singleflight.Group
+
+// fetchTimeout bounds fetches, which outlive the requests that started them.
+const fetchTimeout = 10 * time.Minute
+
+// NewBackend wraps backend so that objects missing from partial clones are
+// fetched from the code host before ReadFile and ArchiveReader read them.
+// Other reads rely on git fetching missing objects from the promisor remote
+// one at a time, which these operations would do for every file they read.
+// Repositories that aren't partial clones are passed through unchanged.
+func NewBackend(logger log.Logger, rcf *wrexec.RecordingCommandFactory, backend git.GitBackend, dir common.GitDir, repoName api.RepoName, source RemoteURLSource) git.GitBackend {
+	return &partialCloneBackend{
+		GitBackend: backend,
+		logger:     logger.Scoped("partialclone").With(log.String("repo", string(repoName))),
+		rcf:        rcf,
+		dir:        dir,
+		repoName:   repoName,
+		source:     source,
+	}
+}
+
+type partialCloneBackend struct {
+	git.GitBackend
+
+	logger   log.Logger
+	rcf      *wrexec.RecordingCommandFactory
+	dir      common.GitDir
+	repoName api.RepoName
+	source   RemoteURLSource
+}
+
+func (b *partialCloneBackend) ReadFile(ctx context.Context, commit api.CommitID, path string) (io.ReadCloser, error) {
+	if b.isPartialClone() {
+		oid, err := MissingBlob(ctx, b.rcf, b.repoName, b.dir, commit, path)
+		if err != nil {
+			// Most likely commit doesn't exist, which the wrapped backend
+			// reports in the way callers expect.
+			b.logger.Debug("failed to check for missing blob", log.String("path", path), log.Error(err))
+		} else if oid != "" {
+			err := b.fetch(ctx, "ReadFile", oid, func(ctx context.Context, remoteURL *vcs.URL) (int, error) {
+				return 1, FetchObjects(ctx, b.rcf, b.repoName, b.dir, remoteURL, []string{oid})
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.GitBackend.ReadFile(ctx, commit, path)
+}
+
+func (b *partialCloneBackend) ArchiveReader(ctx context.Context, format git.ArchiveFormat, treeish string, paths []string) (io.ReadCloser, error) {
+	// Leave invalid arguments for the wrapped backend to reject.
+	if b.isPartialClone() && !strings.HasPrefix(treeish, "-") {
+		key := treeish + "\x00" + strings.Join(paths, "\x00")
+		err := b.fetch(ctx, "ArchiveReader", key, func(ctx context.Context, remoteURL *vcs.URL) (int, error) {
+			return FetchMissingObjects(ctx, b.rcf, b.repoName, b.dir, remoteURL, treeish, paths)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b.GitBackend.ArchiveReader(ctx, format, treeish, paths)
+}
+
+func (b *partialCloneBackend) isPartialClone() bool {
+	status, err := ReadStatus(b.dir)
+	if err != nil {
+		b.logger.Warn("failed to read partial clone status", log.Error(err))
+		return false
+	}
+	return status != nil
+}
+
+// fetch runs fetchFn, deduplicating it with concurrent fetches of the same
+// key. The fetch is shared by all callers, so it isn't canceled when the
+// caller that started it goes away, but each caller stops waiting for it
+// when its own context is done.
+func (b *partialCloneBackend) fetch(ctx context.Context, op, key string, fetchFn func(ctx context.Context, remoteURL *vcs.URL) (int, error)) error {
+	ch := fetches.DoChan(string(b.dir)+"\x00"+key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		remoteURL, err := b.source(ctx, b.repoName)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting remote URL")
+		}
+		n, err := fetchFn(ctx, remoteURL)
+		if err != nil {
+			fetchedObjectsCounter.WithLabelValues(op, "error").Add(float64(n))
+			return nil, err
+		}
+		fetchedObjectsCounter.WithLabelValues(op, "success").Add(float64(n))
+		return nil, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return errors.Wrap(res.Err, "fetching objects missing from partial clone")
+		}
+		return nil
+	}
+}
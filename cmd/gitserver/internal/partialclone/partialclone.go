@@ -0,0 +1,456 @@
+// Package partialclone implements partial clones, which omit objects from the
+// initial clone and fetch them from the code host when they are first read.
+// See https://git-scm.com/docs/partial-clone.
+package partialclone
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/format/config"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/urlredactor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RemoteName is the name of the promisor remote of partial clones, which git
+// records objects omitted by the filter as being available from. Its config is
+// written by WriteRemoteConfig, so that git can fetch missing objects on its
+// own whenever a command reads them. The config holds no credentials, so these
+// lazy fetches only succeed for remotes that don't need the credentials of the
+// remote URL. Fetches we run ourselves pass them per command instead, see
+// ConfigureFetchCommand.
+const RemoteName = "origin"
+
+// missingObjectsKey is the config key under which RecordMissingObjects stores
+// its count, and missingObjectsTreeKey the tree it counted the objects of.
+const (
+	missingObjectsKey     = "sourcegraph.partialCloneMissingObjects"
+	missingObjectsTreeKey = "sourcegraph.partialCloneMissingObjectsTree"
+)
+
+// legacyCredentialsFile is the name of the file in the GIT_DIR of partial
+// clones that earlier versions stored the credentials of the promisor remote
+// in. WriteRemoteConfig removes it.
+const legacyCredentialsFile = "sourcegraph-promisor-credentials"
+
+// envCredentialHelper answers with the credentials ConfigureFetchCommand sets in
+// the environment of a command, like the helper ConfigureRemoteGitCommand
+// configures for commands that are passed the remote URL.
+const envCredentialHelper = `!f() { test -n "$GIT_SG_PASSWORD" && printf 'username=%s\npassword=%s\n' "$GIT_SG_USERNAME" "$GIT_SG_PASSWORD"; }; f`
+
+// Status describes a partial clone.
+type Status struct {
+	// Filter is the object filter the repository was cloned with, such as
+	// blob:none.
+	Filter string
+	// MissingObjects is the number of objects in the tree of HEAD that were
+	// missing after the repository was last fetched.
+	MissingObjects int64
+}
+
+type statusCacheEntry struct {
+	modTime time.Time
+	size    int64
+	status  *Status
+}
+
+// statusCache caches the status of partial clones by the state of their config
+// file, which only changes when repositories are fetched.
+var statusCache, _ = lru.New[common.GitDir, statusCacheEntry](4096)
+
+// ReadStatus returns the status of the partial clone at dir, or nil if the
+// repository is not a partial clone. It parses the repository's config file
+// rather than running git, and only when the file changed, so that it is
+// cheap enough to call on every read.
+func ReadStatus(dir common.GitDir) (*Status, error) {
+	fi, err := os.Stat(dir.Path("config"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if e, ok := statusCache.Get(dir); ok && e.modTime.Equal(fi.ModTime()) && e.size == fi.Size() {
+		return copyStatus(e.status), nil
+	}
+
+	data, err := os.ReadFile(dir.Path("config"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	status, err := parseStatus(data)
+	if err != nil {
+		return nil, err
+	}
+	statusCache.Add(dir, statusCacheEntry{modTime: fi.ModTime(), size: fi.Size(), status: status})
+	return copyStatus(status), nil
+}
+
+func parseStatus(data []byte) (*Status, error) {
+	cfg := config.New()
+	if err := config.NewDecoder(bytes.NewReader(data)).Decode(cfg); err != nil {
+		return nil, errors.Wrap(err, "parsing git config")
+	}
+
+	remote := cfg.Section("remote").Subsection(RemoteName)
+	if !isTrue(remote.Option("promisor")) {
+		return nil, nil
+	}
+
+	s := &Status{Filter: remote.Option("partialclonefilter")}
+	if v := cfg.Section("sourcegraph").Option("partialCloneMissingObjects"); v != "" {
+		s.MissingObjects, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return s, nil
+}
+
+func copyStatus(s *Status) *Status {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func isTrue(v string) bool {
+	switch strings.ToLower(v) {
+	case "true", "yes", "on", "1":
+		return true
+	}
+	return false
+}
+
+// WriteRemoteConfig writes the config of the promisor remote of the partial
+// clone at dir, pointing it at remoteURL. Credentials are stripped from the URL
+// in the config and never written to disk, so that revoked credentials don't
+// outlive the external service config. It has to be called before every fetch,
+// so that git talks to the current remote URL.
+func WriteRemoteConfig(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL) error {
+	// Earlier versions stored the credentials in a file read by a credential
+	// helper in the repository's config. Both are removed below.
+	if err := os.Remove(dir.Path(legacyCredentialsFile)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing credentials")
+	}
+
+	// Like ConfigureRemoteGitCommand, only strip the credentials of HTTP URLs
+	// with a password.
+	configURL := *remoteURL
+	if _, ok := remoteURL.User.Password(); ok && !remoteURL.IsSSH() {
+		configURL.User = nil
+	}
+
+	cfg := executil.PersistentRemoteGitConfig()
+	cfg["remote."+RemoteName+".url"] = configURL.String()
+	cfg["credential.helper"] = ""
+
+	keys := make([]string, 0, len(cfg))
+	for key := range cfg {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if err := setConfig(ctx, rcf, repoName, dir, key, cfg[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Register configures the repository at dir as a partial clone that was cloned
+// with filter, like git does on the first fetch with a filter. It is meant for
+// repositories whose objects were copied from another partial clone rather
+// than fetched. WriteRemoteConfig has to be called before missing objects can
+// be fetched.
+func Register(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, filter string) error {
+	for _, kv := range [][2]string{
+		{"core.repositoryformatversion", "1"},
+		{"extensions.partialclone", RemoteName},
+		{"remote." + RemoteName + ".promisor", "true"},
+		{"remote." + RemoteName + ".partialclonefilter", filter},
+	} {
+		if err := setConfig(ctx, rcf, repoName, dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setConfig sets key to value in the config of the repository at dir, or
+// unsets it if value is empty.
+func setConfig(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, key, value string) error {
+	args := []string{"config", "--replace-all", key, value}
+	if value == "" {
+		args = []string{"config", "--unset-all", key}
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	err := rcf.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).Run()
+	// git config exits with status 5 when unsetting a key that isn't set.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && value == "" && exitErr.ExitCode() == 5 {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(executil.WrapCmdError(cmd, err), "setting %s", key)
+	}
+	return nil
+}
+
+// ConfigureFetchCommand configures cmd, a git command that fetches from
+// RemoteName, to talk to remoteURL. The remote must have been configured with
+// WriteRemoteConfig.
+func ConfigureFetchCommand(cmd *exec.Cmd, remoteURL *vcs.URL) {
+	n := len(cmd.Args)
+	executil.ConfigureRemoteGitCommand(cmd, remoteURL)
+
+	// ConfigureRemoteGitCommand only passes on the credentials of HTTP URLs
+	// when the URL is one of the arguments, but partial clones fetch from
+	// RemoteName: git refuses filters for other remotes. So we pass them
+	// through the environment the same way. ConfigureRemoteGitCommand's
+	// options precede the subcommand, which the helper has to follow to not
+	// be unset again.
+	password, ok := remoteURL.User.Password()
+	if !ok || remoteURL.IsSSH() {
+		return
+	}
+	cmd.Args = slices.Insert(cmd.Args, len(cmd.Args)-n+1, "-c", "credential.helper="+envCredentialHelper)
+	cmd.Env = append(cmd.Env, "GIT_SG_USERNAME="+remoteURL.User.Username(), "GIT_SG_PASSWORD="+password)
+}
+
+// MissingObjects returns the IDs of the objects reachable from the tree of
+// treeish that are missing from the repository. If paths are given, only
+// objects at or below them are considered.
+func MissingObjects(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, treeish string, paths []string) ([]string, error) {
+	var missing []string
+	err := listMissingObjects(ctx, rcf, repoName, dir, treeish, paths, func(oid string) error {
+		missing = append(missing, oid)
+		return nil
+	})
+	return missing, err
+}
+
+// listMissingObjects calls fn with the ID of each object reachable from the
+// tree of treeish that is missing from the repository, as git lists them.
+func listMissingObjects(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, treeish string, paths []string, fn func(oid string) error) error {
+	// Pathspecs would drop the commit unless it modified the paths, so we
+	// list the objects at the paths instead. --ignore-missing makes paths
+	// that don't exist, or a HEAD that doesn't resolve yet as in empty
+	// repositories, list nothing rather than fail.
+	args := []string{"rev-list", "--objects", "--missing=print", "--no-walk", "--ignore-missing"}
+	if len(paths) == 0 {
+		args = append(args, treeish)
+	}
+	for _, p := range paths {
+		args = append(args, treeish+":"+p)
+	}
+	args = append(args, "--")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	wrCmd := rcf.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd)
+	stdout, err := wrCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := wrCmd.Start(); err != nil {
+		return err
+	}
+
+	// Whole trees are listed, so the output can be large. Missing objects
+	// are printed as "?<oid>".
+	var fnErr error
+	sc := bufio.NewScanner(stdout)
+	for sc.Scan() {
+		if oid, ok := strings.CutPrefix(sc.Text(), "?"); ok {
+			if fnErr = fn(oid); fnErr != nil {
+				break
+			}
+		}
+	}
+	scanErr := sc.Err()
+	if fnErr != nil {
+		cancel()
+		_ = wrCmd.Wait()
+		return fnErr
+	}
+
+	if err := wrCmd.Wait(); err != nil {
+		return errors.Wrapf(err, "git rev-list: %s", bytes.TrimSpace(stderr.Bytes()))
+	}
+	return scanErr
+}
+
+// MissingBlob returns the ID of the blob at path in commit if it is missing
+// from the repository, or "" otherwise. Unlike MissingObjects, it doesn't
+// look below path if path is a directory.
+func MissingBlob(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, commit api.CommitID, path string) (string, error) {
+	// rev-list refuses missing objects as arguments, so we look the blob
+	// up first.
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", string(commit), "--", path)
+	dir.Set(cmd)
+	out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).Output()
+	if err != nil {
+		return "", executil.WrapCmdError(cmd, err)
+	}
+
+	// format: 100644 blob 3bad331187e39c05c78a9b5e443689f78f4365a7	README.md
+	fields := bytes.Fields(out)
+	if len(fields) < 3 || string(fields[1]) != "blob" {
+		return "", nil
+	}
+	oid := string(fields[2])
+
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "-e", oid)
+	dir.Set(cmd)
+	// Missing objects are what we want to find out about, so git must not
+	// try to fetch them.
+	cmd.Env = append(os.Environ(), "GIT_NO_LAZY_FETCH=1")
+	err = rcf.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return oid, nil
+	}
+	return "", err
+}
+
+// RecordMissingObjects counts the objects in the tree of HEAD that are
+// missing from the partial clone at dir and stores the count in the
+// repository's config, from where ReadStatus reports it. Counting lists the
+// whole tree, so it is skipped if the tree of HEAD didn't change since the
+// last count. It does nothing for repositories that aren't partial clones.
+func RecordMissingObjects(ctx context.Context, repoName api.RepoName, dir common.GitDir, c git.GitConfigBackend) error {
+	status, err := ReadStatus(dir)
+	if err != nil || status == nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "HEAD^{tree}")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return executil.WrapCmdError(cmd, err)
+	}
+	// The tree is empty if HEAD doesn't resolve, as in empty repositories.
+	tree := string(bytes.TrimSpace(out))
+	if recorded, err := c.Get(ctx, missingObjectsTreeKey); err != nil {
+		return err
+	} else if recorded == tree && tree != "" {
+		return nil
+	}
+
+	missing, err := MissingObjects(ctx, wrexec.NewNoOpRecordingCommandFactory(), repoName, dir, "HEAD", nil)
+	if err != nil {
+		return errors.Wrap(err, "counting missing objects")
+	}
+	if err := c.Set(ctx, missingObjectsKey, strconv.Itoa(len(missing))); err != nil {
+		return err
+	}
+	return c.Set(ctx, missingObjectsTreeKey, tree)
+}
+
+// FetchObjects fetches the objects with the given IDs from remoteURL into the
+// partial clone at dir.
+func FetchObjects(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL, oids []string) error {
+	_, err := fetchObjects(ctx, rcf, repoName, dir, remoteURL, func(stdin io.Writer) (int, error) {
+		_, err := io.WriteString(stdin, strings.Join(oids, "\n")+"\n")
+		return len(oids), err
+	})
+	return err
+}
+
+// FetchMissingObjects fetches the objects reachable from the tree of treeish
+// that are missing from the partial clone at dir from remoteURL, in a single
+// fetch. If paths are given, only objects at or below them are fetched. The
+// IDs of the missing objects are streamed to git fetch as they are listed
+// rather than collected first. It returns the number of objects fetched.
+func FetchMissingObjects(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL, treeish string, paths []string) (int, error) {
+	var (
+		stdin   io.WriteCloser
+		fetched = make(chan error, 1)
+		n       int
+	)
+	err := listMissingObjects(ctx, rcf, repoName, dir, treeish, paths, func(oid string) error {
+		if stdin == nil {
+			// Only fetch once we know that objects are missing.
+			pr, pw := io.Pipe()
+			stdin = pw
+			go func() {
+				_, err := fetchObjects(ctx, rcf, repoName, dir, remoteURL, func(w io.Writer) (int, error) {
+					_, err := io.Copy(w, pr)
+					return 0, err
+				})
+				// Unblock writes to the pipe if git fetch exits early.
+				pr.CloseWithError(errors.New("git fetch exited"))
+				fetched <- err
+			}()
+		}
+		n++
+		_, err := io.WriteString(stdin, oid+"\n")
+		return err
+	})
+	if stdin == nil {
+		return 0, err
+	}
+	stdin.Close()
+	if fetchErr := <-fetched; fetchErr != nil {
+		return n, fetchErr
+	}
+	return n, err
+}
+
+// fetchObjects runs the git fetch that FetchObjects and FetchMissingObjects
+// use, with the object IDs written to its standard input by writeIDs.
+func fetchObjects(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL, writeIDs func(io.Writer) (int, error)) (int, error) {
+	// These are the arguments git itself uses to fetch missing objects
+	// from the promisor remote.
+	cmd := exec.CommandContext(ctx, "git", "fetch",
+		"--no-tags", "--no-write-fetch-head", "--recurse-submodules=no",
+		"--filter=blob:none", "--stdin", RemoteName)
+	dir.Set(cmd)
+	ConfigureFetchCommand(cmd, remoteURL)
+
+	redactor := urlredactor.New(remoteURL)
+	wrCmd := rcf.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).WithRedactorFunc(redactor.Redact)
+	var out bytes.Buffer
+	wrCmd.Stdout = &out
+	wrCmd.Stderr = &out
+	stdin, err := wrCmd.StdinPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := wrCmd.Start(); err != nil {
+		return 0, err
+	}
+	n, writeErr := writeIDs(stdin)
+	stdin.Close()
+	if err := wrCmd.Wait(); err != nil {
+		return n, errors.Wrapf(err, "git fetch: %s", redactor.Redact(string(bytes.TrimSpace(out.Bytes()))))
+	}
+	return n, writeErr
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/vcssyncer"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -48,6 +49,10 @@ func postRepoFetchActions(
 		errs = errors.Append(errs, errors.Wrap(err, "setting git gc mode"))
 	}
 
+	if err := partialclone.RecordMissingObjects(ctx, repo, dir, backend.Config()); err != nil {
+		errs = errors.Append(errs, errors.Wrapf(err, "failed to record missing objects of partial clone %q", repo))
+	}
+
 	// Update the last-changed stamp on disk.
 	if err := setLastChanged(ctx, logger, dir, backend); err != nil {
 		errs = errors.Append(errs, errors.Wrap(err, "failed to update last changed time"))
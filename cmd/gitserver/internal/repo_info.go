@@ -2,6 +2,7 @@ package internal
 
 import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -25,5 +26,17 @@ func repoCloneProgress(fs gitserverfs.FS, locker RepositoryLocker, repo api.Repo
 		resp.CloneInProgress = true
 		resp.CloneProgress = cloneProgress
 	}
+	if cloned {
+		status, err := partialclone.ReadStatus(fs.RepoDir(repo))
+		if err != nil {
+			return nil, errors.Wrap(err, "determine partial clone status")
+		}
+		if status != nil {
+			resp.PartialClone = &protocol.PartialCloneStatus{
+				Filter:         status.Filter,
+				MissingObjects: status.MissingObjects,
+			}
+		}
+	}
 	return &resp, nil
 }
@@ -11,10 +11,16 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/shardcopy"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/connection"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
+	"github.com/sourcegraph/sourcegraph/internal/grpc/streamio"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -24,11 +30,13 @@ type GRPCRepositoryServiceConfig struct {
 
 func NewRepositoryServiceServer(server *Server, config *GRPCRepositoryServiceConfig) proto.GitserverRepositoryServiceServer {
 	var srv proto.GitserverRepositoryServiceServer = &repositoryServiceServer{
-		logger:   server.logger,
-		db:       server.db,
-		hostname: server.hostname,
-		svc:      server,
-		fs:       server.fs,
+		logger:    server.logger,
+		db:        server.db,
+		hostname:  server.hostname,
+		svc:       server,
+		fs:        server.fs,
+		rcf:       server.recordingCommandFactory,
+		dialShard: dialGitserverShard,
 	}
 
 	if config.ExhaustiveRequestLoggingEnabled {
@@ -43,12 +51,38 @@ func NewRepositoryServiceServer(server *Server, config *GRPCRepositoryServiceCon
 	return srv
 }
 
+// dialGitserverShard returns a client for the gitserver shard at addr, using
+// the connections shared with the gitserver client. Shards which were removed
+// from the gitserver addresses this instance knows about are dialed directly,
+// since they keep serving their repos until the rebalance moved them. The
+// returned function releases the connection.
+func dialGitserverShard(addr string) (proto.GitserverRepositoryServiceClient, func(), error) {
+	if ac := connection.GlobalConns.GetAddressWithConn(addr); ac != nil {
+		conn, err := ac.GRPCConn()
+		if err != nil {
+			return nil, nil, err
+		}
+		return proto.NewGitserverRepositoryServiceClient(conn), func() {}, nil
+	}
+
+	conn, err := defaults.Dial(addr, log.Scoped("gitserver.shardcopy"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return proto.NewGitserverRepositoryServiceClient(conn), func() { _ = conn.Close() }, nil
+}
+
 type repositoryServiceServer struct {
 	logger   log.Logger
 	db       database.DB
 	hostname string
 	fs       gitserverfs.FS
 	svc      service
+	rcf      *wrexec.RecordingCommandFactory
+
+	// dialShard returns a client for the gitserver shard at the given
+	// address, and a function which releases it.
+	dialShard func(addr string) (proto.GitserverRepositoryServiceClient, func(), error)
 
 	proto.UnimplementedGitserverRepositoryServiceServer
 }
@@ -157,3 +191,77 @@ func (s *repositoryServiceServer) ListRepositories(ctx context.Context, req *pro
 		NextPageToken: nextCursor,
 	}, nil
 }
+
+func (s *repositoryServiceServer) ExportRepository(req *proto.ExportRepositoryRequest, ss proto.GitserverRepositoryService_ExportRepositoryServer) error {
+	if req.GetRepoName() == "" {
+		return status.New(codes.InvalidArgument, "repo_name must be specified").Err()
+	}
+
+	ctx := ss.Context()
+	repoName := api.RepoName(req.GetRepoName())
+
+	cloned, err := s.fs.RepoCloned(repoName)
+	if err != nil {
+		return status.New(codes.Internal, "failed to determine clone status").Err()
+	}
+
+	if !cloned {
+		return newRepoNotFoundError(repoName, false, "")
+	}
+
+	dir := s.fs.RepoDir(repoName)
+
+	headRef, err := shardcopy.HeadRef(ctx, s.rcf, repoName, dir)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to resolve HEAD of %s: %s", repoName, err)
+	}
+
+	if err := ss.Send(&proto.ExportRepositoryResponse{HeadRef: headRef}); err != nil {
+		return err
+	}
+
+	w := streamio.NewWriter(func(p []byte) error {
+		return ss.Send(&proto.ExportRepositoryResponse{
+			Data: p,
+		})
+	})
+
+	if err := shardcopy.Export(ctx, s.rcf, repoName, dir, w); err != nil {
+		s.logger.Error("failed to export repository", log.String("repo", string(repoName)), log.Error(err))
+		return status.Errorf(codes.Internal, "failed to export repository %s: %s", repoName, err)
+	}
+
+	return nil
+}
+
+func (s *repositoryServiceServer) CopyRepositoryFromShard(ctx context.Context, req *proto.CopyRepositoryFromShardRequest) (*proto.CopyRepositoryFromShardResponse, error) {
+	if req.GetRepoName() == "" {
+		return nil, status.New(codes.InvalidArgument, "repo_name must be specified").Err()
+	}
+
+	if req.GetSourceAddress() == "" {
+		return nil, status.New(codes.InvalidArgument, "source_address must be specified").Err()
+	}
+
+	repoName := api.RepoName(req.GetRepoName())
+
+	source, release, err := s.dialShard(req.GetSourceAddress())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to connect to source shard: %s", err)
+	}
+	defer release()
+
+	if err := s.svc.CopyRepositoryFromShard(ctx, repoName, source); err != nil {
+		if errors.Is(err, ErrFetchInProgress) {
+			return nil, status.New(codes.Unavailable, err.Error()).Err()
+		}
+		if gitdomain.IsRepoNotExist(err) {
+			// The source shard doesn't have the repo, so there is nothing to
+			// copy.
+			return nil, newRepoNotFoundError(repoName, false, "")
+		}
+		return nil, status.New(codes.Internal, errors.Wrap(err, "failed to copy repository").Error()).Err()
+	}
+
+	return &proto.CopyRepositoryFromShardResponse{}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -15,12 +16,17 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/shardcopy"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestRepositoryServiceServer_DeleteRepository(t *testing.T) {
@@ -163,6 +169,119 @@ func TestRepositoryServiceServer_ListRepositories(t *testing.T) {
 	})
 }
 
+func TestRepositoryServiceServer_ExportRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("checks for uncloned repo", func(t *testing.T) {
+		fs := gitserverfs.NewMockFS()
+		fs.RepoClonedFunc.SetDefaultReturn(false, nil)
+		rs := &repositoryServiceServer{fs: fs}
+
+		cli := spawnRepositoryServer(t, rs)
+		stream, err := cli.ExportRepository(ctx, &proto.ExportRepositoryRequest{RepoName: "therepo"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Error(t, err)
+		assertGRPCStatusCode(t, err, codes.NotFound)
+		assertHasGRPCErrorDetailOfType(t, err, &proto.RepoNotFoundPayload{})
+	})
+
+	t.Run("e2e", func(t *testing.T) {
+		remote := t.TempDir()
+		cmd := func(name string, arg ...string) string {
+			return runCmd(t, remote, name, arg...)
+		}
+		makeSingleCommitRepo(cmd)
+		cmd("git", "checkout", "-q", "-b", "trunk")
+		cmd("git", "tag", "v1")
+
+		fs := gitserverfs.NewMockFS()
+		fs.RepoClonedFunc.SetDefaultReturn(true, nil)
+		fs.RepoDirFunc.SetDefaultReturn(common.GitDir(filepath.Join(remote, ".git")))
+		rs := &repositoryServiceServer{
+			fs:     fs,
+			rcf:    wrexec.NewNoOpRecordingCommandFactory(),
+			logger: logtest.NoOp(t),
+		}
+
+		cli := spawnRepositoryServer(t, rs)
+		bundlePath := filepath.Join(t.TempDir(), "repo.bundle")
+		headRef, err := receiveBundle(ctx, cli, "therepo", bundlePath)
+		require.NoError(t, err)
+		require.Equal(t, "refs/heads/trunk", headRef)
+
+		copied := t.TempDir()
+		require.NoError(t, git.MakeBareRepo(ctx, copied))
+		require.NoError(t, shardcopy.Import(ctx, wrexec.NewNoOpRecordingCommandFactory(), "therepo", common.GitDir(copied), bundlePath, headRef))
+		require.Equal(t, cmd("git", "show-ref", "--head"), runCmd(t, copied, "git", "show-ref", "--head"))
+	})
+}
+
+func TestRepositoryServiceServer_CopyRepositoryFromShard(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("argument validation", func(t *testing.T) {
+		gs := &repositoryServiceServer{}
+		_, err := gs.CopyRepositoryFromShard(ctx, &proto.CopyRepositoryFromShardRequest{RepoName: ""})
+		require.ErrorContains(t, err, "repo_name must be specified")
+		assertGRPCStatusCode(t, err, codes.InvalidArgument)
+
+		_, err = gs.CopyRepositoryFromShard(ctx, &proto.CopyRepositoryFromShardRequest{RepoName: "therepo"})
+		require.ErrorContains(t, err, "source_address must be specified")
+		assertGRPCStatusCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("copy in progress", func(t *testing.T) {
+		svc := NewMockService()
+		svc.CopyRepositoryFromShardFunc.SetDefaultReturn(ErrFetchInProgress)
+		gs := &repositoryServiceServer{
+			svc: svc,
+			dialShard: func(string) (proto.GitserverRepositoryServiceClient, func(), error) {
+				return nil, func() {}, nil
+			},
+		}
+		_, err := gs.CopyRepositoryFromShard(ctx, &proto.CopyRepositoryFromShardRequest{RepoName: "therepo", SourceAddress: "gitserver-1"})
+		assertGRPCStatusCode(t, err, codes.Unavailable)
+	})
+
+	t.Run("not cloned on source", func(t *testing.T) {
+		svc := NewMockService()
+		svc.CopyRepositoryFromShardFunc.SetDefaultReturn(errors.Wrap(&gitdomain.RepoNotExistError{Repo: "therepo"}, "receiving bundle"))
+		released := false
+		gs := &repositoryServiceServer{
+			svc: svc,
+			dialShard: func(string) (proto.GitserverRepositoryServiceClient, func(), error) {
+				return nil, func() { released = true }, nil
+			},
+		}
+		_, err := gs.CopyRepositoryFromShard(ctx, &proto.CopyRepositoryFromShardRequest{RepoName: "therepo", SourceAddress: "gitserver-1"})
+		assertGRPCStatusCode(t, err, codes.NotFound)
+		require.True(t, released)
+	})
+
+	t.Run("e2e", func(t *testing.T) {
+		svc := NewMockService()
+		source := proto.NewGitserverRepositoryServiceClient(nil)
+		var dialed string
+		rs := &repositoryServiceServer{
+			svc: svc,
+			dialShard: func(addr string) (proto.GitserverRepositoryServiceClient, func(), error) {
+				dialed = addr
+				return source, func() {}, nil
+			},
+		}
+
+		cli := spawnRepositoryServer(t, rs)
+		_, err := cli.CopyRepositoryFromShard(ctx, &proto.CopyRepositoryFromShardRequest{
+			RepoName:      "therepo",
+			SourceAddress: "gitserver-1",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "gitserver-1", dialed)
+		mockassert.CalledOnceWith(t, svc.CopyRepositoryFromShardFunc, mockassert.Values(mockassert.Skip, api.RepoName("therepo"), source))
+	})
+}
+
 func spawnRepositoryServer(t *testing.T, server *repositoryServiceServer) proto.GitserverRepositoryServiceClient {
 	t.Helper()
 	grpcServer := defaults.NewServer(logtest.Scoped(t))
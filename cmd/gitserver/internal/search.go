@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git/gitcli"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/search"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/signing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
@@ -39,7 +40,7 @@ var (
 	})
 )
 
-func searchWithObservability(ctx context.Context, logger log.Logger, repoDir common.GitDir, tr trace.Trace, args *protocol.SearchRequest, onMatch func(*protocol.CommitMatch) error) (limitHit bool, err error) {
+func searchWithObservability(ctx context.Context, logger log.Logger, repoDir common.GitDir, keyring *signing.Keyring, tr trace.Trace, args *protocol.SearchRequest, onMatch func(*protocol.CommitMatch) error) (limitHit bool, err error) {
 	searchStart := time.Now()
 
 	searchRunning.Inc()
@@ -96,12 +97,12 @@ func searchWithObservability(ctx context.Context, logger log.Logger, repoDir com
 		return onMatch(cm)
 	}
 
-	return doSearch(ctx, logger, repoDir, args, onMatchWithLatency)
+	return doSearch(ctx, logger, repoDir, keyring, args, onMatchWithLatency)
 }
 
 // doSearch handles the core logic of the search. It is passed a matchesBuf so it doesn't need to
 // concern itself with event types, and all instrumentation is handled in the calling function.
-func doSearch(ctx context.Context, logger log.Logger, repoDir common.GitDir, args *protocol.SearchRequest, onMatch func(*protocol.CommitMatch) error) (limitHit bool, err error) {
+func doSearch(ctx context.Context, logger log.Logger, repoDir common.GitDir, keyring *signing.Keyring, args *protocol.SearchRequest, onMatch func(*protocol.CommitMatch) error) (limitHit bool, err error) {
 	if args.Limit == 0 {
 		args.Limit = math.MaxInt32
 	}
@@ -144,6 +145,7 @@ func doSearch(ctx context.Context, logger log.Logger, repoDir common.GitDir, arg
 		Query:                mt,
 		IncludeDiff:          args.IncludeDiff,
 		IncludeModifiedFiles: args.IncludeModifiedFiles || hasDiffModifiesFile,
+		Keyring:              keyring,
 	}
 
 	return hitLimit.Load(), searcher.Search(ctx, limitedOnMatch)
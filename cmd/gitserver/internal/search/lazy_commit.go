@@ -89,6 +89,9 @@ func (l *LazyCommit) RefNames() []string {
 }
 
 func (l *LazyCommit) SourceRefs() []string {
+	if l.RawCommit.revisions != nil {
+		return l.RawCommit.reachableFrom.names(l.RawCommit.revisions)
+	}
 	return strings.Split(utf8String(l.RawCommit.SourceRefs), ", ")
 }
 
@@ -5,6 +5,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/sourcegraph/sourcegraph/internal/byteutils"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -20,6 +21,8 @@ func ToMatchTree(q protocol.Node) (MatchTree, error) {
 		return &CommitBefore{*v}, nil
 	case *protocol.CommitAfter:
 		return &CommitAfter{*v}, nil
+	case *protocol.CommitSigned:
+		return &CommitSigned{*v}, nil
 	case *protocol.AuthorMatches:
 		re, err := casetransform.CompileRegexp(v.Expr, v.IgnoreCase)
 		return &AuthorMatches{re}, err
@@ -124,6 +127,30 @@ func (c *CommitAfter) Match(lc *LazyCommit) (CommitFilterResult, MatchedCommit,
 	return filterResult(committerDate.After(c.Time)), MatchedCommit{}, nil
 }
 
+// CommitSigned is a predicate that matches if the signature of the commit
+// verifies as Signed. It requires the searcher to verify signatures. Commits
+// whose signature couldn't be verified never match.
+type CommitSigned struct {
+	protocol.CommitSigned
+}
+
+func (c *CommitSigned) Match(lc *LazyCommit) (CommitFilterResult, MatchedCommit, error) {
+	var matches bool
+	switch lc.SignatureStatus {
+	case gitdomain.SignatureStatusVerified:
+		matches = c.Signed == protocol.SignedYes
+	case gitdomain.SignatureStatusUnsigned:
+		matches = c.Signed == protocol.SignedNo
+	case gitdomain.SignatureStatusInvalid, gitdomain.SignatureStatusExpired, gitdomain.SignatureStatusRevoked, gitdomain.SignatureStatusUnverified:
+		// Signatures that are expired, made by a revoked key or can't be
+		// checked at all aren't valid either.
+		matches = c.Signed == protocol.SignedInvalid
+	case gitdomain.SignatureStatusUntrusted:
+		matches = c.Signed == protocol.SignedUntrusted
+	}
+	return filterResult(matches), MatchedCommit{}, nil
+}
+
 // MessageMatches is a predicate that matches if the commit message matches
 // the provided regex pattern.
 type MessageMatches struct {
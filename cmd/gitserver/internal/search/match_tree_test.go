@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -89,3 +91,23 @@ func Test_matchesToRanges(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitSigned(t *testing.T) {
+	for status, want := range map[gitdomain.SignatureStatus]protocol.SignedFilter{
+		gitdomain.SignatureStatusVerified:   protocol.SignedYes,
+		gitdomain.SignatureStatusUnsigned:   protocol.SignedNo,
+		gitdomain.SignatureStatusInvalid:    protocol.SignedInvalid,
+		gitdomain.SignatureStatusExpired:    protocol.SignedInvalid,
+		gitdomain.SignatureStatusRevoked:    protocol.SignedInvalid,
+		gitdomain.SignatureStatusUnverified: protocol.SignedInvalid,
+		gitdomain.SignatureStatusUntrusted:  protocol.SignedUntrusted,
+		gitdomain.SignatureStatusUnknown:    0,
+	} {
+		lc := &LazyCommit{RawCommit: &RawCommit{SignatureStatus: status}}
+		for _, signed := range []protocol.SignedFilter{protocol.SignedYes, protocol.SignedNo, protocol.SignedInvalid, protocol.SignedUntrusted} {
+			res, _, err := (&CommitSigned{protocol.CommitSigned{Signed: signed}}).Match(lc)
+			require.NoError(t, err)
+			require.Equal(t, signed == want, res.Satisfies(), "status %d, signed:%s", status, signed)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"math/bits"
+	"os/exec"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// revisionTips are the commits the revisions of a search point to.
+type revisionTips struct {
+	// revisions are the revisions of the search, indexed by refSet.
+	revisions []string
+	// tips maps the hash of each commit to the revisions pointing to it.
+	tips map[string]refSet
+}
+
+// hashes returns the distinct commits the revisions point to. Walking the
+// history of these instead of the revisions visits each commit once, even if
+// many revisions point to it.
+func (t *revisionTips) hashes() []string {
+	hashes := make([]string, 0, len(t.tips))
+	for hash := range t.tips {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// resolveRevisions resolves revs to the commits they point to with a single
+// git cat-file, which reads the revisions from stdin so that searches of
+// thousands of branches don't run into argument limits.
+//
+// It returns nil if a revision is not a plain reference to a commit, like a
+// range or an exclusion, or if it does not exist. Searches of such revisions
+// are left to git log.
+func resolveRevisions(ctx context.Context, repoDir string, revs []string) (*revisionTips, error) {
+	var stdin bytes.Buffer
+	for _, rev := range revs {
+		if strings.HasPrefix(rev, "^") || strings.HasPrefix(rev, "-") || strings.Contains(rev, "..") || strings.ContainsAny(rev, " \n") {
+			return nil, nil
+		}
+		stdin.WriteString(rev)
+		stdin.WriteString("^{commit}\n")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "--batch-check=%(objectname)")
+	cmd.Dir = repoDir
+	cmd.Stdin = &stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving revisions")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(lines) != len(revs) {
+		return nil, errors.Errorf("resolving revisions: expected %d objects, got %d", len(revs), len(lines))
+	}
+
+	t := &revisionTips{
+		revisions: revs,
+		tips:      make(map[string]refSet, len(revs)),
+	}
+	for i, line := range lines {
+		// Revisions that don't resolve are reported as "<rev> missing" or
+		// "<rev> ambiguous".
+		if strings.Contains(line, " ") {
+			return nil, nil
+		}
+		t.tips[line] = t.tips[line].union(newRefSet(i))
+	}
+	return t, nil
+}
+
+// refSet is a set of revisions, as a bitset of indexes into
+// revisionTips.revisions. The nil refSet is the empty set.
+//
+// A refSet is immutable, so that the sets of consecutive commits, which are
+// usually the same, can share memory.
+type refSet []uint64
+
+func newRefSet(i int) refSet {
+	s := make(refSet, i/64+1)
+	s[i/64] = 1 << (i % 64)
+	return s
+}
+
+// union returns the union of s and o. It returns s or o if one contains the
+// other.
+func (s refSet) union(o refSet) refSet {
+	if len(s) < len(o) {
+		s, o = o, s
+	}
+	if o.subsetOf(s) {
+		return s
+	}
+	u := make(refSet, len(s))
+	copy(u, s)
+	for i, w := range o {
+		u[i] |= w
+	}
+	return u
+}
+
+func (s refSet) subsetOf(o refSet) bool {
+	if len(s) > len(o) {
+		return false
+	}
+	for i, w := range s {
+		if w&^o[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// names returns the revisions in s, in the order they were searched.
+func (s refSet) names(revisions []string) []string {
+	var names []string
+	for i, w := range s {
+		for w != 0 {
+			j := bits.TrailingZeros64(w)
+			names = append(names, revisions[i*64+j])
+			w &^= 1 << j
+		}
+	}
+	return names
+}
+
+// reachability tracks the revisions each commit is reachable from during a
+// single walk of the history of all revisions. If the revisions point to
+// several commits, it relies on the walk visiting children before their
+// parents (git log --date-order), so that the set of a commit is complete when
+// the commit is visited.
+//
+// Only the sets of commits whose children were visited but not they
+// themselves are kept, which is the width of the history rather than its
+// length.
+type reachability struct {
+	tips    *revisionTips
+	pending map[string]refSet
+}
+
+func newReachability(tips *revisionTips) *reachability {
+	return &reachability{
+		tips:    tips,
+		pending: map[string]refSet{},
+	}
+}
+
+// visit returns the revisions c is reachable from and passes them on to its
+// parents.
+func (r *reachability) visit(c *RawCommit) refSet {
+	if len(r.tips.tips) == 1 {
+		// Every commit is reachable from all revisions, in whatever order
+		// they are visited.
+		for _, set := range r.tips.tips {
+			return set
+		}
+	}
+
+	hash := string(c.Hash)
+	set := r.pending[hash].union(r.tips.tips[hash])
+	delete(r.pending, hash)
+
+	for _, parent := range bytes.Fields(c.ParentHashes) {
+		r.pending[string(parent)] = r.pending[string(parent)].union(set)
+	}
+	return set
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefSet(t *testing.T) {
+	revisions := make([]string, 130)
+	for i := range revisions {
+		revisions[i] = string(rune('a' + i%26))
+	}
+
+	a := newRefSet(1)
+	b := newRefSet(129)
+	require.Equal(t, []string{"b"}, a.names(revisions))
+	require.Equal(t, []string{"z"}, b.names(revisions))
+
+	u := a.union(b)
+	require.Equal(t, []string{"b", "z"}, u.names(revisions))
+	require.True(t, a.subsetOf(u))
+	require.True(t, b.subsetOf(u))
+	require.False(t, u.subsetOf(a))
+
+	// The union with a subset does not allocate a new set.
+	require.Same(t, &u[0], &u.union(a)[0])
+	require.Same(t, &u[0], &refSet(nil).union(u)[0])
+	require.Nil(t, refSet(nil).names(revisions))
+}
+
+func TestResolveRevisions(t *testing.T) {
+	dir := initGitRepository(t,
+		"git config user.name camden",
+		"git config user.email camden@ccheek.com",
+		"git checkout -b main",
+		"git commit --allow-empty -m base",
+		"git branch same",
+		"git tag v1",
+	)
+
+	t.Run("refs to the same commit", func(t *testing.T) {
+		tips, err := resolveRevisions(context.Background(), dir, []string{"main", "same", "v1", "HEAD"})
+		require.NoError(t, err)
+		require.NotNil(t, tips)
+		require.Len(t, tips.hashes(), 1)
+		require.Equal(t, []string{"main", "same", "v1", "HEAD"}, tips.tips[tips.hashes()[0]].names(tips.revisions))
+	})
+
+	for _, revs := range [][]string{
+		{"main", "missing"},
+		{"same..main"},
+		{"main", "^same"},
+		{"--all"},
+	} {
+		t.Run(revs[len(revs)-1], func(t *testing.T) {
+			tips, err := resolveRevisions(context.Background(), dir, revs)
+			require.NoError(t, err)
+			require.Nil(t, tips)
+		})
+	}
+}
+
+func TestReachability(t *testing.T) {
+	t.Run("single tip", func(t *testing.T) {
+		tips := &revisionTips{
+			revisions: []string{"main", "HEAD"},
+			tips:      map[string]refSet{"c2": newRefSet(0).union(newRefSet(1))},
+		}
+		r := newReachability(tips)
+
+		// Without --date-order a parent may be visited before its child.
+		require.Equal(t, []string{"main", "HEAD"}, r.visit(&RawCommit{Hash: []byte("c1")}).names(tips.revisions))
+		require.Equal(t, []string{"main", "HEAD"}, r.visit(&RawCommit{Hash: []byte("c2"), ParentHashes: []byte("c1")}).names(tips.revisions))
+	})
+
+	t.Run("several tips", func(t *testing.T) {
+		tips := &revisionTips{
+			revisions: []string{"main", "feature"},
+			tips: map[string]refSet{
+				"c2": newRefSet(0),
+				"c3": newRefSet(1),
+			},
+		}
+		r := newReachability(tips)
+
+		require.Equal(t, []string{"feature"}, r.visit(&RawCommit{Hash: []byte("c3"), ParentHashes: []byte("c1")}).names(tips.revisions))
+		require.Equal(t, []string{"main"}, r.visit(&RawCommit{Hash: []byte("c2"), ParentHashes: []byte("c1")}).names(tips.revisions))
+		require.Equal(t, []string{"main", "feature"}, r.visit(&RawCommit{Hash: []byte("c1")}).names(tips.revisions))
+	})
+}
@@ -12,9 +12,12 @@ import (
 	"github.com/sourcegraph/log"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/signing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -89,6 +92,40 @@ type CommitSearcher struct {
 	IncludeDiff          bool
 	IncludeModifiedFiles bool
 	RepoName             api.RepoName
+
+	// Keyring is the keyring commit signatures are verified against. Queries
+	// matching on signatures fail if it is nil.
+	Keyring *signing.Keyring
+}
+
+// verifySignatures returns whether the query needs the signature status of
+// commits.
+func (cs *CommitSearcher) verifySignatures() bool {
+	found := false
+	Visit(cs.Query, func(mt MatchTree) {
+		if _, ok := mt.(*CommitSigned); ok {
+			found = true
+		}
+	})
+	return found
+}
+
+// verifyBatch sets the signature status of the commits in batch. Signatures
+// are verified by a single git process per batch, and commits verified by an
+// earlier search aren't verified again.
+func (cs *CommitSearcher) verifyBatch(ctx context.Context, batch []*RawCommit) error {
+	commits := make([]api.CommitID, 0, len(batch))
+	for _, cv := range batch {
+		commits = append(commits, api.CommitID(cv.Hash))
+	}
+	verifications, err := signing.VerifyCommits(ctx, nil, cs.RepoName, common.GitDir(cs.RepoDir), cs.Keyring, commits)
+	if err != nil {
+		return err
+	}
+	for _, cv := range batch {
+		cv.SignatureStatus = verifications[api.CommitID(cv.Hash)].Status
+	}
+	return nil
 }
 
 // Search runs a search for commits matching the given predicate across the revisions passed in as revisionArgs.
@@ -99,7 +136,14 @@ type CommitSearcher struct {
 // that job should be sent down. We then read from the result channels in the same order that the jobs were sent.
 // This allows our worker pool to run the jobs in parallel, but we still emit matches in the same order that
 // git log outputs them.
+//
+// The history of all revisions is walked once, even if they share most of it, like the branches of a
+// repository do. The SourceRefs of a match are all the revisions the commit is reachable from.
 func (cs *CommitSearcher) Search(ctx context.Context, onMatch func(*protocol.CommitMatch)) error {
+	if cs.verifySignatures() && cs.Keyring == nil {
+		return errors.New("commit signature verification is not enabled. Configure gitSignatureVerification in the site configuration to search by signature")
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	jobs := make(chan job, 128)
@@ -136,7 +180,23 @@ func (cs *CommitSearcher) Search(ctx context.Context, onMatch func(*protocol.Com
 
 func (cs *CommitSearcher) gitArgs() []string {
 	revArgs := revsToGitArgs(cs.Revisions)
-	args := append(logArgs, revArgs...)
+	args := append(logArgs[:len(logArgs):len(logArgs)], revArgs...)
+	if cs.IncludeModifiedFiles {
+		args = append(args, "--name-status")
+	}
+	return args
+}
+
+// gitArgsForTips returns the arguments to walk the history of the commits
+// read from stdin. The history of several commits is walked children before
+// parents, which reachability relies on. --date-order has to read the whole
+// history before git log outputs the first commit unless the repository has a
+// commit-graph, so it's only used when needed.
+func (cs *CommitSearcher) gitArgsForTips(tips *revisionTips) []string {
+	args := append(logArgs[:len(logArgs):len(logArgs)], "--stdin")
+	if len(tips.tips) > 1 {
+		args = append(args, "--date-order")
+	}
 	if cs.IncludeModifiedFiles {
 		args = append(args, "--name-status")
 	}
@@ -156,7 +216,24 @@ func revsToGitArgs(revs []string) []string {
 }
 
 func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, resultChans chan chan *protocol.CommitMatch) (err error) {
-	cmd := exec.CommandContext(ctx, "git", cs.gitArgs()...)
+	revs := cs.Revisions
+	if len(revs) == 0 {
+		revs = []string{""}
+	}
+	tips, err := resolveRevisions(ctx, cs.RepoDir, revsToGitArgs(revs))
+	if err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	var reach *reachability
+	if tips != nil {
+		cmd = exec.CommandContext(ctx, "git", cs.gitArgsForTips(tips)...)
+		cmd.Stdin = strings.NewReader(strings.Join(tips.hashes(), "\n") + "\n")
+		reach = newReachability(tips)
+	} else {
+		cmd = exec.CommandContext(ctx, "git", cs.gitArgs()...)
+	}
 	cmd.Dir = cs.RepoDir
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
@@ -193,6 +270,10 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 			return nil
 		}
 		cv := scanner.NextRawCommit()
+		if reach != nil {
+			cv.reachableFrom = reach.visit(cv)
+			cv.revisions = tips.revisions
+		}
 		batch = append(batch, cv)
 		if len(batch) == batchSize {
 			sendBatch()
@@ -238,10 +319,17 @@ func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	defer diffFetcher.Stop()
 
 	startBuf := make([]byte, 1024)
+	verifySignatures := cs.verifySignatures()
 
 	runJob := func(j job) error {
 		defer close(j.resultChan)
 
+		if verifySignatures {
+			if err := cs.verifyBatch(ctx, j.batch); err != nil {
+				return err
+			}
+		}
+
 		for _, cv := range j.batch {
 			if ctx.Err() != nil {
 				// ignore context error, and don't spend time running the job
@@ -289,6 +377,15 @@ type RawCommit struct {
 	Message        []byte
 	ParentHashes   []byte
 	ModifiedFiles  [][]byte
+
+	// SignatureStatus is only known if the query matches on signatures.
+	SignatureStatus gitdomain.SignatureStatus
+
+	// reachableFrom is the set of revisions, indexes into revisions, the
+	// commit is reachable from. If revisions is nil, SourceRefs is the ref
+	// reported by git log instead.
+	reachableFrom refSet
+	revisions     []string
 }
 
 type CommitScanner struct {
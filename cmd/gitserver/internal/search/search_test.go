@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/signing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
@@ -267,6 +268,138 @@ func TestSearch(t *testing.T) {
 	})
 }
 
+func TestSearch_sourceRefs(t *testing.T) {
+	dir := initGitRepository(t,
+		"git config user.name camden",
+		"git config user.email camden@ccheek.com",
+		"git checkout -b main",
+		"git commit --allow-empty -m base",
+		"git commit --allow-empty -m main1",
+		"git branch same",
+		"git checkout -b feature HEAD~1",
+		"git commit --allow-empty -m feature1",
+		"git checkout main",
+		"git merge --no-ff feature -m merge",
+	)
+
+	search := func(t *testing.T, revisions []string) map[string][]string {
+		tree, err := ToMatchTree(protocol.NewAnd())
+		require.NoError(t, err)
+		searcher := &CommitSearcher{
+			RepoDir:   dir,
+			Query:     tree,
+			Revisions: revisions,
+		}
+		sourceRefs := map[string][]string{}
+		err = searcher.Search(context.Background(), func(match *protocol.CommitMatch) {
+			sourceRefs[match.Message.Content] = match.SourceRefs
+		})
+		require.NoError(t, err)
+		return sourceRefs
+	}
+
+	t.Run("all refs a commit is reachable from", func(t *testing.T) {
+		require.Equal(t, map[string][]string{
+			"merge":    {"main"},
+			"main1":    {"main", "same"},
+			"feature1": {"main", "feature"},
+			"base":     {"main", "feature", "same"},
+		}, search(t, []string{"main", "feature", "same"}))
+	})
+
+	t.Run("HEAD", func(t *testing.T) {
+		require.Equal(t, map[string][]string{
+			"merge":    {"HEAD"},
+			"main1":    {"HEAD"},
+			"feature1": {"HEAD"},
+			"base":     {"HEAD"},
+		}, search(t, nil))
+	})
+
+	t.Run("range falls back to git log", func(t *testing.T) {
+		require.Equal(t, map[string][]string{
+			"merge":    {"main"},
+			"feature1": {"main"},
+		}, search(t, []string{"same..main"}))
+	})
+}
+
+func TestSearch_signed(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+
+	key := path.Join(t.TempDir(), "key")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput()
+	require.NoError(t, err, string(out))
+	publicKey, err := os.ReadFile(key + ".pub")
+	require.NoError(t, err)
+
+	dir := initGitRepository(t,
+		"git config user.name camden",
+		"git config user.email camden@ccheek.com",
+		"git commit --allow-empty -m unsigned",
+		"git -c gpg.format=ssh -c user.signingkey="+key+" commit -S --allow-empty -m signed",
+	)
+
+	ctx := context.Background()
+	keyring, err := signing.Build(ctx, path.Join(t.TempDir(), "keyring"), signing.Keys{
+		SSHAllowedSigners: []string{"camden " + string(publicKey)},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { keyring.Remove(context.Background()) })
+	emptyKeyring, err := signing.Build(ctx, path.Join(t.TempDir(), "keyring"), signing.Keys{})
+	require.NoError(t, err)
+	t.Cleanup(func() { emptyKeyring.Remove(context.Background()) })
+
+	search := func(t *testing.T, keyring *signing.Keyring, query protocol.Node) ([]string, error) {
+		tree, err := ToMatchTree(query)
+		require.NoError(t, err)
+		searcher := &CommitSearcher{
+			RepoDir:              path.Join(dir, ".git"),
+			Query:                tree,
+			IncludeModifiedFiles: true,
+			Keyring:              keyring,
+		}
+		var messages []string
+		err = searcher.Search(ctx, func(match *protocol.CommitMatch) {
+			messages = append(messages, match.Message.Content)
+		})
+		return messages, err
+	}
+
+	for _, tc := range []struct {
+		name    string
+		keyring *signing.Keyring
+		query   protocol.Node
+		want    []string
+	}{
+		{name: "yes", keyring: keyring, query: &protocol.CommitSigned{Signed: protocol.SignedYes}, want: []string{"signed"}},
+		{name: "no", keyring: keyring, query: &protocol.CommitSigned{Signed: protocol.SignedNo}, want: []string{"unsigned"}},
+		{name: "invalid", keyring: keyring, query: &protocol.CommitSigned{Signed: protocol.SignedInvalid}},
+		{name: "untrusted", keyring: keyring, query: &protocol.CommitSigned{Signed: protocol.SignedUntrusted}},
+		{name: "key not trusted", keyring: emptyKeyring, query: &protocol.CommitSigned{Signed: protocol.SignedUntrusted}, want: []string{"signed"}},
+		{name: "key not trusted is not invalid", keyring: emptyKeyring, query: &protocol.CommitSigned{Signed: protocol.SignedInvalid}},
+		{
+			name:    "combined with other predicates",
+			keyring: keyring,
+			query:   protocol.NewAnd(&protocol.MessageMatches{Expr: "signed"}, protocol.NewNot(&protocol.CommitSigned{Signed: protocol.SignedYes})),
+			want:    []string{"unsigned"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			messages, err := search(t, tc.keyring, tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, messages)
+		})
+	}
+
+	t.Run("verification disabled", func(t *testing.T) {
+		_, err := search(t, nil, &protocol.CommitSigned{Signed: protocol.SignedYes})
+		require.Error(t, err)
+	})
+}
+
 func TestCommitScanner(t *testing.T) {
 	cmds := []string{
 		"echo lorem ipsum dolor sit amet > file1",
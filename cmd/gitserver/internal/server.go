@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/signing"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/vcssyncer"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -66,6 +67,16 @@ type ServerOpts struct {
 	// error.
 	GetRemoteURLFunc func(context.Context, api.RepoName) (string, error)
 
+	// GetSubmoduleRepoNameFunc is a function which resolves the URL of a
+	// submodule of a repository to the name of the Sourcegraph repository it
+	// points to. It returns an empty name if the URL doesn't match any known
+	// code host. If nil, submodules are not resolved.
+	GetSubmoduleRepoNameFunc func(ctx context.Context, repo api.RepoName, submoduleURL string) (api.RepoName, error)
+
+	// SigningKeyringSource returns the keyring commit signatures are verified
+	// against by commit search. If nil, signatures are not verified.
+	SigningKeyringSource signing.KeyringSource
+
 	// GetVCSSyncer is a function which returns the VCS syncer for a repository.
 	// This is used when cloning or fetching a repository. In production this will
 	// speak to the database to determine the code host type. In tests this is
@@ -115,6 +126,8 @@ func NewServer(opt *ServerOpts) *Server {
 		logger:                  opt.Logger,
 		gitBackendSource:        opt.GitBackendSource,
 		getRemoteURLFunc:        opt.GetRemoteURLFunc,
+		getSubmoduleRepoName:    opt.GetSubmoduleRepoNameFunc,
+		signingKeyringSource:    opt.SigningKeyringSource,
 		getVCSSyncer:            opt.GetVCSSyncer,
 		hostname:                opt.Hostname,
 		db:                      opt.DB,
@@ -149,6 +162,14 @@ type Server struct {
 	// error.
 	getRemoteURLFunc func(context.Context, api.RepoName) (string, error)
 
+	// getSubmoduleRepoName resolves the URL of a submodule to the name of the
+	// Sourcegraph repository it points to. It may be nil.
+	getSubmoduleRepoName func(ctx context.Context, repo api.RepoName, submoduleURL string) (api.RepoName, error)
+
+	// signingKeyringSource returns the keyring commit signatures are verified
+	// against. It may be nil.
+	signingKeyringSource signing.KeyringSource
+
 	// getVCSSyncer is a function which returns the VCS syncer for a repository.
 	// This is used when cloning or fetching a repository. In production this will
 	// speak to the database to determine the code host type. In tests this is
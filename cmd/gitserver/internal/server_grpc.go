@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"time"
 
@@ -17,9 +18,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/perforce"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/signing"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
@@ -35,6 +38,7 @@ type service interface {
 	IsRepoCloneable(ctx context.Context, repo api.RepoName) (protocol.IsRepoCloneableResponse, error)
 	FetchRepository(ctx context.Context, repo api.RepoName) (lastFetched, lastChanged time.Time, err error)
 	EnsureRevision(ctx context.Context, repo api.RepoName, rev string) (didUpdate bool)
+	CopyRepositoryFromShard(ctx context.Context, repo api.RepoName, source proto.GitserverRepositoryServiceClient) error
 }
 
 type GRPCServerConfig struct {
@@ -50,6 +54,9 @@ func NewGRPCServer(server *Server, config *GRPCServerConfig) proto.GitserverServ
 		gitBackendSource: server.gitBackendSource,
 		svc:              server,
 		fs:               server.fs,
+
+		getSubmoduleRepoName: server.getSubmoduleRepoName,
+		signingKeyringSource: server.signingKeyringSource,
 	}
 
 	if config.ExhaustiveRequestLoggingEnabled {
@@ -73,6 +80,9 @@ type grpcServer struct {
 	fs               gitserverfs.FS
 	svc              service
 
+	getSubmoduleRepoName func(ctx context.Context, repo api.RepoName, submoduleURL string) (api.RepoName, error)
+	signingKeyringSource signing.KeyringSource
+
 	proto.UnimplementedGitserverServiceServer
 }
 
@@ -306,7 +316,12 @@ func (gs *grpcServer) Search(req *proto.SearchRequest, ss proto.GitserverService
 	tr, ctx := trace.New(ss.Context(), "search")
 	defer tr.End()
 
-	limitHit, err := searchWithObservability(ctx, gs.logger, gs.fs.RepoDir(args.Repo), tr, args, onMatch)
+	var keyring *signing.Keyring
+	if gs.signingKeyringSource != nil {
+		keyring = gs.signingKeyringSource()
+	}
+
+	limitHit, err := searchWithObservability(ctx, gs.logger, gs.fs.RepoDir(args.Repo), keyring, tr, args, onMatch)
 	if err != nil {
 		return err
 	}
@@ -820,6 +835,10 @@ func (gs *grpcServer) Blame(req *proto.BlameRequest, ss proto.GitserverService_B
 
 	opts := git.BlameOptions{
 		IgnoreWhitespace: req.GetIgnoreWhitespace(),
+		IgnoreRevs:       req.GetIgnoreRevs(),
+		DetectMoves:      req.GetDetectMoves(),
+		DetectCopies:     req.GetDetectCopies(),
+		ReverseFrom:      api.CommitID(req.GetReverseFrom()),
 	}
 
 	if r := req.GetRange(); r != nil {
@@ -1126,6 +1145,8 @@ func (gs *grpcServer) ListRefs(req *proto.ListRefsRequest, ss proto.GitserverSer
 		TagsOnly:       req.GetTagsOnly(),
 		PointsAtCommit: pointsAtCommit,
 		Contains:       contains,
+
+		VerifyTagSignatures: req.GetVerifyTagSignatures(),
 	}
 
 	it, err := backend.ListRefs(ss.Context(), opt)
@@ -1558,7 +1579,7 @@ func (gs *grpcServer) Stat(ctx context.Context, req *proto.StatRequest) (*proto.
 	}
 
 	return &proto.StatResponse{
-		FileInfo: gitdomain.FSFileInfoToProto(fi),
+		FileInfo: gitdomain.FSFileInfoToProto(gs.resolveSubmodule(ctx, repoName, fi, nil)),
 	}, nil
 }
 
@@ -1619,6 +1640,10 @@ func (gs *grpcServer) ReadDir(req *proto.ReadDirRequest, ss proto.GitserverServi
 		return ss.Send(&proto.ReadDirResponse{FileInfo: fis})
 	})
 
+	// Submodules often share a URL across directories of a large tree, so we
+	// only resolve each URL once per request.
+	submoduleRepoNames := make(map[string]api.RepoName)
+
 	for {
 		fi, err := it.Next()
 		if err != nil {
@@ -1649,7 +1674,7 @@ func (gs *grpcServer) ReadDir(req *proto.ReadDirRequest, ss proto.GitserverServi
 			}
 			return err
 		}
-		err = chunker.Send(gitdomain.FSFileInfoToProto(fi))
+		err = chunker.Send(gitdomain.FSFileInfoToProto(gs.resolveSubmodule(ctx, repoName, fi, submoduleRepoNames)))
 		if err != nil {
 			return errors.Wrap(err, "failed to send file chunk")
 		}
@@ -1663,6 +1688,37 @@ func (gs *grpcServer) ReadDir(req *proto.ReadDirRequest, ss proto.GitserverServi
 	return nil
 }
 
+// resolveSubmodule fills in the Sourcegraph repository name of fi if it
+// describes a submodule. Resolved names are memoized in cache, which may be nil.
+// Failing to resolve a submodule is not fatal, the name is left empty instead.
+func (gs *grpcServer) resolveSubmodule(ctx context.Context, repo api.RepoName, fi fs.FileInfo, cache map[string]api.RepoName) fs.FileInfo {
+	sm, ok := fi.Sys().(gitdomain.Submodule)
+	if !ok || sm.URL == "" || gs.getSubmoduleRepoName == nil {
+		return fi
+	}
+
+	name, ok := cache[sm.URL]
+	if !ok {
+		var err error
+		name, err = gs.getSubmoduleRepoName(ctx, repo, sm.URL)
+		if err != nil {
+			gs.logger.Warn("failed to resolve submodule repository", log.String("repo", string(repo)), log.String("submodule", sm.Path), log.Error(err))
+		}
+		if cache != nil {
+			cache[sm.URL] = name
+		}
+	}
+	sm.RepoName = name
+
+	return &fileutil.FileInfo{
+		Name_:    fi.Name(),
+		Mode_:    fi.Mode(),
+		Size_:    fi.Size(),
+		ModTime_: fi.ModTime(),
+		Sys_:     sm,
+	}
+}
+
 func (gs *grpcServer) CommitLog(req *proto.CommitLogRequest, ss proto.GitserverService_CommitLogServer) (err error) {
 	ctx := ss.Context()
 
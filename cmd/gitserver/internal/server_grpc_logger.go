@@ -682,6 +682,10 @@ func blameRequestToLogFields(req *proto.BlameRequest) []log.Field {
 		log.String("path", string(req.GetPath())),
 		log.Bool("ignoreWhitespace", req.GetIgnoreWhitespace()),
 		log.Object("range", blameRangeToLogFields(req.GetRange())...),
+		log.Bool("ignoreRevs", req.GetIgnoreRevs()),
+		log.Bool("detectMoves", req.GetDetectMoves()),
+		log.Bool("detectCopies", req.GetDetectCopies()),
+		log.String("reverseFrom", req.GetReverseFrom()),
 	}
 }
 
@@ -1211,6 +1215,60 @@ func listRepositoriesRequestToLogFields(req *proto.ListRepositoriesRequest) []lo
 	}
 }
 
+func (l *loggingRepositoryServiceServer) ExportRepository(request *proto.ExportRepositoryRequest, server proto.GitserverRepositoryService_ExportRepositoryServer) (err error) {
+	start := time.Now()
+
+	defer func() {
+		elapsed := time.Since(start)
+
+		doLog(
+			l.logger,
+			proto.GitserverRepositoryService_ExportRepository_FullMethodName,
+			status.Code(err),
+			trace.Context(server.Context()).TraceID,
+			elapsed,
+
+			exportRepositoryRequestToLogFields(request)...,
+		)
+	}()
+
+	return l.base.ExportRepository(request, server)
+}
+
+func exportRepositoryRequestToLogFields(req *proto.ExportRepositoryRequest) []log.Field {
+	return []log.Field{
+		log.String("repoName", req.GetRepoName()),
+	}
+}
+
+func (l *loggingRepositoryServiceServer) CopyRepositoryFromShard(ctx context.Context, request *proto.CopyRepositoryFromShardRequest) (resp *proto.CopyRepositoryFromShardResponse, err error) {
+	start := time.Now()
+
+	defer func() {
+		elapsed := time.Since(start)
+
+		doLog(
+			l.logger,
+
+			proto.GitserverRepositoryService_CopyRepositoryFromShard_FullMethodName,
+			status.Code(err),
+			trace.Context(ctx).TraceID,
+			elapsed,
+
+			copyRepositoryFromShardRequestToLogFields(request)...,
+		)
+	}()
+
+	return l.base.CopyRepositoryFromShard(ctx, request)
+}
+
+func copyRepositoryFromShardRequestToLogFields(req *proto.CopyRepositoryFromShardRequest) []log.Field {
+	return []log.Field{
+		log.String("repoName", req.GetRepoName()),
+		log.String("sourceAddress", req.GetSourceAddress()),
+	}
+}
+
 var (
 	_ proto.GitserverServiceServer           = &loggingGRPCServer{}
 	_ proto.GitserverRepositoryServiceServer = &loggingRepositoryServiceServer{}
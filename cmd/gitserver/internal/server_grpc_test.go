@@ -141,6 +141,37 @@ func TestGRPCServer_Blame(t *testing.T) {
 			assertGRPCStatusCode(t, err, codes.NotFound)
 			assertHasGRPCErrorDetailOfType(t, err, &proto.RevisionNotFoundPayload{})
 		}
+
+		{
+			b.BlameFunc.PushHook(func(_ context.Context, _ api.CommitID, _ string, opt git.BlameOptions) (git.BlameHunkReader, error) {
+				require.Equal(t, git.BlameOptions{
+					IgnoreWhitespace: true,
+					Range:            &git.BlameRange{StartLine: 1, EndLine: 2},
+					IgnoreRevs:       true,
+					DetectMoves:      true,
+					DetectCopies:     true,
+					ReverseFrom:      "cafebabe",
+				}, opt)
+				hr := git.NewMockBlameHunkReader()
+				hr.ReadFunc.PushReturn(nil, io.EOF)
+				return hr, nil
+			})
+			r, err = cli.Blame(context.Background(), &v1.BlameRequest{
+				RepoName:         "therepo",
+				Commit:           "deadbeef",
+				Path:             []byte("thepath"),
+				IgnoreWhitespace: true,
+				Range:            &v1.BlameRange{StartLine: 1, EndLine: 2},
+				IgnoreRevs:       true,
+				DetectMoves:      true,
+				DetectCopies:     true,
+				ReverseFrom:      pointers.Ptr("cafebabe"),
+			})
+			require.NoError(t, err)
+
+			_, err := r.Recv()
+			require.Equal(t, io.EOF, err)
+		}
 	})
 }
 
@@ -1347,6 +1378,44 @@ func TestGRPCServer_Stat(t *testing.T) {
 		assertGRPCStatusCode(t, err, codes.NotFound)
 		assertHasGRPCErrorDetailOfType(t, err, &proto.FileNotFoundPayload{})
 	})
+
+	t.Run("resolves submodules", func(t *testing.T) {
+		fs := gitserverfs.NewMockFS()
+		// Repo is cloned, proceed!
+		fs.RepoClonedFunc.SetDefaultReturn(true, nil)
+		b := git.NewMockGitBackend()
+		b.StatFunc.SetDefaultReturn(&fileutil.FileInfo{
+			Name_: "vendor/lib",
+			Mode_: gitdomain.ModeSubmodule,
+			Sys_: gitdomain.Submodule{
+				URL:      "../lib.git",
+				Path:     "vendor/lib",
+				CommitID: "deadbeef",
+			},
+		}, nil)
+		gs := &grpcServer{
+			svc: NewMockService(),
+			fs:  fs,
+			gitBackendSource: func(common.GitDir, api.RepoName) git.GitBackend {
+				return b
+			},
+			getSubmoduleRepoName: func(_ context.Context, repo api.RepoName, submoduleURL string) (api.RepoName, error) {
+				require.Equal(t, api.RepoName("therepo"), repo)
+				require.Equal(t, "../lib.git", submoduleURL)
+				return "lib", nil
+			},
+		}
+
+		cli := spawnServer(t, gs)
+		response, err := cli.Stat(ctx, &proto.StatRequest{
+			RepoName:  "therepo",
+			CommitSha: "HEAD",
+			Path:      []byte("vendor/lib"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "lib", response.GetFileInfo().GetSubmodule().GetRepoName())
+		require.Equal(t, "deadbeef", response.GetFileInfo().GetSubmodule().GetCommitSha())
+	})
 }
 
 func TestGRPCServer_ReadDir(t *testing.T) {
@@ -0,0 +1,239 @@
+// Package shardcopy copies repositories between gitserver shards. The shard a
+// repository is copied from streams a git bundle of all its refs, which the
+// receiving shard fetches into a new repository.
+//
+// Partial clones are copied as filtered bundles, which record the filter of
+// the partial clone and leave out the objects it omits, so that the copy is a
+// partial clone with the same filter.
+package shardcopy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// HeadRef returns the ref HEAD of the repository in dir points to.
+func HeadRef(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "symbolic-ref", "HEAD")
+	dir.Set(cmd)
+	out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).Output()
+	if err != nil {
+		return "", executil.WrapCmdError(cmd, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Export writes a git bundle of all refs of the repository in dir to w.
+// Repositories without any refs can't be exported.
+func Export(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, w io.Writer) error {
+	status, err := partialclone.ReadStatus(dir)
+	if err != nil {
+		return errors.Wrap(err, "reading partial clone status")
+	}
+	if status != nil {
+		return exportPartialClone(ctx, rcf, repo, dir, status.Filter, w)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "bundle", "create", "--quiet", "-", "--all")
+	dir.Set(cmd)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).Run(); err != nil {
+		return errors.Wrapf(executil.WrapCmdError(cmd, err), "creating bundle: %s", stderr.String())
+	}
+	return nil
+}
+
+// exportPartialClone writes a filtered bundle of all refs of the partial clone
+// in dir to w, in the format git bundle create --filter writes. git bundle
+// create itself would read the objects omitted by the filter, and so fetch
+// all of them from the promisor remote.
+func exportPartialClone(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, filter string, w io.Writer) error {
+	if filter == "" {
+		filter = "blob:none"
+	}
+	out, err := output(ctx, rcf, repo, dir, "for-each-ref", "--format=%(objectname) %(refname)")
+	if err != nil {
+		return errors.Wrap(err, "listing refs")
+	}
+	refs := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(refs) == 0 || refs[0] == "" {
+		return errors.New("creating bundle: repository has no refs")
+	}
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "%s\n@filter=%s\n", bundleV3Signature, filter)
+	tips := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		header.WriteString(ref + "\n")
+		oid, _, _ := strings.Cut(ref, " ")
+		tips = append(tips, oid)
+	}
+	header.WriteString("\n")
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "pack-objects", "--quiet", "--stdout", "--revs",
+		"--filter="+filter, "--missing=allow-promisor")
+	dir.Set(cmd)
+	// Objects missing from the partial clone are left out, not fetched.
+	cmd.Env = append(os.Environ(), "GIT_NO_LAZY_FETCH=1")
+	cmd.Stdin = strings.NewReader(strings.Join(tips, "\n") + "\n")
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).Run(); err != nil {
+		return errors.Wrapf(executil.WrapCmdError(cmd, err), "packing objects: %s", stderr.String())
+	}
+	return nil
+}
+
+const bundleV3Signature = "# v3 git bundle"
+
+// bundleHeader is the header of a git bundle, which precedes its packfile.
+type bundleHeader struct {
+	// filter is the object filter of a filtered bundle, or empty.
+	filter string
+	// refs maps the refs of the bundle to the objects they point to.
+	refs [][2]string
+	// size is the size of the header in bytes.
+	size int64
+}
+
+// readBundleHeader reads the header of the bundle at path. Only the header of
+// v3 bundles is parsed. Bundles with prerequisites are not supported, as
+// Export never creates them.
+func readBundleHeader(path string) (*bundleHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := &bundleHeader{}
+	r := bufio.NewReader(f)
+	for i := 0; ; i++ {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, errors.Wrap(err, "reading bundle header")
+		}
+		h.size += int64(len(line))
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case i == 0:
+			if line != bundleV3Signature {
+				// Only v3 bundles can be filtered. git fetch reads the
+				// others.
+				return h, nil
+			}
+		case line == "":
+			return h, nil
+		case strings.HasPrefix(line, "@filter="):
+			h.filter = strings.TrimPrefix(line, "@filter=")
+		case strings.HasPrefix(line, "@"):
+			// Other capabilities, like the object format, need no handling.
+		case strings.HasPrefix(line, "-"):
+			return nil, errors.New("bundles with prerequisites are not supported")
+		default:
+			oid, ref, ok := strings.Cut(line, " ")
+			if !ok {
+				return nil, errors.Errorf("invalid bundle ref %q", line)
+			}
+			h.refs = append(h.refs, [2]string{oid, ref})
+		}
+	}
+}
+
+// Import fetches all refs of the bundle at bundlePath into the bare repository
+// in dir and points HEAD at headRef. Filtered bundles make dir a partial clone
+// with the filter of the bundle.
+func Import(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, bundlePath, headRef string) error {
+	header, err := readBundleHeader(bundlePath)
+	if err != nil {
+		return err
+	}
+	if header.filter != "" {
+		err = importPartialClone(ctx, rcf, repo, dir, bundlePath, header)
+	} else {
+		err = run(ctx, rcf, repo, dir, "fetch", "--quiet", "--no-write-fetch-head", bundlePath, "+refs/*:refs/*")
+	}
+	if err != nil {
+		return errors.Wrap(err, "fetching from bundle")
+	}
+	if headRef == "" {
+		return nil
+	}
+	return errors.Wrap(run(ctx, rcf, repo, dir, "symbolic-ref", "HEAD", headRef), "setting HEAD")
+}
+
+// importPartialClone imports a filtered bundle into dir. The packfile of the
+// bundle is stored as a promisor packfile, which tells git that the objects
+// it omits are available from the promisor remote.
+func importPartialClone(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, bundlePath string, header *bundleHeader) error {
+	if err := partialclone.Register(ctx, rcf, repo, dir, header.filter); err != nil {
+		return errors.Wrap(err, "configuring partial clone")
+	}
+
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Seek(header.size, io.SeekStart); err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "git", "index-pack", "--stdin", "--promisor")
+	dir.Set(cmd)
+	cmd.Stdin = f
+	if out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).CombinedOutput(); err != nil {
+		return errors.Wrapf(executil.WrapCmdError(cmd, err), "indexing pack: %s", out)
+	}
+
+	var updates strings.Builder
+	for _, ref := range header.refs {
+		fmt.Fprintf(&updates, "update %s %s\n", ref[1], ref[0])
+	}
+	cmd = exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+	dir.Set(cmd)
+	cmd.Stdin = strings.NewReader(updates.String())
+	if out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).CombinedOutput(); err != nil {
+		return errors.Wrapf(executil.WrapCmdError(cmd, err), "updating refs: %s", out)
+	}
+	return nil
+}
+
+func output(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).Output()
+	if err != nil {
+		return nil, executil.WrapCmdError(cmd, err)
+	}
+	return out, nil
+}
+
+func run(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repo api.RepoName, dir common.GitDir, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	if out, err := rcf.WrapWithRepoName(ctx, log.NoOp(), repo, cmd).CombinedOutput(); err != nil {
+		return errors.Wrapf(executil.WrapCmdError(cmd, err), "%s", out)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package shardcopy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	rcf := wrexec.NewNoOpRecordingCommandFactory()
+	root := t.TempDir()
+
+	work := filepath.Join(root, "work")
+	gitCmd(t, "", "init", "--initial-branch=trunk", work)
+	for _, f := range []string{"a", "b"} {
+		require.NoError(t, os.WriteFile(filepath.Join(work, f), []byte(f), 0o644))
+		gitCmd(t, work, "add", f)
+		gitCmd(t, work, "commit", "-m", f)
+	}
+	gitCmd(t, work, "tag", "v1")
+	gitCmd(t, work, "branch", "feature", "HEAD~1")
+
+	source := common.GitDir(filepath.Join(root, "source", ".git"))
+	gitCmd(t, "", "clone", "--bare", work, string(source))
+
+	head, err := HeadRef(ctx, rcf, "repo", source)
+	require.NoError(t, err)
+	require.Equal(t, "refs/heads/trunk", head)
+
+	var bundle bytes.Buffer
+	require.NoError(t, Export(ctx, rcf, "repo", source, &bundle))
+	bundlePath := filepath.Join(root, "repo.bundle")
+	require.NoError(t, os.WriteFile(bundlePath, bundle.Bytes(), 0o644))
+
+	target := common.GitDir(filepath.Join(root, "target", ".git"))
+	require.NoError(t, os.MkdirAll(string(target), os.ModePerm))
+	require.NoError(t, git.MakeBareRepo(ctx, string(target)))
+	require.NoError(t, Import(ctx, rcf, "repo", target, bundlePath, head))
+
+	showRef := func(dir common.GitDir) string {
+		return gitCmd(t, string(dir), "show-ref", "--head")
+	}
+	require.Equal(t, showRef(source), showRef(target))
+	gitCmd(t, string(target), "fsck", "--connectivity-only")
+
+	t.Run("empty repositories can't be exported", func(t *testing.T) {
+		empty := common.GitDir(filepath.Join(root, "empty", ".git"))
+		require.NoError(t, os.MkdirAll(string(empty), os.ModePerm))
+		require.NoError(t, git.MakeBareRepo(ctx, string(empty)))
+		require.Error(t, Export(ctx, rcf, "empty", empty, &bytes.Buffer{}))
+	})
+}
+
+func TestExportImport_PartialClone(t *testing.T) {
+	ctx := context.Background()
+	rcf := wrexec.NewNoOpRecordingCommandFactory()
+	root := t.TempDir()
+
+	work := filepath.Join(root, "work")
+	gitCmd(t, "", "init", "--initial-branch=trunk", work)
+	gitCmd(t, work, "config", "uploadpack.allowFilter", "true")
+	for _, f := range []string{"a", "b"} {
+		require.NoError(t, os.WriteFile(filepath.Join(work, f), []byte(f), 0o644))
+		gitCmd(t, work, "add", f)
+		gitCmd(t, work, "commit", "-m", f)
+	}
+	gitCmd(t, work, "tag", "v1")
+
+	source := common.GitDir(filepath.Join(root, "source", ".git"))
+	gitCmd(t, "", "clone", "--bare", "--filter=blob:none", "file://"+work, string(source))
+	missing := func(dir common.GitDir) int {
+		return strings.Count(gitCmd(t, string(dir), "rev-list", "--objects", "--all", "--missing=print"), "\n?")
+	}
+	require.Equal(t, 2, missing(source))
+
+	var bundle bytes.Buffer
+	require.NoError(t, Export(ctx, rcf, "repo", source, &bundle))
+	require.True(t, strings.HasPrefix(bundle.String(), "# v3 git bundle\n@filter=blob:none\n"))
+	// Exporting doesn't fetch the missing objects.
+	require.Equal(t, 2, missing(source))
+	bundlePath := filepath.Join(root, "repo.bundle")
+	require.NoError(t, os.WriteFile(bundlePath, bundle.Bytes(), 0o644))
+
+	target := common.GitDir(filepath.Join(root, "target", ".git"))
+	require.NoError(t, os.MkdirAll(string(target), os.ModePerm))
+	require.NoError(t, git.MakeBareRepo(ctx, string(target)))
+	require.NoError(t, Import(ctx, rcf, "repo", target, bundlePath, "refs/heads/trunk"))
+
+	showRef := func(dir common.GitDir) string {
+		return gitCmd(t, string(dir), "show-ref", "--head")
+	}
+	require.Equal(t, showRef(source), showRef(target))
+
+	// The copy is a partial clone with the same filter, which is missing the
+	// same objects.
+	status, err := partialclone.ReadStatus(target)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	require.Equal(t, "blob:none", status.Filter)
+	require.Equal(t, 2, missing(target))
+	gitCmd(t, string(target), "fsck", "--connectivity-only")
+
+	// Once the promisor remote is configured, missing objects are fetched
+	// from it.
+	gitCmd(t, string(target), "config", "remote.origin.url", "file://"+work)
+	require.Equal(t, "a", gitCmd(t, string(target), "cat-file", "-p", "HEAD~1:a"))
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_GLOBAL=",
+		"GIT_CONFIG_SYSTEM=",
+		"GIT_AUTHOR_NAME=a",
+		"GIT_AUTHOR_EMAIL=a@a.com",
+		"GIT_COMMITTER_NAME=a",
+		"GIT_COMMITTER_EMAIL=a@a.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), out)
+	return string(out)
+}
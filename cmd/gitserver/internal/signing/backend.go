@@ -0,0 +1,110 @@
+package signing
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+)
+
+// KeyringSource returns the keyring to verify signatures against, or nil if
+// signatures should not be verified.
+type KeyringSource func() *Keyring
+
+// NewBackend wraps backend so that commits returned from GetCommit and, if
+// requested, annotated tags returned from ListRefs carry the result of
+// verifying their signature against the keyring returned by source.
+func NewBackend(logger log.Logger, rcf *wrexec.RecordingCommandFactory, backend git.GitBackend, dir common.GitDir, repoName api.RepoName, source KeyringSource) git.GitBackend {
+	return &signingBackend{
+		GitBackend: backend,
+		logger:     logger.Scoped("signing").With(log.String("repo", string(repoName))),
+		rcf:        rcf,
+		dir:        dir,
+		repoName:   repoName,
+		source:     source,
+	}
+}
+
+type signingBackend struct {
+	git.GitBackend
+
+	logger   log.Logger
+	rcf      *wrexec.RecordingCommandFactory
+	dir      common.GitDir
+	repoName api.RepoName
+	source   KeyringSource
+}
+
+func (b *signingBackend) GetCommit(ctx context.Context, commit api.CommitID, includeModifiedFiles bool) (*git.GitCommitWithFiles, error) {
+	c, err := b.GitBackend.GetCommit(ctx, commit, includeModifiedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring := b.source()
+	if keyring == nil {
+		return c, nil
+	}
+
+	v, err := Verify(ctx, b.rcf, b.repoName, b.dir, keyring, c.ID)
+	if err != nil {
+		// Failing to verify the signature shouldn't fail reading the commit.
+		b.logger.Warn("failed to verify commit signature", log.String("commit", string(c.ID)), log.Error(err))
+		return c, nil
+	}
+	c.SignatureVerification = v
+	return c, nil
+}
+
+func (b *signingBackend) ListRefs(ctx context.Context, opt git.ListRefsOpts) (git.RefIterator, error) {
+	it, err := b.GitBackend.ListRefs(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opt.VerifyTagSignatures {
+		return it, nil
+	}
+	keyring := b.source()
+	if keyring == nil {
+		return it, nil
+	}
+
+	return &verifyingRefIterator{RefIterator: it, ctx: ctx, b: b, keyring: keyring}, nil
+}
+
+// verifyingRefIterator verifies the signature of the annotated tags returned
+// by RefIterator.
+type verifyingRefIterator struct {
+	git.RefIterator
+
+	ctx     context.Context
+	b       *signingBackend
+	keyring *Keyring
+}
+
+func (it *verifyingRefIterator) Next() (*gitdomain.Ref, error) {
+	ref, err := it.RefIterator.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only annotated tags are objects of their own which can be signed.
+	if ref.Type != gitdomain.RefTypeTag || ref.RefOID == ref.CommitID {
+		return ref, nil
+	}
+
+	v, err := VerifyTag(it.ctx, it.b.rcf, it.b.repoName, it.b.dir, it.keyring, ref.RefOID)
+	if err != nil {
+		// Failing to verify the signature shouldn't fail listing the refs.
+		it.b.logger.Warn("failed to verify tag signature", log.String("tag", ref.Name), log.Error(err))
+		return ref, nil
+	}
+	ref.SignatureVerification = v
+	return ref, nil
+}
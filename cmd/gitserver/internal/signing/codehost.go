@@ -0,0 +1,166 @@
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// fetchCodeHostKeys returns the GPG and SSH signing keys of account from the
+// code host API.
+func fetchCodeHostKeys(ctx context.Context, doer httpcli.Doer, account *schema.SignatureVerificationCodeHostAccount) (Keys, error) {
+	switch account.Kind {
+	case "GITHUB":
+		return fetchGitHubKeys(ctx, doer, account)
+	case "GITLAB":
+		return fetchGitLabKeys(ctx, doer, account)
+	default:
+		return Keys{}, errors.Errorf("unsupported code host kind %q", account.Kind)
+	}
+}
+
+// fetchGitHubKeys uses the GPG keys and SSH signing keys endpoints of the
+// GitHub REST API.
+//
+// See https://docs.github.com/en/rest/users/gpg-keys and
+// https://docs.github.com/en/rest/users/ssh-signing-keys.
+func fetchGitHubKeys(ctx context.Context, doer httpcli.Doer, account *schema.SignatureVerificationCodeHostAccount) (Keys, error) {
+	base, err := url.Parse(account.Url)
+	if err != nil {
+		return Keys{}, err
+	}
+	if base.Hostname() == "github.com" || base.Hostname() == "www.github.com" {
+		base = &url.URL{Scheme: "https", Host: "api.github.com"}
+	} else {
+		base = base.JoinPath("api", "v3")
+	}
+
+	header := http.Header{"Accept": {"application/vnd.github+json"}}
+	if account.Token != "" {
+		header.Set("Authorization", "Bearer "+account.Token)
+	}
+
+	var gpgKeys []struct {
+		RawKey string `json:"raw_key"`
+	}
+	if err := getJSON(ctx, doer, base.JoinPath("users", account.Username, "gpg_keys"), header, &gpgKeys); err != nil {
+		return Keys{}, err
+	}
+	var sshKeys []struct {
+		Key string `json:"key"`
+	}
+	if err := getJSON(ctx, doer, base.JoinPath("users", account.Username, "ssh_signing_keys"), header, &sshKeys); err != nil {
+		return Keys{}, err
+	}
+
+	var keys Keys
+	for _, k := range gpgKeys {
+		if k.RawKey != "" {
+			keys.GPGPublicKeys = append(keys.GPGPublicKeys, k.RawKey)
+		}
+	}
+	for _, k := range sshKeys {
+		keys.SSHAllowedSigners = append(keys.SSHAllowedSigners, allowedSigner(account.Username, k.Key))
+	}
+	return keys, nil
+}
+
+// fetchGitLabKeys uses the GPG keys and SSH keys endpoints of the GitLab REST
+// API. Only SSH keys GitLab allows to be used for signing are trusted.
+//
+// See https://docs.gitlab.com/ee/api/users.html.
+func fetchGitLabKeys(ctx context.Context, doer httpcli.Doer, account *schema.SignatureVerificationCodeHostAccount) (Keys, error) {
+	base, err := url.Parse(account.Url)
+	if err != nil {
+		return Keys{}, err
+	}
+	base = base.JoinPath("api", "v4")
+
+	header := http.Header{}
+	if account.Token != "" {
+		header.Set("Private-Token", account.Token)
+	}
+
+	usersURL := base.JoinPath("users")
+	usersURL.RawQuery = url.Values{"username": {account.Username}}.Encode()
+	var users []struct {
+		ID int `json:"id"`
+	}
+	if err := getJSON(ctx, doer, usersURL, header, &users); err != nil {
+		return Keys{}, err
+	}
+	if len(users) == 0 {
+		return Keys{}, errors.Errorf("GitLab user %q not found", account.Username)
+	}
+	userID := fmt.Sprint(users[0].ID)
+
+	var gpgKeys []struct {
+		Key string `json:"key"`
+	}
+	if err := getJSON(ctx, doer, base.JoinPath("users", userID, "gpg_keys"), header, &gpgKeys); err != nil {
+		return Keys{}, err
+	}
+	var sshKeys []struct {
+		Key       string `json:"key"`
+		UsageType string `json:"usage_type"`
+	}
+	if err := getJSON(ctx, doer, base.JoinPath("users", userID, "keys"), header, &sshKeys); err != nil {
+		return Keys{}, err
+	}
+
+	var keys Keys
+	for _, k := range gpgKeys {
+		if k.Key != "" {
+			keys.GPGPublicKeys = append(keys.GPGPublicKeys, k.Key)
+		}
+	}
+	for _, k := range sshKeys {
+		// Keys created before GitLab distinguished usage types have none.
+		if k.UsageType == "" || k.UsageType == "signing" || k.UsageType == "auth_and_signing" {
+			keys.SSHAllowedSigners = append(keys.SSHAllowedSigners, allowedSigner(account.Username, k.Key))
+		}
+	}
+	return keys, nil
+}
+
+// allowedSigner returns the allowed signers line trusting key for signatures
+// made by principal.
+func allowedSigner(principal, key string) string {
+	// Drop the comment of the key, only the type and the key itself are
+	// relevant.
+	fields := strings.Fields(key)
+	if len(fields) > 2 {
+		fields = fields[:2]
+	}
+	return principal + " namespaces=\"git\" " + strings.Join(fields, " ")
+}
+
+func getJSON(ctx context.Context, doer httpcli.Doer, u *url.URL, header http.Header, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("request to %s failed with status %d", u.Redacted(), resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return errors.Wrapf(err, "decoding response of %s", u.Redacted())
+	}
+	return nil
+}
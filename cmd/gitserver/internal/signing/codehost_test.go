@@ -0,0 +1,93 @@
+package signing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestFetchCodeHostKeys(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("github enterprise", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v3/users/alice/gpg_keys", func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `[{"raw_key":"-----BEGIN PGP PUBLIC KEY BLOCK-----"},{"raw_key":""}]`)
+		})
+		mux.HandleFunc("/api/v3/users/alice/ssh_signing_keys", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"key":"ssh-ed25519 AAAA alice@laptop"}]`)
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		keys, err := fetchCodeHostKeys(ctx, httpcli.TestExternalDoer, &schema.SignatureVerificationCodeHostAccount{
+			Kind:     "GITHUB",
+			Url:      srv.URL,
+			Username: "alice",
+			Token:    "token",
+		})
+		require.NoError(t, err)
+		require.Equal(t, Keys{
+			GPGPublicKeys:     []string{"-----BEGIN PGP PUBLIC KEY BLOCK-----"},
+			SSHAllowedSigners: []string{`alice namespaces="git" ssh-ed25519 AAAA`},
+		}, keys)
+	})
+
+	t.Run("gitlab", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "bob", r.URL.Query().Get("username"))
+			require.Equal(t, "token", r.Header.Get("Private-Token"))
+			fmt.Fprint(w, `[{"id":42}]`)
+		})
+		mux.HandleFunc("/api/v4/users/42/gpg_keys", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"key":"-----BEGIN PGP PUBLIC KEY BLOCK-----"}]`)
+		})
+		mux.HandleFunc("/api/v4/users/42/keys", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[
+				{"key":"ssh-ed25519 AAAA","usage_type":"auth_and_signing"},
+				{"key":"ssh-ed25519 BBBB","usage_type":"auth"},
+				{"key":"ssh-rsa CCCC","usage_type":"signing"}
+			]`)
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		keys, err := fetchCodeHostKeys(ctx, httpcli.TestExternalDoer, &schema.SignatureVerificationCodeHostAccount{
+			Kind:     "GITLAB",
+			Url:      srv.URL,
+			Username: "bob",
+			Token:    "token",
+		})
+		require.NoError(t, err)
+		require.Equal(t, Keys{
+			GPGPublicKeys: []string{"-----BEGIN PGP PUBLIC KEY BLOCK-----"},
+			SSHAllowedSigners: []string{
+				`bob namespaces="git" ssh-ed25519 AAAA`,
+				`bob namespaces="git" ssh-rsa CCCC`,
+			},
+		}, keys)
+	})
+
+	t.Run("gitlab user not found", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[]`)
+		}))
+		t.Cleanup(srv.Close)
+
+		_, err := fetchCodeHostKeys(ctx, httpcli.TestExternalDoer, &schema.SignatureVerificationCodeHostAccount{
+			Kind:     "GITLAB",
+			Url:      srv.URL,
+			Username: "nobody",
+		})
+		require.Error(t, err)
+	})
+}
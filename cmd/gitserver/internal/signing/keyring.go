@@ -0,0 +1,162 @@
+// Package signing verifies the GPG, SSH and X.509 signatures of commits
+// against a keyring built from site configuration and keys fetched from code
+// hosts.
+//
+// Verification is delegated to git, which calls out to gpg, gpgsm and
+// ssh-keygen. A Keyring is a directory holding a GNUPGHOME with the trusted GPG
+// keys and X.509 certificates, and an SSH allowed signers file.
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Keys are the keys signatures are verified against.
+type Keys struct {
+	// GPGPublicKeys are ASCII armored GPG public keys.
+	GPGPublicKeys []string
+	// SSHAllowedSigners are lines of an SSH allowed signers file.
+	SSHAllowedSigners []string
+	// X509Certificates are PEM encoded root certificates.
+	X509Certificates []string
+}
+
+// Keyring is a directory with the keys gpg, gpgsm and ssh-keygen verify
+// signatures against.
+type Keyring struct {
+	dir string
+}
+
+// Build creates a keyring with keys in dir. dir must not exist yet.
+func Build(ctx context.Context, dir string, keys Keys) (_ *Keyring, err error) {
+	k := &Keyring{dir: dir}
+	if err := os.MkdirAll(k.gnupgHome(), 0o700); err != nil {
+		return nil, errors.Wrap(err, "creating keyring")
+	}
+	defer func() {
+		if err != nil {
+			k.Remove(context.Background())
+		}
+	}()
+
+	var trustlist bytes.Buffer
+	var certs bytes.Buffer
+	for _, c := range keys.X509Certificates {
+		block, _ := pem.Decode([]byte(c))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, errors.New("invalid X.509 certificate: expected a PEM encoded certificate")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid X.509 certificate")
+		}
+		// gpgsm only trusts root certificates listed in trustlist.txt. The
+		// relax flag skips checks that many self-signed certificates fail,
+		// such as a missing basicConstraints extension.
+		fmt.Fprintf(&trustlist, "%s S relax\n", fingerprint(cert))
+		_ = pem.Encode(&certs, block)
+	}
+
+	files := map[string]string{
+		// Trust is established through the keyring itself, so every key in it
+		// is fully trusted.
+		"gpg.conf": "trust-model always\n",
+		// Certificates are verified offline.
+		"gpgsm.conf":    "disable-crl-checks\ndisable-dirmngr\n",
+		"trustlist.txt": trustlist.String(),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(k.gnupgHome(), name), []byte(content), 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	// The allowed signers file must exist even if it is empty. git reports
+	// SSH signatures as not signed at all otherwise.
+	var allowedSigners bytes.Buffer
+	for _, line := range keys.SSHAllowedSigners {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.ContainsAny(line, "\r\n") {
+			continue
+		}
+		allowedSigners.WriteString(line + "\n")
+	}
+	if err := os.WriteFile(k.allowedSignersFile(), allowedSigners.Bytes(), 0o600); err != nil {
+		return nil, err
+	}
+
+	if len(keys.GPGPublicKeys) > 0 {
+		if err := k.run(ctx, strings.NewReader(strings.Join(keys.GPGPublicKeys, "\n")), "gpg", "--batch", "--import"); err != nil {
+			return nil, errors.Wrap(err, "importing GPG public keys")
+		}
+	}
+	if certs.Len() > 0 {
+		if err := k.run(ctx, &certs, "gpgsm", "--batch", "--import"); err != nil {
+			return nil, errors.Wrap(err, "importing X.509 certificates")
+		}
+	}
+
+	return k, nil
+}
+
+// Env returns the environment variables that make git verify signatures
+// against the keyring.
+func (k *Keyring) Env() []string {
+	return []string{
+		"GNUPGHOME=" + k.gnupgHome(),
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=gpg.ssh.allowedSignersFile",
+		"GIT_CONFIG_VALUE_0=" + k.allowedSignersFile(),
+	}
+}
+
+// Remove stops the gpg-agent started for the keyring and removes it from
+// disk.
+func (k *Keyring) Remove(ctx context.Context) error {
+	// The agent is started on demand by gpg and gpgsm and keeps running
+	// until it's told to stop.
+	_ = k.run(ctx, nil, "gpgconf", "--kill", "all")
+	return os.RemoveAll(k.dir)
+}
+
+func (k *Keyring) gnupgHome() string {
+	return filepath.Join(k.dir, "gnupg")
+}
+
+func (k *Keyring) allowedSignersFile() string {
+	return filepath.Join(k.dir, "allowed_signers")
+}
+
+func (k *Keyring) run(ctx context.Context, stdin io.Reader, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), "GNUPGHOME="+k.gnupgHome())
+	cmd.Stdin = stdin
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(executil.WrapCmdError(cmd, err), "%s", out)
+	}
+	return nil
+}
+
+// fingerprint returns the SHA-1 fingerprint of cert in the format used by
+// gpgsm's trustlist.txt.
+func fingerprint(cert *x509.Certificate) string {
+	sum := sha1.Sum(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
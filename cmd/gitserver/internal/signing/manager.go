@@ -0,0 +1,147 @@
+package signing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// keyringRemovalDelay is how long a replaced keyring is kept around for
+// verifications that are still using it.
+const keyringRemovalDelay = time.Minute
+
+// Manager keeps a keyring in sync with the gitSignatureVerification site
+// configuration and the keys of the configured code host accounts.
+type Manager struct {
+	logger  log.Logger
+	tempDir func(prefix string) (string, error)
+	doer    httpcli.Doer
+
+	// updateMu serializes updates.
+	updateMu sync.Mutex
+	hash     [sha256.Size]byte
+
+	mu      sync.RWMutex
+	keyring *Keyring
+}
+
+// NewManager returns a manager which builds keyrings in directories created
+// by tempDir.
+func NewManager(logger log.Logger, tempDir func(prefix string) (string, error)) *Manager {
+	return &Manager{
+		logger:  logger.Scoped("signing"),
+		tempDir: tempDir,
+		doer:    httpcli.UncachedExternalDoer,
+	}
+}
+
+// Keyring returns the current keyring, or nil if signature verification is
+// disabled.
+func (m *Manager) Keyring() *Keyring {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.keyring
+}
+
+// Update rebuilds the keyring from c. Keys of code host accounts which can't
+// be fetched are skipped.
+func (m *Manager) Update(ctx context.Context, c *schema.GitSignatureVerification) error {
+	m.updateMu.Lock()
+	defer m.updateMu.Unlock()
+
+	if c == nil {
+		m.swap(nil)
+		m.hash = [sha256.Size]byte{}
+		return nil
+	}
+
+	keys := Keys{
+		GPGPublicKeys:     append([]string(nil), c.GpgPublicKeys...),
+		SSHAllowedSigners: append([]string(nil), c.SshAllowedSigners...),
+		X509Certificates:  append([]string(nil), c.X509Certificates...),
+	}
+	for _, account := range c.CodeHostAccounts {
+		accountKeys, err := fetchCodeHostKeys(ctx, m.doer, account)
+		if err != nil {
+			m.logger.Warn("failed to fetch signing keys from code host",
+				log.String("url", account.Url),
+				log.String("username", account.Username),
+				log.Error(err),
+			)
+			continue
+		}
+		keys.GPGPublicKeys = append(keys.GPGPublicKeys, accountKeys.GPGPublicKeys...)
+		keys.SSHAllowedSigners = append(keys.SSHAllowedSigners, accountKeys.SSHAllowedSigners...)
+	}
+
+	b, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(b)
+	if m.Keyring() != nil && hash == m.hash {
+		return nil
+	}
+
+	dir, err := m.tempDir("signing-keyring-")
+	if err != nil {
+		return err
+	}
+	keyring, err := Build(ctx, dir, keys)
+	if err != nil {
+		return err
+	}
+	m.swap(keyring)
+	m.hash = hash
+	return nil
+}
+
+// swap replaces the current keyring and removes the previous one once
+// verifications using it are likely done.
+func (m *Manager) swap(keyring *Keyring) {
+	m.mu.Lock()
+	old := m.keyring
+	m.keyring = keyring
+	m.mu.Unlock()
+
+	if old == nil {
+		return
+	}
+	time.AfterFunc(keyringRemovalDelay, func() {
+		if err := old.Remove(context.Background()); err != nil {
+			m.logger.Warn("failed to remove keyring", log.Error(err))
+		}
+	})
+}
+
+// NewRoutine returns a background routine which periodically updates the
+// keyring of m, so that changes to the keys of code host accounts are picked
+// up. Site configuration changes are applied as soon as they happen.
+func (m *Manager) NewRoutine(ctx context.Context, interval time.Duration) goroutine.BackgroundRoutine {
+	update := func(ctx context.Context) error {
+		return m.Update(ctx, conf.Get().SiteConfig().GitSignatureVerification)
+	}
+
+	go conf.Watch(func() {
+		if err := update(ctx); err != nil {
+			m.logger.Error("failed to update keyring", log.Error(err))
+		}
+	})
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(update),
+		goroutine.WithName("gitserver.signing-keyring"),
+		goroutine.WithDescription("keeps the keyring commit signatures are verified against up to date"),
+		goroutine.WithInterval(interval),
+	)
+}
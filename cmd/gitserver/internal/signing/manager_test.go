@@ -0,0 +1,43 @@
+package signing
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	m := NewManager(logtest.Scoped(t), func(prefix string) (string, error) {
+		return os.MkdirTemp(root, prefix)
+	})
+	t.Cleanup(func() {
+		if k := m.Keyring(); k != nil {
+			k.Remove(context.Background())
+		}
+	})
+
+	require.Nil(t, m.Keyring(), "verification is disabled until configured")
+
+	c := &schema.GitSignatureVerification{SshAllowedSigners: []string{"bob ssh-ed25519 AAAA"}}
+	require.NoError(t, m.Update(ctx, c))
+	first := m.Keyring()
+	require.NotNil(t, first)
+
+	// The keyring is only rebuilt if the keys change.
+	require.NoError(t, m.Update(ctx, c))
+	require.Same(t, first, m.Keyring())
+
+	c.SshAllowedSigners = append(c.SshAllowedSigners, "alice ssh-ed25519 BBBB")
+	require.NoError(t, m.Update(ctx, c))
+	require.NotSame(t, first, m.Keyring())
+
+	require.NoError(t, m.Update(ctx, nil))
+	require.Nil(t, m.Keyring())
+}
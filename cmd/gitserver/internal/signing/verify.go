@@ -0,0 +1,260 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// commitFormat is the git pretty format for the verification of the signature
+// of a commit. Each commit starts with a record separator, followed by six NUL
+// separated fields: the hash, the status code, the raw verification output, the
+// signer, the fingerprint and the ID of the key.
+const commitFormat = "%x1E%H%x00%G?%x00%GG%x00%GS%x00%GF%x00%GK"
+
+type verificationCacheKey struct {
+	keyring  *Keyring
+	repoName api.RepoName
+	oid      api.CommitID
+}
+
+// Commits and tags are immutable, so their verification only changes when the
+// keyring does.
+var globalVerificationCache, _ = lru.New[verificationCacheKey, gitdomain.SignatureVerification](8192)
+
+// StatusFromGit converts the signature status code git prints for %G? and the
+// raw verification output it prints for %GG to a SignatureStatus.
+func StatusFromGit(code, output string) gitdomain.SignatureStatus {
+	switch code {
+	case "G":
+		return gitdomain.SignatureStatusVerified
+	case "U":
+		// A good signature of a key that isn't trusted, like an SSH key that
+		// isn't in the allowed signers file.
+		return gitdomain.SignatureStatusUntrusted
+	case "E":
+		// The signature can't be checked, usually because the key isn't in
+		// the keyring at all.
+		return gitdomain.SignatureStatusUnverified
+	case "B":
+		return gitdomain.SignatureStatusInvalid
+	case "X", "Y":
+		// Expired signature and good signature of an expired key.
+		return gitdomain.SignatureStatusExpired
+	case "R":
+		return gitdomain.SignatureStatusRevoked
+	case "N":
+		// git reports X.509 signatures made by certificates gpgsm doesn't
+		// know as no signature, but gpgsm still complains about them.
+		if strings.TrimSpace(output) != "" {
+			return gitdomain.SignatureStatusUnverified
+		}
+		return gitdomain.SignatureStatusUnsigned
+	default:
+		return gitdomain.SignatureStatusUnknown
+	}
+}
+
+// Verify verifies the signature of commit in the repository at dir against
+// keyring.
+func Verify(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, keyring *Keyring, commit api.CommitID) (*gitdomain.SignatureVerification, error) {
+	verifications, err := VerifyCommits(ctx, rcf, repoName, dir, keyring, []api.CommitID{commit})
+	if err != nil {
+		return nil, err
+	}
+	v, ok := verifications[commit]
+	if !ok {
+		return nil, errors.Errorf("commit %s was not verified", commit)
+	}
+	return &v, nil
+}
+
+// VerifyCommits verifies the signatures of commits in the repository at dir
+// against keyring. Verifications are cached, so git only checks the signatures
+// of commits which weren't verified against keyring before, all in a single
+// invocation. If rcf is nil, the git command isn't recorded.
+func VerifyCommits(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, keyring *Keyring, commits []api.CommitID) (map[api.CommitID]gitdomain.SignatureVerification, error) {
+	verifications := make(map[api.CommitID]gitdomain.SignatureVerification, len(commits))
+	var stdin strings.Builder
+	for _, commit := range commits {
+		if v, ok := globalVerificationCache.Get(verificationCacheKey{keyring: keyring, repoName: repoName, oid: commit}); ok {
+			verifications[commit] = v
+			continue
+		}
+		stdin.WriteString(string(commit))
+		stdin.WriteByte('\n')
+	}
+	if stdin.Len() == 0 {
+		return verifications, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "log", "--no-walk=unsorted", "--stdin", "--format=format:"+commitFormat)
+	dir.Set(cmd)
+	cmd.Env = append(cmd.Env, keyring.Env()...)
+	cmd.Stdin = strings.NewReader(stdin.String())
+	out, err := recordedOutput(ctx, rcf, repoName, cmd)
+	if err != nil {
+		return nil, executil.WrapCmdError(cmd, err)
+	}
+
+	for _, record := range bytes.Split(out, []byte{0x1E})[1:] {
+		fields := bytes.Split(bytes.TrimSuffix(record, []byte("\n")), []byte{0})
+		if len(fields) != 6 {
+			return nil, errors.Errorf("unexpected output of git log: %q", record)
+		}
+
+		v := gitdomain.SignatureVerification{
+			Status: StatusFromGit(string(fields[1]), string(fields[2])),
+			Signer: string(fields[3]),
+			Key:    string(fields[4]),
+		}
+		if v.Key == "" {
+			// The fingerprint isn't known if the key isn't in the keyring,
+			// but the key ID still identifies the key that made the
+			// signature.
+			v.Key = string(fields[5])
+		}
+		commit := api.CommitID(fields[0])
+		globalVerificationCache.Add(verificationCacheKey{keyring: keyring, repoName: repoName, oid: commit}, v)
+		verifications[commit] = v
+	}
+	return verifications, nil
+}
+
+// VerifyTag verifies the signature of the annotated tag object tag in the
+// repository at dir against keyring.
+func VerifyTag(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, dir common.GitDir, keyring *Keyring, tag api.CommitID) (*gitdomain.SignatureVerification, error) {
+	key := verificationCacheKey{keyring: keyring, repoName: repoName, oid: tag}
+	if v, ok := globalVerificationCache.Get(key); ok {
+		return &v, nil
+	}
+
+	// git verify-tag has no format for the verification like git log does,
+	// but prints the raw output of gpg and ssh-keygen to stderr. It exits
+	// with a non-zero status unless the signature is good.
+	cmd := exec.CommandContext(ctx, "git", "verify-tag", "--raw", string(tag))
+	dir.Set(cmd)
+	cmd.Env = append(cmd.Env, keyring.Env()...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := recordedRun(ctx, rcf, repoName, cmd); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, executil.WrapCmdError(cmd, err)
+		}
+	}
+
+	v := parseRawVerification(stderr.String())
+	globalVerificationCache.Add(key, v)
+	return &v, nil
+}
+
+// gpgStatusCodes maps the gpg status keywords describing a signature to the
+// status code git prints for %G?.
+var gpgStatusCodes = map[string]string{
+	"GOODSIG":   "G",
+	"BADSIG":    "B",
+	"ERRSIG":    "E",
+	"EXPSIG":    "X",
+	"EXPKEYSIG": "Y",
+	"REVKEYSIG": "R",
+}
+
+// parseRawVerification parses the raw verification output git verify-tag
+// prints the same way git parses it for the signature of commits. GPG and
+// X.509 signatures are described by the status lines of gpg and gpgsm, SSH
+// signatures by the output of ssh-keygen.
+func parseRawVerification(raw string) gitdomain.SignatureVerification {
+	if strings.Contains(raw, "error: no signature found") {
+		return gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusUnsigned}
+	}
+
+	var code, signer, key string
+	signatures := 0
+	for _, line := range strings.Split(raw, "\n") {
+		status, ok := strings.CutPrefix(line, "[GNUPG:] ")
+		if !ok {
+			continue
+		}
+		keyword, rest, _ := strings.Cut(status, " ")
+		if c, ok := gpgStatusCodes[keyword]; ok {
+			signatures++
+			code = c
+			keyID, uid, _ := strings.Cut(rest, " ")
+			key = keyID
+			if keyword != "ERRSIG" {
+				signer = uid
+			}
+			continue
+		}
+		switch keyword {
+		case "VALIDSIG":
+			if fields := strings.Fields(rest); len(fields) > 0 {
+				key = fields[0]
+			}
+		case "TRUST_UNDEFINED", "TRUST_NEVER":
+			if code == "G" {
+				code = "U"
+			}
+		}
+	}
+	switch {
+	case signatures > 1:
+		// Like git, don't accept several signatures.
+		return gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusUnverified}
+	case signatures == 1:
+		return gitdomain.SignatureVerification{Status: StatusFromGit(code, ""), Signer: signer, Key: key}
+	}
+
+	// ssh-keygen prints
+	//   Good "git" signature for PRINCIPAL with TYPE key FINGERPRINT
+	// for allowed signers and
+	//   Good "git" signature with TYPE key FINGERPRINT
+	// for keys no principal is allowed to sign with.
+	line, _, _ := strings.Cut(raw, "\n")
+	if rest, ok := strings.CutPrefix(line, `Good "git" signature for `); ok {
+		if i := strings.LastIndex(rest, " with "); i >= 0 {
+			return gitdomain.SignatureVerification{
+				Status: gitdomain.SignatureStatusVerified,
+				Signer: rest[:i],
+				Key:    sshFingerprint(rest[i:]),
+			}
+		}
+	}
+	if rest, ok := strings.CutPrefix(line, `Good "git" signature with `); ok {
+		return gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusUntrusted, Key: sshFingerprint(rest)}
+	}
+	return gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusInvalid}
+}
+
+// sshFingerprint returns the fingerprint following "key " in the output of
+// ssh-keygen.
+func sshFingerprint(s string) string {
+	_, fingerprint, _ := strings.Cut(s, " key ")
+	return strings.TrimSpace(fingerprint)
+}
+
+func recordedOutput(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, cmd *exec.Cmd) ([]byte, error) {
+	if rcf == nil {
+		return cmd.Output()
+	}
+	return rcf.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).Output()
+}
+
+func recordedRun(ctx context.Context, rcf *wrexec.RecordingCommandFactory, repoName api.RepoName, cmd *exec.Cmd) error {
+	if rcf == nil {
+		return cmd.Run()
+	}
+	return rcf.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).Run()
+}
@@ -0,0 +1,291 @@
+package signing
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+)
+
+func TestStatusFromGit(t *testing.T) {
+	for code, want := range map[string]gitdomain.SignatureStatus{
+		"G": gitdomain.SignatureStatusVerified,
+		"U": gitdomain.SignatureStatusUntrusted,
+		"E": gitdomain.SignatureStatusUnverified,
+		"B": gitdomain.SignatureStatusInvalid,
+		"X": gitdomain.SignatureStatusExpired,
+		"Y": gitdomain.SignatureStatusExpired,
+		"R": gitdomain.SignatureStatusRevoked,
+		"N": gitdomain.SignatureStatusUnsigned,
+		"":  gitdomain.SignatureStatusUnknown,
+	} {
+		require.Equal(t, want, StatusFromGit(code, ""), "code %q", code)
+	}
+
+	require.Equal(t, gitdomain.SignatureStatusUnverified, StatusFromGit("N", "gpgsm: certificate not found\n"))
+}
+
+func TestVerify(t *testing.T) {
+	for _, bin := range []string{"gpg", "gpgsm", "gpgconf", "ssh-keygen"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not available", bin)
+		}
+	}
+
+	ctx := context.Background()
+	rcf := wrexec.NewNoOpRecordingCommandFactory()
+	root := t.TempDir()
+
+	// Keys of the signers.
+	gpgHome := newGNUPGHome(t, filepath.Join(root, "gpg"))
+	run(t, "", []string{"GNUPGHOME=" + gpgHome}, "gpg", "--batch", "--passphrase", "", "--quick-gen-key", "Alice <alice@example.com>", "ed25519", "sign", "never")
+	alicePublicKey := run(t, "", []string{"GNUPGHOME=" + gpgHome}, "gpg", "--armor", "--export", "alice@example.com")
+
+	strangerHome := newGNUPGHome(t, filepath.Join(root, "stranger"))
+	run(t, "", []string{"GNUPGHOME=" + strangerHome}, "gpg", "--batch", "--passphrase", "", "--quick-gen-key", "Stranger <stranger@example.com>", "ed25519", "sign", "never")
+
+	sshKey := filepath.Join(root, "bob")
+	run(t, "", nil, "ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "bob", "-f", sshKey)
+	bobPublicKey, err := os.ReadFile(sshKey + ".pub")
+	require.NoError(t, err)
+	strangerSSHKey := filepath.Join(root, "stranger-ssh")
+	run(t, "", nil, "ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", strangerSSHKey)
+
+	smHome := newGNUPGHome(t, filepath.Join(root, "gpgsm"))
+	require.NoError(t, os.WriteFile(filepath.Join(smHome, "gpgsm.conf"), []byte("disable-crl-checks\ndisable-dirmngr\n"), 0o600))
+	params := filepath.Join(root, "params")
+	require.NoError(t, os.WriteFile(params, []byte("Key-Type: RSA\nKey-Length: 2048\nKey-Usage: sign, cert\nName-DN: CN=Carol\nName-Email: carol@example.com\nSerial: random\n"), 0o600))
+	carolCert := run(t, "", []string{"GNUPGHOME=" + smHome}, "gpgsm", "--batch", "--pinentry-mode", "loopback", "--passphrase", "", "--armor", "--gen-key", params)
+	certFile := filepath.Join(root, "carol.pem")
+	require.NoError(t, os.WriteFile(certFile, []byte(carolCert), 0o600))
+	run(t, "", []string{"GNUPGHOME=" + smHome}, "gpgsm", "--batch", "--import", certFile)
+	// gpgsm refuses to sign with an untrusted certificate. The agent only
+	// reads the trustlist when it starts.
+	block, _ := pem.Decode([]byte(carolCert))
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(smHome, "trustlist.txt"), []byte(fingerprint(cert)+" S relax\n"), 0o600))
+	run(t, "", []string{"GNUPGHOME=" + smHome}, "gpgconf", "--kill", "all")
+
+	// A repository with a commit signed by each of them.
+	repo := filepath.Join(root, "repo")
+	run(t, "", nil, "git", "init", repo)
+	commit := func(env []string, args ...string) api.CommitID {
+		t.Helper()
+		run(t, repo, env, "git", append(args, "commit", "--allow-empty", "-m", "commit")...)
+		return api.CommitID(strings.TrimSpace(run(t, repo, nil, "git", "rev-parse", "HEAD")))
+	}
+	unsigned := commit(nil)
+	gpgSigned := commit([]string{"GNUPGHOME=" + gpgHome}, "-c", "user.signingkey=alice@example.com", "-c", "commit.gpgsign=true")
+	gpgStranger := commit([]string{"GNUPGHOME=" + strangerHome}, "-c", "user.signingkey=stranger@example.com", "-c", "commit.gpgsign=true")
+	sshSigned := commit(nil, "-c", "gpg.format=ssh", "-c", "user.signingkey="+sshKey, "-c", "commit.gpgsign=true")
+	sshStranger := commit(nil, "-c", "gpg.format=ssh", "-c", "user.signingkey="+strangerSSHKey, "-c", "commit.gpgsign=true")
+	x509Signed := commit([]string{"GNUPGHOME=" + smHome}, "-c", "gpg.format=x509", "-c", "user.signingkey=carol@example.com", "-c", "commit.gpgsign=true")
+
+	keyring, err := Build(ctx, filepath.Join(root, "keyring"), Keys{
+		GPGPublicKeys:     []string{alicePublicKey},
+		SSHAllowedSigners: []string{"bob " + string(bobPublicKey)},
+		X509Certificates:  []string{carolCert},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { keyring.Remove(context.Background()) })
+
+	dir := common.GitDir(filepath.Join(repo, ".git"))
+	for _, tc := range []struct {
+		name       string
+		commit     api.CommitID
+		wantStatus gitdomain.SignatureStatus
+		wantSigner string
+	}{
+		{name: "unsigned", commit: unsigned, wantStatus: gitdomain.SignatureStatusUnsigned},
+		{name: "gpg", commit: gpgSigned, wantStatus: gitdomain.SignatureStatusVerified, wantSigner: "Alice <alice@example.com>"},
+		{name: "gpg key not in keyring", commit: gpgStranger, wantStatus: gitdomain.SignatureStatusUnverified},
+		{name: "ssh", commit: sshSigned, wantStatus: gitdomain.SignatureStatusVerified, wantSigner: "bob"},
+		{name: "ssh key not allowed", commit: sshStranger, wantStatus: gitdomain.SignatureStatusUntrusted},
+		{name: "x509", commit: x509Signed, wantStatus: gitdomain.SignatureStatusVerified, wantSigner: "/CN=Carol"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := Verify(ctx, rcf, "repo", dir, keyring, tc.commit)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantStatus, v.Status)
+			require.Equal(t, tc.wantSigner, v.Signer)
+			if tc.wantStatus != gitdomain.SignatureStatusUnsigned {
+				require.NotEmpty(t, v.Key)
+			}
+		})
+	}
+
+	t.Run("empty keyring", func(t *testing.T) {
+		empty, err := Build(ctx, filepath.Join(root, "empty"), Keys{})
+		require.NoError(t, err)
+		t.Cleanup(func() { empty.Remove(context.Background()) })
+
+		verifications, err := VerifyCommits(ctx, rcf, "repo", dir, empty, []api.CommitID{gpgSigned, sshSigned, x509Signed})
+		require.NoError(t, err)
+		require.Equal(t, gitdomain.SignatureStatusUnverified, verifications[gpgSigned].Status)
+		require.Equal(t, gitdomain.SignatureStatusUntrusted, verifications[sshSigned].Status)
+		require.Equal(t, gitdomain.SignatureStatusUnverified, verifications[x509Signed].Status)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		_, err := VerifyCommits(ctx, rcf, "cached", dir, keyring, []api.CommitID{gpgSigned})
+		require.NoError(t, err)
+
+		// Commits which are cached aren't passed to git, so an unknown
+		// commit next to them is the only one it fails on.
+		missing := api.CommitID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+		_, err = VerifyCommits(ctx, rcf, "cached", dir, keyring, []api.CommitID{gpgSigned, missing})
+		require.Error(t, err)
+		verifications, err := VerifyCommits(ctx, rcf, "cached", common.GitDir(t.TempDir()), keyring, []api.CommitID{gpgSigned})
+		require.NoError(t, err)
+		require.Equal(t, gitdomain.SignatureStatusVerified, verifications[gpgSigned].Status)
+	})
+
+	// Tags signed by each of the signers.
+	tag := func(name string, env []string, args ...string) api.CommitID {
+		t.Helper()
+		run(t, repo, env, "git", append(args, "tag", "-a", "-m", name, name)...)
+		return api.CommitID(strings.TrimSpace(run(t, repo, nil, "git", "rev-parse", name)))
+	}
+	unsignedTag := tag("unsigned", nil)
+	gpgTag := tag("gpg", []string{"GNUPGHOME=" + gpgHome}, "-c", "user.signingkey=alice@example.com", "-c", "tag.gpgsign=true")
+	gpgStrangerTag := tag("gpg-stranger", []string{"GNUPGHOME=" + strangerHome}, "-c", "user.signingkey=stranger@example.com", "-c", "tag.gpgsign=true")
+	sshTag := tag("ssh", nil, "-c", "gpg.format=ssh", "-c", "user.signingkey="+sshKey, "-c", "tag.gpgsign=true")
+	sshStrangerTag := tag("ssh-stranger", nil, "-c", "gpg.format=ssh", "-c", "user.signingkey="+strangerSSHKey, "-c", "tag.gpgsign=true")
+	x509Tag := tag("x509", []string{"GNUPGHOME=" + smHome}, "-c", "gpg.format=x509", "-c", "user.signingkey=carol@example.com", "-c", "tag.gpgsign=true")
+
+	for _, tc := range []struct {
+		name       string
+		tag        api.CommitID
+		wantStatus gitdomain.SignatureStatus
+		wantSigner string
+	}{
+		{name: "unsigned tag", tag: unsignedTag, wantStatus: gitdomain.SignatureStatusUnsigned},
+		{name: "gpg tag", tag: gpgTag, wantStatus: gitdomain.SignatureStatusVerified, wantSigner: "Alice <alice@example.com>"},
+		{name: "gpg tag key not in keyring", tag: gpgStrangerTag, wantStatus: gitdomain.SignatureStatusUnverified},
+		{name: "ssh tag", tag: sshTag, wantStatus: gitdomain.SignatureStatusVerified, wantSigner: "bob"},
+		{name: "ssh tag key not allowed", tag: sshStrangerTag, wantStatus: gitdomain.SignatureStatusUntrusted},
+		{name: "x509 tag", tag: x509Tag, wantStatus: gitdomain.SignatureStatusVerified},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := VerifyTag(ctx, rcf, "repo", dir, keyring, tc.tag)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantStatus, v.Status)
+			if tc.wantSigner != "" {
+				require.Equal(t, tc.wantSigner, v.Signer)
+			}
+			if tc.wantStatus != gitdomain.SignatureStatusUnsigned {
+				require.NotEmpty(t, v.Key)
+			}
+		})
+	}
+}
+
+func TestParseRawVerification(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		raw  string
+		want gitdomain.SignatureVerification
+	}{
+		{
+			name: "unsigned",
+			raw:  "error: no signature found\n",
+			want: gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusUnsigned},
+		},
+		{
+			name: "gpg good",
+			raw: "[GNUPG:] NEWSIG alice@example.com\n" +
+				"[GNUPG:] GOODSIG BB046341ED366A8F Alice <alice@example.com>\n" +
+				"[GNUPG:] VALIDSIG 043F857954C18D10FE368CA0BB046341ED366A8F 2026-10-17 1792215536 0 4 0 22 8 00 043F857954C18D10FE368CA0BB046341ED366A8F\n" +
+				"[GNUPG:] TRUST_ULTIMATE 0 pgp\n",
+			want: gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusVerified, Signer: "Alice <alice@example.com>", Key: "043F857954C18D10FE368CA0BB046341ED366A8F"},
+		},
+		{
+			name: "gpg untrusted",
+			raw: "[GNUPG:] GOODSIG BB046341ED366A8F Alice <alice@example.com>\n" +
+				"[GNUPG:] TRUST_UNDEFINED 0 pgp\n",
+			want: gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusUntrusted, Signer: "Alice <alice@example.com>", Key: "BB046341ED366A8F"},
+		},
+		{
+			name: "gpg bad",
+			raw:  "[GNUPG:] BADSIG BB046341ED366A8F Alice <alice@example.com>\n",
+			want: gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusInvalid, Signer: "Alice <alice@example.com>", Key: "BB046341ED366A8F"},
+		},
+		{
+			name: "gpg revoked",
+			raw:  "[GNUPG:] REVKEYSIG BB046341ED366A8F Alice <alice@example.com>\n",
+			want: gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusRevoked, Signer: "Alice <alice@example.com>", Key: "BB046341ED366A8F"},
+		},
+		{
+			name: "gpg several signatures",
+			raw: "[GNUPG:] GOODSIG BB046341ED366A8F Alice <alice@example.com>\n" +
+				"[GNUPG:] GOODSIG 0123456789ABCDEF Mallory <mallory@example.com>\n",
+			want: gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusUnverified},
+		},
+		{
+			name: "ssh bad",
+			raw:  "Signature verification failed: incorrect signature\n",
+			want: gitdomain.SignatureVerification{Status: gitdomain.SignatureStatusInvalid},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, parseRawVerification(tc.raw))
+		})
+	}
+}
+
+func TestBuild_InvalidCertificate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keyring")
+	_, err := Build(context.Background(), dir, Keys{X509Certificates: []string{"not a certificate"}})
+	require.Error(t, err)
+
+	// Nothing is left behind.
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+}
+
+// newGNUPGHome creates a GNUPGHOME whose agent accepts empty passphrases and
+// is stopped at the end of the test.
+func newGNUPGHome(t *testing.T, dir string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gpg-agent.conf"), []byte("allow-loopback-pinentry\n"), 0o600))
+	t.Cleanup(func() {
+		cmd := exec.Command("gpgconf", "--kill", "all")
+		cmd.Env = append(os.Environ(), "GNUPGHOME="+dir)
+		_ = cmd.Run()
+	})
+	return dir
+}
+
+func run(t *testing.T, dir string, env []string, name string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_GLOBAL=",
+		"GIT_CONFIG_SYSTEM=",
+		"GIT_AUTHOR_NAME=a",
+		"GIT_AUTHOR_EMAIL=a@a.com",
+		"GIT_COMMITTER_NAME=a",
+		"GIT_COMMITTER_EMAIL=a@a.com",
+	)
+	cmd.Env = append(cmd.Env, env...)
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		require.NoError(t, err, "%s %s: %s", name, strings.Join(args, " "), exitErr.Stderr)
+	}
+	require.NoError(t, err)
+	return string(out)
+}
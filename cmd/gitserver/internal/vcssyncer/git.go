@@ -13,8 +13,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/urlredactor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
@@ -200,26 +202,17 @@ func (s *gitRepoSyncer) runFetchCommand(ctx context.Context, repoName api.RepoNa
 	var cmd *exec.Cmd
 
 	configRemoteOpts := true
+	partial := false
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
 		cmd = customCmd
 		configRemoteOpts = false
+	} else if filter, ok := s.partialCloneFilter(repoName, dir); ok {
+		cmd = partialCloneFetchCmd(ctx, filter)
+		partial = true
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch",
-			"--progress", "--prune", remoteURL.String(),
-			// Normal git refs
-			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
-			// GitHub pull requests
-			"+refs/pull/*:refs/pull/*",
-			// GitLab merge requests
-			"+refs/merge-requests/*:refs/merge-requests/*",
-			// Bitbucket pull requests
-			"+refs/pull-requests/*:refs/pull-requests/*",
-			// Gerrit changesets
-			"+refs/changes/*:refs/changes/*",
-			// Possibly deprecated refs for sourcegraph zap experiment?
-			"+refs/sourcegraph/*:refs/sourcegraph/*")
+		cmd = exec.CommandContext(ctx, "git", append([]string{"fetch", "--progress", "--prune", remoteURL.String()}, defaultRefspecs...)...)
 	}
 
 	if cmd.Env == nil {
@@ -232,7 +225,15 @@ func (s *gitRepoSyncer) runFetchCommand(ctx context.Context, repoName api.RepoNa
 	// Set the working directory for the command.
 	dir.Set(cmd)
 
-	if configRemoteOpts {
+	if partial {
+		// Point the promisor remote at the current remote URL, which git
+		// also fetches missing objects from on its own.
+		if err := partialclone.WriteRemoteConfig(ctx, s.recordingCommandFactory, repoName, dir, remoteURL); err != nil {
+			return -1, errors.Wrap(err, "failed to configure promisor remote")
+		}
+		// Configure the command to be able to talk to the promisor remote.
+		partialclone.ConfigureFetchCommand(cmd, remoteURL)
+	} else if configRemoteOpts {
 		// Configure the command to be able to talk to a remote.
 		executil.ConfigureRemoteGitCommand(cmd, remoteURL)
 	}
@@ -247,6 +248,59 @@ func (s *gitRepoSyncer) runFetchCommand(ctx context.Context, repoName api.RepoNa
 	return executil.RunCommandWriteOutput(ctx, wrCmd, progressWriter, redactor.Redact)
 }
 
+// defaultRefspecs are the refs we fetch from the remote.
+var defaultRefspecs = []string{
+	// Normal git refs
+	"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
+	// GitHub pull requests
+	"+refs/pull/*:refs/pull/*",
+	// GitLab merge requests
+	"+refs/merge-requests/*:refs/merge-requests/*",
+	// Bitbucket pull requests
+	"+refs/pull-requests/*:refs/pull-requests/*",
+	// Gerrit changesets
+	"+refs/changes/*:refs/changes/*",
+	// Possibly deprecated refs for sourcegraph zap experiment?
+	"+refs/sourcegraph/*:refs/sourcegraph/*",
+}
+
+var partialCloneFilters = conf.Cached(func() map[string]string {
+	return conf.ExperimentalFeatures().GitServerPartialClone
+})
+
+// partialCloneFilter returns whether the repository at dir is fetched as a
+// partial clone, and the filter to request. An empty filter means git uses
+// the one the repository was cloned with. Repositories stay partial clones
+// until they are recloned, even if they are no longer configured to be.
+func (s *gitRepoSyncer) partialCloneFilter(repoName api.RepoName, dir common.GitDir) (string, bool) {
+	if filter := partialCloneFilters()[string(repoName)]; filter != "" {
+		return filter, true
+	}
+	status, err := partialclone.ReadStatus(dir)
+	if err != nil {
+		s.logger.Warn("failed to read partial clone status", log.Error(err), log.String("repo", string(repoName)))
+		return "", false
+	}
+	return "", status != nil
+}
+
+// partialCloneFetchCmd fetches from the promisor remote of a partial clone
+// rather than from the remote URL, so that git records the filter and where
+// to fetch omitted objects from.
+func partialCloneFetchCmd(ctx context.Context, filter string) *exec.Cmd {
+	args := []string{"fetch", "--progress", "--prune"}
+	if filter != "" {
+		args = append(args, "--filter="+filter)
+	}
+	args = append(args, partialclone.RemoteName)
+	if useRefspecOverrides() {
+		args = append(args, refspecOverrides...)
+	} else {
+		args = append(args, defaultRefspecs...)
+	}
+	return exec.CommandContext(ctx, "git", args...)
+}
+
 var headBranchPattern = lazyregexp.New(`HEAD branch: (.+?)\n`)
 
 // setHEAD configures git repo defaults (such as what HEAD is) which are
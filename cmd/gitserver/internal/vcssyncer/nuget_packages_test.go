@@ -0,0 +1,75 @@
+package vcssyncer
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnpackNuGetPackage(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for _, f := range []fileInfo{
+		{path: "_rels/.rels", contents: []byte("<Relationships/>")},
+		{path: "[Content_Types].xml", contents: []byte("<Types/>")},
+		{path: "package/services/metadata/core-properties/abc.psmdcp", contents: []byte("<coreProperties/>")},
+		{path: ".signature.p7s", contents: []byte("signature")},
+		{path: "Newtonsoft.Json.nuspec", contents: []byte("<package/>")},
+		{path: "README.md", contents: []byte("# Json.NET")},
+		{path: "lib/net6.0/Newtonsoft.Json.xml", contents: []byte("<doc/>")},
+		{path: "contentFiles/cs/any/Helpers.cs", contents: []byte("class Helpers {}")},
+		{path: "../escape.cs", contents: []byte("filter me")},
+	} {
+		fw, err := zw.Create(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = fw.Write(f.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tmp := t.TempDir()
+	workDir := filepath.Join(tmp, "work")
+	if err := unpackNuGetPackage(&zipBuf, func() (string, error) { return os.MkdirTemp(tmp, "zip") }, workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	if err := filepath.Walk(workDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		got = append(got, strings.TrimPrefix(path, workDir))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"/Newtonsoft.Json.nuspec",
+		"/README.md",
+		"/contentFiles/cs/any/Helpers.cs",
+		"/lib/net6.0/Newtonsoft.Json.xml",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
@@ -0,0 +1,526 @@
+package vcssyncer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/urlredactor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// svnRefPrefix is where git-svn keeps the branches and tags it imported.
+const svnRefPrefix = "refs/remotes/svn/"
+
+// svnDefaultBranch is the branch the trunk of a Subversion repository is
+// imported into.
+const svnDefaultBranch = "refs/heads/master"
+
+// gitSVNIDPattern matches the line git-svn appends to the message of every
+// commit it imports, e.g.
+// git-svn-id: https://svn.example.com/svn/project/trunk@42 6a7d5b4c-...
+var gitSVNIDPattern = lazyregexp.New(`(?m)^git-svn-id: \S+@(\d+) `)
+
+// subversionSyncer is a syncer for Subversion repositories. It converts their
+// history to Git with git-svn, which keeps track of the revisions it imported
+// so that every fetch only imports the revisions committed since.
+type subversionSyncer struct {
+	logger                  log.Logger
+	recordingCommandFactory *wrexec.RecordingCommandFactory
+	fs                      gitserverfs.FS
+
+	// layout configures the directories of the trunk, branches and tags. It is
+	// nil for the standard layout.
+	layout *schema.SubversionLayout
+
+	// authors maps Subversion usernames to Git authors.
+	authors map[string]string
+
+	// getRemoteURLSource returns the RemoteURLSource for the given repository.
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error)
+}
+
+func NewSubversionSyncer(logger log.Logger, r *wrexec.RecordingCommandFactory, fs gitserverfs.FS, connection *schema.SubversionConnection, getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error)) VCSSyncer {
+	return &subversionSyncer{
+		logger:                  logger.Scoped("SubversionSyncer"),
+		recordingCommandFactory: r,
+		fs:                      fs,
+		layout:                  connection.Layout,
+		authors:                 connection.Authors,
+		getRemoteURLSource:      getRemoteURLSource,
+	}
+}
+
+func (s *subversionSyncer) Type() string {
+	return "subversion"
+}
+
+// IsCloneable checks to see if the Subversion repository can be accessed.
+func (s *subversionSyncer) IsCloneable(ctx context.Context, repoName api.RepoName) error {
+	remoteURL, err := s.remoteURL(ctx, repoName)
+	if err != nil {
+		return err
+	}
+	remote := decomposeSubversionRemoteURL(remoteURL)
+
+	configDir, err := s.fs.TempDir("svn-config-")
+	if err != nil {
+		return errors.Wrap(err, "creating svn config dir")
+	}
+	defer os.RemoveAll(configDir)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	args := []string{"info", "--non-interactive", "--no-auth-cache", "--config-dir", configDir}
+	if remote.username != "" {
+		args = append(args, "--username", remote.username)
+	}
+	var stdin io.Reader
+	if remote.password != "" {
+		args = append(args, "--password-from-stdin")
+		stdin = strings.NewReader(remote.password)
+	}
+	args = append(args, remote.url)
+
+	cmd := exec.CommandContext(ctx, "svn", args...)
+	cmd.Stdin = stdin
+
+	r := urlredactor.New(remoteURL)
+	out, err := s.recordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).WithRedactorFunc(r.Redact).CombinedOutput()
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Wrap(err, "failed to check remote access: "+r.Redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// Clone converts the history of a Subversion repository into a Git repository
+// in tmpPath. It reports redacted progress logs via the progressWriter.
+func (s *subversionSyncer) Clone(ctx context.Context, repo api.RepoName, _ common.GitDir, tmpPath string, progressWriter io.Writer) error {
+	remoteURL, err := s.remoteURL(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+	dir := common.GitDir(tmpPath)
+
+	tryWrite(s.logger, progressWriter, "Creating bare repo\n")
+	if err := git.MakeBareRepo(ctx, tmpPath); err != nil {
+		return err
+	}
+	if err := s.runGit(ctx, repo, dir, "symbolic-ref", "HEAD", svnDefaultBranch); err != nil {
+		return err
+	}
+
+	if err := s.init(ctx, repo, dir, remoteURL, progressWriter); err != nil {
+		return err
+	}
+
+	tryWrite(s.logger, progressWriter, "Converting repository using git-svn\n")
+	if err := s.fetch(ctx, repo, dir, remoteURL, progressWriter); err != nil {
+		return err
+	}
+
+	// Repack all the loose objects git-svn created, see perforceDepotSyncer.Clone
+	// for an overview of the arguments.
+	tryWrite(s.logger, progressWriter, "Repacking loose git objects for efficiency\n")
+	repack := exec.CommandContext(ctx, "git", "repack", "-d", "--local", "--cruft", "--cruft-expiration=2.weeks.ago", "--write-bitmap-index", "--window-memory=100m")
+	dir.Set(repack)
+	redactor := urlredactor.New(remoteURL)
+	exitCode, err := executil.RunCommandWriteOutput(
+		ctx,
+		s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repo, repack).WithRedactorFunc(redactor.Redact),
+		progressWriter,
+		redactor.Redact,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run git repack: exit code %d", exitCode)
+	}
+	tryWrite(s.logger, progressWriter, "Repacked loose git objects!\n")
+
+	return nil
+}
+
+// Fetch imports the revisions committed to a Subversion repository since it
+// was last cloned or fetched.
+func (s *subversionSyncer) Fetch(ctx context.Context, repoName api.RepoName, dir common.GitDir, progressWriter io.Writer) error {
+	remoteURL, err := s.remoteURL(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.fetch(ctx, repoName, dir, remoteURL, progressWriter); err != nil {
+		return err
+	}
+
+	// The update was successful, after a git fetch it is expected that a repos
+	// FETCH_HEAD has either been updated, or that HEAD has been touched, even
+	// if no changes were fetched.
+	// Since we use this for last_fetched, we touch it here.
+	if err := os.Chtimes(dir.Path("HEAD"), time.Time{}, time.Now()); err != nil {
+		s.logger.Error("failed to touch HEAD after subversion fetch", log.Error(err))
+	}
+
+	return nil
+}
+
+// init configures git-svn to import the Subversion repository at remoteURL
+// with the layout of the connection.
+func (s *subversionSyncer) init(ctx context.Context, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL, progressWriter io.Writer) error {
+	tryWrite(s.logger, progressWriter, "Configuring git-svn\n")
+	remote := decomposeSubversionRemoteURL(remoteURL)
+	args := append([]string{"init", "--prefix=" + strings.TrimPrefix(svnRefPrefix, "refs/remotes/"), "--no-minimize-url"}, s.layoutOptions()...)
+	return s.runGitSVN(ctx, repoName, dir, remoteURL, progressWriter, append(args, remote.url)...)
+}
+
+// fetch imports new revisions with git-svn and points the branches and tags of
+// the repository at them.
+func (s *subversionSyncer) fetch(ctx context.Context, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL, progressWriter io.Writer) error {
+	// Copies of repositories from other shards only bring along their refs,
+	// not the git-svn config. git-svn rebuilds the rest of its state from the
+	// commits it imported.
+	if initialized, err := s.initialized(ctx, repoName, dir); err != nil {
+		return err
+	} else if !initialized {
+		if err := s.init(ctx, repoName, dir, remoteURL, progressWriter); err != nil {
+			return err
+		}
+	}
+
+	refs, err := s.listRefs(ctx, repoName, dir)
+	if err != nil {
+		return err
+	}
+	if refs.lastRevision > 0 {
+		tryWrite(s.logger, progressWriter, "Fetching Subversion revisions after r%d\n", refs.lastRevision)
+	}
+
+	if err := s.runGitSVN(ctx, repoName, dir, remoteURL, progressWriter, "fetch"); err != nil {
+		return err
+	}
+
+	refs, err = s.listRefs(ctx, repoName, dir)
+	if err != nil {
+		return err
+	}
+	if err := s.updateRefs(ctx, repoName, dir, refs); err != nil {
+		return err
+	}
+	tryWrite(s.logger, progressWriter, "Imported Subversion revisions up to r%d\n", refs.lastRevision)
+
+	return nil
+}
+
+// layoutOptions returns the git svn init options for the configured layout.
+func (s *subversionSyncer) layoutOptions() []string {
+	if s.layout == nil {
+		return []string{"--stdlayout"}
+	}
+
+	var opts []string
+	if s.layout.Trunk != "" {
+		opts = append(opts, "--trunk="+s.layout.Trunk)
+	}
+	if s.layout.Branches != "" {
+		opts = append(opts, "--branches="+s.layout.Branches)
+	}
+	if s.layout.Tags != "" {
+		opts = append(opts, "--tags="+s.layout.Tags)
+	}
+	return opts
+}
+
+// runGitSVN runs git svn with the credentials and authors of the connection.
+func (s *subversionSyncer) runGitSVN(ctx context.Context, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL, progressWriter io.Writer, args ...string) error {
+	helperDir, err := s.fs.TempDir("svn-")
+	if err != nil {
+		return errors.Wrap(err, "creating git-svn helper dir")
+	}
+	defer os.RemoveAll(helperDir)
+
+	remote := decomposeSubversionRemoteURL(remoteURL)
+	env, opts, err := s.writeHelpers(helperDir, remote, args[0] == "fetch")
+	if err != nil {
+		return errors.Wrap(err, "writing git-svn helpers")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", append(append([]string{"svn", args[0]}, opts...), args[1:]...)...)
+	cmd.Env = append(os.Environ(), env...)
+	dir.Set(cmd)
+
+	redactor := urlredactor.New(remoteURL)
+	wrCmd := s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repoName, cmd).WithRedactorFunc(redactor.Redact)
+	// Note: Using RunCommandWriteOutput here does NOT store the output of the
+	// command as the command output of the wrexec command, because the pipes are
+	// already used.
+	exitCode, err := executil.RunCommandWriteOutput(ctx, wrCmd, progressWriter, redactor.Redact)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run git svn %s: exit code %d", args[0], exitCode)
+	}
+	return nil
+}
+
+// askPassScript answers the username and password prompts of git-svn. Any
+// other prompt, like accepting an untrusted certificate, gets an empty answer
+// and is declined.
+const askPassScript = `#!/bin/sh
+case "$1" in
+Username*) printf '%s\n' "$SRC_SVN_USERNAME" ;;
+Password*) printf '%s\n' "$SRC_SVN_PASSWORD" ;;
+esac
+`
+
+// authorsProgScript maps Subversion usernames which aren't in the authors file
+// to "username <username>", so that unknown authors don't fail the import.
+const authorsProgScript = `#!/bin/sh
+printf '%s <%s>\n' "$1" "$1"
+`
+
+// writeHelpers writes the files git-svn needs to run non-interactively into
+// dir, and returns the environment and options to run git svn with.
+func (s *subversionSyncer) writeHelpers(dir string, remote subversionRemote, withAuthors bool) (env, opts []string, err error) {
+	configDir := filepath.Join(dir, "config")
+	if err := os.Mkdir(configDir, 0o700); err != nil {
+		return nil, nil, err
+	}
+	askPass := filepath.Join(dir, "askpass")
+	if err := os.WriteFile(askPass, []byte(askPassScript), 0o700); err != nil {
+		return nil, nil, err
+	}
+
+	env = []string{
+		"GIT_ASKPASS=" + askPass,
+		"SRC_SVN_USERNAME=" + remote.username,
+		"SRC_SVN_PASSWORD=" + remote.password,
+		// git-svn keeps state in $HOME/.subversion unless told otherwise.
+		"HOME=" + dir,
+	}
+	opts = []string{"--config-dir=" + configDir, "--no-auth-cache"}
+	if remote.username != "" {
+		opts = append(opts, "--username="+remote.username)
+	}
+
+	if !withAuthors {
+		return env, opts, nil
+	}
+
+	authorsFile := filepath.Join(dir, "authors")
+	if err := os.WriteFile(authorsFile, formatAuthorsFile(s.authors), 0o600); err != nil {
+		return nil, nil, err
+	}
+	authorsProg := filepath.Join(dir, "authors-prog")
+	if err := os.WriteFile(authorsProg, []byte(authorsProgScript), 0o700); err != nil {
+		return nil, nil, err
+	}
+	opts = append(opts, "--authors-file="+authorsFile, "--authors-prog="+authorsProg)
+
+	return env, opts, nil
+}
+
+// formatAuthorsFile formats authors in the format of git-svn's authors file.
+func formatAuthorsFile(authors map[string]string) []byte {
+	usernames := make([]string, 0, len(authors))
+	for username := range authors {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	var b bytes.Buffer
+	for _, username := range usernames {
+		fmt.Fprintf(&b, "%s = %s\n", username, authors[username])
+	}
+	return b.Bytes()
+}
+
+// svnRefs are the refs of a repository imported with git-svn.
+type svnRefs struct {
+	// imported maps the branches and tags imported by git-svn to the commits
+	// they point at, keyed by the Git ref they should be available at.
+	imported map[string]string
+	// existing are the branches and tags of the repository.
+	existing map[string]string
+	// lastRevision is the last Subversion revision imported into any branch or
+	// tag.
+	lastRevision int
+}
+
+func (s *subversionSyncer) listRefs(ctx context.Context, repoName api.RepoName, dir common.GitDir) (svnRefs, error) {
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(refname)%00%(objectname)%00%(contents)%00", svnRefPrefix, "refs/heads/", "refs/tags/")
+	dir.Set(cmd)
+	out, err := s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repoName, cmd).CombinedOutput()
+	if err != nil {
+		return svnRefs{}, errors.Wrapf(err, "failed to list refs with output %q", string(out))
+	}
+	return parseSVNRefs(out), nil
+}
+
+// parseSVNRefs parses the output of git for-each-ref with the format used by
+// listRefs.
+func parseSVNRefs(out []byte) svnRefs {
+	refs := svnRefs{
+		imported: map[string]string{},
+		existing: map[string]string{},
+	}
+
+	fields := strings.Split(string(out), "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		// Every record is terminated by a newline.
+		ref, commit, message := strings.TrimPrefix(fields[i], "\n"), fields[i+1], fields[i+2]
+
+		name, ok := strings.CutPrefix(ref, svnRefPrefix)
+		if !ok {
+			refs.existing[ref] = commit
+			continue
+		}
+
+		if m := gitSVNIDPattern.FindAllStringSubmatch(message, -1); len(m) > 0 {
+			if rev, err := strconv.Atoi(m[len(m)-1][1]); err == nil && rev > refs.lastRevision {
+				refs.lastRevision = rev
+			}
+		}
+
+		if target, ok := gitRefForSVNRef(name); ok {
+			refs.imported[target] = commit
+		}
+	}
+
+	return refs
+}
+
+// gitRefForSVNRef returns the ref a branch or tag imported by git-svn should
+// be available at.
+func gitRefForSVNRef(name string) (string, bool) {
+	switch {
+	// git-svn keeps the history of branches and tags that were deleted or
+	// replaced at name@revision.
+	case strings.Contains(name, "@"):
+		return "", false
+	// The trunk, or the whole repository if there is no layout.
+	case name == "trunk" || name == "git-svn":
+		return svnDefaultBranch, true
+	case strings.HasPrefix(name, "tags/"):
+		return "refs/tags/" + strings.TrimPrefix(name, "tags/"), true
+	case "refs/heads/"+name == svnDefaultBranch:
+		// The trunk takes precedence over a branch that is called master.
+		return "", false
+	default:
+		return "refs/heads/" + name, true
+	}
+}
+
+// updateRefs points the branches and tags of the repository at the ones
+// imported by git-svn, and removes branches and tags which weren't imported.
+func (s *subversionSyncer) updateRefs(ctx context.Context, repoName api.RepoName, dir common.GitDir, refs svnRefs) error {
+	var stdin strings.Builder
+	for ref, commit := range refs.imported {
+		if refs.existing[ref] != commit {
+			fmt.Fprintf(&stdin, "update %s %s\n", ref, commit)
+		}
+	}
+	for ref := range refs.existing {
+		if _, ok := refs.imported[ref]; !ok {
+			fmt.Fprintf(&stdin, "delete %s\n", ref)
+		}
+	}
+	if stdin.Len() == 0 {
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+	cmd.Stdin = strings.NewReader(stdin.String())
+	dir.Set(cmd)
+	if out, err := s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repoName, cmd).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to update refs with output %q", string(out))
+	}
+	return nil
+}
+
+// initialized returns true if git-svn is configured for the repository.
+func (s *subversionSyncer) initialized(ctx context.Context, repoName api.RepoName, dir common.GitDir) (bool, error) {
+	cmd := exec.CommandContext(ctx, "git", "config", "--get", "svn-remote.svn.url")
+	dir.Set(cmd)
+	out, err := s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repoName, cmd).CombinedOutput()
+	// git config exits with status 1 if the key isn't set.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read git-svn config with output %q", string(out))
+	}
+	return true, nil
+}
+
+func (s *subversionSyncer) runGit(ctx context.Context, repoName api.RepoName, dir common.GitDir, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	if out, err := s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repoName, cmd).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to run git %s with output %q", args[0], string(out))
+	}
+	return nil
+}
+
+func (s *subversionSyncer) remoteURL(ctx context.Context, repoName api.RepoName) (*vcs.URL, error) {
+	source, err := s.getRemoteURLSource(ctx, repoName)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting remote URL source")
+	}
+	remoteURL, err := source.RemoteURL(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting remote URL")
+	}
+	return remoteURL, nil
+}
+
+// subversionRemote is the URL of a Subversion repository and the credentials
+// to access it with.
+type subversionRemote struct {
+	url      string
+	username string
+	password string
+}
+
+// decomposeSubversionRemoteURL splits the credentials from the remote URL.
+// The username of svn+ssh URLs is kept, because it is used by ssh.
+func decomposeSubversionRemoteURL(remoteURL *vcs.URL) subversionRemote {
+	u := *remoteURL
+	remote := subversionRemote{username: u.User.Username()}
+	remote.password, _ = u.User.Password()
+	if u.Scheme == "svn+ssh" && remote.username != "" {
+		u.User = url.User(remote.username)
+		remote.username = ""
+	} else {
+		u.User = nil
+	}
+	remote.url = u.String()
+	return remote
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/crates"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npm"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pypi"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -119,6 +120,13 @@ func NewVCSSyncer(ctx context.Context, opts *NewVCSSyncerOpts) (VCSSyncer, error
 			}
 
 			return NewPerforceDepotSyncer(opts.Logger, opts.RecordingCommandFactory, opts.FS, &c, opts.GetRemoteURLSource), nil
+		case extsvc.TypeSubversion:
+			var c schema.SubversionConnection
+			if _, err := extractOptions(&c); err != nil {
+				return nil, err
+			}
+
+			return NewSubversionSyncer(opts.Logger, opts.RecordingCommandFactory, opts.FS, &c, opts.GetRemoteURLSource), nil
 		case extsvc.TypeJVMPackages:
 			var c schema.JVMPackagesConnection
 			if _, err := extractOptions(&c); err != nil {
@@ -177,6 +185,17 @@ func NewVCSSyncer(ctx context.Context, opts *NewVCSSyncerOpts) (VCSSyncer, error
 				return nil, err
 			}
 			return NewRubyPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
+		case extsvc.TypeNuGetPackages:
+			var c schema.NuGetPackagesConnection
+			urn, err := extractOptions(&c)
+			if err != nil {
+				return nil, err
+			}
+			cli, err := nuget.NewClient(urn, c.Registry, c.Credentials, httpcli.ExternalClientFactory)
+			if err != nil {
+				return nil, err
+			}
+			return NewNuGetPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
 		}
 
 		return NewGitRepoSyncer(opts.Logger, opts.RecordingCommandFactory, opts.GetRemoteURLSource), nil
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git/native"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -38,6 +39,13 @@ type Config struct {
 	JanitorInterval                       time.Duration
 	JanitorDisableDeleteReposOnWrongShard bool
 
+	SigningKeyringRefreshInterval time.Duration
+
+	// NativeBackendOperations are the git backend operations served by
+	// reading repositories in-process instead of running git.
+	NativeBackendOperations native.Operations
+	NativePackCacheSize     int
+
 	ExhaustiveRequestLoggingEnabled bool
 }
 
@@ -77,5 +85,14 @@ func (c *Config) Load() {
 	c.JanitorInterval = c.GetInterval("SRC_REPOS_JANITOR_INTERVAL", "1m", "Interval between cleanup runs")
 	c.JanitorDisableDeleteReposOnWrongShard = c.GetBool("SRC_REPOS_JANITOR_DISABLE_DELETE_REPOS_ON_WRONG_SHARD", "false", "Disable deleting repos on wrong shard")
 
+	c.SigningKeyringRefreshInterval = c.GetInterval("SRC_GITSERVER_SIGNING_KEYRING_REFRESH_INTERVAL", "1h", "Interval between refreshes of the commit signature verification keys fetched from code hosts")
+
+	nativeOps, err := native.ParseOperations(c.GetOptional("SRC_GITSERVER_NATIVE_BACKEND_OPERATIONS", "Comma-separated list of git backend operations (ReadFile, Stat, ReadDir, ResolveRevision, or all) served by reading repositories in-process instead of running git."))
+	if err != nil {
+		c.AddError(err)
+	}
+	c.NativeBackendOperations = nativeOps
+	c.NativePackCacheSize = c.GetInt("SRC_GITSERVER_NATIVE_PACK_CACHE_SIZE", "1000", "Maximum number of packfiles, pack indexes and related files kept memory-mapped by the native git backend while not in use.")
+
 	c.ExhaustiveRequestLoggingEnabled = c.GetBool("SRC_GITSERVER_EXHAUSTIVE_LOGGING_ENABLED", "false", "Enable exhaustive request logging in gitserver")
 }
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
@@ -30,11 +31,16 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git/gitcli"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git/native"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/lfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/partialclone"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/signing"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/vcssyncer"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/authz/subrepoperms"
+	"github.com/sourcegraph/sourcegraph/internal/cloneurls"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/collections"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -52,6 +58,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/service"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 type LazyDebugserverEndpoint struct {
@@ -91,8 +98,51 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	recordingCommandFactory := wrexec.NewRecordingCommandFactory(nil, 0)
 	locker := server.NewRepositoryLocker()
 	hostname := config.ExternalAddress
+	signingManager := signing.NewManager(logger, fs.TempDir)
+	packCache, err := native.NewPackCache(config.NativePackCacheSize)
+	if err != nil {
+		return errors.Wrap(err, "initializing pack cache")
+	}
+	// A backend is created for every request, so we cache the LFS options
+	// across them to avoid hitting the database for every archive.
+	lfsOptions := lfs.NewCachedOptionsSource(func(ctx context.Context, repo api.RepoName) (*lfs.Options, error) {
+		return getLFSOptionsFunc(ctx, db, repo)
+	}, 8192, time.Minute)
 	backendSource := func(dir common.GitDir, repoName api.RepoName) git.GitBackend {
-		return git.NewObservableBackend(gitcli.NewBackend(logger, recordingCommandFactory, dir, repoName))
+		return git.NewObservableBackend(signing.NewBackend(
+			logger,
+			recordingCommandFactory,
+			lfs.NewBackend(
+				logger,
+				partialclone.NewBackend(
+					logger,
+					recordingCommandFactory,
+					native.NewBackend(
+						logger,
+						gitcli.NewBackend(logger, recordingCommandFactory, dir, repoName),
+						packCache,
+						dir,
+						repoName,
+						config.NativeBackendOperations,
+					),
+					dir,
+					repoName,
+					func(ctx context.Context, repo api.RepoName) (*vcs.URL, error) {
+						rawURL, err := getRemoteURLFunc(ctx, db, repo)
+						if err != nil {
+							return nil, errors.Wrapf(err, "getting remote URL for %q", repo)
+						}
+						return vcs.ParseURL(rawURL)
+					},
+				),
+				dir,
+				repoName,
+				lfsOptions,
+			),
+			dir,
+			repoName,
+			signingManager.Keyring,
+		))
 	}
 	gitserver := makeServer(
 		observationCtx,
@@ -106,6 +156,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		func(ctx context.Context, repo api.RepoName) (string, error) {
 			return getRemoteURLFunc(ctx, db, repo)
 		},
+		signingManager.Keyring,
 	)
 
 	// Make sure we watch for config updates that affect the recordingCommandFactory.
@@ -151,6 +202,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 			recordingCommandFactory,
 			logger,
 		),
+		signingManager.NewRoutine(ctx, config.SigningKeyringRefreshInterval),
 	}
 
 	// Register recorder in all routines that support it.
@@ -202,11 +254,16 @@ func makeServer(
 	coursierCacheDir string,
 	locker internal.RepositoryLocker,
 	getRemoteURLFunc func(ctx context.Context, repo api.RepoName) (string, error),
+	signingKeyringSource signing.KeyringSource,
 ) *internal.Server {
 	return server.NewServer(&server.ServerOpts{
 		Logger:           observationCtx.Logger,
 		GitBackendSource: backendSource,
 		GetRemoteURLFunc: getRemoteURLFunc,
+		GetSubmoduleRepoNameFunc: func(ctx context.Context, repo api.RepoName, submoduleURL string) (api.RepoName, error) {
+			return getSubmoduleRepoNameFunc(ctx, db, getRemoteURLFunc, repo, submoduleURL)
+		},
+		SigningKeyringSource: signingKeyringSource,
 		GetVCSSyncer: func(ctx context.Context, repo api.RepoName) (vcssyncer.VCSSyncer, error) {
 			return vcssyncer.NewVCSSyncer(ctx, &vcssyncer.NewVCSSyncerOpts{
 				ExternalServiceStore:    db.ExternalServices(),
@@ -326,6 +383,97 @@ func getRemoteURLFunc(
 	return "", errors.Errorf("no sources for %q", repo)
 }
 
+// getSubmoduleRepoNameFunc resolves the URL of a submodule of repo to the name
+// of the Sourcegraph repository it points to. Relative URLs are resolved
+// against the remote URL of repo, the same way git does.
+func getSubmoduleRepoNameFunc(
+	ctx context.Context,
+	db database.DB,
+	getRemoteURLFunc func(ctx context.Context, repo api.RepoName) (string, error),
+	repo api.RepoName,
+	submoduleURL string,
+) (api.RepoName, error) {
+	if strings.HasPrefix(submoduleURL, "./") || strings.HasPrefix(submoduleURL, "../") {
+		remoteURL, err := getRemoteURLFunc(ctx, repo)
+		if err != nil {
+			return "", err
+		}
+		u, err := vcs.ParseURL(remoteURL)
+		if err != nil {
+			return "", errors.Wrap(err, "parsing remote URL")
+		}
+		u = u.JoinPath(submoduleURL)
+		// The remote URL may contain credentials, which never appear in the
+		// clone URLs we match against.
+		u.User = nil
+		submoduleURL = u.String()
+	}
+
+	return cloneurls.RepoSourceCloneURLToRepoName(actor.WithInternalActor(ctx), db, submoduleURL)
+}
+
+// getLFSOptionsFunc returns the Git LFS options for repo from the first code
+// host connection of the repository that has LFS enabled, or nil if none does.
+func getLFSOptionsFunc(
+	ctx context.Context,
+	db database.DB,
+	repo api.RepoName,
+) (*lfs.Options, error) {
+	ctx = actor.WithInternalActor(ctx)
+
+	r, err := db.Repos().GetByName(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range r.Sources {
+		svc, err := db.ExternalServices().GetByID(ctx, info.ExternalServiceID())
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := svc.Configuration(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			enabled       bool
+			maxObjectSize int
+		)
+		switch c := cfg.(type) {
+		case *schema.GitHubConnection:
+			if c.GitLFS != nil {
+				enabled, maxObjectSize = c.GitLFS.Enabled, c.GitLFS.MaxObjectSize
+			}
+		case *schema.GitLabConnection:
+			if c.GitLFS != nil {
+				enabled, maxObjectSize = c.GitLFS.Enabled, c.GitLFS.MaxObjectSize
+			}
+		case *schema.BitbucketServerConnection:
+			if c.GitLFS != nil {
+				enabled, maxObjectSize = c.GitLFS.Enabled, c.GitLFS.MaxObjectSize
+			}
+		case *schema.BitbucketCloudConnection:
+			if c.GitLFS != nil {
+				enabled, maxObjectSize = c.GitLFS.Enabled, c.GitLFS.MaxObjectSize
+			}
+		}
+		if !enabled {
+			continue
+		}
+
+		remoteURL, err := cloneurl.ForEncryptableConfig(ctx, db, svc.Kind, svc.Config, r)
+		if err != nil {
+			return nil, err
+		}
+		return &lfs.Options{
+			RemoteURL:     remoteURL,
+			MaxObjectSize: int64(maxObjectSize),
+		}, nil
+	}
+	return nil, nil
+}
+
 var defaultIgnoredGitCommands = []string{
 	"show",
 	"rev-parse",
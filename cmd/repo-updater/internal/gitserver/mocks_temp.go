@@ -19,6 +19,9 @@ import (
 // github.com/sourcegraph/sourcegraph/cmd/repo-updater/internal/gitserver)
 // used for unit testing.
 type MockRepositoryServiceClient struct {
+	// CopyRepositoryFromShardFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyRepositoryFromShard.
+	CopyRepositoryFromShardFunc *RepositoryServiceClientCopyRepositoryFromShardFunc
 	// DeleteRepositoryFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteRepository.
 	DeleteRepositoryFunc *RepositoryServiceClientDeleteRepositoryFunc
@@ -32,6 +35,11 @@ type MockRepositoryServiceClient struct {
 // results, unless overwritten.
 func NewMockRepositoryServiceClient() *MockRepositoryServiceClient {
 	return &MockRepositoryServiceClient{
+		CopyRepositoryFromShardFunc: &RepositoryServiceClientCopyRepositoryFromShardFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (r0 error) {
+				return
+			},
+		},
 		DeleteRepositoryFunc: &RepositoryServiceClientDeleteRepositoryFunc{
 			defaultHook: func(context.Context, api.RepoName) (r0 error) {
 				return
@@ -50,6 +58,11 @@ func NewMockRepositoryServiceClient() *MockRepositoryServiceClient {
 // unless overwritten.
 func NewStrictMockRepositoryServiceClient() *MockRepositoryServiceClient {
 	return &MockRepositoryServiceClient{
+		CopyRepositoryFromShardFunc: &RepositoryServiceClientCopyRepositoryFromShardFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) error {
+				panic("unexpected invocation of MockRepositoryServiceClient.CopyRepositoryFromShard")
+			},
+		},
 		DeleteRepositoryFunc: &RepositoryServiceClientDeleteRepositoryFunc{
 			defaultHook: func(context.Context, api.RepoName) error {
 				panic("unexpected invocation of MockRepositoryServiceClient.DeleteRepository")
@@ -68,6 +81,9 @@ func NewStrictMockRepositoryServiceClient() *MockRepositoryServiceClient {
 // implementation, unless overwritten.
 func NewMockRepositoryServiceClientFrom(i RepositoryServiceClient) *MockRepositoryServiceClient {
 	return &MockRepositoryServiceClient{
+		CopyRepositoryFromShardFunc: &RepositoryServiceClientCopyRepositoryFromShardFunc{
+			defaultHook: i.CopyRepositoryFromShard,
+		},
 		DeleteRepositoryFunc: &RepositoryServiceClientDeleteRepositoryFunc{
 			defaultHook: i.DeleteRepository,
 		},
@@ -77,6 +93,121 @@ func NewMockRepositoryServiceClientFrom(i RepositoryServiceClient) *MockReposito
 	}
 }
 
+// RepositoryServiceClientCopyRepositoryFromShardFunc describes the behavior
+// when the CopyRepositoryFromShard method of the parent
+// MockRepositoryServiceClient instance is invoked.
+type RepositoryServiceClientCopyRepositoryFromShardFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, string) error
+	hooks       []func(context.Context, api.RepoName, string, string) error
+	history     []RepositoryServiceClientCopyRepositoryFromShardFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyRepositoryFromShard delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockRepositoryServiceClient) CopyRepositoryFromShard(v0 context.Context, v1 api.RepoName, v2 string, v3 string) error {
+	r0 := m.CopyRepositoryFromShardFunc.nextHook()(v0, v1, v2, v3)
+	m.CopyRepositoryFromShardFunc.appendCall(RepositoryServiceClientCopyRepositoryFromShardFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// CopyRepositoryFromShard method of the parent MockRepositoryServiceClient
+// instance is invoked and the hook queue is empty.
+func (f *RepositoryServiceClientCopyRepositoryFromShardFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyRepositoryFromShard method of the parent MockRepositoryServiceClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *RepositoryServiceClientCopyRepositoryFromShardFunc) PushHook(hook func(context.Context, api.RepoName, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepositoryServiceClientCopyRepositoryFromShardFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepositoryServiceClientCopyRepositoryFromShardFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, string) error {
+		return r0
+	})
+}
+
+func (f *RepositoryServiceClientCopyRepositoryFromShardFunc) nextHook() func(context.Context, api.RepoName, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepositoryServiceClientCopyRepositoryFromShardFunc) appendCall(r0 RepositoryServiceClientCopyRepositoryFromShardFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// RepositoryServiceClientCopyRepositoryFromShardFuncCall objects describing
+// the invocations of this function.
+func (f *RepositoryServiceClientCopyRepositoryFromShardFunc) History() []RepositoryServiceClientCopyRepositoryFromShardFuncCall {
+	f.mutex.Lock()
+	history := make([]RepositoryServiceClientCopyRepositoryFromShardFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepositoryServiceClientCopyRepositoryFromShardFuncCall is an object that
+// describes an invocation of method CopyRepositoryFromShard on an instance
+// of MockRepositoryServiceClient.
+type RepositoryServiceClientCopyRepositoryFromShardFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepositoryServiceClientCopyRepositoryFromShardFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepositoryServiceClientCopyRepositoryFromShardFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // RepositoryServiceClientDeleteRepositoryFunc describes the behavior when
 // the DeleteRepository method of the parent MockRepositoryServiceClient
 // instance is invoked.
@@ -11,8 +11,9 @@ import (
 )
 
 type operations struct {
-	deleteRepository *observation.Operation
-	fetchRepository  *observation.Operation
+	copyRepositoryFromShard *observation.Operation
+	deleteRepository        *observation.Operation
+	fetchRepository         *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -35,8 +36,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		deleteRepository: op("DeleteRepository"),
-		fetchRepository:  op("FetchRepository"),
+		copyRepositoryFromShard: op("CopyRepositoryFromShard"),
+		deleteRepository:        op("DeleteRepository"),
+		fetchRepository:         op("FetchRepository"),
 	}
 }
 
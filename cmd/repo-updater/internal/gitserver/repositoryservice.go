@@ -5,15 +5,23 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/connection"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type RepositoryServiceClient interface {
+	// CopyRepositoryFromShard makes the gitserver at target copy repo from
+	// the gitserver at source. It returns a gitdomain.RepoNotExistError if
+	// source doesn't have repo.
+	CopyRepositoryFromShard(ctx context.Context, repo api.RepoName, target, source string) error
 	DeleteRepository(context.Context, api.RepoName) error
 	FetchRepository(context.Context, api.RepoName) (lastFetched, lastChanged time.Time, err error)
 }
@@ -30,6 +38,40 @@ type repositoryServiceClient struct {
 	scope      string
 }
 
+func (c *repositoryServiceClient) CopyRepositoryFromShard(ctx context.Context, repo api.RepoName, target, source string) (err error) {
+	ctx, _, endObservation := c.operations.copyRepositoryFromShard.With(ctx,
+		&err,
+		observation.Args{
+			Attrs: []attribute.KeyValue{
+				repo.Attr(),
+				attribute.String("target", target),
+				attribute.String("source", source),
+			},
+			MetricLabelValues: []string{c.scope},
+		},
+	)
+	defer endObservation(1, observation.Args{})
+
+	// The repo isn't routed to target yet, so we can't use clientForRepo.
+	ac := connection.GlobalConns.GetAddressWithConn(target)
+	if ac == nil {
+		return errors.Newf("unknown gitserver address %q", target)
+	}
+	conn, err := ac.GRPCConn()
+	if err != nil {
+		return err
+	}
+
+	_, err = proto.NewGitserverRepositoryServiceClient(conn).CopyRepositoryFromShard(ctx, &proto.CopyRepositoryFromShardRequest{
+		RepoName:      string(repo),
+		SourceAddress: source,
+	}, defaults.RetryPolicy...)
+	if status.Code(err) == codes.NotFound {
+		return &gitdomain.RepoNotExistError{Repo: repo}
+	}
+	return err
+}
+
 func (c *repositoryServiceClient) DeleteRepository(ctx context.Context, repo api.RepoName) (err error) {
 	ctx, _, endObservation := c.operations.deleteRepository.With(ctx,
 		&err,
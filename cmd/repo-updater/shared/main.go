@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/internal/scheduler"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/batches"
 	"github.com/sourcegraph/sourcegraph/internal/batches/syncer"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
@@ -34,6 +35,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/connection"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/rebalance"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine/recorder"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
@@ -49,6 +52,11 @@ import (
 
 const port = "3182"
 
+var (
+	gitserverRebalanceBatchSize   = env.MustGetInt("SRC_GITSERVER_REBALANCE_BATCH_SIZE", 100, "The number of repositories moved to their new gitserver shard at once when gitserver instances are added or removed.")
+	gitserverRebalanceConcurrency = env.MustGetInt("SRC_GITSERVER_REBALANCE_CONCURRENCY", 10, "The number of repositories copied between gitserver shards concurrently when gitserver instances are added or removed.")
+)
+
 //go:embed state.html.tmpl
 var stateHTMLTemplate string
 
@@ -122,6 +130,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	routines := []goroutine.BackgroundRoutine{
 		makeGRPCServer(logger, server),
 		newUnclonedReposManager(ctx, logger, updateScheduler, store),
+		newGitserverRebalancer(ctx, logger, db),
 		// Run git fetches scheduler
 		updateScheduler,
 	}
@@ -344,6 +353,41 @@ func newUnclonedReposManager(ctx context.Context, logger log.Logger, sched *sche
 	)
 }
 
+// newGitserverRebalancer creates a background routine that moves repositories
+// to their new gitserver shard when gitserver instances are added or removed.
+func newGitserverRebalancer(ctx context.Context, logger log.Logger, db database.DB) goroutine.BackgroundRoutine {
+	client := repogitserver.NewRepositoryServiceClient("repoupdater.rebalancer")
+	r := &rebalance.Rebalancer{
+		Logger: logger.Scoped("gitserverRebalancer"),
+		Store:  rebalance.NewStore(db.Handle()),
+		ListRepos: func(ctx context.Context) ([]api.RepoName, error) {
+			rs, err := db.Repos().ListMinimalRepos(ctx, database.ReposListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			names := make([]api.RepoName, 0, len(rs))
+			for _, r := range rs {
+				names = append(names, r.Name)
+			}
+			return names, nil
+		},
+		CopyRepo:    client.CopyRepositoryFromShard,
+		BatchSize:   gitserverRebalanceBatchSize,
+		Concurrency: gitserverRebalanceConcurrency,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		actor.WithInternalActor(ctx),
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			addrs := connection.NewGitserverAddresses(conf.Get())
+			return r.Run(ctx, addrs.Addresses, addrs.PinnedServers)
+		}),
+		goroutine.WithName("repo-updater.gitserver-rebalancer"),
+		goroutine.WithDescription("moves repositories to their new gitserver shard when gitserver instances are added or removed"),
+		goroutine.WithInterval(time.Minute),
+	)
+}
+
 func mustRegisterMetrics(logger log.Logger, db dbutil.DB) {
 	scanCount := func(sql string) (float64, error) {
 		row := db.QueryRowContext(context.Background(), sql)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 )
 
 // NewFilterFactory creates a filter function that calls gitserver to retrieve the
@@ -46,6 +47,33 @@ func NewFilterFactory(client gitserver.Client) func(ctx context.Context, repo ap
 	}
 }
 
+// fileMetricsFilter keeps the files that satisfy all the file:size and
+// file:lines filters of a search.
+//
+// The size of files whose content we skip, like binary files and large files
+// (see SkipContent), is the size in the archive. We don't know their number
+// of lines, so they never satisfy file:lines filters.
+type fileMetricsFilter []query.FileMetricFilter
+
+// Matches returns true if file, which is a srcFile in zf, satisfies all
+// filters.
+func (f fileMetricsFilter) Matches(zf *zipFile, file *srcFile) bool {
+	size, skipped := zf.Size(file)
+	for _, m := range f {
+		switch m.Metric {
+		case query.FileMetricSize:
+			if !m.Compare(size) {
+				return false
+			}
+		case query.FileMetricLines:
+			if skipped || !m.Compare(query.CountLines(zf.DataFor(file))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func newSearchableFilter(searchLargeFiles []string) *searchableFilter {
 	return &searchableFilter{
 		SearchLargeFiles: searchLargeFiles,
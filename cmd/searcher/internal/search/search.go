@@ -105,6 +105,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 			log.String("commit", string(p.Commit)),
 			log.String("query", p.String()),
 			log.Bool("isStructuralPat", p.IsStructuralPat),
+			log.Bool("isTreeSitterPat", p.IsTreeSitterPat),
 			log.Strings("languages", p.IncludeLangs),
 			log.Bool("isCaseSensitive", p.IsCaseSensitive),
 			log.Bool("patternMatchesContent", p.PatternMatchesContent),
@@ -119,6 +120,15 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		p.FetchTimeout = 500 * time.Millisecond
 	}
 
+	if p.IsTreeSitterPat {
+		_, zf, err := s.getZipFile(ctx, tr, p, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get archive")
+		}
+		defer zf.Close()
+		return treeSitterSearch(ctx, zf, &p.PatternInfo, sender, int32(p.NumContextLines))
+	}
+
 	if p.IsStructuralPat {
 		if p.Indexed {
 			// Execute the new structural search path that directly calls Zoekt.
@@ -147,7 +157,9 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	}
 
 	var paths []string
-	if !s.DisableHybridSearch {
+	// Hybrid search doesn't apply file:size and file:lines filters to the
+	// files it searches with zoekt, so we search all files ourselves.
+	if !s.DisableHybridSearch && len(p.FileMetrics) == 0 {
 		logger := logWithTrace(ctx, s.Logger).Scoped("hybrid").With(
 			log.String("repo", string(p.Repo)),
 			log.String("commit", string(p.Commit)),
@@ -177,7 +189,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	}
 	defer zf.Close()
 
-	return regexSearch(ctx, rm, pm, lm, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, p.NumContextLines)
+	return regexSearch(ctx, rm, pm, lm, p.FileMetrics, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, p.NumContextLines)
 }
 
 func (s *Service) getZipFile(ctx context.Context, tr trace.Trace, p *protocol.Request, paths []string) (string, *zipFile, error) {
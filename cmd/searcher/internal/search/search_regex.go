@@ -35,7 +35,7 @@ func regexSearchBatch(
 
 	ctx, cancel, sender := newLimitedStreamCollector(ctx, p.Limit)
 	defer cancel()
-	err = regexSearch(ctx, m, pm, lm, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, contextLines)
+	err = regexSearch(ctx, m, pm, lm, p.FileMetrics, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, contextLines)
 	return sender.collected, err
 }
 
@@ -58,6 +58,7 @@ func regexSearch(
 	m matchTree,
 	pm *pathMatcher,
 	lm langMatcher,
+	mf fileMetricsFilter,
 	zf *zipFile,
 	patternMatchesContent, patternMatchesPaths bool,
 	isCaseSensitive bool,
@@ -131,6 +132,12 @@ func regexSearch(
 					filesSkipped.Inc()
 					continue
 				}
+
+				// Apply file:size and file:lines filters
+				if !mf.Matches(zf, f) {
+					filesSkipped.Inc()
+					continue
+				}
 				filesSearched.Inc()
 
 				// Check pattern against file path and contents
@@ -16,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
 )
 
@@ -389,7 +390,7 @@ func TestMaxMatches(t *testing.T) {
 
 	ctx, cancel, sender := newLimitedStreamCollector(context.Background(), maxMatches)
 	defer cancel()
-	err = regexSearch(ctx, m, pm, lm, zf, true, false, false, sender, 0)
+	err = regexSearch(ctx, m, pm, lm, nil, zf, true, false, false, sender, 0)
 	fileMatches := sender.collected
 	limitHit := sender.LimitHit()
 
@@ -807,6 +808,63 @@ func TestLangFilters(t *testing.T) {
 	}
 }
 
+func TestFileMetricsFilters(t *testing.T) {
+	zipData, _ := createZip(map[string]string{
+		"empty.txt": "",
+		"short.go":  "package a\n",
+		"long.go":   "package b\n\nfunc b() {\n}\n",
+	})
+	file, _ := mockZipFile(zipData)
+
+	tests := []struct {
+		name      string
+		metrics   []query.FileMetricFilter
+		wantPaths []string
+	}{{
+		name:      "no filters",
+		wantPaths: []string{"empty.txt", "long.go", "short.go"},
+	}, {
+		name:      "lines",
+		metrics:   []query.FileMetricFilter{{Metric: query.FileMetricLines, Op: ">", Value: 1}},
+		wantPaths: []string{"long.go"},
+	}, {
+		name: "size range",
+		metrics: []query.FileMetricFilter{
+			{Metric: query.FileMetricSize, Op: ">", Value: 0},
+			{Metric: query.FileMetricSize, Op: "<=", Value: 10},
+		},
+		wantPaths: []string{"short.go"},
+	}, {
+		name:      "negated",
+		metrics:   []query.FileMetricFilter{{Metric: query.FileMetricSize, Op: "=", Value: 0, Negated: true}},
+		wantPaths: []string{"long.go", "short.go"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &protocol.PatternInfo{
+				Query:                 &protocol.PatternNode{Value: ""},
+				PatternMatchesContent: true,
+				FileMetrics:           tt.metrics,
+				Limit:                 10,
+			}
+
+			fileMatches, err := regexSearchBatch(context.Background(), p, file, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotPaths []string
+			for _, fm := range fileMatches {
+				gotPaths = append(gotPaths, fm.Path)
+			}
+			sort.Strings(gotPaths)
+			if !reflect.DeepEqual(gotPaths, tt.wantPaths) {
+				t.Errorf("got paths %v, want %v", gotPaths, tt.wantPaths)
+			}
+		})
+	}
+}
+
 func Test_locsToRanges(t *testing.T) {
 	cases := []struct {
 		buf    string
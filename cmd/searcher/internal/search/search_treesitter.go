@@ -0,0 +1,308 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/c"
+	"github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/csharp"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/smacker/go-tree-sitter/php"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/scala"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/languages"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// treeSitterGrammars maps the languages detected by lib/codeintel/languages
+// to the tree-sitter grammar used to parse them. Files in other languages are
+// not searched by tree-sitter queries.
+var treeSitterGrammars = map[string]*sitter.Language{
+	"C":          c.GetLanguage(),
+	"C#":         csharp.GetLanguage(),
+	"C++":        cpp.GetLanguage(),
+	"Go":         golang.GetLanguage(),
+	"Java":       java.GetLanguage(),
+	"JavaScript": javascript.GetLanguage(),
+	"Kotlin":     kotlin.GetLanguage(),
+	"PHP":        php.GetLanguage(),
+	"Python":     python.GetLanguage(),
+	"Ruby":       ruby.GetLanguage(),
+	"Rust":       rust.GetLanguage(),
+	"Scala":      scala.GetLanguage(),
+	"TSX":        tsx.GetLanguage(),
+	"TypeScript": typescript.GetLanguage(),
+}
+
+// treeSitterDefaultCapture is the capture added to queries which don't
+// capture any node, so that each match has a range.
+const treeSitterDefaultCapture = "match"
+
+// treeSitterSearch concurrently searches files in zf with the tree-sitter
+// query in p. Every node captured by a query match is a match range. If
+// p.Select is capture.<name>, only the nodes captured as @<name> are.
+//
+// A tree-sitter query names the node types of a grammar, so it usually only
+// compiles for some of the languages we support. Files in other languages are
+// skipped, and the search only fails if the query compiles for none of the
+// languages in zf.
+func treeSitterSearch(
+	ctx context.Context,
+	zf *zipFile,
+	p *protocol.PatternInfo,
+	sender matchSender,
+	contextLines int32,
+) (err error) {
+	tr, ctx := trace.New(ctx, "treeSitterSearch")
+	defer tr.EndWithErr(&err)
+
+	pattern, ok := p.Query.(*protocol.PatternNode)
+	if !ok {
+		return badRequestError{"tree-sitter search expects a single tree-sitter query"}
+	}
+	tr.SetAttributes(attribute.String("query", pattern.Value))
+
+	lm := toLangMatcher(p)
+	pm, err := toPathMatcher(p)
+	if err != nil {
+		return badRequestError{err.Error()}
+	}
+	mf := fileMetricsFilter(p.FileMetrics)
+
+	capture, _ := strings.CutPrefix(p.Select, "capture.")
+	if capture == p.Select {
+		capture = ""
+	}
+	m := newTreeSitterMatcher(pattern.Value, capture)
+	defer m.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		files         = zf.Files
+		lastFileIdx   = atomic.NewInt32(-1)
+		filesSearched atomic.Uint32
+	)
+
+	g, ctx := errgroup.WithContext(ctx)
+	for range numWorkers {
+		g.Go(func() error {
+			parser := sitter.NewParser()
+			defer parser.Close()
+			cursor := sitter.NewQueryCursor()
+			defer cursor.Close()
+
+			for ctx.Err() == nil {
+				idx := int(lastFileIdx.Inc())
+				if idx >= len(files) {
+					return nil
+				}
+				f := &files[idx]
+
+				if !pm.Matches(f.Name) {
+					continue
+				}
+
+				if !mf.Matches(zf, f) {
+					continue
+				}
+				content := zf.DataFor(f)
+				getContent := func() ([]byte, error) { return content, nil }
+				langMatch, _ := lm.Matches(f.Name, getContent)
+				if !langMatch {
+					continue
+				}
+				lang, grammar := treeSitterGrammar(f.Name, getContent)
+				if grammar == nil {
+					continue
+				}
+				q := m.Query(lang, grammar)
+				if q == nil {
+					continue
+				}
+				filesSearched.Inc()
+
+				// find limit+1 matches so we know whether we hit the limit
+				locs, err := m.MatchesFile(ctx, parser, cursor, q, grammar, content, sender.Remaining()+1)
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+					return err
+				}
+				if len(locs) == 0 {
+					continue
+				}
+
+				fm := locsToFileMatch(content, f.Name, locs, contextLines)
+				fm.Language = lang
+				sender.Send(fm)
+			}
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	tr.AddEvent("done", attribute.Int("filesSearched", int(filesSearched.Load())))
+	if err != nil {
+		return err
+	}
+	return m.Err()
+}
+
+// treeSitterGrammar returns the most likely language of path which has a
+// tree-sitter grammar, or a nil grammar if there is none.
+func treeSitterGrammar(path string, getContent func() ([]byte, error)) (string, *sitter.Language) {
+	langs, _ := languages.GetLanguages(path, getContent)
+	for _, lang := range langs {
+		if grammar, ok := treeSitterGrammars[lang]; ok {
+			return lang, grammar
+		}
+	}
+	return "", nil
+}
+
+// treeSitterMatcher compiles a tree-sitter query once per language and runs
+// it against files. It is safe for concurrent use.
+type treeSitterMatcher struct {
+	pattern string
+
+	// capture is the name of the only capture to return. If empty, all
+	// captures are returned.
+	capture string
+
+	mu      sync.Mutex
+	queries map[string]*sitter.Query
+	errs    map[string]error
+}
+
+func newTreeSitterMatcher(pattern, capture string) *treeSitterMatcher {
+	return &treeSitterMatcher{
+		pattern: pattern,
+		capture: capture,
+		queries: map[string]*sitter.Query{},
+		errs:    map[string]error{},
+	}
+}
+
+// Query returns the query compiled for the grammar of lang, or nil if the
+// query is invalid for lang.
+func (m *treeSitterMatcher) Query(lang string, grammar *sitter.Language) *sitter.Query {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if q, ok := m.queries[lang]; ok {
+		return q
+	}
+	if _, ok := m.errs[lang]; ok {
+		return nil
+	}
+
+	q, err := sitter.NewQuery([]byte(m.pattern), grammar)
+	if err == nil && q.CaptureCount() == 0 {
+		q.Close()
+		q, err = sitter.NewQuery([]byte(m.pattern+" @"+treeSitterDefaultCapture), grammar)
+	}
+	if err != nil {
+		m.errs[lang] = errors.Wrapf(err, "invalid tree-sitter query for %s", lang)
+		return nil
+	}
+	m.queries[lang] = q
+	return q
+}
+
+// MatchesFile returns the sorted, non-overlapping byte ranges of the nodes q
+// captures in content. It stops after limit ranges.
+func (m *treeSitterMatcher) MatchesFile(ctx context.Context, parser *sitter.Parser, cursor *sitter.QueryCursor, q *sitter.Query, grammar *sitter.Language, content []byte, limit int) ([][]int, error) {
+	parser.SetLanguage(grammar)
+	tree, err := parser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	var locs [][]int
+	cursor.Exec(q, tree.RootNode())
+	for len(locs) < limit {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+		match = cursor.FilterPredicates(match, content)
+		for _, c := range match.Captures {
+			if m.capture != "" && q.CaptureNameForId(c.Index) != m.capture {
+				continue
+			}
+			locs = append(locs, []int{int(c.Node.StartByte()), int(c.Node.EndByte())})
+		}
+	}
+	return mergeLocs(locs), nil
+}
+
+// Err returns an error if the query did not compile for any language it was
+// tried with.
+func (m *treeSitterMatcher) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.queries) > 0 || len(m.errs) == 0 {
+		return nil
+	}
+	langs := make([]string, 0, len(m.errs))
+	for lang := range m.errs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return badRequestError{m.errs[langs[0]].Error()}
+}
+
+func (m *treeSitterMatcher) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, q := range m.queries {
+		q.Close()
+	}
+}
+
+// mergeLocs sorts locs and merges the ones that overlap, since a node can be
+// captured by several matches and captures can be nested.
+func mergeLocs(locs [][]int) [][]int {
+	if len(locs) == 0 {
+		return nil
+	}
+	sort.Slice(locs, func(i, j int) bool {
+		if locs[i][0] == locs[j][0] {
+			return locs[i][1] > locs[j][1]
+		}
+		return locs[i][0] < locs[j][0]
+	})
+	merged := [][]int{locs[0]}
+	for _, loc := range locs[1:] {
+		last := merged[len(merged)-1]
+		if loc[0] < last[1] {
+			last[1] = max(last[1], loc[1])
+			continue
+		}
+		merged = append(merged, loc)
+	}
+	return merged
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestTreeSitterSearch(t *testing.T) {
+	zipData, err := createZip(map[string]string{
+		"main.go": `package main
+
+func foo() int { return 1 }
+
+func bar() { foo() }
+`,
+		"util.py": `def baz():
+    return 1
+`,
+		"README.md": "func foo() in markdown",
+	})
+	require.NoError(t, err)
+	zf, err := mockZipFile(zipData)
+	require.NoError(t, err)
+
+	search := func(p *protocol.PatternInfo) (map[string][]string, error) {
+		ctx, cancel, sender := newLimitedStreamCollector(context.Background(), 100)
+		defer cancel()
+		err := treeSitterSearch(ctx, zf, p, sender, 0)
+
+		got := map[string][]string{}
+		for _, fm := range sender.collected {
+			for _, cm := range fm.ChunkMatches {
+				got[fm.Path] = append(got[fm.Path], cm.MatchedContent()...)
+			}
+			sort.Strings(got[fm.Path])
+		}
+		return got, err
+	}
+
+	t.Run("captures", func(t *testing.T) {
+		got, err := search(&protocol.PatternInfo{
+			Query: &protocol.PatternNode{Value: `(function_declaration name: (identifier) @name)`},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"main.go": {"bar", "foo"}}, got)
+	})
+
+	t.Run("no captures", func(t *testing.T) {
+		got, err := search(&protocol.PatternInfo{
+			Query: &protocol.PatternNode{Value: `(function_definition)`},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"util.py": {"def baz():\n    return 1"}}, got)
+	})
+
+	t.Run("select capture", func(t *testing.T) {
+		got, err := search(&protocol.PatternInfo{
+			Query:  &protocol.PatternNode{Value: `(call_expression function: (identifier) @fn arguments: (argument_list) @args)`},
+			Select: "capture.fn",
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"main.go": {"foo"}}, got)
+	})
+
+	t.Run("predicates", func(t *testing.T) {
+		got, err := search(&protocol.PatternInfo{
+			Query: &protocol.PatternNode{Value: `((identifier) @id (#eq? @id "baz"))`},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"util.py": {"baz"}}, got)
+	})
+
+	t.Run("language filter", func(t *testing.T) {
+		got, err := search(&protocol.PatternInfo{
+			Query:        &protocol.PatternNode{Value: `(identifier) @id`},
+			IncludeLangs: []string{"Python"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"util.py": {"baz"}}, got)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := search(&protocol.PatternInfo{
+			Query: &protocol.PatternNode{Value: `(not_a_node)`},
+		})
+		require.True(t, errors.HasType[badRequestError](err))
+	})
+}
+
+func TestMergeLocs(t *testing.T) {
+	got := mergeLocs([][]int{{10, 12}, {0, 5}, {2, 3}, {4, 8}, {12, 14}})
+	require.Equal(t, [][]int{{0, 8}, {10, 12}, {12, 14}}, got)
+}
@@ -162,6 +162,9 @@ func (s *Store) PrepareZip(ctx context.Context, repo api.RepoName, commit api.Co
 	h := sha256.New()
 	_, _ = fmt.Fprintf(h, "%q %q", repo, commit)
 	filter.HashKey(h)
+	// Zips written before we stored the size of skipped files lack it, so they
+	// must not be reused.
+	_, _ = io.WriteString(h, "\x00SkippedSizes")
 	_, _ = io.WriteString(h, "\x00Paths")
 	for _, p := range paths {
 		_, _ = h.Write([]byte{0})
@@ -327,35 +330,37 @@ func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) er
 				continue
 			}
 
-			// We are happy with the file, so we can write it to zw.
-			w, err := zw.CreateHeader(&zip.FileHeader{
-				Name:   hdr.Name,
-				Method: zip.Store,
-			})
-			if err != nil {
-				return err
-			}
-
 			// We do not search the content of large files unless they are
 			// allowed.
-			if filter.SkipContent(hdr) {
-				continue
-			}
+			skip := filter.SkipContent(hdr)
 
-			n, err := tr.Read(buf)
-			switch err {
-			case io.EOF:
-				if n == 0 {
-					continue
+			var n int
+			if !skip {
+				n, err = tr.Read(buf)
+				if err != nil && err != io.EOF {
+					return err
 				}
-			case nil:
-			default:
-				return err
+
+				// Heuristic: Assume file is binary if first 256 bytes contain a
+				// 0x00. Best effort, so ignore err. We only search names of binary files.
+				skip = n > 0 && bytes.IndexByte(buf[:n], 0x00) >= 0
 			}
 
-			// Heuristic: Assume file is binary if first 256 bytes contain a
-			// 0x00. Best effort, so ignore err. We only search names of binary files.
-			if n > 0 && bytes.IndexByte(buf[:n], 0x00) >= 0 {
+			// We are happy with the file, so we can write it to zw. We store
+			// the size of files whose content we skip, so that file:size
+			// filters still apply to them.
+			fh := &zip.FileHeader{
+				Name:   hdr.Name,
+				Method: zip.Store,
+			}
+			if skip {
+				fh.Extra = skippedSizeExtra(hdr.Size)
+			}
+			w, err := zw.CreateHeader(fh)
+			if err != nil {
+				return err
+			}
+			if skip || n == 0 {
 				continue
 			}
 
@@ -193,6 +193,75 @@ func TestSearchLargeFiles(t *testing.T) {
 	}
 }
 
+func TestCopySearchable_skippedSizes(t *testing.T) {
+	files := []struct {
+		name    string
+		size    int64
+		content string
+	}{
+		{name: "large", size: maxFileSize + 1},
+		{name: "binary", size: 3, content: "a\x00b"},
+		{name: "text", size: 4, content: "abc\n"},
+	}
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     f.name,
+			Mode:     0o600,
+			Size:     f.size,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		content := []byte(f.content)
+		if f.content == "" {
+			content = make([]byte, f.size)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	if err := copySearchable(tar.NewReader(&tarBuf), zw, newSearchableFilter([]string{})); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zf, err := mockZipFile(zipBuf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range zf.Files {
+		file := &zf.Files[i]
+		size, skipped := zf.Size(file)
+		switch file.Name {
+		case "large":
+			if size != maxFileSize+1 || !skipped {
+				t.Errorf("large: got size %d, skipped %v", size, skipped)
+			}
+		case "binary":
+			if size != 3 || !skipped {
+				t.Errorf("binary: got size %d, skipped %v", size, skipped)
+			}
+		case "text":
+			if size != 4 || skipped {
+				t.Errorf("text: got size %d, skipped %v", size, skipped)
+			}
+		default:
+			t.Fatalf("unexpected file %q", file.Name)
+		}
+	}
+}
+
 func TestSymlink(t *testing.T) {
 	dir := t.TempDir()
 	if err := createSymlinkRepo(dir); err != nil {
@@ -2,6 +2,7 @@ package search
 
 import (
 	"archive/zip"
+	"encoding/binary"
 	"fmt"
 	"hash/fnv"
 	"io"
@@ -98,8 +99,14 @@ type zipFile struct {
 	Files  []srcFile
 	MaxLen int
 	Data   []byte
-	f      *os.File
-	wg     sync.WaitGroup // ensures underlying file is not munmap'd or closed while in use
+
+	// SkippedSizes are the sizes of the files whose content we didn't store,
+	// like large and binary files, by name. Few files are skipped, which is
+	// why their size is not part of srcFile.
+	SkippedSizes map[string]int64
+
+	f  *os.File
+	wg sync.WaitGroup // ensures underlying file is not munmap'd or closed while in use
 }
 
 func readZipFile(path string) (*zipFile, error) {
@@ -149,6 +156,12 @@ func (f *zipFile) PopulateFiles(r *zip.Reader) error {
 		if size > f.MaxLen {
 			f.MaxLen = size
 		}
+		if skippedSize, ok := readSkippedSize(file.Extra); ok {
+			if f.SkippedSizes == nil {
+				f.SkippedSizes = map[string]int64{}
+			}
+			f.SkippedSizes[file.Name] = skippedSize
+		}
 	}
 
 	// We want sequential reads.
@@ -159,6 +172,48 @@ func (f *zipFile) PopulateFiles(r *zip.Reader) error {
 	return nil
 }
 
+// Size returns the size of s, which is a srcFile in f, even if we didn't
+// store its content. skipped is true if we didn't.
+func (f *zipFile) Size(s *srcFile) (size int64, skipped bool) {
+	if size, ok := f.SkippedSizes[s.Name]; ok {
+		return size, true
+	}
+	return int64(s.Len), false
+}
+
+// skippedSizeExtraID is the ID of the zip extra field in which we store the
+// size of files whose content we skip ("SG", not used by any zip extension we
+// know of).
+const skippedSizeExtraID = 0x5347
+
+// skippedSizeExtra returns the zip extra field that stores size as the size of
+// a file whose content we skip.
+func skippedSizeExtra(size int64) []byte {
+	extra := make([]byte, 12)
+	binary.LittleEndian.PutUint16(extra[0:], skippedSizeExtraID)
+	binary.LittleEndian.PutUint16(extra[2:], 8)
+	binary.LittleEndian.PutUint64(extra[4:], uint64(size))
+	return extra
+}
+
+// readSkippedSize reads the size stored by skippedSizeExtra from the extra
+// fields of a zip file header.
+func readSkippedSize(extra []byte) (int64, bool) {
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra[0:])
+		n := int(binary.LittleEndian.Uint16(extra[2:]))
+		extra = extra[4:]
+		if n > len(extra) {
+			return 0, false
+		}
+		if id == skippedSizeExtraID && n == 8 {
+			return int64(binary.LittleEndian.Uint64(extra)), true
+		}
+		extra = extra[n:]
+	}
+	return 0, false
+}
+
 // Close allows resources associated with f to be released.
 // It MUST be called exactly once for every file retrieved using get.
 // Contents from any SrcFile from within f MUST NOT be used after
@@ -73,10 +73,9 @@ func (r *batchSpecWorkspaceCreator) process(
 		return err
 	}
 
-	evaluatableSpec, err := batcheslib.ParseBatchSpec([]byte(spec.RawSpec))
-	if err != nil {
-		return err
-	}
+	// The spec of the batch spec already has the step definitions its steps
+	// use resolved, unlike the raw spec, so we don't parse the raw spec again.
+	evaluatableSpec := spec.Spec
 
 	// Next, we fetch all secrets that are requested by the spec.
 	rk := spec.Spec.RequiredEnvVars()
@@ -205,6 +205,9 @@ type ServiceConnections struct {
 	// cache the list of indexed repository. After TTL is over, new list will
 	// get requested from Zoekt shards.
 	ZoektListTtl *durationpb.Duration `protobuf:"bytes,10,opt,name=zoekt_list_ttl,json=zoektListTtl,proto3" json:"zoekt_list_ttl,omitempty"`
+	// GitServersRebalance is set while repositories are being moved between
+	// gitserver shards after the set of gitserver instances changed.
+	GitServersRebalance *GitServersRebalance `protobuf:"bytes,11,opt,name=git_servers_rebalance,json=gitServersRebalance,proto3" json:"git_servers_rebalance,omitempty"`
 }
 
 func (x *ServiceConnections) Reset() {
@@ -302,6 +305,83 @@ func (x *ServiceConnections) GetZoektListTtl() *durationpb.Duration {
 	return nil
 }
 
+func (x *ServiceConnections) GetGitServersRebalance() *GitServersRebalance {
+	if x != nil {
+		return x.GitServersRebalance
+	}
+	return nil
+}
+
+// GitServersRebalance describes an in-progress move of repositories from one
+// set of gitserver shards to another.
+type GitServersRebalance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Addresses is the set of gitserver addresses repositories are moved to.
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// PreviousAddresses is the set of gitserver addresses repositories are
+	// moved from.
+	PreviousAddresses []string `protobuf:"bytes,2,rep,name=previous_addresses,json=previousAddresses,proto3" json:"previous_addresses,omitempty"`
+	// Cursor is the hash of the first repository which hasn't been moved yet.
+	// Repositories with a lower hash are served by addresses.
+	Cursor uint64 `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *GitServersRebalance) Reset() {
+	*x = GitServersRebalance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internalapi_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GitServersRebalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitServersRebalance) ProtoMessage() {}
+
+func (x *GitServersRebalance) ProtoReflect() protoreflect.Message {
+	mi := &file_internalapi_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitServersRebalance.ProtoReflect.Descriptor instead.
+func (*GitServersRebalance) Descriptor() ([]byte, []int) {
+	return file_internalapi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GitServersRebalance) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *GitServersRebalance) GetPreviousAddresses() []string {
+	if x != nil {
+		return x.PreviousAddresses
+	}
+	return nil
+}
+
+func (x *GitServersRebalance) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
 var File_internalapi_proto protoreflect.FileDescriptor
 
 var file_internalapi_proto_rawDesc = []byte{
@@ -324,7 +404,7 @@ var file_internalapi_proto_rawDesc = []byte{
 	0x0b, 0x32, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
 	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f,
 	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xfa, 0x02,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd7, 0x03,
 	0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
 	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x69, 0x74, 0x53, 0x65,
@@ -347,20 +427,33 @@ var file_internalapi_proto_rawDesc = []byte{
 	0x6f, 0x65, 0x6b, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x0a, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
-	0x7a, 0x6f, 0x65, 0x6b, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x74, 0x6c, 0x4a, 0x04, 0x08, 0x08,
-	0x10, 0x09, 0x52, 0x06, 0x71, 0x64, 0x72, 0x61, 0x6e, 0x74, 0x32, 0x6e, 0x0a, 0x0d, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5d, 0x0a, 0x09, 0x47,
-	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68,
-	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x7a, 0x6f, 0x65, 0x6b, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x74, 0x6c, 0x12, 0x5b, 0x0a, 0x15,
+	0x67, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x69, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x67, 0x69, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4a, 0x04, 0x08, 0x08, 0x10, 0x09, 0x52,
+	0x06, 0x71, 0x64, 0x72, 0x61, 0x6e, 0x74, 0x22, 0x7a, 0x0a, 0x13, 0x47, 0x69, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x12,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x32, 0x6e, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x5d, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03,
+	0x90, 0x02, 0x01, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -375,25 +468,27 @@ func file_internalapi_proto_rawDescGZIP() []byte {
 	return file_internalapi_proto_rawDescData
 }
 
-var file_internalapi_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_internalapi_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_internalapi_proto_goTypes = []interface{}{
 	(*GetConfigRequest)(nil),    // 0: api.internalapi.v1.GetConfigRequest
 	(*GetConfigResponse)(nil),   // 1: api.internalapi.v1.GetConfigResponse
 	(*RawUnified)(nil),          // 2: api.internalapi.v1.RawUnified
 	(*ServiceConnections)(nil),  // 3: api.internalapi.v1.ServiceConnections
-	(*durationpb.Duration)(nil), // 4: google.protobuf.Duration
+	(*GitServersRebalance)(nil), // 4: api.internalapi.v1.GitServersRebalance
+	(*durationpb.Duration)(nil), // 5: google.protobuf.Duration
 }
 var file_internalapi_proto_depIdxs = []int32{
 	2, // 0: api.internalapi.v1.GetConfigResponse.raw_unified:type_name -> api.internalapi.v1.RawUnified
 	3, // 1: api.internalapi.v1.RawUnified.service_connections:type_name -> api.internalapi.v1.ServiceConnections
-	4, // 2: api.internalapi.v1.ServiceConnections.zoekt_list_ttl:type_name -> google.protobuf.Duration
-	0, // 3: api.internalapi.v1.ConfigService.GetConfig:input_type -> api.internalapi.v1.GetConfigRequest
-	1, // 4: api.internalapi.v1.ConfigService.GetConfig:output_type -> api.internalapi.v1.GetConfigResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 2: api.internalapi.v1.ServiceConnections.zoekt_list_ttl:type_name -> google.protobuf.Duration
+	4, // 3: api.internalapi.v1.ServiceConnections.git_servers_rebalance:type_name -> api.internalapi.v1.GitServersRebalance
+	0, // 4: api.internalapi.v1.ConfigService.GetConfig:input_type -> api.internalapi.v1.GetConfigRequest
+	1, // 5: api.internalapi.v1.ConfigService.GetConfig:output_type -> api.internalapi.v1.GetConfigResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_internalapi_proto_init() }
@@ -450,6 +545,18 @@ func file_internalapi_proto_init() {
 				return nil
 			}
 		}
+		file_internalapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitServersRebalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -457,7 +564,7 @@ func file_internalapi_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internalapi_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,9 @@ type MockGerritClient struct {
 	// AbandonChangeFunc is an instance of a mock function object
 	// controlling the behavior of the method AbandonChange.
 	AbandonChangeFunc *GerritClientAbandonChangeFunc
+	// AddReviewerFunc is an instance of a mock function object controlling
+	// the behavior of the method AddReviewer.
+	AddReviewerFunc *GerritClientAddReviewerFunc
 	// AuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method Authenticator.
 	AuthenticatorFunc *GerritClientAuthenticatorFunc
@@ -47,18 +50,27 @@ type MockGerritClient struct {
 	// GetURLFunc is an instance of a mock function object controlling the
 	// behavior of the method GetURL.
 	GetURLFunc *GerritClientGetURLFunc
+	// ListGroupMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method ListGroupMembers.
+	ListGroupMembersFunc *GerritClientListGroupMembersFunc
 	// ListProjectsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListProjects.
 	ListProjectsFunc *GerritClientListProjectsFunc
 	// MoveChangeFunc is an instance of a mock function object controlling
 	// the behavior of the method MoveChange.
 	MoveChangeFunc *GerritClientMoveChangeFunc
+	// RemoveReviewerFunc is an instance of a mock function object
+	// controlling the behavior of the method RemoveReviewer.
+	RemoveReviewerFunc *GerritClientRemoveReviewerFunc
 	// RestoreChangeFunc is an instance of a mock function object
 	// controlling the behavior of the method RestoreChange.
 	RestoreChangeFunc *GerritClientRestoreChangeFunc
 	// SetCommitMessageFunc is an instance of a mock function object
 	// controlling the behavior of the method SetCommitMessage.
 	SetCommitMessageFunc *GerritClientSetCommitMessageFunc
+	// SetHashtagsFunc is an instance of a mock function object controlling
+	// the behavior of the method SetHashtags.
+	SetHashtagsFunc *GerritClientSetHashtagsFunc
 	// SetReadyForReviewFunc is an instance of a mock function object
 	// controlling the behavior of the method SetReadyForReview.
 	SetReadyForReviewFunc *GerritClientSetReadyForReviewFunc
@@ -85,6 +97,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		AddReviewerFunc: &GerritClientAddReviewerFunc{
+			defaultHook: func(context.Context, string, gerrit.AddReviewerPayload) (r0 error) {
+				return
+			},
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: func() (r0 auth.Authenticator) {
 				return
@@ -125,6 +142,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		ListGroupMembersFunc: &GerritClientListGroupMembersFunc{
+			defaultHook: func(context.Context, string) (r0 []gerrit.Account, r1 error) {
+				return
+			},
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: func(context.Context, gerrit.ListProjectsArgs) (r0 gerrit.ListProjectsResponse, r1 bool, r2 error) {
 				return
@@ -135,6 +157,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		RemoveReviewerFunc: &GerritClientRemoveReviewerFunc{
+			defaultHook: func(context.Context, string, string) (r0 error) {
+				return
+			},
+		},
 		RestoreChangeFunc: &GerritClientRestoreChangeFunc{
 			defaultHook: func(context.Context, string) (r0 *gerrit.Change, r1 error) {
 				return
@@ -145,6 +172,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		SetHashtagsFunc: &GerritClientSetHashtagsFunc{
+			defaultHook: func(context.Context, string, gerrit.SetHashtagsPayload) (r0 []string, r1 error) {
+				return
+			},
+		},
 		SetReadyForReviewFunc: &GerritClientSetReadyForReviewFunc{
 			defaultHook: func(context.Context, string) (r0 error) {
 				return
@@ -182,6 +214,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.AbandonChange")
 			},
 		},
+		AddReviewerFunc: &GerritClientAddReviewerFunc{
+			defaultHook: func(context.Context, string, gerrit.AddReviewerPayload) error {
+				panic("unexpected invocation of MockGerritClient.AddReviewer")
+			},
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: func() auth.Authenticator {
 				panic("unexpected invocation of MockGerritClient.Authenticator")
@@ -222,6 +259,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.GetURL")
 			},
 		},
+		ListGroupMembersFunc: &GerritClientListGroupMembersFunc{
+			defaultHook: func(context.Context, string) ([]gerrit.Account, error) {
+				panic("unexpected invocation of MockGerritClient.ListGroupMembers")
+			},
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: func(context.Context, gerrit.ListProjectsArgs) (gerrit.ListProjectsResponse, bool, error) {
 				panic("unexpected invocation of MockGerritClient.ListProjects")
@@ -232,6 +274,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.MoveChange")
 			},
 		},
+		RemoveReviewerFunc: &GerritClientRemoveReviewerFunc{
+			defaultHook: func(context.Context, string, string) error {
+				panic("unexpected invocation of MockGerritClient.RemoveReviewer")
+			},
+		},
 		RestoreChangeFunc: &GerritClientRestoreChangeFunc{
 			defaultHook: func(context.Context, string) (*gerrit.Change, error) {
 				panic("unexpected invocation of MockGerritClient.RestoreChange")
@@ -242,6 +289,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.SetCommitMessage")
 			},
 		},
+		SetHashtagsFunc: &GerritClientSetHashtagsFunc{
+			defaultHook: func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+				panic("unexpected invocation of MockGerritClient.SetHashtags")
+			},
+		},
 		SetReadyForReviewFunc: &GerritClientSetReadyForReviewFunc{
 			defaultHook: func(context.Context, string) error {
 				panic("unexpected invocation of MockGerritClient.SetReadyForReview")
@@ -278,6 +330,9 @@ func NewMockGerritClientFrom(i gerrit.Client) *MockGerritClient {
 		AbandonChangeFunc: &GerritClientAbandonChangeFunc{
 			defaultHook: i.AbandonChange,
 		},
+		AddReviewerFunc: &GerritClientAddReviewerFunc{
+			defaultHook: i.AddReviewer,
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: i.Authenticator,
 		},
@@ -302,18 +357,27 @@ func NewMockGerritClientFrom(i gerrit.Client) *MockGerritClient {
 		GetURLFunc: &GerritClientGetURLFunc{
 			defaultHook: i.GetURL,
 		},
+		ListGroupMembersFunc: &GerritClientListGroupMembersFunc{
+			defaultHook: i.ListGroupMembers,
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: i.ListProjects,
 		},
 		MoveChangeFunc: &GerritClientMoveChangeFunc{
 			defaultHook: i.MoveChange,
 		},
+		RemoveReviewerFunc: &GerritClientRemoveReviewerFunc{
+			defaultHook: i.RemoveReviewer,
+		},
 		RestoreChangeFunc: &GerritClientRestoreChangeFunc{
 			defaultHook: i.RestoreChange,
 		},
 		SetCommitMessageFunc: &GerritClientSetCommitMessageFunc{
 			defaultHook: i.SetCommitMessage,
 		},
+		SetHashtagsFunc: &GerritClientSetHashtagsFunc{
+			defaultHook: i.SetHashtags,
+		},
 		SetReadyForReviewFunc: &GerritClientSetReadyForReviewFunc{
 			defaultHook: i.SetReadyForReview,
 		},
@@ -440,6 +504,114 @@ func (c GerritClientAbandonChangeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GerritClientAddReviewerFunc describes the behavior when the AddReviewer
+// method of the parent MockGerritClient instance is invoked.
+type GerritClientAddReviewerFunc struct {
+	defaultHook func(context.Context, string, gerrit.AddReviewerPayload) error
+	hooks       []func(context.Context, string, gerrit.AddReviewerPayload) error
+	history     []GerritClientAddReviewerFuncCall
+	mutex       sync.Mutex
+}
+
+// AddReviewer delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGerritClient) AddReviewer(v0 context.Context, v1 string, v2 gerrit.AddReviewerPayload) error {
+	r0 := m.AddReviewerFunc.nextHook()(v0, v1, v2)
+	m.AddReviewerFunc.appendCall(GerritClientAddReviewerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddReviewer method
+// of the parent MockGerritClient instance is invoked and the hook queue is
+// empty.
+func (f *GerritClientAddReviewerFunc) SetDefaultHook(hook func(context.Context, string, gerrit.AddReviewerPayload) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddReviewer method of the parent MockGerritClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GerritClientAddReviewerFunc) PushHook(hook func(context.Context, string, gerrit.AddReviewerPayload) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientAddReviewerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, gerrit.AddReviewerPayload) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientAddReviewerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, gerrit.AddReviewerPayload) error {
+		return r0
+	})
+}
+
+func (f *GerritClientAddReviewerFunc) nextHook() func(context.Context, string, gerrit.AddReviewerPayload) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientAddReviewerFunc) appendCall(r0 GerritClientAddReviewerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientAddReviewerFuncCall objects
+// describing the invocations of this function.
+func (f *GerritClientAddReviewerFunc) History() []GerritClientAddReviewerFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientAddReviewerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientAddReviewerFuncCall is an object that describes an invocation
+// of method AddReviewer on an instance of MockGerritClient.
+type GerritClientAddReviewerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 gerrit.AddReviewerPayload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientAddReviewerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientAddReviewerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GerritClientAuthenticatorFunc describes the behavior when the
 // Authenticator method of the parent MockGerritClient instance is invoked.
 type GerritClientAuthenticatorFunc struct {
@@ -1284,6 +1456,115 @@ func (c GerritClientGetURLFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GerritClientListGroupMembersFunc describes the behavior when the
+// ListGroupMembers method of the parent MockGerritClient instance is
+// invoked.
+type GerritClientListGroupMembersFunc struct {
+	defaultHook func(context.Context, string) ([]gerrit.Account, error)
+	hooks       []func(context.Context, string) ([]gerrit.Account, error)
+	history     []GerritClientListGroupMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// ListGroupMembers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGerritClient) ListGroupMembers(v0 context.Context, v1 string) ([]gerrit.Account, error) {
+	r0, r1 := m.ListGroupMembersFunc.nextHook()(v0, v1)
+	m.ListGroupMembersFunc.appendCall(GerritClientListGroupMembersFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListGroupMembers
+// method of the parent MockGerritClient instance is invoked and the hook
+// queue is empty.
+func (f *GerritClientListGroupMembersFunc) SetDefaultHook(hook func(context.Context, string) ([]gerrit.Account, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListGroupMembers method of the parent MockGerritClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GerritClientListGroupMembersFunc) PushHook(hook func(context.Context, string) ([]gerrit.Account, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientListGroupMembersFunc) SetDefaultReturn(r0 []gerrit.Account, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]gerrit.Account, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientListGroupMembersFunc) PushReturn(r0 []gerrit.Account, r1 error) {
+	f.PushHook(func(context.Context, string) ([]gerrit.Account, error) {
+		return r0, r1
+	})
+}
+
+func (f *GerritClientListGroupMembersFunc) nextHook() func(context.Context, string) ([]gerrit.Account, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientListGroupMembersFunc) appendCall(r0 GerritClientListGroupMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientListGroupMembersFuncCall
+// objects describing the invocations of this function.
+func (f *GerritClientListGroupMembersFunc) History() []GerritClientListGroupMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientListGroupMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientListGroupMembersFuncCall is an object that describes an
+// invocation of method ListGroupMembers on an instance of MockGerritClient.
+type GerritClientListGroupMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gerrit.Account
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientListGroupMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientListGroupMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GerritClientListProjectsFunc describes the behavior when the ListProjects
 // method of the parent MockGerritClient instance is invoked.
 type GerritClientListProjectsFunc struct {
@@ -1506,6 +1787,114 @@ func (c GerritClientMoveChangeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GerritClientRemoveReviewerFunc describes the behavior when the
+// RemoveReviewer method of the parent MockGerritClient instance is invoked.
+type GerritClientRemoveReviewerFunc struct {
+	defaultHook func(context.Context, string, string) error
+	hooks       []func(context.Context, string, string) error
+	history     []GerritClientRemoveReviewerFuncCall
+	mutex       sync.Mutex
+}
+
+// RemoveReviewer delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGerritClient) RemoveReviewer(v0 context.Context, v1 string, v2 string) error {
+	r0 := m.RemoveReviewerFunc.nextHook()(v0, v1, v2)
+	m.RemoveReviewerFunc.appendCall(GerritClientRemoveReviewerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RemoveReviewer
+// method of the parent MockGerritClient instance is invoked and the hook
+// queue is empty.
+func (f *GerritClientRemoveReviewerFunc) SetDefaultHook(hook func(context.Context, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RemoveReviewer method of the parent MockGerritClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GerritClientRemoveReviewerFunc) PushHook(hook func(context.Context, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientRemoveReviewerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientRemoveReviewerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+func (f *GerritClientRemoveReviewerFunc) nextHook() func(context.Context, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientRemoveReviewerFunc) appendCall(r0 GerritClientRemoveReviewerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientRemoveReviewerFuncCall objects
+// describing the invocations of this function.
+func (f *GerritClientRemoveReviewerFunc) History() []GerritClientRemoveReviewerFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientRemoveReviewerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientRemoveReviewerFuncCall is an object that describes an
+// invocation of method RemoveReviewer on an instance of MockGerritClient.
+type GerritClientRemoveReviewerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientRemoveReviewerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientRemoveReviewerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GerritClientRestoreChangeFunc describes the behavior when the
 // RestoreChange method of the parent MockGerritClient instance is invoked.
 type GerritClientRestoreChangeFunc struct {
@@ -1723,6 +2112,117 @@ func (c GerritClientSetCommitMessageFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GerritClientSetHashtagsFunc describes the behavior when the SetHashtags
+// method of the parent MockGerritClient instance is invoked.
+type GerritClientSetHashtagsFunc struct {
+	defaultHook func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)
+	hooks       []func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)
+	history     []GerritClientSetHashtagsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetHashtags delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGerritClient) SetHashtags(v0 context.Context, v1 string, v2 gerrit.SetHashtagsPayload) ([]string, error) {
+	r0, r1 := m.SetHashtagsFunc.nextHook()(v0, v1, v2)
+	m.SetHashtagsFunc.appendCall(GerritClientSetHashtagsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SetHashtags method
+// of the parent MockGerritClient instance is invoked and the hook queue is
+// empty.
+func (f *GerritClientSetHashtagsFunc) SetDefaultHook(hook func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetHashtags method of the parent MockGerritClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GerritClientSetHashtagsFunc) PushHook(hook func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientSetHashtagsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientSetHashtagsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *GerritClientSetHashtagsFunc) nextHook() func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientSetHashtagsFunc) appendCall(r0 GerritClientSetHashtagsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientSetHashtagsFuncCall objects
+// describing the invocations of this function.
+func (f *GerritClientSetHashtagsFunc) History() []GerritClientSetHashtagsFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientSetHashtagsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientSetHashtagsFuncCall is an object that describes an invocation
+// of method SetHashtags on an instance of MockGerritClient.
+type GerritClientSetHashtagsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 gerrit.SetHashtagsPayload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientSetHashtagsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientSetHashtagsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GerritClientSetReadyForReviewFunc describes the behavior when the
 // SetReadyForReview method of the parent MockGerritClient instance is
 // invoked.
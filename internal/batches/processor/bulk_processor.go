@@ -179,6 +179,7 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		TargetRepo: b.repo,
 		RemoteRepo: remoteRepo,
 	}
+	wasMerged := cs.Changeset.ExternalState == btypes.ChangesetExternalStateMerged
 	if err := b.css.MergeChangeset(ctx, cs, typedPayload.Squash); err != nil {
 		return nil, err
 	}
@@ -200,6 +201,15 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	// Changesets that depend on this one may be published now that it's been
+	// merged.
+	if !wasMerged && cs.Changeset.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := b.tx.EnqueueChangesetDependents(ctx, cs.Changeset.ID); err != nil {
+			b.logger.Error("EnqueueChangesetDependents", log.Error(err))
+			return nil, errcode.MakeNonRetryable(err)
+		}
+	}
+
 	afterDone = func(s *store.Store) { b.enqueueWebhook(ctx, s, webhooks.ChangesetClose) }
 	return afterDone, nil
 }
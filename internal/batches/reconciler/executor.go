@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"text/template"
@@ -39,6 +40,7 @@ func executePlan(ctx context.Context, logger log.Logger, client gitserver.Client
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		prevSpec:          plan.PreviousChangesetSpec,
 	}
 
 	return e.Run(ctx, plan)
@@ -52,6 +54,7 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	prevSpec          *btypes.ChangesetSpec
 
 	// targetRepo represents the repo where the changeset should be opened.
 	targetRepo *types.Repo
@@ -271,6 +274,7 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (afterDon
 		TargetRepo: e.targetRepo,
 		Changeset:  e.ch,
 	}
+	e.setAttributes(cs)
 
 	var exists, outdated bool
 	if asDraft {
@@ -375,6 +379,39 @@ func (e *executor) loadChangeset(ctx context.Context) error {
 	return css.LoadChangeset(ctx, repoChangeset)
 }
 
+// setAttributes sets the reviewers, labels, assignees and milestone of the
+// changeset spec on cs, together with the ones the previous changeset spec
+// applied that the current one doesn't, so that the source removes them again.
+func (e *executor) setAttributes(cs *sources.Changeset) {
+	cs.Reviewers = e.spec.Reviewers
+	cs.TeamReviewers = e.spec.TeamReviewers
+	cs.Labels = e.spec.Labels
+	cs.Assignees = e.spec.Assignees
+	cs.Milestone = e.spec.Milestone
+
+	if e.prevSpec == nil {
+		return
+	}
+	cs.RemovedReviewers = removedValues(e.prevSpec.Reviewers, e.spec.Reviewers)
+	cs.RemovedTeamReviewers = removedValues(e.prevSpec.TeamReviewers, e.spec.TeamReviewers)
+	cs.RemovedLabels = removedValues(e.prevSpec.Labels, e.spec.Labels)
+	cs.RemovedAssignees = removedValues(e.prevSpec.Assignees, e.spec.Assignees)
+	if e.spec.Milestone == "" {
+		cs.RemovedMilestone = e.prevSpec.Milestone
+	}
+}
+
+// removedValues returns the values in previous that are not in current.
+func removedValues(previous, current []string) []string {
+	var removed []string
+	for _, v := range previous {
+		if !slices.Contains(current, v) {
+			removed = append(removed, v)
+		}
+	}
+	return removed
+}
+
 // updateChangeset updates the given changeset's attribute on the code host
 // according to its ChangesetSpec and the delta previously computed.
 func (e *executor) updateChangeset(ctx context.Context) (afterDone func(store *store.Store), err error) {
@@ -407,6 +444,7 @@ func (e *executor) updateChangeset(ctx context.Context) (afterDone func(store *s
 		TargetRepo: e.targetRepo,
 		Changeset:  e.ch,
 	}
+	e.setAttributes(&cs)
 
 	if err := css.UpdateChangeset(ctx, &cs); err != nil {
 		if errcode.IsArchived(err) {
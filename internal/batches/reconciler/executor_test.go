@@ -1254,6 +1254,45 @@ func TestDecorateChangesetBody(t *testing.T) {
 	}
 }
 
+func TestExecutor_SetAttributes(t *testing.T) {
+	prev := &btypes.ChangesetSpec{
+		Reviewers:     []string{"alice", "bob"},
+		TeamReviewers: []string{"frontend"},
+		Labels:        []string{"keep", "drop"},
+		Assignees:     []string{"carol"},
+		Milestone:     "v1",
+	}
+	spec := &btypes.ChangesetSpec{
+		Reviewers: []string{"bob", "dave"},
+		Labels:    []string{"keep"},
+	}
+
+	t.Run("without previous spec", func(t *testing.T) {
+		e := &executor{spec: spec}
+		var cs sources.Changeset
+		e.setAttributes(&cs)
+		assert.Equal(t, sources.Changeset{
+			Reviewers: []string{"bob", "dave"},
+			Labels:    []string{"keep"},
+		}, cs)
+	})
+
+	t.Run("with previous spec", func(t *testing.T) {
+		e := &executor{spec: spec, prevSpec: prev}
+		var cs sources.Changeset
+		e.setAttributes(&cs)
+		assert.Equal(t, sources.Changeset{
+			Reviewers:            []string{"bob", "dave"},
+			Labels:               []string{"keep"},
+			RemovedReviewers:     []string{"alice"},
+			RemovedTeamReviewers: []string{"frontend"},
+			RemovedLabels:        []string{"drop"},
+			RemovedAssignees:     []string{"carol"},
+			RemovedMilestone:     "v1",
+		}, cs)
+	})
+}
+
 func TestHandleArchivedRepo(t *testing.T) {
 	ctx := context.Background()
 
@@ -3,6 +3,7 @@ package reconciler
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -101,6 +102,10 @@ type Plan struct {
 	// The changeset spec that is used in this plan.
 	ChangesetSpec *btypes.ChangesetSpec
 
+	// The changeset spec that was applied to the changeset before
+	// ChangesetSpec, if any.
+	PreviousChangesetSpec *btypes.ChangesetSpec
+
 	// The operations that need to be done to reconcile the changeset.
 	Ops Operations
 
@@ -112,6 +117,27 @@ type Plan struct {
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
 func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 
+// HoldPublication removes the operations from the plan that would publish the
+// changeset or take it out of draft, so that it stays unpublished or in draft
+// until the changesets it depends on have been merged. Publishing as a draft
+// is still allowed.
+func (p *Plan) HoldPublication() {
+	if p.Ops.Contains(btypes.ReconcilerOperationPublish) {
+		// Publishing is planned together with pushing the commit, which
+		// we can also hold off on.
+		p.Ops = Operations{}
+		return
+	}
+
+	ops := make(Operations, 0, len(p.Ops))
+	for _, op := range p.Ops {
+		if op != btypes.ReconcilerOperationUndraft {
+			ops = append(ops, op)
+		}
+	}
+	p.Ops = ops
+}
+
 // DeterminePlan looks at the given changeset to determine what action the
 // reconciler should take.
 // It consumes the current and the previous changeset spec, if they exist. If
@@ -119,8 +145,9 @@ func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 // error.
 func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChangeset, wantedChangeset *btypes.Changeset) (*Plan, error) {
 	pl := &Plan{
-		Changeset:     wantedChangeset,
-		ChangesetSpec: currentSpec,
+		Changeset:             wantedChangeset,
+		ChangesetSpec:         currentSpec,
+		PreviousChangesetSpec: previousSpec,
 	}
 
 	wantDetach := false
@@ -299,6 +326,18 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	if previous.BaseRef != current.BaseRef {
 		delta.BaseRefChanged = true
 	}
+	if !slices.Equal(previous.Reviewers, current.Reviewers) || !slices.Equal(previous.TeamReviewers, current.TeamReviewers) {
+		delta.ReviewersChanged = true
+	}
+	if !slices.Equal(previous.Labels, current.Labels) {
+		delta.LabelsChanged = true
+	}
+	if !slices.Equal(previous.Assignees, current.Assignees) {
+		delta.AssigneesChanged = true
+	}
+	if previous.Milestone != current.Milestone {
+		delta.MilestoneChanged = true
+	}
 
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
@@ -344,6 +383,10 @@ type ChangesetSpecDelta struct {
 	BodyChanged          bool
 	Undraft              bool
 	BaseRefChanged       bool
+	ReviewersChanged     bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
+	MilestoneChanged     bool
 	DiffChanged          bool
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
@@ -357,7 +400,8 @@ func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
-	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
+	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged ||
+		d.ReviewersChanged || d.LabelsChanged || d.AssigneesChanged || d.MilestoneChanged
 }
 
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
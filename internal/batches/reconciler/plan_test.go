@@ -219,6 +219,42 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			},
 			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
 		},
+		{
+			name:         "labels changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"before"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"before", "after"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "team reviewers changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true},
+			currentSpec:  &bt.TestSpecOpts{Published: true, TeamReviewers: []string{"frontend"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "reviewers removed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "milestone changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Milestone: "v1"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Milestone: "v2"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
 		{
 			name:         "title changed on read-only changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, Title: "Before"},
@@ -460,3 +496,33 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 		})
 	}
 }
+
+func TestPlan_HoldPublication(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		ops  Operations
+		want Operations
+	}{
+		"publish": {
+			ops:  Operations{btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPush},
+			want: Operations{},
+		},
+		"publish draft": {
+			ops:  Operations{btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush},
+			want: Operations{btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush},
+		},
+		"undraft and update": {
+			ops:  Operations{btypes.ReconcilerOperationUndraft, btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+			want: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			plan := &Plan{Ops: tc.ops}
+			plan.HoldPublication()
+			if have, want := plan.Ops, tc.want; !have.Equal(want) {
+				t.Fatalf("incorrect plan, want=%v have=%v", want, have)
+			}
+		})
+	}
+}
@@ -97,6 +97,29 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return nil, err
 	}
 
+	// Changesets that depend on other changesets of the batch change are only
+	// published once those have been merged. We record that the changeset is
+	// held, so that it's enqueued again when they are.
+	held := false
+	if plan.Ops.Contains(btypes.ReconcilerOperationPublish) || plan.Ops.Contains(btypes.ReconcilerOperationUndraft) {
+		deps, err := tx.GetChangesetDependencyState(ctx, ch.ID)
+		if err != nil {
+			return nil, err
+		}
+		if deps.Unmerged > 0 {
+			logger.Info("Holding publication of changeset until its dependencies are merged",
+				log.Int64("changeset", ch.ID),
+				log.Int("unmerged", deps.Unmerged),
+				log.Int("abandoned", deps.Abandoned),
+			)
+			plan.HoldPublication()
+			held = true
+		}
+	}
+	if err := tx.SetChangesetDependenciesHeld(ctx, ch.ID, held); err != nil {
+		return nil, err
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// updateChangesetDependencies records which of the given changesets of the
+// batch change must be merged before which others are published, as declared
+// by the dependsOn fields of the on entries of the batch spec.
+func updateChangesetDependencies(ctx context.Context, tx *store.Store, batchSpec *btypes.BatchSpec, batchChangeID int64, changesets []*btypes.Changeset) error {
+	var deps []*btypes.ChangesetDependency
+	if batchSpec.Spec != nil && hasDependencies(batchSpec.Spec.On) {
+		wr := newWorkspaceResolver(tx)
+		r := &dependencyRepoResolver{
+			reposOn: func(on *batcheslib.OnQueryOrRepository) ([]*types.Repo, error) {
+				if on.Repository != "" {
+					repo, err := tx.Repos().GetByName(ctx, api.RepoName(on.Repository))
+					if err != nil {
+						return nil, err
+					}
+					return []*types.Repo{repo}, nil
+				}
+				revs, err := wr.resolveRepositoriesMatchingQuery(ctx, on.RepositoriesMatchingQuery, batchSpec.Spec.Version)
+				if err != nil {
+					return nil, err
+				}
+				repos := make([]*types.Repo, 0, len(revs))
+				for _, rev := range revs {
+					repos = append(repos, rev.Repo)
+				}
+				return repos, nil
+			},
+			repoByName: func(name string) (*types.Repo, error) {
+				return tx.Repos().GetByName(ctx, api.RepoName(name))
+			},
+		}
+
+		var err error
+		deps, err = changesetDependencies(batchSpec.Spec.On, batchChangeID, changesets, r)
+		if err != nil {
+			return batcheslib.NewValidationError(err)
+		}
+	}
+
+	return tx.ReplaceChangesetDependencies(ctx, batchChangeID, deps)
+}
+
+func hasDependencies(on []batcheslib.OnQueryOrRepository) bool {
+	for _, o := range on {
+		if len(o.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencyRepoResolver resolves the repositories that the on entries and
+// dependsOn fields of a batch spec refer to.
+type dependencyRepoResolver struct {
+	reposOn    func(on *batcheslib.OnQueryOrRepository) ([]*types.Repo, error)
+	repoByName func(name string) (*types.Repo, error)
+}
+
+// changesetDependencies computes the dependencies between the changesets of
+// the batch change. A changeset depends on all other changesets that are owned
+// by the batch change and in a repository that a dependsOn field of an on
+// entry including the changeset's repository refers to, except for those in
+// its own repository.
+func changesetDependencies(on []batcheslib.OnQueryOrRepository, batchChangeID int64, changesets []*btypes.Changeset, r *dependencyRepoResolver) ([]*btypes.ChangesetDependency, error) {
+	entries := make(map[string]int, len(on))
+	for i, o := range on {
+		if o.Name != "" {
+			entries[o.Name] = i
+		}
+	}
+
+	// Entries are resolved lazily, as resolving queries runs a search.
+	resolved := make(map[int][]*types.Repo)
+	reposOn := func(i int) ([]*types.Repo, error) {
+		if repos, ok := resolved[i]; ok {
+			return repos, nil
+		}
+		repos, err := r.reposOn(&on[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving repositories of %s", on[i].String())
+		}
+		resolved[i] = repos
+		return repos, nil
+	}
+
+	changesetsByRepo := make(map[api.RepoID][]*btypes.Changeset)
+	for _, c := range changesets {
+		if ownedAndAttached(c, batchChangeID) {
+			changesetsByRepo[c.RepoID] = append(changesetsByRepo[c.RepoID], c)
+		}
+	}
+
+	type edge struct{ from, to int64 }
+	seen := make(map[edge]struct{})
+	var deps []*btypes.ChangesetDependency
+	for i, o := range on {
+		if len(o.DependsOn) == 0 {
+			continue
+		}
+
+		var prerequisites []*types.Repo
+		for _, dep := range o.DependsOn {
+			if j, ok := entries[dep]; ok {
+				repos, err := reposOn(j)
+				if err != nil {
+					return nil, err
+				}
+				prerequisites = append(prerequisites, repos...)
+				continue
+			}
+
+			repo, err := r.repoByName(dep)
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolving dependency %q of %s", dep, o.String())
+			}
+			prerequisites = append(prerequisites, repo)
+		}
+
+		dependents, err := reposOn(i)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range dependents {
+			for _, c := range changesetsByRepo[repo.ID] {
+				for _, prerequisite := range prerequisites {
+					if prerequisite.ID == repo.ID {
+						continue
+					}
+					for _, p := range changesetsByRepo[prerequisite.ID] {
+						e := edge{from: c.ID, to: p.ID}
+						if _, ok := seen[e]; ok {
+							continue
+						}
+						seen[e] = struct{}{}
+						deps = append(deps, &btypes.ChangesetDependency{
+							ChangesetID:          c.ID,
+							DependsOnChangesetID: p.ID,
+							BatchChangeID:        batchChangeID,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	if err := checkDependencyCycles(deps, changesets, resolved); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// ownedAndAttached returns true if the changeset is owned by the batch change
+// and is neither being detached from nor archived in it.
+func ownedAndAttached(c *btypes.Changeset, batchChangeID int64) bool {
+	if c.OwnedByBatchChangeID != batchChangeID {
+		return false
+	}
+	for _, assoc := range c.BatchChanges {
+		if assoc.BatchChangeID == batchChangeID {
+			return !assoc.Detach && !assoc.Archive && !assoc.IsArchived
+		}
+	}
+	return false
+}
+
+// checkDependencyCycles returns an error if the dependencies form a cycle, in
+// which case none of the changesets in it could ever be published.
+func checkDependencyCycles(deps []*btypes.ChangesetDependency, changesets []*btypes.Changeset, resolved map[int][]*types.Repo) error {
+	edges := make(map[int64][]int64)
+	for _, d := range deps {
+		edges[d.ChangesetID] = append(edges[d.ChangesetID], d.DependsOnChangesetID)
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[int64]int)
+	var visit func(id int64, path []int64) error
+	visit = func(id int64, path []int64) error {
+		switch state[id] {
+		case visiting:
+			return errors.Newf("the dependencies in on form a cycle: %s", describeCycle(append(path[slices.Index(path, id):], id), changesets, resolved))
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		for _, next := range edges[id] {
+			if err := visit(next, append(path, id)); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+	for _, d := range deps {
+		if err := visit(d.ChangesetID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describeCycle describes a cycle of changesets by their repositories, all of
+// which have been resolved as they include dependent changesets.
+func describeCycle(cycle []int64, changesets []*btypes.Changeset, resolved map[int][]*types.Repo) string {
+	names := make(map[api.RepoID]api.RepoName)
+	for _, repos := range resolved {
+		for _, repo := range repos {
+			names[repo.ID] = repo.Name
+		}
+	}
+	repos := make(map[int64]api.RepoID, len(changesets))
+	for _, c := range changesets {
+		repos[c.ID] = c.RepoID
+	}
+
+	parts := make([]string, 0, len(cycle))
+	for _, id := range cycle {
+		parts = append(parts, string(names[repos[id]]))
+	}
+	return strings.Join(parts, " -> ")
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestChangesetDependencies(t *testing.T) {
+	const batchChangeID = 1
+
+	lib := &types.Repo{ID: 1, Name: "github.com/foo/lib"}
+	consumer := &types.Repo{ID: 2, Name: "github.com/foo/consumer"}
+	app := &types.Repo{ID: 3, Name: "github.com/foo/app"}
+	repos := []*types.Repo{lib, consumer, app}
+
+	repoByName := func(name string) (*types.Repo, error) {
+		for _, repo := range repos {
+			if string(repo.Name) == name {
+				return repo, nil
+			}
+		}
+		return nil, errors.New("not found")
+	}
+	// The query matches all repositories, including the library itself.
+	queried := 0
+	r := &dependencyRepoResolver{
+		reposOn: func(on *batcheslib.OnQueryOrRepository) ([]*types.Repo, error) {
+			if on.RepositoriesMatchingQuery != "" {
+				queried++
+				return repos, nil
+			}
+			repo, err := repoByName(on.Repository)
+			return []*types.Repo{repo}, err
+		},
+		repoByName: repoByName,
+	}
+
+	changeset := func(id int64, repo *types.Repo) *btypes.Changeset {
+		return &btypes.Changeset{
+			ID:                   id,
+			RepoID:               repo.ID,
+			OwnedByBatchChangeID: batchChangeID,
+			BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: batchChangeID}},
+		}
+	}
+	libChangeset := changeset(10, lib)
+	consumerChangeset := changeset(20, consumer)
+	otherConsumerChangeset := changeset(21, consumer)
+	appChangeset := changeset(30, app)
+	changesets := []*btypes.Changeset{libChangeset, consumerChangeset, otherConsumerChangeset, appChangeset}
+
+	dependency := func(from, to *btypes.Changeset) *btypes.ChangesetDependency {
+		return &btypes.ChangesetDependency{ChangesetID: from.ID, DependsOnChangesetID: to.ID, BatchChangeID: batchChangeID}
+	}
+
+	t.Run("entries and repositories", func(t *testing.T) {
+		queried = 0
+		on := []batcheslib.OnQueryOrRepository{
+			{Repository: "github.com/foo/lib", Name: "lib"},
+			{RepositoriesMatchingQuery: "lang:go", DependsOn: []string{"lib"}},
+			{Repository: "github.com/foo/app", DependsOn: []string{"github.com/foo/consumer"}},
+		}
+
+		deps, err := changesetDependencies(on, batchChangeID, changesets, r)
+		require.NoError(t, err)
+		assert.Equal(t, []*btypes.ChangesetDependency{
+			dependency(consumerChangeset, libChangeset),
+			dependency(otherConsumerChangeset, libChangeset),
+			dependency(appChangeset, libChangeset),
+			dependency(appChangeset, consumerChangeset),
+			dependency(appChangeset, otherConsumerChangeset),
+		}, deps)
+		assert.Equal(t, 1, queried)
+	})
+
+	t.Run("detached changesets", func(t *testing.T) {
+		detached := changeset(11, lib)
+		detached.BatchChanges[0].Detach = true
+		on := []batcheslib.OnQueryOrRepository{
+			{Repository: "github.com/foo/consumer", DependsOn: []string{"github.com/foo/lib"}},
+		}
+
+		deps, err := changesetDependencies(on, batchChangeID, []*btypes.Changeset{detached, consumerChangeset}, r)
+		require.NoError(t, err)
+		assert.Empty(t, deps)
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		on := []batcheslib.OnQueryOrRepository{
+			{Repository: "github.com/foo/app", DependsOn: []string{"github.com/foo/missing"}},
+		}
+
+		_, err := changesetDependencies(on, batchChangeID, changesets, r)
+		assert.ErrorContains(t, err, `resolving dependency "github.com/foo/missing" of repository:github.com/foo/app`)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		on := []batcheslib.OnQueryOrRepository{
+			{Repository: "github.com/foo/lib", DependsOn: []string{"github.com/foo/app"}},
+			{Repository: "github.com/foo/app", DependsOn: []string{"github.com/foo/lib"}},
+		}
+
+		_, err := changesetDependencies(on, batchChangeID, changesets, r)
+		assert.EqualError(t, err, "the dependencies in on form a cycle: github.com/foo/lib -> github.com/foo/app -> github.com/foo/lib")
+	})
+}
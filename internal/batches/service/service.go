@@ -102,6 +102,8 @@ type operations struct {
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
+	createBatchStepDefinition            *observation.Operation
+	parseBatchSpec                       *observation.Operation
 }
 
 var (
@@ -152,6 +154,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
+			createBatchStepDefinition:            op("CreateBatchStepDefinition"),
+			parseBatchSpec:                       op("ParseBatchSpec"),
 		}
 	})
 
@@ -396,7 +400,7 @@ func (s *Service) CreateBatchSpec(ctx context.Context, opts CreateBatchSpecOpts)
 
 	// TODO move license check logic from resolver to here
 
-	spec, err = btypes.NewBatchSpecFromRaw(opts.RawSpec)
+	spec, err = s.newBatchSpecFromRaw(ctx, opts.RawSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -487,7 +491,7 @@ func (s *Service) CreateBatchSpecFromRaw(ctx context.Context, opts CreateBatchSp
 	}})
 	defer endObservation(1, observation.Args{})
 
-	spec, err = btypes.NewBatchSpecFromRaw(opts.RawSpec)
+	spec, err = s.newBatchSpecFromRaw(ctx, opts.RawSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -725,7 +729,7 @@ func (s *Service) ReplaceBatchSpecInput(ctx context.Context, opts ReplaceBatchSp
 	defer endObservation(1, observation.Args{})
 
 	// Before we hit the database, validate the new spec.
-	newSpec, err := btypes.NewBatchSpecFromRaw(opts.RawSpec)
+	newSpec, err := s.newBatchSpecFromRaw(ctx, opts.RawSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -778,7 +782,7 @@ func (s *Service) UpsertBatchSpecInput(ctx context.Context, opts UpsertBatchSpec
 	}})
 	defer endObservation(1, observation.Args{})
 
-	spec, err = btypes.NewBatchSpecFromRaw(opts.RawSpec)
+	spec, err = s.newBatchSpecFromRaw(ctx, opts.RawSpec)
 	if err != nil {
 		return nil, errors.Wrap(err, "parsing batch spec")
 	}
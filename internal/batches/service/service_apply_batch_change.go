@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	bgql "github.com/sourcegraph/sourcegraph/internal/batches/graphql"
@@ -193,6 +194,12 @@ func (s *Service) ApplyBatchChange(
 		}
 	}
 
+	// The changesets have IDs now, so we can record which of them must be
+	// merged before others are published.
+	if err := updateChangesetDependencies(ctx, tx, batchSpec, batchChange.ID, slices.Concat(newChangesets, updatedChangesets)); err != nil {
+		return nil, err
+	}
+
 	return batchChange, nil
 }
 
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type CreateBatchStepDefinitionOpts struct {
+	RawDefinition string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CreateBatchStepDefinition publishes a new version of a step definition in
+// the given namespace.
+func (s *Service) CreateBatchStepDefinition(ctx context.Context, opts CreateBatchStepDefinitionOpts) (def *btypes.BatchStepDefinition, err error) {
+	ctx, _, endObservation := s.operations.createBatchStepDefinition.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: Only users with access to the namespace may publish step
+	// definitions in it.
+	if err := s.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	stepDef, err := batcheslib.ParseStepDefinition([]byte(opts.RawDefinition))
+	if err != nil {
+		return nil, err
+	}
+
+	def = &btypes.BatchStepDefinition{
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+		Name:            stepDef.Name,
+		Version:         stepDef.Version,
+		RawDefinition:   opts.RawDefinition,
+		Definition:      stepDef,
+		// Actor is guaranteed to be set here, because CheckNamespaceAccess
+		// above enforces it.
+		CreatorID: sgactor.FromContext(ctx).UID,
+	}
+	return def, s.store.CreateBatchStepDefinition(ctx, def)
+}
+
+// ParseBatchSpec parses the raw batch spec and replaces the steps that use a
+// step definition with the steps that run the step definition. The result is
+// the canonical spec that is stored with the batch spec and executed, so step
+// definitions are resolved exactly once, when the batch spec is created.
+//
+// Only step definitions in namespaces the current user has access to can be
+// used. Those in other namespaces are reported as not existing.
+func (s *Service) ParseBatchSpec(ctx context.Context, rawSpec string) (spec *batcheslib.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.parseBatchSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	spec, err = batcheslib.ParseBatchSpec([]byte(rawSpec))
+	if err != nil {
+		return spec, err
+	}
+
+	return spec, resolveStepDefinitions(spec, func(ref batcheslib.StepReference) (*batcheslib.StepDefinition, error) {
+		ns, err := s.store.DatabaseDB().Namespaces().GetByName(ctx, ref.Namespace)
+		if err != nil {
+			if err == database.ErrNamespaceNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		// 🚨 SECURITY: Only users with access to the namespace may use its
+		// step definitions. We don't reveal whether they exist otherwise.
+		if err := s.CheckNamespaceAccess(ctx, ns.User, ns.Organization); err != nil {
+			if errors.IsAny(err, auth.ErrNotAuthenticated, auth.ErrNotAnOrgMember, auth.ErrMustBeSiteAdminOrSameUser) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		def, err := s.store.GetBatchStepDefinition(ctx, store.GetBatchStepDefinitionOpts{
+			NamespaceUserID: ns.User,
+			NamespaceOrgID:  ns.Organization,
+			Name:            ref.Name,
+			Version:         ref.Version,
+		})
+		if err != nil {
+			if err == store.ErrNoResults {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return def.Definition, nil
+	})
+}
+
+// newBatchSpecFromRaw is like btypes.NewBatchSpecFromRaw, but parses the raw
+// batch spec with ParseBatchSpec.
+func (s *Service) newBatchSpecFromRaw(ctx context.Context, rawSpec string) (*btypes.BatchSpec, error) {
+	spec, err := s.ParseBatchSpec(ctx, rawSpec)
+	return &btypes.BatchSpec{RawSpec: rawSpec, Spec: spec}, err
+}
+
+// resolveStepDefinitions replaces the steps that use a step definition with
+// the steps that run the step definition returned by getDefinition, which
+// returns nil if the step definition doesn't exist. Steps that can't be
+// resolved result in validation errors.
+func resolveStepDefinitions(spec *batcheslib.BatchSpec, getDefinition func(batcheslib.StepReference) (*batcheslib.StepDefinition, error)) error {
+	definitions := map[batcheslib.StepReference]*batcheslib.StepDefinition{}
+
+	var errs error
+	for i, step := range spec.Steps {
+		if step.Uses == "" {
+			continue
+		}
+
+		ref, err := batcheslib.ParseStepReference(step.Uses)
+		if err != nil {
+			errs = errors.Append(errs, batcheslib.NewValidationError(errors.Wrapf(err, "step %d", i+1)))
+			continue
+		}
+
+		def, ok := definitions[ref]
+		if !ok {
+			def, err = getDefinition(ref)
+			if err != nil {
+				return errors.Wrapf(err, "getting step definition %s", ref)
+			}
+			definitions[ref] = def
+		}
+		if def == nil {
+			errs = errors.Append(errs, batcheslib.NewValidationError(errors.Newf("step %d: the step definition %s doesn't exist", i+1, ref)))
+			continue
+		}
+
+		resolved, err := def.Apply(step)
+		if err != nil {
+			errs = errors.Append(errs, batcheslib.NewValidationError(errors.Wrapf(err, "step %d", i+1)))
+			continue
+		}
+		spec.Steps[i] = resolved
+	}
+
+	return errs
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestServiceParseBatchSpec(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	s := store.New(db, observation.TestContextTB(t), nil)
+	svc := New(s)
+
+	member := bt.CreateTestUser(t, db, false)
+	outsider := bt.CreateTestUser(t, db, false)
+	org := bt.CreateTestOrg(t, db, "step-definitions", member.ID)
+
+	rawDefinition := "name: gofmt\nversion: 1.0.0\ncontainer: golang:1\nrun: gofmt -w .\n"
+	definition, err := batcheslib.ParseStepDefinition([]byte(rawDefinition))
+	require.NoError(t, err)
+	require.NoError(t, s.CreateBatchStepDefinition(context.Background(), &btypes.BatchStepDefinition{
+		NamespaceOrgID: org.ID,
+		Name:           definition.Name,
+		Version:        definition.Version,
+		RawDefinition:  rawDefinition,
+		Definition:     definition,
+		CreatorID:      member.ID,
+	}))
+
+	rawSpec := "name: test\nsteps:\n  - uses: step-definitions/gofmt@1.0.0\n"
+
+	t.Run("member of the namespace", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(member.ID))
+
+		spec, err := svc.ParseBatchSpec(ctx, rawSpec)
+		require.NoError(t, err)
+		require.Len(t, spec.Steps, 1)
+		assert.Empty(t, spec.Steps[0].Uses)
+		assert.Equal(t, "gofmt -w .", spec.Steps[0].Run)
+	})
+
+	t.Run("outsider", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(outsider.ID))
+
+		_, err := svc.ParseBatchSpec(ctx, rawSpec)
+		assert.ErrorContains(t, err, "the step definition step-definitions/gofmt@1.0.0 doesn't exist")
+		assert.True(t, errors.HasType[batcheslib.BatchSpecValidationError](err))
+	})
+}
+
+func TestResolveStepDefinitions(t *testing.T) {
+	gofmt := &batcheslib.StepDefinition{
+		Name:      "gofmt",
+		Version:   "1.0.0",
+		Inputs:    map[string]batcheslib.StepDefinitionInput{"path": {Default: "."}},
+		Run:       `gofmt -w "$INPUT_PATH"`,
+		Container: "golang:1",
+	}
+
+	var lookups []batcheslib.StepReference
+	getDefinition := func(ref batcheslib.StepReference) (*batcheslib.StepDefinition, error) {
+		lookups = append(lookups, ref)
+		if ref.String() == "my-org/gofmt@1.0.0" {
+			return gofmt, nil
+		}
+		return nil, nil
+	}
+
+	t.Run("resolves steps", func(t *testing.T) {
+		lookups = nil
+		spec := &batcheslib.BatchSpec{Steps: []batcheslib.Step{
+			{Run: "echo 1", Container: "alpine:3"},
+			{Uses: "my-org/gofmt@1.0.0", With: map[string]string{"path": "cmd"}},
+			{Uses: "my-org/gofmt@1.0.0"},
+		}}
+
+		require.NoError(t, resolveStepDefinitions(spec, getDefinition))
+
+		assert.Equal(t, "echo 1", spec.Steps[0].Run)
+		for _, step := range spec.Steps[1:] {
+			assert.Empty(t, step.Uses)
+			assert.Equal(t, gofmt.Run, step.Run)
+			assert.Equal(t, gofmt.Container, step.Container)
+		}
+		env, err := spec.Steps[1].Env.Resolve(nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"INPUT_PATH": "cmd"}, env)
+
+		// Step definitions are only looked up once.
+		assert.Len(t, lookups, 1)
+	})
+
+	t.Run("unknown step definition", func(t *testing.T) {
+		spec := &batcheslib.BatchSpec{Steps: []batcheslib.Step{
+			{Uses: "my-org/gofmt@2.0.0"},
+		}}
+
+		err := resolveStepDefinitions(spec, getDefinition)
+		assert.ErrorContains(t, err, "step 1: the step definition my-org/gofmt@2.0.0 doesn't exist")
+		assert.True(t, errors.HasType[batcheslib.BatchSpecValidationError](err))
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		spec := &batcheslib.BatchSpec{Steps: []batcheslib.Step{
+			{Uses: "my-org/gofmt@1.0.0", With: map[string]string{"dir": "cmd"}},
+		}}
+
+		err := resolveStepDefinitions(spec, getDefinition)
+		assert.ErrorContains(t, err, `step 1: gofmt has no input "dir"`)
+		assert.True(t, errors.HasType[batcheslib.BatchSpecValidationError](err))
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		spec := &batcheslib.BatchSpec{Steps: []batcheslib.Step{
+			{Uses: "my-org/gofmt@1.0.0"},
+		}}
+
+		err := resolveStepDefinitions(spec, func(batcheslib.StepReference) (*batcheslib.StepDefinition, error) {
+			return nil, errors.New("database is down")
+		})
+		assert.ErrorContains(t, err, "database is down")
+		assert.False(t, errors.HasType[batcheslib.BatchSpecValidationError](err))
+	})
+}
@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -131,6 +132,11 @@ func (s AzureDevOpsSource) createChangeset(ctx context.Context, cs *Changeset, i
 		RepoNameOrID: repo.Name,
 	}
 
+	input.Reviewers, err = s.resolveReviewers(ctx, org, cs)
+	if err != nil {
+		return false, err
+	}
+
 	pr, err := s.client.CreatePullRequest(ctx, args, input)
 	if err != nil {
 		return false, errors.Wrap(err, "creating pull request")
@@ -213,6 +219,29 @@ func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, cs *Changeset) e
 		}
 	}
 
+	reviewers, err := s.resolveReviewers(ctx, args.Org, cs)
+	if err != nil {
+		return err
+	}
+	if len(reviewers) > 0 {
+		if _, err := s.client.AddPullRequestReviewers(ctx, args, reviewers); err != nil {
+			return errors.Wrap(err, "adding pull request reviewers")
+		}
+	}
+	for _, name := range append(slices.Clone(cs.RemovedReviewers), cs.RemovedTeamReviewers...) {
+		identity, err := s.client.GetIdentity(ctx, args.Org, name)
+		if err != nil {
+			return errors.Wrapf(err, "looking up Azure DevOps identity %q", name)
+		}
+		if err := s.client.RemovePullRequestReviewer(ctx, args, identity.ID); err != nil {
+			return errors.Wrapf(err, "removing pull request reviewer %q", name)
+		}
+	}
+
+	if err := s.updateLabels(ctx, args, cs.Labels, cs.RemovedLabels); err != nil {
+		return err
+	}
+
 	input := s.changesetToUpdatePullRequestInput(cs, false)
 	updated, err := s.client.UpdatePullRequest(ctx, args, input)
 	if err != nil {
@@ -222,6 +251,54 @@ func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, cs *Changeset) e
 	return errors.Wrap(s.setChangesetMetadata(ctx, repo, &updated, cs), "setting Azure DevOps changeset metadata")
 }
 
+// resolveReviewers looks up the identities of the reviewers and team
+// reviewers of the changeset.
+func (s AzureDevOpsSource) resolveReviewers(ctx context.Context, org string, cs *Changeset) ([]azuredevops.Reviewer, error) {
+	var reviewers []azuredevops.Reviewer
+	for _, name := range append(slices.Clone(cs.Reviewers), cs.TeamReviewers...) {
+		identity, err := s.client.GetIdentity(ctx, org, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "looking up Azure DevOps identity %q", name)
+		}
+		reviewers = append(reviewers, azuredevops.Reviewer{ID: identity.ID})
+	}
+	return reviewers, nil
+}
+
+// updateLabels adds the given labels to the pull request and removes the
+// removed ones. Labels that were added on the code host are left alone.
+func (s AzureDevOpsSource) updateLabels(ctx context.Context, args azuredevops.PullRequestCommonArgs, labels, removed []string) error {
+	if len(labels) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	current, err := s.client.ListPullRequestLabels(ctx, args)
+	if err != nil {
+		return errors.Wrap(err, "listing pull request labels")
+	}
+
+	var have []string
+	for _, label := range current {
+		if slices.Contains(removed, label.Name) && !slices.Contains(labels, label.Name) {
+			if err := s.client.RemovePullRequestLabel(ctx, args, label.Name); err != nil {
+				return errors.Wrapf(err, "removing pull request label %q", label.Name)
+			}
+			continue
+		}
+		have = append(have, label.Name)
+	}
+
+	for _, label := range labels {
+		if !slices.Contains(have, label) {
+			if _, err := s.client.AddPullRequestLabel(ctx, args, label); err != nil {
+				return errors.Wrapf(err, "adding pull request label %q", label)
+			}
+		}
+	}
+
+	return nil
+}
+
 // ReopenChangeset will reopen the Changeset on the source, if it's closed.
 // If not, it's a noop.
 func (s AzureDevOpsSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
@@ -443,6 +520,9 @@ func (s AzureDevOpsSource) changesetToPullRequestInput(cs *Changeset) azuredevop
 			DeleteSourceBranch: deleteSourceBranch,
 		},
 	}
+	for _, label := range cs.Labels {
+		input.Labels = append(input.Labels, azuredevops.Label{Name: label})
+	}
 
 	// If we're forking, then we need to set the source repository as well.
 	if cs.RemoteRepo != cs.TargetRepo {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -223,6 +224,30 @@ func TestAzureDevOpsSource_CreateChangeset(t *testing.T) {
 		assertChangesetMatchesPullRequest(t, cs, pr)
 	})
 
+	t.Run("success with reviewers and labels", func(t *testing.T) {
+		cs, _ := mockAzureDevOpsChangeset()
+		cs.Reviewers = []string{"alice@example.com"}
+		cs.TeamReviewers = []string{"[project]\\team"}
+		cs.Labels = []string{"batch-change"}
+		s, client := mockAzureDevOpsSource()
+		mockAzureDevOpsAnnotatePullRequestSuccess(client)
+
+		client.GetIdentityFunc.SetDefaultHook(func(ctx context.Context, org, name string) (azuredevops.Identity, error) {
+			assert.Equal(t, testOrgProjectRepoArgs.Org, org)
+			return azuredevops.Identity{ID: "id-" + name}, nil
+		})
+		pr := mockAzureDevOpsPullRequest(&testRepository)
+		client.CreatePullRequestFunc.SetDefaultHook(func(ctx context.Context, r azuredevops.OrgProjectRepoArgs, pri azuredevops.CreatePullRequestInput) (azuredevops.PullRequest, error) {
+			assert.Equal(t, []azuredevops.Reviewer{{ID: "id-alice@example.com"}, {ID: "id-[project]\\team"}}, pri.Reviewers)
+			assert.Equal(t, []azuredevops.Label{{Name: "batch-change"}}, pri.Labels)
+			return *pr, nil
+		})
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.True(t, exists)
+		assert.Nil(t, err)
+	})
+
 	t.Run("success with fork", func(t *testing.T) {
 		cs, _ := mockAzureDevOpsChangeset()
 		s, client := mockAzureDevOpsSource()
@@ -474,6 +499,45 @@ func TestAzureDevOpsSource_UpdateChangeset(t *testing.T) {
 		assert.Nil(t, err)
 		assertChangesetMatchesPullRequest(t, cs, pr)
 	})
+
+	t.Run("success with reviewers and labels", func(t *testing.T) {
+		cs, _ := mockAzureDevOpsChangeset()
+		cs.Reviewers = []string{"alice@example.com"}
+		cs.RemovedReviewers = []string{"bob@example.com"}
+		cs.Labels = []string{"keep", "add"}
+		cs.RemovedLabels = []string{"remove"}
+		s, client := mockAzureDevOpsSource()
+		mockAzureDevOpsAnnotatePullRequestSuccess(client)
+
+		pr := mockAzureDevOpsPullRequest(&testRepository)
+		client.GetPullRequestFunc.SetDefaultReturn(*pr, nil)
+		client.UpdatePullRequestFunc.SetDefaultReturn(*pr, nil)
+		client.GetIdentityFunc.SetDefaultHook(func(_ context.Context, _, name string) (azuredevops.Identity, error) {
+			return azuredevops.Identity{ID: strings.TrimSuffix(name, "@example.com")}, nil
+		})
+		client.ListPullRequestLabelsFunc.SetDefaultReturn([]azuredevops.Label{{Name: "keep"}, {Name: "remove"}, {Name: "manual"}}, nil)
+		client.AddPullRequestReviewersFunc.SetDefaultReturn(nil, nil)
+		client.RemovePullRequestReviewerFunc.SetDefaultReturn(nil)
+		client.RemovePullRequestLabelFunc.SetDefaultReturn(nil)
+		client.AddPullRequestLabelFunc.SetDefaultReturn(azuredevops.Label{}, nil)
+
+		annotateChangesetWithPullRequest(cs, pr)
+		err := s.UpdateChangeset(ctx, cs)
+		assert.Nil(t, err)
+
+		if assert.Len(t, client.AddPullRequestReviewersFunc.History(), 1) {
+			assert.Equal(t, []azuredevops.Reviewer{{ID: "alice"}}, client.AddPullRequestReviewersFunc.History()[0].Arg2)
+		}
+		if assert.Len(t, client.RemovePullRequestReviewerFunc.History(), 1) {
+			assert.Equal(t, "bob", client.RemovePullRequestReviewerFunc.History()[0].Arg2)
+		}
+		if assert.Len(t, client.RemovePullRequestLabelFunc.History(), 1) {
+			assert.Equal(t, "remove", client.RemovePullRequestLabelFunc.History()[0].Arg2)
+		}
+		if assert.Len(t, client.AddPullRequestLabelFunc.History(), 1) {
+			assert.Equal(t, "add", client.AddPullRequestLabelFunc.History()[0].Arg2)
+		}
+	})
 }
 
 func TestAzureDevOpsSource_UndraftChangeset(t *testing.T) {
@@ -2,6 +2,7 @@ package sources
 
 import (
 	"context"
+	"slices"
 	"strconv"
 
 	bbcs "github.com/sourcegraph/sourcegraph/internal/batches/sources/bitbucketcloud"
@@ -151,8 +152,13 @@ func (s BitbucketCloudSource) UpdateChangeset(ctx context.Context, cs *Changeset
 	pr := cs.Metadata.(*bbcs.AnnotatedPullRequest)
 	// The endpoint for updating a bitbucket pullrequest is a PUT endpoint which means if a field isn't provided
 	// it'll override it's value to it's empty value. We always want to retain the reviewers assigned to a pull
-	// request when updating a pull request.
-	opts.Reviewers = pr.Reviewers
+	// request when updating a pull request, apart from the ones a previous changeset spec requested that the
+	// current one doesn't.
+	for _, r := range pr.Reviewers {
+		if !slices.Contains(cs.RemovedReviewers, r.UUID) && (r.AccountID == "" || !slices.Contains(cs.RemovedReviewers, r.AccountID)) {
+			opts.Reviewers = append(opts.Reviewers, r)
+		}
+	}
 
 	if conf.Get().BatchChangesAutoDeleteBranch {
 		opts.CloseSourceBranch = true
@@ -338,6 +344,7 @@ func (s BitbucketCloudSource) changesetToPullRequestInput(cs *Changeset) bitbuck
 		SourceBranch:      gitdomain.AbbreviateRef(cs.HeadRef),
 		DestinationBranch: &destBranch,
 		CloseSourceBranch: closeSourceBranch,
+		ReviewerIDs:       cs.Reviewers,
 	}
 
 	// If we're forking, then we need to set the source repository as well.
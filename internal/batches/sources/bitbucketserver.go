@@ -2,6 +2,7 @@ package sources
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -108,6 +109,9 @@ func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset
 	targetRepo := c.TargetRepo.Metadata.(*bitbucketserver.Repo)
 
 	pr := &bitbucketserver.PullRequest{Title: c.Title, Description: c.Body}
+	for _, name := range c.Reviewers {
+		pr.Reviewers = append(pr.Reviewers, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: name}})
+	}
 
 	pr.ToRef.Repository.Slug = targetRepo.Slug
 	pr.ToRef.Repository.ID = targetRepo.ID
@@ -229,8 +233,9 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 		Version:       pr.Version,
 		// The endpoint for updating a bitbucket pullrequest is a PUT endpoint which means if a field isn't provided
 		// it'll override it's value to it's empty value. We always want to retain the reviewers assigned to a pull
-		// request when updating a pull request.
-		Reviewers: pr.Reviewers,
+		// request when updating a pull request, apart from the ones a previous changeset spec requested that the
+		// current one doesn't.
+		Reviewers: withReviewers(pr.Reviewers, c.Reviewers, c.RemovedReviewers),
 	}
 	update.ToRef.ID = c.BaseRef
 	update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
@@ -419,3 +424,21 @@ func (s BitbucketServerSource) checkAndCopy(targetRepo *types.Repo, fork *bitbuc
 
 	return forkRepo, nil
 }
+
+// withReviewers returns the reviewers of a pull request with the users with
+// the given names added, unless they are reviewers already, and the users
+// with the removed names removed.
+func withReviewers(reviewers []bitbucketserver.Reviewer, names, removed []string) []bitbucketserver.Reviewer {
+	result := make([]bitbucketserver.Reviewer, 0, len(reviewers)+len(names))
+	for _, r := range reviewers {
+		if r.User == nil || !slices.Contains(removed, r.User.Name) {
+			result = append(result, r)
+		}
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(reviewers, func(r bitbucketserver.Reviewer) bool { return r.User != nil && r.User.Name == name }) {
+			result = append(result, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: name}})
+		}
+	}
+	return result
+}
@@ -572,6 +572,16 @@ func TestBitbucketServerSource_UpdateChangeset(t *testing.T) {
 	}
 }
 
+func TestWithReviewers(t *testing.T) {
+	reviewer := func(name string) bitbucketserver.Reviewer {
+		return bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: name}}
+	}
+	existing := []bitbucketserver.Reviewer{reviewer("alice"), reviewer("bob")}
+
+	have := withReviewers(existing, []string{"bob", "carol"}, []string{"alice"})
+	assert.Equal(t, []bitbucketserver.Reviewer{reviewer("bob"), reviewer("carol")}, have)
+}
+
 func TestBitbucketServerSource_CreateComment(t *testing.T) {
 	ratelimit.SetupForTest(t)
 
@@ -126,6 +126,25 @@ type Changeset struct {
 	HeadRef string
 	BaseRef string
 
+	// Reviewers, TeamReviewers, Labels, Assignees and Milestone are applied
+	// by CreateChangeset and UpdateChangeset. Sources ignore the ones their
+	// code host has no equivalent of.
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+	Milestone     string
+
+	// RemovedReviewers, RemovedTeamReviewers, RemovedLabels,
+	// RemovedAssignees and RemovedMilestone were applied by a previous
+	// changeset spec and are removed again by UpdateChangeset. Values that
+	// were added on the code host are left untouched.
+	RemovedReviewers     []string
+	RemovedTeamReviewers []string
+	RemovedLabels        []string
+	RemovedAssignees     []string
+	RemovedMilestone     string
+
 	// RemoteRepo is the repository the branch will be pushed to. This must be
 	// the same as TargetRepo if forking is not in use.
 	RemoteRepo *types.Repo
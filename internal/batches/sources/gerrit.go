@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
@@ -117,6 +119,10 @@ func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool,
 		return false, errors.Wrap(err, "getting change")
 	}
 
+	if err := s.updateReviewersAndHashtags(ctx, pr, cs); err != nil {
+		return false, err
+	}
+
 	// The Changeset technically "exists" at this point because it gets created at push time,
 	// therefore exists would always return true. However, we send false here because otherwise we would always
 	// enqueue a ChangesetUpdate webhook event instead of the regular publish event.
@@ -142,6 +148,11 @@ func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (
 		}
 		return false, errors.Wrap(err, "getting change")
 	}
+
+	if err := s.updateReviewersAndHashtags(ctx, pr, cs); err != nil {
+		return false, err
+	}
+
 	// The Changeset technically "exists" at this point because it gets created at push time,
 	// therefore exists would always return true. However, we send false here because otherwise we would always
 	// enqueue a ChangesetUpdate webhook event instead of the regular publish event.
@@ -230,9 +241,67 @@ func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error
 			return errors.Wrap(err, "setting change commit message")
 		}
 	}
+	if err := s.updateReviewersAndHashtags(ctx, pr, cs); err != nil {
+		return err
+	}
 	return s.LoadChangeset(ctx, cs)
 }
 
+// updateReviewersAndHashtags adds the reviewers, team reviewers and labels of
+// the changeset to the change as reviewers and hashtags, and removes the ones
+// that were dropped from the spec. Gerrit has no assignees or milestones.
+func (s GerritSource) updateReviewersAndHashtags(ctx context.Context, pr *gerrit.Change, cs *Changeset) error {
+	// Gerrit adds the members of a group instead of the group itself, so we
+	// have to remove removed team reviewers member by member, except for
+	// members that are still requested as reviewers themselves.
+	removed := slices.Clone(cs.RemovedReviewers)
+	for _, group := range cs.RemovedTeamReviewers {
+		members, err := s.client.ListGroupMembers(ctx, group)
+		if err != nil {
+			return errors.Wrapf(err, "listing members of group %q", group)
+		}
+		for _, member := range members {
+			if slices.Contains(cs.Reviewers, member.Username) || slices.Contains(cs.Reviewers, member.Email) {
+				continue
+			}
+			removed = append(removed, strconv.Itoa(int(member.ID)))
+		}
+	}
+	for _, reviewer := range removed {
+		if err := s.client.RemoveReviewer(ctx, pr.ID, reviewer); err != nil {
+			return errors.Wrapf(err, "removing reviewer %q", reviewer)
+		}
+	}
+
+	for _, reviewer := range append(slices.Clone(cs.Reviewers), cs.TeamReviewers...) {
+		if err := s.client.AddReviewer(ctx, pr.ID, gerrit.AddReviewerPayload{Reviewer: reviewer}); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", reviewer)
+		}
+	}
+
+	var input gerrit.SetHashtagsPayload
+	for _, label := range cs.Labels {
+		if !slices.Contains(pr.Hashtags, label) {
+			input.Add = append(input.Add, label)
+		}
+	}
+	for _, label := range cs.RemovedLabels {
+		if slices.Contains(pr.Hashtags, label) && !slices.Contains(cs.Labels, label) {
+			input.Remove = append(input.Remove, label)
+		}
+	}
+	if len(input.Add) == 0 && len(input.Remove) == 0 {
+		return nil
+	}
+
+	hashtags, err := s.client.SetHashtags(ctx, pr.ID, input)
+	if err != nil {
+		return errors.Wrap(err, "setting change hashtags")
+	}
+	pr.Hashtags = hashtags
+	return nil
+}
+
 // ReopenChangeset will reopen the Changeset on the source, if it's closed.
 // If not, it's a noop.
 func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
@@ -197,6 +197,49 @@ func TestGerritSource_CreateChangeset(t *testing.T) {
 		assert.Nil(t, err)
 		assert.False(t, b)
 	})
+
+	t.Run("success with reviewers and hashtags", func(t *testing.T) {
+		cs, id, _ := mockGerritChangeset()
+		cs.Reviewers = []string{"alice"}
+		cs.TeamReviewers = []string{"maintainers"}
+		cs.RemovedReviewers = []string{"bob"}
+		cs.RemovedTeamReviewers = []string{"former-maintainers"}
+		cs.Labels = []string{"keep", "add"}
+		cs.RemovedLabels = []string{"remove"}
+		s, client := mockGerritSource()
+
+		change := mockGerritChange(&testProject, id)
+		change.ID = testChangeIDPrefix + id
+		change.Hashtags = []string{"keep", "remove", "manual"}
+		client.GetURLFunc.SetDefaultReturn(&url.URL{})
+		client.GetChangeFunc.SetDefaultReturn(change, nil)
+		client.GetChangeReviewsFunc.SetDefaultReturn(&[]gerrit.Reviewer{}, nil)
+		client.AddReviewerFunc.SetDefaultReturn(nil)
+		client.RemoveReviewerFunc.SetDefaultReturn(nil)
+		client.ListGroupMembersFunc.SetDefaultReturn([]gerrit.Account{{ID: 7, Username: "carol"}, {ID: 8, Username: "alice"}}, nil)
+		client.SetHashtagsFunc.SetDefaultHook(func(ctx context.Context, changeID string, input gerrit.SetHashtagsPayload) ([]string, error) {
+			assert.Equal(t, testChangeIDPrefix+id, changeID)
+			assert.Equal(t, gerrit.SetHashtagsPayload{Add: []string{"add"}, Remove: []string{"remove"}}, input)
+			return []string{"keep", "manual", "add"}, nil
+		})
+
+		b, err := s.CreateChangeset(ctx, cs)
+		assert.Nil(t, err)
+		assert.False(t, b)
+
+		var reviewers []string
+		for _, call := range client.AddReviewerFunc.History() {
+			reviewers = append(reviewers, call.Arg2.Reviewer)
+		}
+		assert.Equal(t, []string{"alice", "maintainers"}, reviewers)
+		var removed []string
+		for _, call := range client.RemoveReviewerFunc.History() {
+			removed = append(removed, call.Arg2)
+		}
+		assert.Equal(t, []string{"bob", "7"}, removed)
+		assert.Len(t, client.SetHashtagsFunc.History(), 1)
+		assert.Equal(t, []string{"keep", "manual", "add"}, change.Hashtags)
+	})
 }
 
 func TestGerritSource_CreateDraftChangeset(t *testing.T) {
@@ -238,6 +238,10 @@ func (s GitHubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 		exists = true
 	}
 
+	if err := s.updatePullRequestMetadata(ctx, c, pr); err != nil {
+		return exists, err
+	}
+
 	if err := c.SetMetadata(pr); err != nil {
 		return false, errors.Wrap(err, "setting changeset metadata")
 	}
@@ -245,6 +249,97 @@ func (s GitHubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 	return exists, nil
 }
 
+// updatePullRequestMetadata requests reviews from the reviewers of c and adds
+// its labels and assignees to pr, removes the ones c no longer has, and sets
+// its milestone, reloading pr if anything was changed.
+func (s GitHubSource) updatePullRequestMetadata(ctx context.Context, c *Changeset, pr *github.PullRequest) error {
+	if len(c.Reviewers) == 0 && len(c.TeamReviewers) == 0 && len(c.Labels) == 0 && len(c.Assignees) == 0 && c.Milestone == "" &&
+		len(c.RemovedReviewers) == 0 && len(c.RemovedTeamReviewers) == 0 && len(c.RemovedLabels) == 0 && len(c.RemovedAssignees) == 0 && c.RemovedMilestone == "" {
+		return nil
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	if len(c.Labels) > 0 {
+		if err := s.client.AddIssueLabels(ctx, owner, name, pr.Number, c.Labels); err != nil {
+			return errors.Wrap(err, "adding labels")
+		}
+	}
+	for _, label := range c.RemovedLabels {
+		if err := s.client.RemoveIssueLabel(ctx, owner, name, pr.Number, label); err != nil {
+			return errors.Wrapf(err, "removing label %q", label)
+		}
+	}
+
+	if len(c.Assignees) > 0 {
+		if err := s.client.AddIssueAssignees(ctx, owner, name, pr.Number, c.Assignees); err != nil {
+			return errors.Wrap(err, "adding assignees")
+		}
+	}
+	if len(c.RemovedAssignees) > 0 {
+		if err := s.client.RemoveIssueAssignees(ctx, owner, name, pr.Number, c.RemovedAssignees); err != nil {
+			return errors.Wrap(err, "removing assignees")
+		}
+	}
+
+	if c.Milestone != "" {
+		number, err := s.milestoneNumber(ctx, owner, name, c.Milestone)
+		if err != nil {
+			return err
+		}
+		if err := s.client.SetIssueMilestone(ctx, owner, name, pr.Number, &number); err != nil {
+			return errors.Wrap(err, "setting milestone")
+		}
+	} else if c.RemovedMilestone != "" {
+		if err := s.client.SetIssueMilestone(ctx, owner, name, pr.Number, nil); err != nil {
+			return errors.Wrap(err, "removing milestone")
+		}
+	}
+
+	// GitHub doesn't allow requesting a review from the author.
+	reviewers := make([]string, 0, len(c.Reviewers))
+	for _, r := range c.Reviewers {
+		if !strings.EqualFold(r, pr.Author.Login) {
+			reviewers = append(reviewers, r)
+		}
+	}
+	if len(reviewers) > 0 || len(c.TeamReviewers) > 0 {
+		if err := s.client.RequestReviewers(ctx, owner, name, pr.Number, reviewers, c.TeamReviewers); err != nil {
+			return errors.Wrap(err, "requesting reviews")
+		}
+	}
+	if len(c.RemovedReviewers) > 0 || len(c.RemovedTeamReviewers) > 0 {
+		if err := s.client.RemoveRequestedReviewers(ctx, owner, name, pr.Number, c.RemovedReviewers, c.RemovedTeamReviewers); err != nil {
+			return errors.Wrap(err, "removing review requests")
+		}
+	}
+
+	pr.RepoWithOwner = repo.NameWithOwner
+	return s.client.LoadPullRequest(ctx, pr)
+}
+
+// milestoneNumber returns the number of the milestone with the given title.
+func (s GitHubSource) milestoneNumber(ctx context.Context, owner, name, title string) (int64, error) {
+	for page := 1; ; page++ {
+		milestones, hasNextPage, err := s.client.ListMilestones(ctx, owner, name, page)
+		if err != nil {
+			return 0, errors.Wrap(err, "listing milestones")
+		}
+		for _, m := range milestones {
+			if m.Title == title {
+				return m.Number, nil
+			}
+		}
+		if !hasNextPage {
+			return 0, errors.Newf("milestone %q not found in %s/%s", title, owner, name)
+		}
+	}
+}
+
 // CloseChangeset closes the given *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset to the newly closed pull request.
 func (s GitHubSource) CloseChangeset(ctx context.Context, c *Changeset) error {
@@ -331,6 +426,10 @@ func (s GitHubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 		return err
 	}
 
+	if err := s.updatePullRequestMetadata(ctx, c, updated); err != nil {
+		return err
+	}
+
 	return c.Changeset.SetMetadata(updated)
 }
 
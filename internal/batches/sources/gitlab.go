@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -125,6 +126,11 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 	}
 	removeSource := conf.Get().BatchChangesAutoDeleteBranch
 
+	md, err := s.resolveMergeRequestMetadata(ctx, targetProject, c)
+	if err != nil {
+		return exists, err
+	}
+
 	// We have to create the merge request against the remote project, not the
 	// target project, because that's how GitLab's API works: you provide the
 	// target project ID as one of the parameters. Yes, this is weird.
@@ -138,6 +144,10 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 		Title:              c.Title,
 		Description:        c.Body,
 		RemoveSourceBranch: removeSource,
+		Labels:             md.labels,
+		AssigneeIDs:        md.assigneeIDs,
+		ReviewerIDs:        md.reviewerIDs,
+		MilestoneID:        md.milestoneID,
 	})
 	if err != nil {
 		if err == gitlab.ErrMergeRequestAlreadyExists {
@@ -493,12 +503,38 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 
 	removeSource := conf.Get().BatchChangesAutoDeleteBranch
 
-	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
+	md, err := s.resolveMergeRequestMetadata(ctx, project, c)
+	if err != nil {
+		return err
+	}
+
+	opts := gitlab.UpdateMergeRequestOpts{
 		Title:              title,
 		Description:        c.Body,
 		TargetBranch:       gitdomain.AbbreviateRef(c.BaseRef),
 		RemoveSourceBranch: removeSource,
-	})
+		AddLabels:          md.labels,
+		RemoveLabels:       strings.Join(c.RemovedLabels, ","),
+	}
+	// The GitLab API replaces assignees and reviewers wholesale, so we keep
+	// the ones that were added on the code host and only drop the ones that
+	// were removed from the spec.
+	if len(c.Assignees) > 0 || len(c.RemovedAssignees) > 0 {
+		ids := mergeUserIDs(mr.Assignees, c.RemovedAssignees, md.assigneeIDs)
+		opts.AssigneeIDs = &ids
+	}
+	if len(c.Reviewers) > 0 || len(c.RemovedReviewers) > 0 {
+		ids := mergeUserIDs(mr.Reviewers, c.RemovedReviewers, md.reviewerIDs)
+		opts.ReviewerIDs = &ids
+	}
+	if c.Milestone != "" {
+		opts.MilestoneID = &md.milestoneID
+	} else if c.RemovedMilestone != "" {
+		var unset gitlab.ID
+		opts.MilestoneID = &unset
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, opts)
 	if err != nil {
 		return errors.Wrap(err, "updating GitLab merge request")
 	}
@@ -511,6 +547,69 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// mergeRequestMetadata holds the labels, assignees, reviewers and milestone
+// of a changeset in the form the GitLab API expects them.
+type mergeRequestMetadata struct {
+	labels      string
+	assigneeIDs []int32
+	reviewerIDs []int32
+	milestoneID gitlab.ID
+}
+
+// resolveMergeRequestMetadata looks up the IDs of the assignees, reviewers and
+// milestone of the changeset. Team reviewers are not supported by GitLab and
+// ignored.
+func (s *GitLabSource) resolveMergeRequestMetadata(ctx context.Context, project *gitlab.Project, c *Changeset) (mergeRequestMetadata, error) {
+	md := mergeRequestMetadata{labels: strings.Join(c.Labels, ",")}
+
+	userIDs := func(usernames []string) ([]int32, error) {
+		var ids []int32
+		for _, username := range usernames {
+			user, err := s.client.GetUserByUsername(ctx, username)
+			if err != nil {
+				return nil, errors.Wrapf(err, "looking up GitLab user %q", username)
+			}
+			ids = append(ids, user.ID)
+		}
+		return ids, nil
+	}
+
+	var err error
+	if md.assigneeIDs, err = userIDs(c.Assignees); err != nil {
+		return md, err
+	}
+	if md.reviewerIDs, err = userIDs(c.Reviewers); err != nil {
+		return md, err
+	}
+
+	if c.Milestone != "" {
+		milestone, err := s.client.GetMilestoneByTitle(ctx, project, c.Milestone)
+		if err != nil {
+			return md, errors.Wrapf(err, "looking up GitLab milestone %q", c.Milestone)
+		}
+		md.milestoneID = milestone.ID
+	}
+
+	return md, nil
+}
+
+// mergeUserIDs returns the IDs of the current users that are not in removed,
+// followed by the IDs in added that are not already present.
+func mergeUserIDs(current []gitlab.User, removed []string, added []int32) []int32 {
+	ids := []int32{}
+	for _, u := range current {
+		if !slices.Contains(removed, u.Username) {
+			ids = append(ids, u.ID)
+		}
+	}
+	for _, id := range added {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -789,6 +789,111 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("UpdateChangeset metadata", func(t *testing.T) {
+		in := &gitlab.MergeRequest{IID: 2}
+		out := &gitlab.MergeRequest{}
+
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = in
+		p.changeset.Labels = []string{"bug", "batch-change"}
+		p.changeset.Assignees = []string{"alice"}
+		p.changeset.Reviewers = []string{"bob"}
+		p.changeset.TeamReviewers = []string{"ignored"}
+		p.changeset.Milestone = "v1.0"
+
+		gitlab.MockListUsers = func(_ *gitlab.Client, _ context.Context, urlStr string) ([]*gitlab.AuthUser, *string, error) {
+			switch urlStr {
+			case "users?username=alice":
+				return []*gitlab.AuthUser{{ID: 10, Username: "alice"}}, nil, nil
+			case "users?username=bob":
+				return []*gitlab.AuthUser{{ID: 20, Username: "bob"}}, nil, nil
+			}
+			return nil, nil, nil
+		}
+		gitlab.MockGetMilestoneByTitle = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, title string) (*gitlab.Milestone, error) {
+			p.testCommonParams(ctx, client, project)
+			if title != "v1.0" {
+				t.Errorf("unexpected milestone title: %q", title)
+			}
+			return &gitlab.Milestone{ID: 30, Title: title}, nil
+		}
+		gitlab.MockUpdateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+			p.testCommonParams(ctx, client, project)
+			want := gitlab.UpdateMergeRequestOpts{
+				Title:        "title",
+				Description:  "description",
+				TargetBranch: "base",
+				AddLabels:    "bug,batch-change",
+				AssigneeIDs:  pointers.Ptr([]int32{10}),
+				ReviewerIDs:  pointers.Ptr([]int32{20}),
+				MilestoneID:  pointers.Ptr(gitlab.ID(30)),
+			}
+			if diff := cmp.Diff(want, opts); diff != "" {
+				t.Errorf("unexpected options (-want +got):\n%s", diff)
+			}
+			return out, nil
+		}
+		t.Cleanup(func() {
+			gitlab.MockListUsers = nil
+			gitlab.MockGetMilestoneByTitle = nil
+		})
+		p.mockGetMergeRequestNotes(in.IID, nil, 20, nil)
+		p.mockGetMergeRequestResourceStateEvents(in.IID, nil, 20, nil)
+		p.mockGetMergeRequestPipelines(in.IID, nil, 20, nil)
+
+		if err := p.source.UpdateChangeset(p.ctx, p.changeset); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+
+		t.Run("removed values", func(t *testing.T) {
+			in.Assignees = []gitlab.User{{ID: 10, Username: "alice"}, {ID: 40, Username: "carol"}}
+			in.Reviewers = []gitlab.User{{ID: 20, Username: "bob"}}
+			p.changeset.Labels = nil
+			p.changeset.Assignees = nil
+			p.changeset.Reviewers = nil
+			p.changeset.Milestone = ""
+			p.changeset.RemovedLabels = []string{"bug"}
+			p.changeset.RemovedAssignees = []string{"alice"}
+			p.changeset.RemovedReviewers = []string{"bob"}
+			p.changeset.RemovedMilestone = "v1.0"
+			t.Cleanup(func() {
+				in.Assignees, in.Reviewers = nil, nil
+				p.changeset.RemovedLabels = nil
+				p.changeset.RemovedAssignees = nil
+				p.changeset.RemovedReviewers = nil
+				p.changeset.RemovedMilestone = ""
+			})
+
+			gitlab.MockUpdateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+				want := gitlab.UpdateMergeRequestOpts{
+					Title:        "title",
+					Description:  "description",
+					TargetBranch: "base",
+					RemoveLabels: "bug",
+					AssigneeIDs:  pointers.Ptr([]int32{40}),
+					ReviewerIDs:  pointers.Ptr([]int32{}),
+					MilestoneID:  pointers.Ptr(gitlab.ID(0)),
+				}
+				if diff := cmp.Diff(want, opts); diff != "" {
+					t.Errorf("unexpected options (-want +got):\n%s", diff)
+				}
+				return out, nil
+			}
+
+			if err := p.source.UpdateChangeset(p.ctx, p.changeset); err != nil {
+				t.Errorf("unexpected non-nil error: %+v", err)
+			}
+		})
+
+		t.Run("unknown user", func(t *testing.T) {
+			p.changeset.Assignees = []string{"mallory"}
+			err := p.source.UpdateChangeset(p.ctx, p.changeset)
+			if !errors.Is(err, gitlab.ErrUserNotFound) {
+				t.Errorf("unexpected error: %+v", err)
+			}
+		})
+	})
+
 	t.Run("UpdateChangeset draft", func(t *testing.T) {
 		t.Run("GitLab version is greater than 14.0.0", func(t *testing.T) {
 			// We won't test the full set of UpdateChangeset scenarios; instead
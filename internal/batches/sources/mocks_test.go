@@ -6560,6 +6560,12 @@ type MockAzureDevOpsClient struct {
 	// AbandonPullRequestFunc is an instance of a mock function object
 	// controlling the behavior of the method AbandonPullRequest.
 	AbandonPullRequestFunc *AzureDevOpsClientAbandonPullRequestFunc
+	// AddPullRequestLabelFunc is an instance of a mock function object
+	// controlling the behavior of the method AddPullRequestLabel.
+	AddPullRequestLabelFunc *AzureDevOpsClientAddPullRequestLabelFunc
+	// AddPullRequestReviewersFunc is an instance of a mock function object
+	// controlling the behavior of the method AddPullRequestReviewers.
+	AddPullRequestReviewersFunc *AzureDevOpsClientAddPullRequestReviewersFunc
 	// AuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method Authenticator.
 	AuthenticatorFunc *AzureDevOpsClientAuthenticatorFunc
@@ -6579,6 +6585,9 @@ type MockAzureDevOpsClient struct {
 	// GetAuthorizedProfileFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAuthorizedProfile.
 	GetAuthorizedProfileFunc *AzureDevOpsClientGetAuthorizedProfileFunc
+	// GetIdentityFunc is an instance of a mock function object controlling
+	// the behavior of the method GetIdentity.
+	GetIdentityFunc *AzureDevOpsClientGetIdentityFunc
 	// GetProjectFunc is an instance of a mock function object controlling
 	// the behavior of the method GetProject.
 	GetProjectFunc *AzureDevOpsClientGetProjectFunc
@@ -6604,10 +6613,20 @@ type MockAzureDevOpsClient struct {
 	// object controlling the behavior of the method
 	// ListAuthorizedUserOrganizations.
 	ListAuthorizedUserOrganizationsFunc *AzureDevOpsClientListAuthorizedUserOrganizationsFunc
+	// ListPullRequestLabelsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListPullRequestLabels.
+	ListPullRequestLabelsFunc *AzureDevOpsClientListPullRequestLabelsFunc
 	// ListRepositoriesByProjectOrOrgFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// ListRepositoriesByProjectOrOrg.
 	ListRepositoriesByProjectOrOrgFunc *AzureDevOpsClientListRepositoriesByProjectOrOrgFunc
+	// RemovePullRequestLabelFunc is an instance of a mock function object
+	// controlling the behavior of the method RemovePullRequestLabel.
+	RemovePullRequestLabelFunc *AzureDevOpsClientRemovePullRequestLabelFunc
+	// RemovePullRequestReviewerFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// RemovePullRequestReviewer.
+	RemovePullRequestReviewerFunc *AzureDevOpsClientRemovePullRequestReviewerFunc
 	// SetWaitForRateLimitFunc is an instance of a mock function object
 	// controlling the behavior of the method SetWaitForRateLimit.
 	SetWaitForRateLimitFunc *AzureDevOpsClientSetWaitForRateLimitFunc
@@ -6628,6 +6647,16 @@ func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				return
 			},
 		},
+		AddPullRequestLabelFunc: &AzureDevOpsClientAddPullRequestLabelFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (r0 azuredevops.Label, r1 error) {
+				return
+			},
+		},
+		AddPullRequestReviewersFunc: &AzureDevOpsClientAddPullRequestReviewersFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) (r0 []azuredevops.Reviewer, r1 error) {
+				return
+			},
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: func() (r0 auth.Authenticator) {
 				return
@@ -6658,6 +6687,11 @@ func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				return
 			},
 		},
+		GetIdentityFunc: &AzureDevOpsClientGetIdentityFunc{
+			defaultHook: func(context.Context, string, string) (r0 azuredevops.Identity, r1 error) {
+				return
+			},
+		},
 		GetProjectFunc: &AzureDevOpsClientGetProjectFunc{
 			defaultHook: func(context.Context, string, string) (r0 azuredevops.Project, r1 error) {
 				return
@@ -6698,11 +6732,26 @@ func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				return
 			},
 		},
+		ListPullRequestLabelsFunc: &AzureDevOpsClientListPullRequestLabelsFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs) (r0 []azuredevops.Label, r1 error) {
+				return
+			},
+		},
 		ListRepositoriesByProjectOrOrgFunc: &AzureDevOpsClientListRepositoriesByProjectOrOrgFunc{
 			defaultHook: func(context.Context, azuredevops.ListRepositoriesByProjectOrOrgArgs) (r0 []azuredevops.Repository, r1 error) {
 				return
 			},
 		},
+		RemovePullRequestLabelFunc: &AzureDevOpsClientRemovePullRequestLabelFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (r0 error) {
+				return
+			},
+		},
+		RemovePullRequestReviewerFunc: &AzureDevOpsClientRemovePullRequestReviewerFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (r0 error) {
+				return
+			},
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: func(bool) {
 				return
@@ -6730,6 +6779,16 @@ func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				panic("unexpected invocation of MockAzureDevOpsClient.AbandonPullRequest")
 			},
 		},
+		AddPullRequestLabelFunc: &AzureDevOpsClientAddPullRequestLabelFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.AddPullRequestLabel")
+			},
+		},
+		AddPullRequestReviewersFunc: &AzureDevOpsClientAddPullRequestReviewersFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.AddPullRequestReviewers")
+			},
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: func() auth.Authenticator {
 				panic("unexpected invocation of MockAzureDevOpsClient.Authenticator")
@@ -6760,6 +6819,11 @@ func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				panic("unexpected invocation of MockAzureDevOpsClient.GetAuthorizedProfile")
 			},
 		},
+		GetIdentityFunc: &AzureDevOpsClientGetIdentityFunc{
+			defaultHook: func(context.Context, string, string) (azuredevops.Identity, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.GetIdentity")
+			},
+		},
 		GetProjectFunc: &AzureDevOpsClientGetProjectFunc{
 			defaultHook: func(context.Context, string, string) (azuredevops.Project, error) {
 				panic("unexpected invocation of MockAzureDevOpsClient.GetProject")
@@ -6800,11 +6864,26 @@ func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				panic("unexpected invocation of MockAzureDevOpsClient.ListAuthorizedUserOrganizations")
 			},
 		},
+		ListPullRequestLabelsFunc: &AzureDevOpsClientListPullRequestLabelsFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.ListPullRequestLabels")
+			},
+		},
 		ListRepositoriesByProjectOrOrgFunc: &AzureDevOpsClientListRepositoriesByProjectOrOrgFunc{
 			defaultHook: func(context.Context, azuredevops.ListRepositoriesByProjectOrOrgArgs) ([]azuredevops.Repository, error) {
 				panic("unexpected invocation of MockAzureDevOpsClient.ListRepositoriesByProjectOrOrg")
 			},
 		},
+		RemovePullRequestLabelFunc: &AzureDevOpsClientRemovePullRequestLabelFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+				panic("unexpected invocation of MockAzureDevOpsClient.RemovePullRequestLabel")
+			},
+		},
+		RemovePullRequestReviewerFunc: &AzureDevOpsClientRemovePullRequestReviewerFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+				panic("unexpected invocation of MockAzureDevOpsClient.RemovePullRequestReviewer")
+			},
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: func(bool) {
 				panic("unexpected invocation of MockAzureDevOpsClient.SetWaitForRateLimit")
@@ -6831,6 +6910,12 @@ func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
 		AbandonPullRequestFunc: &AzureDevOpsClientAbandonPullRequestFunc{
 			defaultHook: i.AbandonPullRequest,
 		},
+		AddPullRequestLabelFunc: &AzureDevOpsClientAddPullRequestLabelFunc{
+			defaultHook: i.AddPullRequestLabel,
+		},
+		AddPullRequestReviewersFunc: &AzureDevOpsClientAddPullRequestReviewersFunc{
+			defaultHook: i.AddPullRequestReviewers,
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: i.Authenticator,
 		},
@@ -6849,6 +6934,9 @@ func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
 		GetAuthorizedProfileFunc: &AzureDevOpsClientGetAuthorizedProfileFunc{
 			defaultHook: i.GetAuthorizedProfile,
 		},
+		GetIdentityFunc: &AzureDevOpsClientGetIdentityFunc{
+			defaultHook: i.GetIdentity,
+		},
 		GetProjectFunc: &AzureDevOpsClientGetProjectFunc{
 			defaultHook: i.GetProject,
 		},
@@ -6873,9 +6961,18 @@ func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
 		ListAuthorizedUserOrganizationsFunc: &AzureDevOpsClientListAuthorizedUserOrganizationsFunc{
 			defaultHook: i.ListAuthorizedUserOrganizations,
 		},
+		ListPullRequestLabelsFunc: &AzureDevOpsClientListPullRequestLabelsFunc{
+			defaultHook: i.ListPullRequestLabels,
+		},
 		ListRepositoriesByProjectOrOrgFunc: &AzureDevOpsClientListRepositoriesByProjectOrOrgFunc{
 			defaultHook: i.ListRepositoriesByProjectOrOrg,
 		},
+		RemovePullRequestLabelFunc: &AzureDevOpsClientRemovePullRequestLabelFunc{
+			defaultHook: i.RemovePullRequestLabel,
+		},
+		RemovePullRequestReviewerFunc: &AzureDevOpsClientRemovePullRequestReviewerFunc{
+			defaultHook: i.RemovePullRequestReviewer,
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: i.SetWaitForRateLimit,
 		},
@@ -6999,6 +7096,236 @@ func (c AzureDevOpsClientAbandonPullRequestFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientAddPullRequestLabelFunc describes the behavior when the
+// AddPullRequestLabel method of the parent MockAzureDevOpsClient instance
+// is invoked.
+type AzureDevOpsClientAddPullRequestLabelFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error)
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error)
+	history     []AzureDevOpsClientAddPullRequestLabelFuncCall
+	mutex       sync.Mutex
+}
+
+// AddPullRequestLabel delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) AddPullRequestLabel(v0 context.Context, v1 azuredevops.PullRequestCommonArgs, v2 string) (azuredevops.Label, error) {
+	r0, r1 := m.AddPullRequestLabelFunc.nextHook()(v0, v1, v2)
+	m.AddPullRequestLabelFunc.appendCall(AzureDevOpsClientAddPullRequestLabelFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AddPullRequestLabel
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddPullRequestLabel method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) SetDefaultReturn(r0 azuredevops.Label, r1 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) PushReturn(r0 azuredevops.Label, r1 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Label, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) appendCall(r0 AzureDevOpsClientAddPullRequestLabelFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientAddPullRequestLabelFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) History() []AzureDevOpsClientAddPullRequestLabelFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientAddPullRequestLabelFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientAddPullRequestLabelFuncCall is an object that describes
+// an invocation of method AddPullRequestLabel on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientAddPullRequestLabelFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 azuredevops.Label
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestLabelFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestLabelFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientAddPullRequestReviewersFunc describes the behavior when
+// the AddPullRequestReviewers method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientAddPullRequestReviewersFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error)
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error)
+	history     []AzureDevOpsClientAddPullRequestReviewersFuncCall
+	mutex       sync.Mutex
+}
+
+// AddPullRequestReviewers delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) AddPullRequestReviewers(v0 context.Context, v1 azuredevops.PullRequestCommonArgs, v2 []azuredevops.Reviewer) ([]azuredevops.Reviewer, error) {
+	r0, r1 := m.AddPullRequestReviewersFunc.nextHook()(v0, v1, v2)
+	m.AddPullRequestReviewersFunc.appendCall(AzureDevOpsClientAddPullRequestReviewersFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// AddPullRequestReviewers method of the parent MockAzureDevOpsClient
+// instance is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddPullRequestReviewers method of the parent MockAzureDevOpsClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) SetDefaultReturn(r0 []azuredevops.Reviewer, r1 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) PushReturn(r0 []azuredevops.Reviewer, r1 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs, []azuredevops.Reviewer) ([]azuredevops.Reviewer, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) appendCall(r0 AzureDevOpsClientAddPullRequestReviewersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientAddPullRequestReviewersFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) History() []AzureDevOpsClientAddPullRequestReviewersFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientAddPullRequestReviewersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientAddPullRequestReviewersFuncCall is an object that
+// describes an invocation of method AddPullRequestReviewers on an instance
+// of MockAzureDevOpsClient.
+type AzureDevOpsClientAddPullRequestReviewersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []azuredevops.Reviewer
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []azuredevops.Reviewer
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestReviewersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestReviewersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AzureDevOpsClientAuthenticatorFunc describes the behavior when the
 // Authenticator method of the parent MockAzureDevOpsClient instance is
 // invoked.
@@ -7667,36 +7994,36 @@ func (c AzureDevOpsClientGetAuthorizedProfileFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// AzureDevOpsClientGetProjectFunc describes the behavior when the
-// GetProject method of the parent MockAzureDevOpsClient instance is
+// AzureDevOpsClientGetIdentityFunc describes the behavior when the
+// GetIdentity method of the parent MockAzureDevOpsClient instance is
 // invoked.
-type AzureDevOpsClientGetProjectFunc struct {
-	defaultHook func(context.Context, string, string) (azuredevops.Project, error)
-	hooks       []func(context.Context, string, string) (azuredevops.Project, error)
-	history     []AzureDevOpsClientGetProjectFuncCall
+type AzureDevOpsClientGetIdentityFunc struct {
+	defaultHook func(context.Context, string, string) (azuredevops.Identity, error)
+	hooks       []func(context.Context, string, string) (azuredevops.Identity, error)
+	history     []AzureDevOpsClientGetIdentityFuncCall
 	mutex       sync.Mutex
 }
 
-// GetProject delegates to the next hook function in the queue and stores
+// GetIdentity delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockAzureDevOpsClient) GetProject(v0 context.Context, v1 string, v2 string) (azuredevops.Project, error) {
-	r0, r1 := m.GetProjectFunc.nextHook()(v0, v1, v2)
-	m.GetProjectFunc.appendCall(AzureDevOpsClientGetProjectFuncCall{v0, v1, v2, r0, r1})
+func (m *MockAzureDevOpsClient) GetIdentity(v0 context.Context, v1 string, v2 string) (azuredevops.Identity, error) {
+	r0, r1 := m.GetIdentityFunc.nextHook()(v0, v1, v2)
+	m.GetIdentityFunc.appendCall(AzureDevOpsClientGetIdentityFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetProject method of
-// the parent MockAzureDevOpsClient instance is invoked and the hook queue
-// is empty.
-func (f *AzureDevOpsClientGetProjectFunc) SetDefaultHook(hook func(context.Context, string, string) (azuredevops.Project, error)) {
+// SetDefaultHook sets function that is called when the GetIdentity method
+// of the parent MockAzureDevOpsClient instance is invoked and the hook
+// queue is empty.
+func (f *AzureDevOpsClientGetIdentityFunc) SetDefaultHook(hook func(context.Context, string, string) (azuredevops.Identity, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetProject method of the parent MockAzureDevOpsClient instance invokes
+// GetIdentity method of the parent MockAzureDevOpsClient instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *AzureDevOpsClientGetProjectFunc) PushHook(hook func(context.Context, string, string) (azuredevops.Project, error)) {
+func (f *AzureDevOpsClientGetIdentityFunc) PushHook(hook func(context.Context, string, string) (azuredevops.Identity, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -7704,20 +8031,20 @@ func (f *AzureDevOpsClientGetProjectFunc) PushHook(hook func(context.Context, st
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *AzureDevOpsClientGetProjectFunc) SetDefaultReturn(r0 azuredevops.Project, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, string) (azuredevops.Project, error) {
+func (f *AzureDevOpsClientGetIdentityFunc) SetDefaultReturn(r0 azuredevops.Identity, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (azuredevops.Identity, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *AzureDevOpsClientGetProjectFunc) PushReturn(r0 azuredevops.Project, r1 error) {
-	f.PushHook(func(context.Context, string, string) (azuredevops.Project, error) {
+func (f *AzureDevOpsClientGetIdentityFunc) PushReturn(r0 azuredevops.Identity, r1 error) {
+	f.PushHook(func(context.Context, string, string) (azuredevops.Identity, error) {
 		return r0, r1
 	})
 }
 
-func (f *AzureDevOpsClientGetProjectFunc) nextHook() func(context.Context, string, string) (azuredevops.Project, error) {
+func (f *AzureDevOpsClientGetIdentityFunc) nextHook() func(context.Context, string, string) (azuredevops.Identity, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -7730,26 +8057,26 @@ func (f *AzureDevOpsClientGetProjectFunc) nextHook() func(context.Context, strin
 	return hook
 }
 
-func (f *AzureDevOpsClientGetProjectFunc) appendCall(r0 AzureDevOpsClientGetProjectFuncCall) {
+func (f *AzureDevOpsClientGetIdentityFunc) appendCall(r0 AzureDevOpsClientGetIdentityFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of AzureDevOpsClientGetProjectFuncCall objects
-// describing the invocations of this function.
-func (f *AzureDevOpsClientGetProjectFunc) History() []AzureDevOpsClientGetProjectFuncCall {
+// History returns a sequence of AzureDevOpsClientGetIdentityFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientGetIdentityFunc) History() []AzureDevOpsClientGetIdentityFuncCall {
 	f.mutex.Lock()
-	history := make([]AzureDevOpsClientGetProjectFuncCall, len(f.history))
+	history := make([]AzureDevOpsClientGetIdentityFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// AzureDevOpsClientGetProjectFuncCall is an object that describes an
-// invocation of method GetProject on an instance of MockAzureDevOpsClient.
-type AzureDevOpsClientGetProjectFuncCall struct {
+// AzureDevOpsClientGetIdentityFuncCall is an object that describes an
+// invocation of method GetIdentity on an instance of MockAzureDevOpsClient.
+type AzureDevOpsClientGetIdentityFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -7761,7 +8088,7 @@ type AzureDevOpsClientGetProjectFuncCall struct {
 	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 azuredevops.Project
+	Result0 azuredevops.Identity
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -7769,27 +8096,139 @@ type AzureDevOpsClientGetProjectFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c AzureDevOpsClientGetProjectFuncCall) Args() []interface{} {
+func (c AzureDevOpsClientGetIdentityFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c AzureDevOpsClientGetProjectFuncCall) Results() []interface{} {
+func (c AzureDevOpsClientGetIdentityFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// AzureDevOpsClientGetPullRequestFunc describes the behavior when the
-// GetPullRequest method of the parent MockAzureDevOpsClient instance is
+// AzureDevOpsClientGetProjectFunc describes the behavior when the
+// GetProject method of the parent MockAzureDevOpsClient instance is
 // invoked.
-type AzureDevOpsClientGetPullRequestFunc struct {
-	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs) (azuredevops.PullRequest, error)
-	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs) (azuredevops.PullRequest, error)
-	history     []AzureDevOpsClientGetPullRequestFuncCall
+type AzureDevOpsClientGetProjectFunc struct {
+	defaultHook func(context.Context, string, string) (azuredevops.Project, error)
+	hooks       []func(context.Context, string, string) (azuredevops.Project, error)
+	history     []AzureDevOpsClientGetProjectFuncCall
 	mutex       sync.Mutex
 }
 
-// GetPullRequest delegates to the next hook function in the queue and
+// GetProject delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) GetProject(v0 context.Context, v1 string, v2 string) (azuredevops.Project, error) {
+	r0, r1 := m.GetProjectFunc.nextHook()(v0, v1, v2)
+	m.GetProjectFunc.appendCall(AzureDevOpsClientGetProjectFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetProject method of
+// the parent MockAzureDevOpsClient instance is invoked and the hook queue
+// is empty.
+func (f *AzureDevOpsClientGetProjectFunc) SetDefaultHook(hook func(context.Context, string, string) (azuredevops.Project, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetProject method of the parent MockAzureDevOpsClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AzureDevOpsClientGetProjectFunc) PushHook(hook func(context.Context, string, string) (azuredevops.Project, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientGetProjectFunc) SetDefaultReturn(r0 azuredevops.Project, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (azuredevops.Project, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientGetProjectFunc) PushReturn(r0 azuredevops.Project, r1 error) {
+	f.PushHook(func(context.Context, string, string) (azuredevops.Project, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientGetProjectFunc) nextHook() func(context.Context, string, string) (azuredevops.Project, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientGetProjectFunc) appendCall(r0 AzureDevOpsClientGetProjectFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientGetProjectFuncCall objects
+// describing the invocations of this function.
+func (f *AzureDevOpsClientGetProjectFunc) History() []AzureDevOpsClientGetProjectFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientGetProjectFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientGetProjectFuncCall is an object that describes an
+// invocation of method GetProject on an instance of MockAzureDevOpsClient.
+type AzureDevOpsClientGetProjectFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 azuredevops.Project
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientGetProjectFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientGetProjectFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientGetPullRequestFunc describes the behavior when the
+// GetPullRequest method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientGetPullRequestFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs) (azuredevops.PullRequest, error)
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs) (azuredevops.PullRequest, error)
+	history     []AzureDevOpsClientGetPullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPullRequest delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
 func (m *MockAzureDevOpsClient) GetPullRequest(v0 context.Context, v1 azuredevops.PullRequestCommonArgs) (azuredevops.PullRequest, error) {
 	r0, r1 := m.GetPullRequestFunc.nextHook()(v0, v1)
@@ -8539,6 +8978,118 @@ func (c AzureDevOpsClientListAuthorizedUserOrganizationsFuncCall) Results() []in
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientListPullRequestLabelsFunc describes the behavior when
+// the ListPullRequestLabels method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientListPullRequestLabelsFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error)
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error)
+	history     []AzureDevOpsClientListPullRequestLabelsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListPullRequestLabels delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) ListPullRequestLabels(v0 context.Context, v1 azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error) {
+	r0, r1 := m.ListPullRequestLabelsFunc.nextHook()(v0, v1)
+	m.ListPullRequestLabelsFunc.appendCall(AzureDevOpsClientListPullRequestLabelsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListPullRequestLabels method of the parent MockAzureDevOpsClient instance
+// is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientListPullRequestLabelsFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListPullRequestLabels method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientListPullRequestLabelsFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientListPullRequestLabelsFunc) SetDefaultReturn(r0 []azuredevops.Label, r1 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientListPullRequestLabelsFunc) PushReturn(r0 []azuredevops.Label, r1 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientListPullRequestLabelsFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs) ([]azuredevops.Label, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientListPullRequestLabelsFunc) appendCall(r0 AzureDevOpsClientListPullRequestLabelsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientListPullRequestLabelsFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientListPullRequestLabelsFunc) History() []AzureDevOpsClientListPullRequestLabelsFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientListPullRequestLabelsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientListPullRequestLabelsFuncCall is an object that
+// describes an invocation of method ListPullRequestLabels on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientListPullRequestLabelsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []azuredevops.Label
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientListPullRequestLabelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientListPullRequestLabelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AzureDevOpsClientListRepositoriesByProjectOrOrgFunc describes the
 // behavior when the ListRepositoriesByProjectOrOrg method of the parent
 // MockAzureDevOpsClient instance is invoked.
@@ -8651,6 +9202,230 @@ func (c AzureDevOpsClientListRepositoriesByProjectOrOrgFuncCall) Results() []int
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientRemovePullRequestLabelFunc describes the behavior when
+// the RemovePullRequestLabel method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientRemovePullRequestLabelFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs, string) error
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs, string) error
+	history     []AzureDevOpsClientRemovePullRequestLabelFuncCall
+	mutex       sync.Mutex
+}
+
+// RemovePullRequestLabel delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) RemovePullRequestLabel(v0 context.Context, v1 azuredevops.PullRequestCommonArgs, v2 string) error {
+	r0 := m.RemovePullRequestLabelFunc.nextHook()(v0, v1, v2)
+	m.RemovePullRequestLabelFunc.appendCall(AzureDevOpsClientRemovePullRequestLabelFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// RemovePullRequestLabel method of the parent MockAzureDevOpsClient
+// instance is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientRemovePullRequestLabelFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RemovePullRequestLabel method of the parent MockAzureDevOpsClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AzureDevOpsClientRemovePullRequestLabelFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientRemovePullRequestLabelFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientRemovePullRequestLabelFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+		return r0
+	})
+}
+
+func (f *AzureDevOpsClientRemovePullRequestLabelFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientRemovePullRequestLabelFunc) appendCall(r0 AzureDevOpsClientRemovePullRequestLabelFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientRemovePullRequestLabelFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientRemovePullRequestLabelFunc) History() []AzureDevOpsClientRemovePullRequestLabelFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientRemovePullRequestLabelFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientRemovePullRequestLabelFuncCall is an object that
+// describes an invocation of method RemovePullRequestLabel on an instance
+// of MockAzureDevOpsClient.
+type AzureDevOpsClientRemovePullRequestLabelFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientRemovePullRequestLabelFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientRemovePullRequestLabelFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AzureDevOpsClientRemovePullRequestReviewerFunc describes the behavior
+// when the RemovePullRequestReviewer method of the parent
+// MockAzureDevOpsClient instance is invoked.
+type AzureDevOpsClientRemovePullRequestReviewerFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs, string) error
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs, string) error
+	history     []AzureDevOpsClientRemovePullRequestReviewerFuncCall
+	mutex       sync.Mutex
+}
+
+// RemovePullRequestReviewer delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) RemovePullRequestReviewer(v0 context.Context, v1 azuredevops.PullRequestCommonArgs, v2 string) error {
+	r0 := m.RemovePullRequestReviewerFunc.nextHook()(v0, v1, v2)
+	m.RemovePullRequestReviewerFunc.appendCall(AzureDevOpsClientRemovePullRequestReviewerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// RemovePullRequestReviewer method of the parent MockAzureDevOpsClient
+// instance is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientRemovePullRequestReviewerFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RemovePullRequestReviewer method of the parent MockAzureDevOpsClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AzureDevOpsClientRemovePullRequestReviewerFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientRemovePullRequestReviewerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientRemovePullRequestReviewerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+		return r0
+	})
+}
+
+func (f *AzureDevOpsClientRemovePullRequestReviewerFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientRemovePullRequestReviewerFunc) appendCall(r0 AzureDevOpsClientRemovePullRequestReviewerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientRemovePullRequestReviewerFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientRemovePullRequestReviewerFunc) History() []AzureDevOpsClientRemovePullRequestReviewerFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientRemovePullRequestReviewerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientRemovePullRequestReviewerFuncCall is an object that
+// describes an invocation of method RemovePullRequestReviewer on an
+// instance of MockAzureDevOpsClient.
+type AzureDevOpsClientRemovePullRequestReviewerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientRemovePullRequestReviewerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientRemovePullRequestReviewerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // AzureDevOpsClientSetWaitForRateLimitFunc describes the behavior when the
 // SetWaitForRateLimit method of the parent MockAzureDevOpsClient instance
 // is invoked.
@@ -8983,6 +9758,9 @@ type MockGerritClient struct {
 	// AbandonChangeFunc is an instance of a mock function object
 	// controlling the behavior of the method AbandonChange.
 	AbandonChangeFunc *GerritClientAbandonChangeFunc
+	// AddReviewerFunc is an instance of a mock function object controlling
+	// the behavior of the method AddReviewer.
+	AddReviewerFunc *GerritClientAddReviewerFunc
 	// AuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method Authenticator.
 	AuthenticatorFunc *GerritClientAuthenticatorFunc
@@ -9008,18 +9786,27 @@ type MockGerritClient struct {
 	// GetURLFunc is an instance of a mock function object controlling the
 	// behavior of the method GetURL.
 	GetURLFunc *GerritClientGetURLFunc
+	// ListGroupMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method ListGroupMembers.
+	ListGroupMembersFunc *GerritClientListGroupMembersFunc
 	// ListProjectsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListProjects.
 	ListProjectsFunc *GerritClientListProjectsFunc
 	// MoveChangeFunc is an instance of a mock function object controlling
 	// the behavior of the method MoveChange.
 	MoveChangeFunc *GerritClientMoveChangeFunc
+	// RemoveReviewerFunc is an instance of a mock function object
+	// controlling the behavior of the method RemoveReviewer.
+	RemoveReviewerFunc *GerritClientRemoveReviewerFunc
 	// RestoreChangeFunc is an instance of a mock function object
 	// controlling the behavior of the method RestoreChange.
 	RestoreChangeFunc *GerritClientRestoreChangeFunc
 	// SetCommitMessageFunc is an instance of a mock function object
 	// controlling the behavior of the method SetCommitMessage.
 	SetCommitMessageFunc *GerritClientSetCommitMessageFunc
+	// SetHashtagsFunc is an instance of a mock function object controlling
+	// the behavior of the method SetHashtags.
+	SetHashtagsFunc *GerritClientSetHashtagsFunc
 	// SetReadyForReviewFunc is an instance of a mock function object
 	// controlling the behavior of the method SetReadyForReview.
 	SetReadyForReviewFunc *GerritClientSetReadyForReviewFunc
@@ -9046,6 +9833,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		AddReviewerFunc: &GerritClientAddReviewerFunc{
+			defaultHook: func(context.Context, string, gerrit.AddReviewerPayload) (r0 error) {
+				return
+			},
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: func() (r0 auth.Authenticator) {
 				return
@@ -9086,6 +9878,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		ListGroupMembersFunc: &GerritClientListGroupMembersFunc{
+			defaultHook: func(context.Context, string) (r0 []gerrit.Account, r1 error) {
+				return
+			},
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: func(context.Context, gerrit.ListProjectsArgs) (r0 gerrit.ListProjectsResponse, r1 bool, r2 error) {
 				return
@@ -9096,6 +9893,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		RemoveReviewerFunc: &GerritClientRemoveReviewerFunc{
+			defaultHook: func(context.Context, string, string) (r0 error) {
+				return
+			},
+		},
 		RestoreChangeFunc: &GerritClientRestoreChangeFunc{
 			defaultHook: func(context.Context, string) (r0 *gerrit.Change, r1 error) {
 				return
@@ -9106,6 +9908,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		SetHashtagsFunc: &GerritClientSetHashtagsFunc{
+			defaultHook: func(context.Context, string, gerrit.SetHashtagsPayload) (r0 []string, r1 error) {
+				return
+			},
+		},
 		SetReadyForReviewFunc: &GerritClientSetReadyForReviewFunc{
 			defaultHook: func(context.Context, string) (r0 error) {
 				return
@@ -9143,6 +9950,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.AbandonChange")
 			},
 		},
+		AddReviewerFunc: &GerritClientAddReviewerFunc{
+			defaultHook: func(context.Context, string, gerrit.AddReviewerPayload) error {
+				panic("unexpected invocation of MockGerritClient.AddReviewer")
+			},
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: func() auth.Authenticator {
 				panic("unexpected invocation of MockGerritClient.Authenticator")
@@ -9183,6 +9995,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.GetURL")
 			},
 		},
+		ListGroupMembersFunc: &GerritClientListGroupMembersFunc{
+			defaultHook: func(context.Context, string) ([]gerrit.Account, error) {
+				panic("unexpected invocation of MockGerritClient.ListGroupMembers")
+			},
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: func(context.Context, gerrit.ListProjectsArgs) (gerrit.ListProjectsResponse, bool, error) {
 				panic("unexpected invocation of MockGerritClient.ListProjects")
@@ -9193,6 +10010,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.MoveChange")
 			},
 		},
+		RemoveReviewerFunc: &GerritClientRemoveReviewerFunc{
+			defaultHook: func(context.Context, string, string) error {
+				panic("unexpected invocation of MockGerritClient.RemoveReviewer")
+			},
+		},
 		RestoreChangeFunc: &GerritClientRestoreChangeFunc{
 			defaultHook: func(context.Context, string) (*gerrit.Change, error) {
 				panic("unexpected invocation of MockGerritClient.RestoreChange")
@@ -9203,6 +10025,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.SetCommitMessage")
 			},
 		},
+		SetHashtagsFunc: &GerritClientSetHashtagsFunc{
+			defaultHook: func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+				panic("unexpected invocation of MockGerritClient.SetHashtags")
+			},
+		},
 		SetReadyForReviewFunc: &GerritClientSetReadyForReviewFunc{
 			defaultHook: func(context.Context, string) error {
 				panic("unexpected invocation of MockGerritClient.SetReadyForReview")
@@ -9239,6 +10066,9 @@ func NewMockGerritClientFrom(i gerrit.Client) *MockGerritClient {
 		AbandonChangeFunc: &GerritClientAbandonChangeFunc{
 			defaultHook: i.AbandonChange,
 		},
+		AddReviewerFunc: &GerritClientAddReviewerFunc{
+			defaultHook: i.AddReviewer,
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: i.Authenticator,
 		},
@@ -9263,18 +10093,27 @@ func NewMockGerritClientFrom(i gerrit.Client) *MockGerritClient {
 		GetURLFunc: &GerritClientGetURLFunc{
 			defaultHook: i.GetURL,
 		},
+		ListGroupMembersFunc: &GerritClientListGroupMembersFunc{
+			defaultHook: i.ListGroupMembers,
+		},
 		ListProjectsFunc: &GerritClientListProjectsFunc{
 			defaultHook: i.ListProjects,
 		},
 		MoveChangeFunc: &GerritClientMoveChangeFunc{
 			defaultHook: i.MoveChange,
 		},
+		RemoveReviewerFunc: &GerritClientRemoveReviewerFunc{
+			defaultHook: i.RemoveReviewer,
+		},
 		RestoreChangeFunc: &GerritClientRestoreChangeFunc{
 			defaultHook: i.RestoreChange,
 		},
 		SetCommitMessageFunc: &GerritClientSetCommitMessageFunc{
 			defaultHook: i.SetCommitMessage,
 		},
+		SetHashtagsFunc: &GerritClientSetHashtagsFunc{
+			defaultHook: i.SetHashtags,
+		},
 		SetReadyForReviewFunc: &GerritClientSetReadyForReviewFunc{
 			defaultHook: i.SetReadyForReview,
 		},
@@ -9381,24 +10220,132 @@ type GerritClientAbandonChangeFuncCall struct {
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 string
-	// Result0 is the value of the 1st result returned from this method
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gerrit.Change
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientAbandonChangeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientAbandonChangeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GerritClientAddReviewerFunc describes the behavior when the AddReviewer
+// method of the parent MockGerritClient instance is invoked.
+type GerritClientAddReviewerFunc struct {
+	defaultHook func(context.Context, string, gerrit.AddReviewerPayload) error
+	hooks       []func(context.Context, string, gerrit.AddReviewerPayload) error
+	history     []GerritClientAddReviewerFuncCall
+	mutex       sync.Mutex
+}
+
+// AddReviewer delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGerritClient) AddReviewer(v0 context.Context, v1 string, v2 gerrit.AddReviewerPayload) error {
+	r0 := m.AddReviewerFunc.nextHook()(v0, v1, v2)
+	m.AddReviewerFunc.appendCall(GerritClientAddReviewerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddReviewer method
+// of the parent MockGerritClient instance is invoked and the hook queue is
+// empty.
+func (f *GerritClientAddReviewerFunc) SetDefaultHook(hook func(context.Context, string, gerrit.AddReviewerPayload) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddReviewer method of the parent MockGerritClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GerritClientAddReviewerFunc) PushHook(hook func(context.Context, string, gerrit.AddReviewerPayload) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientAddReviewerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, gerrit.AddReviewerPayload) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientAddReviewerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, gerrit.AddReviewerPayload) error {
+		return r0
+	})
+}
+
+func (f *GerritClientAddReviewerFunc) nextHook() func(context.Context, string, gerrit.AddReviewerPayload) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientAddReviewerFunc) appendCall(r0 GerritClientAddReviewerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientAddReviewerFuncCall objects
+// describing the invocations of this function.
+func (f *GerritClientAddReviewerFunc) History() []GerritClientAddReviewerFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientAddReviewerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientAddReviewerFuncCall is an object that describes an invocation
+// of method AddReviewer on an instance of MockGerritClient.
+type GerritClientAddReviewerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 *gerrit.Change
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 gerrit.AddReviewerPayload
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GerritClientAbandonChangeFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c GerritClientAddReviewerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GerritClientAbandonChangeFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c GerritClientAddReviewerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
 // GerritClientAuthenticatorFunc describes the behavior when the
@@ -10245,6 +11192,115 @@ func (c GerritClientGetURLFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GerritClientListGroupMembersFunc describes the behavior when the
+// ListGroupMembers method of the parent MockGerritClient instance is
+// invoked.
+type GerritClientListGroupMembersFunc struct {
+	defaultHook func(context.Context, string) ([]gerrit.Account, error)
+	hooks       []func(context.Context, string) ([]gerrit.Account, error)
+	history     []GerritClientListGroupMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// ListGroupMembers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGerritClient) ListGroupMembers(v0 context.Context, v1 string) ([]gerrit.Account, error) {
+	r0, r1 := m.ListGroupMembersFunc.nextHook()(v0, v1)
+	m.ListGroupMembersFunc.appendCall(GerritClientListGroupMembersFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListGroupMembers
+// method of the parent MockGerritClient instance is invoked and the hook
+// queue is empty.
+func (f *GerritClientListGroupMembersFunc) SetDefaultHook(hook func(context.Context, string) ([]gerrit.Account, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListGroupMembers method of the parent MockGerritClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GerritClientListGroupMembersFunc) PushHook(hook func(context.Context, string) ([]gerrit.Account, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientListGroupMembersFunc) SetDefaultReturn(r0 []gerrit.Account, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]gerrit.Account, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientListGroupMembersFunc) PushReturn(r0 []gerrit.Account, r1 error) {
+	f.PushHook(func(context.Context, string) ([]gerrit.Account, error) {
+		return r0, r1
+	})
+}
+
+func (f *GerritClientListGroupMembersFunc) nextHook() func(context.Context, string) ([]gerrit.Account, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientListGroupMembersFunc) appendCall(r0 GerritClientListGroupMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientListGroupMembersFuncCall
+// objects describing the invocations of this function.
+func (f *GerritClientListGroupMembersFunc) History() []GerritClientListGroupMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientListGroupMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientListGroupMembersFuncCall is an object that describes an
+// invocation of method ListGroupMembers on an instance of MockGerritClient.
+type GerritClientListGroupMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gerrit.Account
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientListGroupMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientListGroupMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GerritClientListProjectsFunc describes the behavior when the ListProjects
 // method of the parent MockGerritClient instance is invoked.
 type GerritClientListProjectsFunc struct {
@@ -10467,6 +11523,114 @@ func (c GerritClientMoveChangeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GerritClientRemoveReviewerFunc describes the behavior when the
+// RemoveReviewer method of the parent MockGerritClient instance is invoked.
+type GerritClientRemoveReviewerFunc struct {
+	defaultHook func(context.Context, string, string) error
+	hooks       []func(context.Context, string, string) error
+	history     []GerritClientRemoveReviewerFuncCall
+	mutex       sync.Mutex
+}
+
+// RemoveReviewer delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGerritClient) RemoveReviewer(v0 context.Context, v1 string, v2 string) error {
+	r0 := m.RemoveReviewerFunc.nextHook()(v0, v1, v2)
+	m.RemoveReviewerFunc.appendCall(GerritClientRemoveReviewerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RemoveReviewer
+// method of the parent MockGerritClient instance is invoked and the hook
+// queue is empty.
+func (f *GerritClientRemoveReviewerFunc) SetDefaultHook(hook func(context.Context, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RemoveReviewer method of the parent MockGerritClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GerritClientRemoveReviewerFunc) PushHook(hook func(context.Context, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientRemoveReviewerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientRemoveReviewerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+func (f *GerritClientRemoveReviewerFunc) nextHook() func(context.Context, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientRemoveReviewerFunc) appendCall(r0 GerritClientRemoveReviewerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientRemoveReviewerFuncCall objects
+// describing the invocations of this function.
+func (f *GerritClientRemoveReviewerFunc) History() []GerritClientRemoveReviewerFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientRemoveReviewerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientRemoveReviewerFuncCall is an object that describes an
+// invocation of method RemoveReviewer on an instance of MockGerritClient.
+type GerritClientRemoveReviewerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientRemoveReviewerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientRemoveReviewerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GerritClientRestoreChangeFunc describes the behavior when the
 // RestoreChange method of the parent MockGerritClient instance is invoked.
 type GerritClientRestoreChangeFunc struct {
@@ -10684,6 +11848,117 @@ func (c GerritClientSetCommitMessageFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GerritClientSetHashtagsFunc describes the behavior when the SetHashtags
+// method of the parent MockGerritClient instance is invoked.
+type GerritClientSetHashtagsFunc struct {
+	defaultHook func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)
+	hooks       []func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)
+	history     []GerritClientSetHashtagsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetHashtags delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGerritClient) SetHashtags(v0 context.Context, v1 string, v2 gerrit.SetHashtagsPayload) ([]string, error) {
+	r0, r1 := m.SetHashtagsFunc.nextHook()(v0, v1, v2)
+	m.SetHashtagsFunc.appendCall(GerritClientSetHashtagsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SetHashtags method
+// of the parent MockGerritClient instance is invoked and the hook queue is
+// empty.
+func (f *GerritClientSetHashtagsFunc) SetDefaultHook(hook func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetHashtags method of the parent MockGerritClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GerritClientSetHashtagsFunc) PushHook(hook func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientSetHashtagsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientSetHashtagsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *GerritClientSetHashtagsFunc) nextHook() func(context.Context, string, gerrit.SetHashtagsPayload) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientSetHashtagsFunc) appendCall(r0 GerritClientSetHashtagsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientSetHashtagsFuncCall objects
+// describing the invocations of this function.
+func (f *GerritClientSetHashtagsFunc) History() []GerritClientSetHashtagsFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientSetHashtagsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientSetHashtagsFuncCall is an object that describes an invocation
+// of method SetHashtags on an instance of MockGerritClient.
+type GerritClientSetHashtagsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 gerrit.SetHashtagsPayload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientSetHashtagsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientSetHashtagsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GerritClientSetReadyForReviewFunc describes the behavior when the
 // SetReadyForReview method of the parent MockGerritClient instance is
 // invoked.
@@ -788,6 +788,98 @@ func selectReviewState(states map[btypes.ChangesetReviewState]bool) btypes.Chang
 	return btypes.ChangesetReviewStatePending
 }
 
+// CountApprovals returns the number of reviewers that currently approve the
+// changeset. Reviewers that approved the changeset and later requested changes
+// or had their review dismissed are not counted.
+func CountApprovals(c *btypes.Changeset) int {
+	approvals := 0
+
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		// Reviews are listed in chronological order, so the last review of
+		// each author that isn't a comment is the one that counts.
+		latest := map[string]string{}
+		for _, item := range m.TimelineItems {
+			review, ok := item.Item.(*github.PullRequestReview)
+			if !ok || review.State == "COMMENTED" || review.State == "PENDING" {
+				continue
+			}
+			latest[review.Author.Login] = review.State
+		}
+		for _, state := range latest {
+			if state == "APPROVED" {
+				approvals++
+			}
+		}
+
+	case *bitbucketserver.PullRequest:
+		for _, r := range m.Reviewers {
+			if r.Status == "APPROVED" {
+				approvals++
+			}
+		}
+
+	case *gitlab.MergeRequest:
+		// Notes are listed with the most recent first, so the first approval
+		// or unapproval of each author is the one that counts.
+		seen := map[string]bool{}
+		for _, note := range m.Notes {
+			e := note.ToEvent()
+			switch e.(type) {
+			case *gitlab.ReviewApprovedEvent, *gitlab.ReviewUnapprovedEvent:
+			default:
+				continue
+			}
+			if seen[note.Author.Username] {
+				continue
+			}
+			seen[note.Author.Username] = true
+			if _, ok := e.(*gitlab.ReviewApprovedEvent); ok {
+				approvals++
+			}
+		}
+
+	case *bbcs.AnnotatedPullRequest:
+		for _, participant := range m.Participants {
+			if participant.State == bitbucketcloud.ParticipantStateApproved {
+				approvals++
+			}
+		}
+
+	case *azuredevops.AnnotatedPullRequest:
+		for _, reviewer := range m.Reviewers {
+			if reviewer.Vote == 10 {
+				approvals++
+			}
+		}
+
+	case *gerritbatches.AnnotatedChange:
+		for _, reviewer := range m.Reviewers {
+			switch reviewer.Approvals[gerrit.CodeReviewKey] {
+			case "+2", "+1":
+				approvals++
+			}
+		}
+	}
+
+	return approvals
+}
+
+// IsConflicted returns true if the code host reports that the changeset can't
+// be merged into its base branch because of merge conflicts. Code hosts that
+// don't report conflicts never have conflicted changesets.
+func IsConflicted(c *btypes.Changeset) bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.Mergeable == "CONFLICTING"
+	case *gitlab.MergeRequest:
+		return m.HasConflicts
+	case *azuredevops.AnnotatedPullRequest:
+		return m.MergeStatus == "conflicts"
+	}
+	return false
+}
+
 // computeDiffStat computes the up to date diffstat for the changeset, based on
 // the values in c.SyncState.
 func computeDiffStat(ctx context.Context, client gitserver.Client, c *btypes.Changeset, repo api.RepoName) (*diff.Stat, error) {
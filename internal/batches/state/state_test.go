@@ -697,6 +697,113 @@ func TestComputeReviewState(t *testing.T) {
 	}
 }
 
+func TestCountApprovals(t *testing.T) {
+	t.Parallel()
+
+	review := func(login, state string) github.TimelineItem {
+		return github.TimelineItem{
+			Type: "PullRequestReview",
+			Item: &github.PullRequestReview{Author: github.Actor{Login: login}, State: state},
+		}
+	}
+	note := func(username string, body gitlab.SystemNoteBody) *gitlab.Note {
+		return &gitlab.Note{System: true, Body: body, Author: gitlab.User{Username: username}}
+	}
+
+	for name, tc := range map[string]struct {
+		changeset *btypes.Changeset
+		want      int
+	}{
+		"github - latest review of each author counts": {
+			changeset: &btypes.Changeset{Metadata: &github.PullRequest{TimelineItems: []github.TimelineItem{
+				review("alice", "APPROVED"),
+				review("bob", "APPROVED"),
+				review("bob", "CHANGES_REQUESTED"),
+				review("carol", "CHANGES_REQUESTED"),
+				review("carol", "APPROVED"),
+				review("carol", "COMMENTED"),
+				review("dave", "APPROVED"),
+				review("dave", "DISMISSED"),
+			}}},
+			want: 2,
+		},
+		"bitbucketserver": {
+			changeset: &btypes.Changeset{Metadata: &bitbucketserver.PullRequest{Reviewers: []bitbucketserver.Reviewer{
+				{Status: "APPROVED"},
+				{Status: "NEEDS_WORK"},
+				{Status: "APPROVED"},
+			}}},
+			want: 2,
+		},
+		"gitlab - most recent note of each author counts": {
+			changeset: gitLabChangeset(time.Now(), gitlab.MergeRequestStateOpened, []*gitlab.Note{
+				note("alice", gitlab.SystemNoteBodyReviewApproved),
+				note("bob", gitlab.SystemNoteBodyReviewUnapproved),
+				note("bob", gitlab.SystemNoteBodyReviewApproved),
+				note("alice", gitlab.SystemNoteBodyReviewUnapproved),
+				{Body: "looks good to me", Author: gitlab.User{Username: "carol"}},
+			}),
+			want: 1,
+		},
+		"azuredevops": {
+			changeset: &btypes.Changeset{Metadata: &azuredevops2.AnnotatedPullRequest{PullRequest: &azuredevops.PullRequest{
+				Reviewers: []azuredevops.Reviewer{{Vote: 10}, {Vote: 5}, {Vote: 0}},
+			}}},
+			want: 1,
+		},
+		"perforce": {
+			changeset: perforceChangeset(time.Now(), perforce.ChangelistStateSubmitted),
+			want:      0,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CountApprovals(tc.changeset))
+		})
+	}
+}
+
+func TestIsConflicted(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		changeset *btypes.Changeset
+		want      bool
+	}{
+		"github conflicting": {
+			changeset: &btypes.Changeset{Metadata: &github.PullRequest{Mergeable: "CONFLICTING"}},
+			want:      true,
+		},
+		"github unknown": {
+			changeset: &btypes.Changeset{Metadata: &github.PullRequest{Mergeable: "UNKNOWN"}},
+			want:      false,
+		},
+		"gitlab conflicting": {
+			changeset: &btypes.Changeset{Metadata: &gitlab.MergeRequest{HasConflicts: true}},
+			want:      true,
+		},
+		"azuredevops conflicting": {
+			changeset: &btypes.Changeset{Metadata: &azuredevops2.AnnotatedPullRequest{PullRequest: &azuredevops.PullRequest{
+				MergeStatus: "conflicts",
+			}}},
+			want: true,
+		},
+		"azuredevops succeeded": {
+			changeset: &btypes.Changeset{Metadata: &azuredevops2.AnnotatedPullRequest{PullRequest: &azuredevops.PullRequest{
+				MergeStatus: "succeeded",
+			}}},
+			want: false,
+		},
+		"bitbucketserver": {
+			changeset: &btypes.Changeset{Metadata: &bitbucketserver.PullRequest{}},
+			want:      false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsConflicted(tc.changeset))
+		})
+	}
+}
+
 func TestComputeExternalState(t *testing.T) {
 	t.Parallel()
 
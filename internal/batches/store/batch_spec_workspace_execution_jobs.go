@@ -235,6 +235,9 @@ FROM
 %s
 WHERE
 	%s
+-- Workspaces can have more than one job, if they were executed again to
+-- rebase their changesets. The latest one is the current one.
+ORDER BY batch_spec_workspace_execution_jobs.id DESC
 LIMIT 1
 `
 
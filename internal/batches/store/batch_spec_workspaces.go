@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"sort"
+	"strconv"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -146,6 +147,9 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 // GetBatchSpecWorkspaceOpts captures the query options needed for getting a BatchSpecWorkspace
 type GetBatchSpecWorkspaceOpts struct {
 	ID int64
+	// ChangesetSpecID, if set, finds the workspace that produced the
+	// changeset spec with the given ID.
+	ChangesetSpecID int64
 }
 
 // GetBatchSpecWorkspace gets a BatchSpecWorkspace matching the given options.
@@ -181,7 +185,14 @@ LIMIT 1
 func getBatchSpecWorkspaceQuery(opts *GetBatchSpecWorkspaceOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
-		sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID),
+	}
+
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID))
+	}
+
+	if opts.ChangesetSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.changeset_spec_ids ? %s", strconv.FormatInt(opts.ChangesetSpecID, 10)))
 	}
 
 	return sqlf.Sprintf(
@@ -230,7 +241,7 @@ func (opts ListBatchSpecWorkspacesOpts) SQLConds(ctx context.Context, db databas
 		if joinedExecution {
 			return
 		}
-		joins = append(joins, sqlf.Sprintf("LEFT JOIN batch_spec_workspace_execution_jobs ON batch_spec_workspace_execution_jobs.batch_spec_workspace_id = batch_spec_workspaces.id"))
+		joins = append(joins, sqlf.Sprintf(latestBatchSpecWorkspaceExecutionJobJoin))
 		joinedExecution = true
 	}
 
@@ -281,6 +292,16 @@ func (opts ListBatchSpecWorkspacesOpts) SQLConds(ctx context.Context, db databas
 	return sqlf.Join(preds, "\n AND "), sqlf.Join(joins, "\n"), nil
 }
 
+// latestBatchSpecWorkspaceExecutionJobJoin joins the batch spec workspaces
+// with their latest execution job. Workspaces whose changesets were rebased
+// have been executed more than once, and the earlier jobs are kept around.
+const latestBatchSpecWorkspaceExecutionJobJoin = `LEFT JOIN LATERAL (
+	SELECT * FROM batch_spec_workspace_execution_jobs
+	WHERE batch_spec_workspace_execution_jobs.batch_spec_workspace_id = batch_spec_workspaces.id
+	ORDER BY batch_spec_workspace_execution_jobs.id DESC
+	LIMIT 1
+) AS batch_spec_workspace_execution_jobs ON TRUE`
+
 // ListBatchSpecWorkspaces lists batch spec workspaces with the given filters.
 func (s *Store) ListBatchSpecWorkspaces(ctx context.Context, opts ListBatchSpecWorkspacesOpts) (cs []*btypes.BatchSpecWorkspace, next int64, err error) {
 	ctx, _, endObservation := s.operations.listBatchSpecWorkspaces.With(ctx, &err, observation.Args{})
@@ -443,11 +464,54 @@ const listRetryBatchSpecWorkspacesFmtstr = `
 SELECT batch_spec_workspaces.id, batch_spec_workspaces.changeset_spec_ids
 FROM batch_spec_workspaces
 		 INNER JOIN repo ON repo.id = batch_spec_workspaces.repo_id
-		 INNER JOIN batch_spec_workspace_execution_jobs
-					ON batch_spec_workspaces.id = batch_spec_workspace_execution_jobs.batch_spec_workspace_id
+		 ` + latestBatchSpecWorkspaceExecutionJobJoin + `
 WHERE %s
+AND batch_spec_workspace_execution_jobs.id IS NOT NULL
+`
+
+const rebaseBatchSpecWorkspaceQueryFmtstr = `
+UPDATE
+	batch_spec_workspaces
+SET
+	commit = %s,
+	step_cache_results = %s,
+	cached_result_found = FALSE,
+	updated_at = %s
+WHERE
+	id = %s
 `
 
+// RebaseBatchSpecWorkspace moves the given workspace onto the commit and
+// step cache results set on ws and queues a new execution job for it. The
+// previous execution jobs of the workspace, and their logs, are kept. It is
+// used to regenerate the changesets of a workspace after its base branch
+// moved.
+func (s *Store) RebaseBatchSpecWorkspace(ctx context.Context, ws *btypes.BatchSpecWorkspace) (err error) {
+	ctx, _, endObservation := s.operations.rebaseBatchSpecWorkspace.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(ws.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	marshaledStepCacheResults, err := json.Marshal(ws.StepCacheResults)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	ws.UpdatedAt = s.now()
+	ws.CachedResultFound = false
+	q := sqlf.Sprintf(rebaseBatchSpecWorkspaceQueryFmtstr, ws.Commit, marshaledStepCacheResults, ws.UpdatedAt, ws.ID)
+	if err := tx.Exec(ctx, q); err != nil {
+		return err
+	}
+	return tx.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx, []int64{ws.ID})
+}
+
 const disableBatchSpecWorkspaceExecutionCacheQueryFmtstr = `
 WITH batch_spec AS (
 	SELECT
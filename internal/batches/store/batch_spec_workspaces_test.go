@@ -107,6 +107,17 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 			}
 		})
 
+		t.Run("GetByChangesetSpecID", func(t *testing.T) {
+			have, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ChangesetSpecID: workspaces[1].ChangesetSpecIDs[1]})
+			require.NoError(t, err)
+			if diff := cmp.Diff(have, workspaces[1]); diff != "" {
+				t.Fatal(diff)
+			}
+
+			_, err = s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ChangesetSpecID: 0xdeadbeef})
+			assert.Equal(t, ErrNoResults, err)
+		})
+
 		t.Run("NoResults", func(t *testing.T) {
 			opts := GetBatchSpecWorkspaceOpts{ID: 0xdeadbeef}
 
@@ -501,4 +512,57 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 		})
 		require.Error(t, err, ErrNoResults)
 	})
+
+	t.Run("RebaseBatchSpecWorkspace", func(t *testing.T) {
+		bs := &btypes.BatchSpec{UserID: user.ID, NamespaceUserID: user.ID}
+		require.NoError(t, s.CreateBatchSpec(ctx, bs))
+
+		workspace := &btypes.BatchSpecWorkspace{
+			BatchSpecID:       bs.ID,
+			RepoID:            repos[0].ID,
+			Commit:            "d34db33f",
+			CachedResultFound: true,
+		}
+		require.NoError(t, s.CreateBatchSpecWorkspace(ctx, workspace))
+		require.NoError(t, s.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx, []int64{workspace.ID}))
+		oldJob, err := s.GetBatchSpecWorkspaceExecutionJob(ctx, GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: workspace.ID, ExcludeRank: true})
+		require.NoError(t, err)
+		require.NoError(t, s.Exec(ctx, sqlf.Sprintf("UPDATE batch_spec_workspace_execution_jobs SET state = %s WHERE id = %s", btypes.BatchSpecWorkspaceExecutionJobStateCompleted, oldJob.ID)))
+
+		workspace.Commit = "f00b4r"
+		workspace.StepCacheResults = map[int]btypes.StepCacheResult{
+			1: {Key: "asdf", Value: &execution.AfterStepResult{StepIndex: 0}},
+		}
+		require.NoError(t, s.RebaseBatchSpecWorkspace(ctx, workspace))
+
+		have, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		require.NoError(t, err)
+		if diff := cmp.Diff(workspace, have); diff != "" {
+			t.Fatalf("invalid workspace state: %s", diff)
+		}
+		assert.False(t, have.CachedResultFound)
+		// The results of the steps the rebase doesn't affect are kept.
+		assert.Len(t, have.StepCacheResults, 1)
+
+		job, err := s.GetBatchSpecWorkspaceExecutionJob(ctx, GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: workspace.ID, ExcludeRank: true})
+		require.NoError(t, err)
+		assert.NotEqual(t, oldJob.ID, job.ID)
+		assert.Equal(t, btypes.BatchSpecWorkspaceExecutionJobStateQueued, job.State)
+
+		// The previous job and its logs are kept.
+		jobs, err := s.ListBatchSpecWorkspaceExecutionJobs(ctx, ListBatchSpecWorkspaceExecutionJobsOpts{BatchSpecWorkspaceIDs: []int64{workspace.ID}, ExcludeRank: true})
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		assert.Equal(t, oldJob.ID, jobs[0].ID)
+		assert.Equal(t, btypes.BatchSpecWorkspaceExecutionJobStateCompleted, jobs[0].State)
+
+		// Only the latest job is considered when listing workspaces.
+		workspaces, _, err := s.ListBatchSpecWorkspaces(ctx, ListBatchSpecWorkspacesOpts{BatchSpecID: bs.ID, State: btypes.BatchSpecWorkspaceExecutionJobStateCompleted})
+		require.NoError(t, err)
+		assert.Empty(t, workspaces)
+		workspaces, _, err = s.ListBatchSpecWorkspaces(ctx, ListBatchSpecWorkspacesOpts{BatchSpecID: bs.ID, State: btypes.BatchSpecWorkspaceExecutionJobStateQueued})
+		require.NoError(t, err)
+		require.Len(t, workspaces, 1)
+		assert.Equal(t, workspace.ID, workspaces[0].ID)
+	})
 }
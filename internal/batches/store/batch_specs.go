@@ -600,7 +600,12 @@ SELECT
 FROM batch_specs
 LEFT JOIN batch_spec_resolution_jobs res_job ON res_job.batch_spec_id = batch_specs.id
 LEFT JOIN batch_spec_workspaces ws ON ws.batch_spec_id = batch_specs.id
-LEFT JOIN batch_spec_workspace_execution_jobs jobs ON jobs.batch_spec_workspace_id = ws.id
+LEFT JOIN LATERAL (
+	SELECT * FROM batch_spec_workspace_execution_jobs
+	WHERE batch_spec_workspace_execution_jobs.batch_spec_workspace_id = ws.id
+	ORDER BY batch_spec_workspace_execution_jobs.id DESC
+	LIMIT 1
+) AS jobs ON TRUE
 WHERE
 	%s
 GROUP BY batch_specs.id, res_job.state
@@ -0,0 +1,261 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var batchStepDefinitionColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_step_definitions.id"),
+	sqlf.Sprintf("batch_step_definitions.namespace_user_id"),
+	sqlf.Sprintf("batch_step_definitions.namespace_org_id"),
+	sqlf.Sprintf("batch_step_definitions.name"),
+	sqlf.Sprintf("batch_step_definitions.version"),
+	sqlf.Sprintf("batch_step_definitions.raw_definition"),
+	sqlf.Sprintf("batch_step_definitions.definition"),
+	sqlf.Sprintf("batch_step_definitions.creator_id"),
+	sqlf.Sprintf("batch_step_definitions.created_at"),
+}
+
+// ErrBatchStepDefinitionExists is returned by CreateBatchStepDefinition if the
+// version of the step definition has already been published in the namespace.
+type ErrBatchStepDefinitionExists struct {
+	Name    string
+	Version string
+}
+
+func (e ErrBatchStepDefinitionExists) Error() string {
+	return fmt.Sprintf("version %s of the step definition %s already exists", e.Version, e.Name)
+}
+
+// CreateBatchStepDefinition creates the given BatchStepDefinition. Versions of
+// step definitions can't be updated once they're created.
+func (s *Store) CreateBatchStepDefinition(ctx context.Context, d *btypes.BatchStepDefinition) (err error) {
+	ctx, _, endObservation := s.operations.createBatchStepDefinition.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q, err := s.createBatchStepDefinitionQuery(d)
+	if err != nil {
+		return err
+	}
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchStepDefinition(d, sc) })
+	if err != nil && (isUniqueConstraintViolation(err, "batch_step_definitions_unique_user_id") || isUniqueConstraintViolation(err, "batch_step_definitions_unique_org_id")) {
+		return ErrBatchStepDefinitionExists{Name: d.Name, Version: d.Version}
+	}
+	return err
+}
+
+var createBatchStepDefinitionQueryFmtstr = `
+INSERT INTO batch_step_definitions (
+	namespace_user_id,
+	namespace_org_id,
+	name,
+	version,
+	raw_definition,
+	definition,
+	creator_id,
+	created_at
+)
+VALUES
+	(%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+	%s
+`
+
+func (s *Store) createBatchStepDefinitionQuery(d *btypes.BatchStepDefinition) (*sqlf.Query, error) {
+	definition, err := jsonbColumn(d.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = s.now()
+	}
+
+	return sqlf.Sprintf(
+		createBatchStepDefinitionQueryFmtstr,
+		dbutil.NullInt32Column(d.NamespaceUserID),
+		dbutil.NullInt32Column(d.NamespaceOrgID),
+		d.Name,
+		d.Version,
+		d.RawDefinition,
+		definition,
+		dbutil.NullInt32Column(d.CreatorID),
+		d.CreatedAt,
+		sqlf.Join(batchStepDefinitionColumns, ", "),
+	), nil
+}
+
+// GetBatchStepDefinitionOpts captures the query options needed for getting a
+// BatchStepDefinition.
+type GetBatchStepDefinitionOpts struct {
+	ID int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	Name            string
+	Version         string
+}
+
+// GetBatchStepDefinition gets a BatchStepDefinition matching the given options.
+func (s *Store) GetBatchStepDefinition(ctx context.Context, opts GetBatchStepDefinitionOpts) (d *btypes.BatchStepDefinition, err error) {
+	ctx, _, endObservation := s.operations.getBatchStepDefinition.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(opts.ID)),
+		attribute.String("name", opts.Name),
+		attribute.String("version", opts.Version),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := getBatchStepDefinitionQuery(&opts)
+
+	var def btypes.BatchStepDefinition
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchStepDefinition(&def, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if def.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &def, nil
+}
+
+var getBatchStepDefinitionQueryFmtstr = `
+SELECT %s FROM batch_step_definitions
+WHERE %s
+LIMIT 1
+`
+
+func getBatchStepDefinitionQuery(opts *GetBatchStepDefinitionOpts) *sqlf.Query {
+	preds := []*sqlf.Query{}
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.id = %s", opts.ID))
+	}
+	if opts.NamespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.namespace_user_id = %s", opts.NamespaceUserID))
+	}
+	if opts.NamespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.namespace_org_id = %s", opts.NamespaceOrgID))
+	}
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.name = %s", opts.Name))
+	}
+	if opts.Version != "" {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.version = %s", opts.Version))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		getBatchStepDefinitionQueryFmtstr,
+		sqlf.Join(batchStepDefinitionColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListBatchStepDefinitionsOpts captures the query options needed for listing
+// BatchStepDefinitions.
+type ListBatchStepDefinitionsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	Name            string
+}
+
+// ListBatchStepDefinitions lists BatchStepDefinitions with the given filters,
+// newest first.
+func (s *Store) ListBatchStepDefinitions(ctx context.Context, opts ListBatchStepDefinitionsOpts) (ds []*btypes.BatchStepDefinition, next int64, err error) {
+	ctx, _, endObservation := s.operations.listBatchStepDefinitions.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listBatchStepDefinitionsQuery(&opts)
+
+	ds = make([]*btypes.BatchStepDefinition, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var d btypes.BatchStepDefinition
+		if err := scanBatchStepDefinition(&d, sc); err != nil {
+			return err
+		}
+		ds = append(ds, &d)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ds) == opts.DBLimit() {
+		next = ds[len(ds)-1].ID
+		ds = ds[:len(ds)-1]
+	}
+
+	return ds, next, err
+}
+
+var listBatchStepDefinitionsQueryFmtstr = `
+SELECT %s FROM batch_step_definitions
+WHERE %s
+ORDER BY batch_step_definitions.id DESC
+`
+
+func listBatchStepDefinitionsQuery(opts *ListBatchStepDefinitionsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{}
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.id <= %s", opts.Cursor))
+	}
+	if opts.NamespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.namespace_user_id = %s", opts.NamespaceUserID))
+	}
+	if opts.NamespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.namespace_org_id = %s", opts.NamespaceOrgID))
+	}
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("batch_step_definitions.name = %s", opts.Name))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		listBatchStepDefinitionsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchStepDefinitionColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanBatchStepDefinition(d *btypes.BatchStepDefinition, s dbutil.Scanner) error {
+	var definition json.RawMessage
+
+	if err := s.Scan(
+		&d.ID,
+		&dbutil.NullInt32{N: &d.NamespaceUserID},
+		&dbutil.NullInt32{N: &d.NamespaceOrgID},
+		&d.Name,
+		&d.Version,
+		&d.RawDefinition,
+		&definition,
+		&dbutil.NullInt32{N: &d.CreatorID},
+		&d.CreatedAt,
+	); err != nil {
+		return errors.Wrap(err, "scanning batch step definition")
+	}
+
+	var def batcheslib.StepDefinition
+	if err := json.Unmarshal(definition, &def); err != nil {
+		return errors.Wrap(err, "scanBatchStepDefinition: failed to unmarshal definition")
+	}
+	d.Definition = &def
+
+	return nil
+}
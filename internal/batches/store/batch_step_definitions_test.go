@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreBatchStepDefinitions(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	org := bt.CreateTestOrg(t, s.DatabaseDB(), "step-definitions", user.ID)
+
+	newDefinition := func(userID, orgID int32, name, version string) *btypes.BatchStepDefinition {
+		return &btypes.BatchStepDefinition{
+			NamespaceUserID: userID,
+			NamespaceOrgID:  orgID,
+			Name:            name,
+			Version:         version,
+			RawDefinition:   "name: " + name,
+			Definition: &batcheslib.StepDefinition{
+				Name:      name,
+				Version:   version,
+				Run:       "gofmt -w .",
+				Container: "golang:1",
+				Inputs:    map[string]batcheslib.StepDefinitionInput{"path": {Default: "."}},
+			},
+			CreatorID: user.ID,
+		}
+	}
+
+	defs := []*btypes.BatchStepDefinition{
+		newDefinition(0, org.ID, "gofmt", "1.0.0"),
+		newDefinition(0, org.ID, "gofmt", "1.1.0"),
+		newDefinition(user.ID, 0, "gofmt", "1.0.0"),
+		newDefinition(0, org.ID, "goimports", "1.0.0"),
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, d := range defs {
+			require.NoError(t, s.CreateBatchStepDefinition(ctx, d))
+			assert.NotZero(t, d.ID)
+			assert.True(t, clock.Now().Equal(d.CreatedAt))
+		}
+	})
+
+	t.Run("Create existing version", func(t *testing.T) {
+		err := s.CreateBatchStepDefinition(ctx, newDefinition(0, org.ID, "gofmt", "1.0.0"))
+		assert.Equal(t, ErrBatchStepDefinitionExists{Name: "gofmt", Version: "1.0.0"}, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetBatchStepDefinition(ctx, GetBatchStepDefinitionOpts{
+			NamespaceUserID: user.ID,
+			Name:            "gofmt",
+			Version:         "1.0.0",
+		})
+		require.NoError(t, err)
+		if diff := cmp.Diff(defs[2], have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.GetBatchStepDefinition(ctx, GetBatchStepDefinitionOpts{ID: defs[1].ID})
+		require.NoError(t, err)
+		if diff := cmp.Diff(defs[1], have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetBatchStepDefinition(ctx, GetBatchStepDefinitionOpts{
+			NamespaceUserID: user.ID,
+			Name:            "gofmt",
+			Version:         "1.1.0",
+		})
+		assert.Equal(t, ErrNoResults, err)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, next, err := s.ListBatchStepDefinitions(ctx, ListBatchStepDefinitionsOpts{NamespaceOrgID: org.ID, Name: "gofmt"})
+		require.NoError(t, err)
+		assert.Zero(t, next)
+		if diff := cmp.Diff([]*btypes.BatchStepDefinition{defs[1], defs[0]}, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, next, err = s.ListBatchStepDefinitions(ctx, ListBatchStepDefinitionsOpts{NamespaceOrgID: org.ID, LimitOpts: LimitOpts{Limit: 2}})
+		require.NoError(t, err)
+		if diff := cmp.Diff([]*btypes.BatchStepDefinition{defs[3], defs[1]}, have); diff != "" {
+			t.Fatal(diff)
+		}
+		assert.Equal(t, defs[0].ID, next)
+
+		have, next, err = s.ListBatchStepDefinitions(ctx, ListBatchStepDefinitionsOpts{NamespaceOrgID: org.ID, LimitOpts: LimitOpts{Limit: 2}, Cursor: next})
+		require.NoError(t, err)
+		if diff := cmp.Diff([]*btypes.BatchStepDefinition{defs[0]}, have); diff != "" {
+			t.Fatal(diff)
+		}
+		assert.Zero(t, next)
+	})
+}
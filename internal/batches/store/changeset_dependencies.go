@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ReplaceChangesetDependencies replaces the dependencies between the
+// changesets of the given batch change with deps. Dependencies that already
+// existed keep whether they held the publication of their changeset.
+func (s *Store) ReplaceChangesetDependencies(ctx context.Context, batchChangeID int64, deps []*btypes.ChangesetDependency) (err error) {
+	ctx, _, endObservation := s.operations.replaceChangesetDependencies.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(batchChangeID)),
+		attribute.Int("count", len(deps)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(deps) == 0 {
+		return s.Store.Exec(ctx, sqlf.Sprintf(deleteChangesetDependenciesQueryFmtstr, batchChangeID))
+	}
+
+	values := make([]*sqlf.Query, 0, len(deps))
+	for _, d := range deps {
+		values = append(values, sqlf.Sprintf("(%s::bigint, %s::bigint)", d.ChangesetID, d.DependsOnChangesetID))
+	}
+	if err := s.Store.Exec(ctx, sqlf.Sprintf(deleteOtherChangesetDependenciesQueryFmtstr, batchChangeID, sqlf.Join(values, ",\n"))); err != nil {
+		return err
+	}
+
+	values = values[:0]
+	for _, d := range deps {
+		values = append(values, sqlf.Sprintf("(%s, %s, %s)", d.ChangesetID, d.DependsOnChangesetID, batchChangeID))
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(createChangesetDependenciesQueryFmtstr, sqlf.Join(values, ",\n")))
+}
+
+var deleteChangesetDependenciesQueryFmtstr = `
+DELETE FROM changeset_dependencies
+WHERE batch_change_id = %s
+`
+
+var deleteOtherChangesetDependenciesQueryFmtstr = `
+DELETE FROM changeset_dependencies
+WHERE
+	batch_change_id = %s
+	AND (changeset_id, depends_on_changeset_id) NOT IN (VALUES %s)
+`
+
+var createChangesetDependenciesQueryFmtstr = `
+INSERT INTO changeset_dependencies (changeset_id, depends_on_changeset_id, batch_change_id)
+VALUES %s
+ON CONFLICT (changeset_id, depends_on_changeset_id) DO NOTHING
+`
+
+// ListChangesetDependenciesOpts captures the query options needed for listing
+// changeset dependencies.
+type ListChangesetDependenciesOpts struct {
+	BatchChangeID         int64
+	ChangesetIDs          []int64
+	DependsOnChangesetIDs []int64
+}
+
+// ListChangesetDependencies lists the changeset dependencies matching the
+// given options.
+func (s *Store) ListChangesetDependencies(ctx context.Context, opts ListChangesetDependenciesOpts) (deps []*btypes.ChangesetDependency, err error) {
+	ctx, _, endObservation := s.operations.listChangesetDependencies.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	err = s.query(ctx, listChangesetDependenciesQuery(&opts), func(sc dbutil.Scanner) error {
+		var d btypes.ChangesetDependency
+		if err := sc.Scan(&d.ChangesetID, &d.DependsOnChangesetID, &d.BatchChangeID, &d.Held); err != nil {
+			return err
+		}
+		deps = append(deps, &d)
+		return nil
+	})
+	return deps, err
+}
+
+var listChangesetDependenciesQueryFmtstr = `
+SELECT
+	changeset_id,
+	depends_on_changeset_id,
+	batch_change_id,
+	held
+FROM changeset_dependencies
+WHERE %s
+ORDER BY changeset_id ASC, depends_on_changeset_id ASC
+`
+
+func listChangesetDependenciesQuery(opts *ListChangesetDependenciesOpts) *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_id = %s", opts.BatchChangeID))
+	}
+	if len(opts.ChangesetIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_id = ANY (%s)", pq.Array(opts.ChangesetIDs)))
+	}
+	if len(opts.DependsOnChangesetIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("depends_on_changeset_id = ANY (%s)", pq.Array(opts.DependsOnChangesetIDs)))
+	}
+
+	return sqlf.Sprintf(listChangesetDependenciesQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
+// GetChangesetDependencyState returns the state of the dependencies of the
+// given changeset that haven't been merged yet.
+func (s *Store) GetChangesetDependencyState(ctx context.Context, changesetID int64) (state btypes.ChangesetDependencyState, err error) {
+	ctx, _, endObservation := s.operations.getChangesetDependencyState.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getChangesetDependencyStateQueryFmtstr,
+		btypes.ChangesetExternalStateMerged,
+		pq.Array([]btypes.ChangesetExternalState{
+			btypes.ChangesetExternalStateClosed,
+			btypes.ChangesetExternalStateDeleted,
+			btypes.ChangesetExternalStateReadOnly,
+		}),
+		changesetID,
+	)
+	row := s.QueryRow(ctx, q)
+	err = row.Scan(&state.Unmerged, &state.Abandoned, &state.Held)
+	return state, err
+}
+
+var getChangesetDependencyStateQueryFmtstr = `
+SELECT
+	COUNT(*) FILTER (WHERE changesets.external_state IS DISTINCT FROM %s),
+	COUNT(*) FILTER (WHERE changesets.external_state = ANY (%s)),
+	COALESCE(bool_or(changeset_dependencies.held), FALSE)
+FROM changeset_dependencies
+JOIN changesets ON changesets.id = changeset_dependencies.depends_on_changeset_id
+WHERE changeset_dependencies.changeset_id = %s
+`
+
+// SetChangesetDependenciesHeld records whether the reconciler held the
+// publication of the given changeset until its dependencies are merged.
+func (s *Store) SetChangesetDependenciesHeld(ctx context.Context, changesetID int64, held bool) (err error) {
+	ctx, _, endObservation := s.operations.setChangesetDependenciesHeld.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(changesetID)),
+		attribute.Bool("held", held),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(setChangesetDependenciesHeldQueryFmtstr, held, changesetID, held))
+}
+
+var setChangesetDependenciesHeldQueryFmtstr = `
+UPDATE changeset_dependencies
+SET held = %s
+WHERE changeset_id = %s AND held != %s
+`
+
+// EnqueueChangesetDependents enqueues the changesets whose publication the
+// reconciler held until the given changeset was merged, so that it publishes
+// them if their other dependencies have been merged too. Changesets that are
+// being processed by the reconciler already are left alone.
+func (s *Store) EnqueueChangesetDependents(ctx context.Context, changesetID int64) (err error) {
+	ctx, _, endObservation := s.operations.enqueueChangesetDependents.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(
+		enqueueChangesetDependentsQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		changesetID,
+		btypes.ReconcilerStateCompleted.ToDB(),
+	))
+}
+
+var enqueueChangesetDependentsQueryFmtstr = `
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	updated_at = %s
+WHERE
+	id IN (
+		SELECT changeset_id
+		FROM changeset_dependencies
+		WHERE depends_on_changeset_id = %s AND held
+	)
+	AND reconciler_state = %s
+`
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreChangesetDependencies(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	require.NoError(t, repoStore.Create(ctx, repo))
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "deps", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "deps", user.ID, batchSpec.ID)
+	otherBatchChange := bt.CreateBatchChange(t, ctx, s, "other-deps", user.ID, batchSpec.ID)
+
+	lib := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:          repo.ID,
+		BatchChange:   batchChange.ID,
+		ExternalState: btypes.ChangesetExternalStateOpen,
+	})
+	otherLib := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:          repo.ID,
+		BatchChange:   batchChange.ID,
+		ExternalState: btypes.ChangesetExternalStateMerged,
+	})
+	closedLib := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:          repo.ID,
+		BatchChange:   batchChange.ID,
+		ExternalState: btypes.ChangesetExternalStateClosed,
+	})
+	consumer := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      batchChange.ID,
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	publishedConsumer := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      batchChange.ID,
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	otherConsumer := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      otherBatchChange.ID,
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	unpublishedConsumer := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      batchChange.ID,
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+
+	deps := []*btypes.ChangesetDependency{
+		{ChangesetID: consumer.ID, DependsOnChangesetID: lib.ID, BatchChangeID: batchChange.ID},
+		{ChangesetID: consumer.ID, DependsOnChangesetID: otherLib.ID, BatchChangeID: batchChange.ID},
+		{ChangesetID: publishedConsumer.ID, DependsOnChangesetID: lib.ID, BatchChangeID: batchChange.ID},
+		{ChangesetID: unpublishedConsumer.ID, DependsOnChangesetID: lib.ID, BatchChangeID: batchChange.ID},
+		{ChangesetID: unpublishedConsumer.ID, DependsOnChangesetID: closedLib.ID, BatchChangeID: batchChange.ID},
+	}
+	otherDeps := []*btypes.ChangesetDependency{
+		{ChangesetID: otherConsumer.ID, DependsOnChangesetID: otherLib.ID, BatchChangeID: otherBatchChange.ID},
+	}
+
+	t.Run("Replace", func(t *testing.T) {
+		require.NoError(t, s.ReplaceChangesetDependencies(ctx, batchChange.ID, deps[1:2]))
+		require.NoError(t, s.ReplaceChangesetDependencies(ctx, otherBatchChange.ID, otherDeps))
+		require.NoError(t, s.ReplaceChangesetDependencies(ctx, batchChange.ID, deps))
+
+		have, err := s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{BatchChangeID: batchChange.ID})
+		require.NoError(t, err)
+		assert.Equal(t, deps, have)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{DependsOnChangesetIDs: []int64{otherLib.ID}})
+		require.NoError(t, err)
+		assert.Equal(t, []*btypes.ChangesetDependency{deps[1], otherDeps[0]}, have)
+
+		have, err = s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{ChangesetIDs: []int64{otherConsumer.ID}})
+		require.NoError(t, err)
+		assert.Equal(t, otherDeps, have)
+	})
+
+	t.Run("Held", func(t *testing.T) {
+		require.NoError(t, s.SetChangesetDependenciesHeld(ctx, consumer.ID, true))
+
+		have, err := s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{ChangesetIDs: []int64{consumer.ID}})
+		require.NoError(t, err)
+		for _, d := range have {
+			assert.True(t, d.Held)
+		}
+
+		// Replacing the dependencies keeps whether they held their changeset.
+		require.NoError(t, s.ReplaceChangesetDependencies(ctx, batchChange.ID, deps))
+
+		have, err = s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{ChangesetIDs: []int64{consumer.ID}})
+		require.NoError(t, err)
+		for _, d := range have {
+			assert.True(t, d.Held)
+		}
+	})
+
+	t.Run("GetState", func(t *testing.T) {
+		state, err := s.GetChangesetDependencyState(ctx, consumer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, btypes.ChangesetDependencyState{Unmerged: 1, Held: true}, state)
+
+		state, err = s.GetChangesetDependencyState(ctx, unpublishedConsumer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, btypes.ChangesetDependencyState{Unmerged: 2, Abandoned: 1}, state)
+
+		state, err = s.GetChangesetDependencyState(ctx, otherConsumer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, btypes.ChangesetDependencyState{}, state)
+	})
+
+	t.Run("EnqueueDependents", func(t *testing.T) {
+		require.NoError(t, s.EnqueueChangesetDependents(ctx, lib.ID))
+
+		have, err := s.GetChangesetByID(ctx, consumer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, btypes.ReconcilerStateQueued, have.ReconcilerState)
+
+		// Changesets that weren't held, such as published changesets or
+		// changesets that are unpublished on purpose, and changesets that
+		// don't depend on lib aren't affected.
+		for _, id := range []int64{publishedConsumer.ID, unpublishedConsumer.ID, otherConsumer.ID} {
+			have, err = s.GetChangesetByID(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, btypes.ReconcilerStateCompleted, have.ReconcilerState)
+		}
+	})
+
+	t.Run("Unheld", func(t *testing.T) {
+		require.NoError(t, s.SetChangesetDependenciesHeld(ctx, consumer.ID, false))
+
+		state, err := s.GetChangesetDependencyState(ctx, consumer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, btypes.ChangesetDependencyState{Unmerged: 1}, state)
+	})
+
+	t.Run("ReplaceWithNone", func(t *testing.T) {
+		require.NoError(t, s.ReplaceChangesetDependencies(ctx, batchChange.ID, nil))
+
+		have, err := s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, otherDeps, have)
+	})
+}
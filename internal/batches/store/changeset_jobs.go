@@ -118,9 +118,12 @@ func (s *Store) CreateChangesetJob(ctx context.Context, cs ...*btypes.ChangesetJ
 	)
 }
 
-// GetChangesetJobOpts captures the query options needed for getting a ChangesetJob
+// GetChangesetJobOpts captures the query options needed for getting a ChangesetJob.
+// If more than one job matches, the most recently created one is returned.
 type GetChangesetJobOpts struct {
-	ID int64
+	ID          int64
+	ChangesetID int64
+	JobType     btypes.ChangesetJobType
 }
 
 // GetChangesetJob gets a ChangesetJob matching the given options.
@@ -151,13 +154,22 @@ SELECT %s FROM changeset_jobs
 INNER JOIN changesets ON changesets.id = changeset_jobs.changeset_id
 INNER JOIN repo ON repo.id = changesets.repo_id
 WHERE %s
+ORDER BY changeset_jobs.id DESC
 LIMIT 1
 `
 
 func getChangesetJobQuery(opts *GetChangesetJobOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
-		sqlf.Sprintf("changeset_jobs.id = %s", opts.ID),
+	}
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.id = %s", opts.ID))
+	}
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.changeset_id = %s", opts.ChangesetID))
+	}
+	if opts.JobType != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.job_type = %s", opts.JobType))
 	}
 
 	return sqlf.Sprintf(
@@ -99,6 +99,21 @@ func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, clock b
 			})
 		}
 
+		t.Run("LatestByChangesetAndType", func(t *testing.T) {
+			have, err := s.GetChangesetJob(ctx, GetChangesetJobOpts{ChangesetID: changeset.ID, JobType: btypes.ChangesetJobTypeComment})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, jobs[cap(jobs)-2]); diff != "" {
+				t.Fatal(diff)
+			}
+
+			_, err = s.GetChangesetJob(ctx, GetChangesetJobOpts{ChangesetID: changeset.ID, JobType: btypes.ChangesetJobTypeMerge})
+			if err != ErrNoResults {
+				t.Fatalf("have err %v, want %v", err, ErrNoResults)
+			}
+		})
+
 		t.Run("NoResults", func(t *testing.T) {
 			opts := GetChangesetJobOpts{ID: 0xdeadbeef}
 
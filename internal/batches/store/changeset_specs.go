@@ -45,6 +45,11 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"reviewers",
+	"team_reviewers",
+	"labels",
+	"assignees",
+	"milestone",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +77,11 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.reviewers",
+	"changeset_specs.team_reviewers",
+	"changeset_specs.labels",
+	"changeset_specs.assignees",
+	"changeset_specs.milestone",
 }
 
 var oneGigabyte = 1000000000
@@ -137,6 +147,11 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				pq.Array(c.Reviewers),
+				pq.Array(c.TeamReviewers),
+				pq.Array(c.Labels),
+				pq.Array(c.Assignees),
+				dbutil.NewNullString(c.Milestone),
 			); err != nil {
 				return err
 			}
@@ -440,7 +455,7 @@ func (s *Store) ListChangesetSpecsWithConflictingHeadRef(ctx context.Context, ba
 }
 
 // DeleteUnattachedExpiredChangesetSpecs deletes each ChangesetSpec that has not been
-// attached to a BatchSpec within ChangesetSpecTTL.
+// attached to a BatchSpec within ChangesetSpecTTL, unless a Changeset uses it.
 func (s *Store) DeleteUnattachedExpiredChangesetSpecs(ctx context.Context) (err error) {
 	ctx, _, endObservation := s.operations.deleteUnattachedExpiredChangesetSpecs.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -457,8 +472,15 @@ WHERE
   -- The spec is older than the ChangesetSpecTTL
   created_at < %s
   AND
-  -- and it was never attached to a batch_spec
+  -- and it was never attached to a batch_spec, or was detached from it
   batch_spec_id IS NULL
+  AND
+  -- and it is not attached to a changeset, as detached specs of rebased
+  -- changesets may still be
+  NOT EXISTS (
+    SELECT 1 FROM changesets
+    WHERE changesets.current_spec_id = changeset_specs.id OR changesets.previous_spec_id = changeset_specs.id
+  )
 `
 
 // DeleteExpiredChangesetSpecs deletes each ChangesetSpec that is attached
@@ -563,6 +585,11 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		pq.Array(&c.Reviewers),
+		pq.Array(&c.TeamReviewers),
+		pq.Array(&c.Labels),
+		pq.Array(&c.Assignees),
+		&dbutil.NullString{S: &c.Milestone},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -78,6 +78,7 @@ var changesetStringColumns = SQLColumns{
 	"syncer_error",
 	"detached_at",
 	"previous_failure_message",
+	"auto_merge_blocked_reason",
 }
 
 // ChangesetColumns are used by the changeset related Store methods and by
@@ -123,6 +124,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
 	sqlf.Sprintf("changesets.previous_failure_message"),
+	sqlf.Sprintf("changesets.auto_merge_blocked_reason"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -1106,6 +1108,17 @@ func (s *Store) UpdateChangesetCommitVerification(ctx context.Context, cs *btype
 	return s.updateChangesetColumn(ctx, cs, "commit_verification", cv)
 }
 
+// UpdateChangesetAutoMergeBlockedReason records the reason the changeset is not
+// merged automatically, as stored in cs.AutoMergeBlockedReason.
+func (s *Store) UpdateChangesetAutoMergeBlockedReason(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetAutoMergeBlockedReason.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.updateChangesetColumn(ctx, cs, "auto_merge_blocked_reason", cs.AutoMergeBlockedReason)
+}
+
 // updateChangesetColumn updates the column with the given name, setting it to
 // the given value, and updating the updated_at column.
 func (s *Store) updateChangesetColumn(ctx context.Context, cs *btypes.Changeset, name string, val any) error {
@@ -1447,6 +1460,7 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		syncErrorMessage       string
 		reconcilerState        string
 		previousFailureMessage string
+		autoMergeBlockedReason string
 	)
 	err := s.Scan(
 		&t.ID,
@@ -1486,6 +1500,7 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
 		&dbutil.NullString{S: &previousFailureMessage},
+		&dbutil.NullString{S: &autoMergeBlockedReason},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1503,6 +1518,9 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	if syncErrorMessage != "" {
 		t.SyncErrorMessage = &syncErrorMessage
 	}
+	if autoMergeBlockedReason != "" {
+		t.AutoMergeBlockedReason = &autoMergeBlockedReason
+	}
 	t.ReconcilerState = btypes.ReconcilerState(strings.ToUpper(reconcilerState))
 
 	switch t.ExternalServiceType {
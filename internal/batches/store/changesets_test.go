@@ -1651,6 +1651,25 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 			t.Fatalf("found diff with unsigned commit: %s", diff)
 		}
 	})
+
+	t.Run("UpdateChangesetAutoMergeBlockedReason", func(t *testing.T) {
+		c1 := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID})
+
+		for _, reason := range []*string{pointers.Ptr("checks have not passed"), nil} {
+			c1.AutoMergeBlockedReason = reason
+			want := c1.Clone()
+
+			// Other columns should not be updated in the DB.
+			c1.ExternalServiceType = "external-service-type"
+
+			if err := s.UpdateChangesetAutoMergeBlockedReason(ctx, c1); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c1, want); diff != "" {
+				t.Fatalf("invalid changeset: %s", diff)
+			}
+		}
+	})
 }
 
 func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
@@ -203,6 +203,10 @@ type operations struct {
 	listBatchSpecRepoIDs    *observation.Operation
 	deleteExpiredBatchSpecs *observation.Operation
 
+	createBatchStepDefinition *observation.Operation
+	getBatchStepDefinition    *observation.Operation
+	listBatchStepDefinitions  *observation.Operation
+
 	upsertBatchSpecWorkspaceFile *observation.Operation
 	deleteBatchSpecWorkspaceFile *observation.Operation
 	getBatchSpecWorkspaceFile    *observation.Operation
@@ -222,6 +226,12 @@ type operations struct {
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
 
+	replaceChangesetDependencies *observation.Operation
+	listChangesetDependencies    *observation.Operation
+	getChangesetDependencyState  *observation.Operation
+	setChangesetDependenciesHeld *observation.Operation
+	enqueueChangesetDependents   *observation.Operation
+
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
 	deleteChangesetSpec                      *observation.Operation
@@ -234,27 +244,28 @@ type operations struct {
 	listChangesetSpecsWithConflictingHeadRef *observation.Operation
 	deleteChangesetSpecs                     *observation.Operation
 
-	createChangeset                   *observation.Operation
-	deleteChangeset                   *observation.Operation
-	countChangesets                   *observation.Operation
-	getChangeset                      *observation.Operation
-	listChangesetSyncData             *observation.Operation
-	listChangesets                    *observation.Operation
-	enqueueChangeset                  *observation.Operation
-	updateChangeset                   *observation.Operation
-	updateChangesetBatchChanges       *observation.Operation
-	updateChangesetUIPublicationState *observation.Operation
-	updateChangesetCodeHostState      *observation.Operation
-	updateChangesetCommitVerification *observation.Operation
-	getChangesetExternalIDs           *observation.Operation
-	cancelQueuedBatchChangeChangesets *observation.Operation
-	enqueueChangesetsToClose          *observation.Operation
-	getChangesetsStats                *observation.Operation
-	getRepoChangesetsStats            *observation.Operation
-	getGlobalChangesetsStats          *observation.Operation
-	enqueueNextScheduledChangeset     *observation.Operation
-	getChangesetPlaceInSchedulerQueue *observation.Operation
-	cleanDetachedChangesets           *observation.Operation
+	createChangeset                       *observation.Operation
+	deleteChangeset                       *observation.Operation
+	countChangesets                       *observation.Operation
+	getChangeset                          *observation.Operation
+	listChangesetSyncData                 *observation.Operation
+	listChangesets                        *observation.Operation
+	enqueueChangeset                      *observation.Operation
+	updateChangeset                       *observation.Operation
+	updateChangesetBatchChanges           *observation.Operation
+	updateChangesetUIPublicationState     *observation.Operation
+	updateChangesetCodeHostState          *observation.Operation
+	updateChangesetCommitVerification     *observation.Operation
+	updateChangesetAutoMergeBlockedReason *observation.Operation
+	getChangesetExternalIDs               *observation.Operation
+	cancelQueuedBatchChangeChangesets     *observation.Operation
+	enqueueChangesetsToClose              *observation.Operation
+	getChangesetsStats                    *observation.Operation
+	getRepoChangesetsStats                *observation.Operation
+	getGlobalChangesetsStats              *observation.Operation
+	enqueueNextScheduledChangeset         *observation.Operation
+	getChangesetPlaceInSchedulerQueue     *observation.Operation
+	cleanDetachedChangesets               *observation.Operation
 
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
@@ -271,6 +282,7 @@ type operations struct {
 	countBatchSpecWorkspaces       *observation.Operation
 	markSkippedBatchSpecWorkspaces *observation.Operation
 	listRetryBatchSpecWorkspaces   *observation.Operation
+	rebaseBatchSpecWorkspace       *observation.Operation
 
 	createBatchSpecWorkspaceExecutionJobs              *observation.Operation
 	createBatchSpecWorkspaceExecutionJobsForWorkspaces *observation.Operation
@@ -348,6 +360,10 @@ func newOperations(observationCtx *observation.Context) *operations {
 			listBatchSpecRepoIDs:    op("ListBatchSpecRepoIDs"),
 			deleteExpiredBatchSpecs: op("DeleteExpiredBatchSpecs"),
 
+			createBatchStepDefinition: op("CreateBatchStepDefinition"),
+			getBatchStepDefinition:    op("GetBatchStepDefinition"),
+			listBatchStepDefinitions:  op("ListBatchStepDefinitions"),
+
 			upsertBatchSpecWorkspaceFile: op("UpsertBatchSpecWorkspaceFile"),
 			deleteBatchSpecWorkspaceFile: op("DeleteBatchSpecWorkspaceFile"),
 			getBatchSpecWorkspaceFile:    op("GetBatchSpecWorkspaceFile"),
@@ -367,6 +383,12 @@ func newOperations(observationCtx *observation.Context) *operations {
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
 
+			replaceChangesetDependencies: op("ReplaceChangesetDependencies"),
+			listChangesetDependencies:    op("ListChangesetDependencies"),
+			getChangesetDependencyState:  op("GetChangesetDependencyState"),
+			setChangesetDependenciesHeld: op("SetChangesetDependenciesHeld"),
+			enqueueChangesetDependents:   op("EnqueueChangesetDependents"),
+
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
 			deleteChangesetSpec:                      op("DeleteChangesetSpec"),
@@ -379,27 +401,28 @@ func newOperations(observationCtx *observation.Context) *operations {
 			getRewirerMappings:                       op("GetRewirerMappings"),
 			listChangesetSpecsWithConflictingHeadRef: op("ListChangesetSpecsWithConflictingHeadRef"),
 
-			createChangeset:                   op("CreateChangeset"),
-			deleteChangeset:                   op("DeleteChangeset"),
-			countChangesets:                   op("CountChangesets"),
-			getChangeset:                      op("GetChangeset"),
-			listChangesetSyncData:             op("ListChangesetSyncData"),
-			listChangesets:                    op("ListChangesets"),
-			enqueueChangeset:                  op("EnqueueChangeset"),
-			updateChangeset:                   op("UpdateChangeset"),
-			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
-			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
-			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
-			updateChangesetCommitVerification: op("UpdateChangesetCommitVerification"),
-			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
-			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
-			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
-			getChangesetsStats:                op("GetChangesetsStats"),
-			getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
-			getGlobalChangesetsStats:          op("GetGlobalChangesetsStats"),
-			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
-			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
-			cleanDetachedChangesets:           op("CleanDetachedChangesets"),
+			createChangeset:                       op("CreateChangeset"),
+			deleteChangeset:                       op("DeleteChangeset"),
+			countChangesets:                       op("CountChangesets"),
+			getChangeset:                          op("GetChangeset"),
+			listChangesetSyncData:                 op("ListChangesetSyncData"),
+			listChangesets:                        op("ListChangesets"),
+			enqueueChangeset:                      op("EnqueueChangeset"),
+			updateChangeset:                       op("UpdateChangeset"),
+			updateChangesetBatchChanges:           op("UpdateChangesetBatchChanges"),
+			updateChangesetUIPublicationState:     op("UpdateChangesetUIPublicationState"),
+			updateChangesetCodeHostState:          op("UpdateChangesetCodeHostState"),
+			updateChangesetCommitVerification:     op("UpdateChangesetCommitVerification"),
+			updateChangesetAutoMergeBlockedReason: op("UpdateChangesetAutoMergeBlockedReason"),
+			getChangesetExternalIDs:               op("GetChangesetExternalIDs"),
+			cancelQueuedBatchChangeChangesets:     op("CancelQueuedBatchChangeChangesets"),
+			enqueueChangesetsToClose:              op("EnqueueChangesetsToClose"),
+			getChangesetsStats:                    op("GetChangesetsStats"),
+			getRepoChangesetsStats:                op("GetRepoChangesetsStats"),
+			getGlobalChangesetsStats:              op("GetGlobalChangesetsStats"),
+			enqueueNextScheduledChangeset:         op("EnqueueNextScheduledChangeset"),
+			getChangesetPlaceInSchedulerQueue:     op("GetChangesetPlaceInSchedulerQueue"),
+			cleanDetachedChangesets:               op("CleanDetachedChangesets"),
 
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
@@ -416,6 +439,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			countBatchSpecWorkspaces:       op("CountBatchSpecWorkspaces"),
 			markSkippedBatchSpecWorkspaces: op("MarkSkippedBatchSpecWorkspaces"),
 			listRetryBatchSpecWorkspaces:   op("ListRetryBatchSpecWorkspaces"),
+			rebaseBatchSpecWorkspace:       op("RebaseBatchSpecWorkspace"),
 
 			createBatchSpecWorkspaceExecutionJobs:              op("CreateBatchSpecWorkspaceExecutionJobs"),
 			createBatchSpecWorkspaceExecutionJobsForWorkspaces: op("CreateBatchSpecWorkspaceExecutionJobsForWorkspaces"),
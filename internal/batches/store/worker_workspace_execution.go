@@ -8,10 +8,13 @@ import (
 
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store/author"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -262,6 +265,13 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		return false, errors.Wrap(err, "setChangesetSpecIDs")
 	}
 
+	// Workspaces of applied batch specs are only executed again to rebase
+	// their changesets onto a new base commit, so the changesets have to be
+	// moved to the regenerated specs.
+	if err := rewireRebasedChangesets(ctx, tx, batchSpec, workspace.ChangesetSpecIDs, specs); err != nil {
+		return false, errors.Wrap(err, "updating rebased changesets")
+	}
+
 	return s.Store.With(tx).MarkComplete(ctx, id, options)
 }
 
@@ -302,6 +312,83 @@ SET
 WHERE id = %s
 `
 
+// rewireRebasedChangesets moves the changesets of the batch change that
+// batchSpec is applied to from the old changeset specs of a workspace to the
+// new specs with the same head ref, and enqueues them so that the reconciler
+// force-pushes the regenerated commits. The replaced specs are detached from
+// the batch spec, so that it doesn't contain two specs for the same branch.
+// It does nothing if batchSpec isn't applied.
+func rewireRebasedChangesets(ctx context.Context, tx *Store, batchSpec *btypes.BatchSpec, oldSpecIDs []int64, newSpecs []*btypes.ChangesetSpec) error {
+	if len(oldSpecIDs) == 0 || len(newSpecs) == 0 {
+		return nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, GetBatchChangeOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		if err == ErrNoResults {
+			return nil
+		}
+		return err
+	}
+
+	oldSpecs, _, err := tx.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{IDs: oldSpecIDs})
+	if err != nil {
+		return err
+	}
+	oldHeadRefs := make(map[int64]string, len(oldSpecs))
+	for _, spec := range oldSpecs {
+		oldHeadRefs[spec.ID] = spec.HeadRef
+	}
+	newSpecsByHeadRef := make(map[string]*btypes.ChangesetSpec, len(newSpecs))
+	for _, spec := range newSpecs {
+		newSpecsByHeadRef[spec.HeadRef] = spec
+	}
+
+	changesets, _, err := tx.ListChangesets(ctx, ListChangesetsOpts{
+		OwnedByBatchChangeID: batchChange.ID,
+		RepoIDs:              []api.RepoID{newSpecs[0].BaseRepoID},
+	})
+	if err != nil {
+		return err
+	}
+
+	var replaced []int64
+	for _, c := range changesets {
+		headRef, ok := oldHeadRefs[c.CurrentSpecID]
+		if !ok {
+			continue
+		}
+		spec, ok := newSpecsByHeadRef[headRef]
+		if !ok {
+			continue
+		}
+
+		replaced = append(replaced, c.CurrentSpecID)
+		if c.ReconcilerState == btypes.ReconcilerStateCompleted {
+			c.PreviousSpecID = c.CurrentSpecID
+		}
+		c.SetCurrentSpec(spec)
+		c.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+		if err := tx.UpdateChangeset(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	if len(replaced) == 0 {
+		return nil
+	}
+	return tx.Exec(ctx, sqlf.Sprintf(detachChangesetSpecsQueryFmtstr, pq.Array(replaced)))
+}
+
+const detachChangesetSpecsQueryFmtstr = `
+UPDATE
+	changeset_specs
+SET
+	batch_spec_id = NULL
+WHERE
+	id = ANY (%s)
+`
+
 // storeCacheResults builds DB cache entries for all the results and store them using the given tx.
 func storeCacheResults(ctx context.Context, tx *Store, results []*batcheslib.CacheAfterStepResultMetadata, userID int32) error {
 	for _, result := range results {
@@ -323,6 +410,12 @@ func storeCacheResults(ctx context.Context, tx *Store, results []*batcheslib.Cac
 	return nil
 }
 
+// StepResultsFromExecutionLogs returns the step results, along with their
+// cache keys, that an execution logged.
+func StepResultsFromExecutionLogs(logs []executor.ExecutionLogEntry) ([]*batcheslib.CacheAfterStepResultMetadata, error) {
+	return extractCacheEntries(logEventsFromLogEntries(logs))
+}
+
 func extractCacheEntries(events []*batcheslib.LogEvent) (cacheEntries []*batcheslib.CacheAfterStepResultMetadata, err error) {
 	for _, e := range events {
 		if e.Operation == batcheslib.LogEventOperationCacheAfterStepResult {
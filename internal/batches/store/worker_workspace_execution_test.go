@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/internal/batches/global"
 	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -216,6 +217,84 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 
 		assertNoChangesetSpecsCreated(t)
 	})
+
+	t.Run("rebase of applied batch spec", func(t *testing.T) {
+		job, workspace := setupEntities(t)
+
+		oldSpec := &btypes.ChangesetSpec{
+			BatchSpecID: batchSpec.ID,
+			BaseRepoID:  repo.ID,
+			UserID:      user.ID,
+			HeadRef:     "refs/heads/",
+			Type:        btypes.ChangesetSpecTypeBranch,
+		}
+		if err := s.CreateChangesetSpec(ctx, oldSpec); err != nil {
+			t.Fatal(err)
+		}
+		if err := executionStore.setChangesetSpecIDs(ctx, s, workspace.ID, []int64{oldSpec.ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := &btypes.BatchChange{
+			Name:            "rebased-batch-change",
+			BatchSpecID:     batchSpec.ID,
+			CreatorID:       user.ID,
+			NamespaceUserID: user.ID,
+			LastApplierID:   user.ID,
+			LastAppliedAt:   time.Now(),
+		}
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		changeset := &btypes.Changeset{
+			RepoID:               repo.ID,
+			ExternalServiceType:  "github",
+			OwnedByBatchChangeID: batchChange.ID,
+			BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			CurrentSpecID:        oldSpec.ID,
+			PublicationState:     btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:      btypes.ReconcilerStateCompleted,
+		}
+		if err := s.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		setProcessing(t, job)
+		ok, err := executionStore.MarkComplete(context.Background(), int(job.ID), opts)
+		if !ok || err != nil {
+			t.Fatalf("MarkComplete failed. ok=%t, err=%s", ok, err)
+		}
+
+		reloadedWorkspace, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(reloadedWorkspace.ChangesetSpecIDs), 1; have != want {
+			t.Fatalf("invalid number of changeset specs: have=%d want=%d", have, want)
+		}
+
+		reloadedChangeset, err := s.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloadedChangeset.CurrentSpecID, reloadedWorkspace.ChangesetSpecIDs[0]; have != want {
+			t.Fatalf("wrong current spec: have=%d want=%d", have, want)
+		}
+		if have, want := reloadedChangeset.PreviousSpecID, oldSpec.ID; have != want {
+			t.Fatalf("wrong previous spec: have=%d want=%d", have, want)
+		}
+		if have, want := reloadedChangeset.ReconcilerState, global.DefaultReconcilerEnqueueState(); have != want {
+			t.Fatalf("wrong reconciler state: have=%s want=%s", have, want)
+		}
+
+		reloadedOldSpec, err := s.GetChangesetSpecByID(ctx, oldSpec.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reloadedOldSpec.BatchSpecID != 0 {
+			t.Fatalf("old changeset spec is still attached to batch spec %d", reloadedOldSpec.BatchSpecID)
+		}
+	})
 }
 
 func TestBatchSpecWorkspaceExecutionWorkerStore_MarkFailed(t *testing.T) {
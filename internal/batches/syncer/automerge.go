@@ -0,0 +1,256 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/batches/types/scheduler/window"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// batchSpecStore is the subset of the store that loadOwningBatchSpec needs.
+type batchSpecStore interface {
+	GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error)
+	GetBatchSpec(ctx context.Context, opts store.GetBatchSpecOpts) (*btypes.BatchSpec, error)
+}
+
+// AutoMergeStore is the subset of the store that ApplyAutoMergePolicy needs.
+type AutoMergeStore interface {
+	batchSpecStore
+	GetChangesetJob(ctx context.Context, opts store.GetChangesetJobOpts) (*btypes.ChangesetJob, error)
+	CreateChangesetJob(ctx context.Context, cs ...*btypes.ChangesetJob) error
+	UpdateChangesetAutoMergeBlockedReason(ctx context.Context, cs *btypes.Changeset) error
+}
+
+// ApplyAutoMergePolicy checks the changeset against the auto-merge policy of
+// the batch change that owns it. Changesets that satisfy the policy are merged
+// by enqueueing a merge job for the bulk processor. For all others, the reason
+// they are not merged is recorded on the changeset, which includes a merge job
+// that failed for the current state of the changeset.
+func ApplyAutoMergePolicy(ctx context.Context, tx AutoMergeStore, c *btypes.Changeset, now time.Time) error {
+	policy, batchChange, err := loadAutoMergePolicy(ctx, tx, c)
+	if err != nil {
+		return err
+	}
+
+	var reason string
+	if policy != nil {
+		reason = autoMergeBlockedReason(policy, c, now)
+		if reason == "" && mergeable(c) {
+			if reason, err = enqueueAutoMerge(ctx, tx, c, batchChange, policy); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Only write the reason when it changed, as most syncs don't change it.
+	var have string
+	if c.AutoMergeBlockedReason != nil {
+		have = *c.AutoMergeBlockedReason
+	}
+	if have == reason {
+		return nil
+	}
+	c.AutoMergeBlockedReason = nil
+	if reason != "" {
+		c.AutoMergeBlockedReason = &reason
+	}
+	return tx.UpdateChangesetAutoMergeBlockedReason(ctx, c)
+}
+
+// mergeable returns true if the changeset is open on the code host.
+func mergeable(c *btypes.Changeset) bool {
+	return c.Published() && !c.IsDeleted() && c.ExternalState == btypes.ChangesetExternalStateOpen
+}
+
+// loadAutoMergePolicy returns the auto-merge policy that applies to the
+// changeset, along with the batch change it belongs to. The policy is nil if
+// the changeset has no owning batch spec, or the batch spec doesn't have a
+// policy.
+func loadAutoMergePolicy(ctx context.Context, tx batchSpecStore, c *btypes.Changeset) (*batcheslib.AutoMerge, *btypes.BatchChange, error) {
+	batchChange, batchSpec, err := loadOwningBatchSpec(ctx, tx, c)
+	if err != nil || batchSpec == nil {
+		return nil, nil, err
+	}
+	return batchSpec.Spec.AutoMerge, batchChange, nil
+}
+
+// loadOwningBatchSpec returns the batch change that owns the changeset and
+// the batch spec currently applied to it. Both are nil if the changeset isn't
+// owned by a batch change, has been detached or archived from it, or the
+// batch change is closed.
+func loadOwningBatchSpec(ctx context.Context, tx batchSpecStore, c *btypes.Changeset) (*btypes.BatchChange, *btypes.BatchSpec, error) {
+	if c.OwnedByBatchChangeID == 0 {
+		return nil, nil, nil
+	}
+	attached := false
+	for _, assoc := range c.BatchChanges {
+		if assoc.BatchChangeID == c.OwnedByBatchChangeID {
+			attached = !assoc.Detach && !assoc.Archive && !assoc.IsArchived
+		}
+	}
+	if !attached {
+		return nil, nil, nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: c.OwnedByBatchChangeID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrap(err, "getting batch change")
+	}
+	if batchChange.Closed() {
+		return nil, nil, nil
+	}
+
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "getting batch spec")
+	}
+	if batchSpec.Spec == nil {
+		return nil, nil, nil
+	}
+	return batchChange, batchSpec, nil
+}
+
+// autoMergeBlockedReason returns the reason the changeset may not be merged
+// under the given policy at the given time, or an empty string if it may be.
+// Changesets that can't be merged at all, such as closed ones, have no reason.
+func autoMergeBlockedReason(policy *batcheslib.AutoMerge, c *btypes.Changeset, now time.Time) string {
+	if c.Published() && !c.IsDeleted() && c.ExternalState == btypes.ChangesetExternalStateDraft {
+		return "The changeset is a draft."
+	}
+	if !mergeable(c) {
+		return ""
+	}
+	if state.IsConflicted(c) {
+		return "The changeset has merge conflicts."
+	}
+
+	switch policy.RequiredChecks() {
+	case batcheslib.AutoMergeChecksPassed:
+		if c.ExternalCheckState != btypes.ChangesetCheckStatePassed {
+			return checksBlockedReason(c.ExternalCheckState)
+		}
+	case batcheslib.AutoMergeChecksPassedOrNone:
+		if c.ExternalCheckState != btypes.ChangesetCheckStatePassed && c.ExternalCheckState != btypes.ChangesetCheckStateUnknown {
+			return checksBlockedReason(c.ExternalCheckState)
+		}
+	}
+
+	if c.ExternalReviewState == btypes.ChangesetReviewStateChangesRequested {
+		return "Changes were requested."
+	}
+	if approvals := state.CountApprovals(c); approvals < policy.Approvals {
+		return fmt.Sprintf("The changeset has %d of %d required approvals.", approvals, policy.Approvals)
+	}
+
+	windows, err := autoMergeWindows(policy)
+	if err != nil {
+		return fmt.Sprintf("The merge windows are invalid: %s.", err)
+	}
+	if !windows.IsOpen(now) {
+		return "Outside of the merge windows."
+	}
+
+	return ""
+}
+
+func checksBlockedReason(s btypes.ChangesetCheckState) string {
+	switch s {
+	case btypes.ChangesetCheckStatePending:
+		return "Checks are pending."
+	case btypes.ChangesetCheckStateFailed:
+		return "Checks have failed."
+	default:
+		return "The changeset has no checks."
+	}
+}
+
+// autoMergeWindows converts the merge windows of the policy into a rollout
+// window configuration, in which every window has an unlimited rate.
+func autoMergeWindows(policy *batcheslib.AutoMerge) (*window.Configuration, error) {
+	raw := make([]*schema.BatchChangeRolloutWindow, 0, len(policy.Windows))
+	for _, w := range policy.Windows {
+		raw = append(raw, &schema.BatchChangeRolloutWindow{
+			Days:  w.Days,
+			Start: w.Start,
+			End:   w.End,
+			Rate:  "unlimited",
+		})
+	}
+	return window.NewConfiguration(&raw)
+}
+
+// enqueueAutoMerge enqueues a merge job for the changeset on behalf of the user
+// that last applied the batch change, unless one is already in progress. If
+// the last merge job failed for the current state of the changeset, no job is
+// enqueued and the failure is returned as the reason the changeset is not
+// merged. Pushing a new head commit, such as a rebase, or applying the batch
+// change again retries the merge.
+func enqueueAutoMerge(ctx context.Context, tx AutoMergeStore, c *btypes.Changeset, batchChange *btypes.BatchChange, policy *batcheslib.AutoMerge) (string, error) {
+	last, err := tx.GetChangesetJob(ctx, store.GetChangesetJobOpts{
+		ChangesetID: c.ID,
+		JobType:     btypes.ChangesetJobTypeMerge,
+	})
+	if err != nil && err != store.ErrNoResults {
+		return "", errors.Wrap(err, "getting last merge job")
+	}
+	if last != nil {
+		switch last.State {
+		case btypes.ChangesetJobStateQueued, btypes.ChangesetJobStateProcessing, btypes.ChangesetJobStateErrored:
+			return "", nil
+		case btypes.ChangesetJobStateFailed:
+			if mergeFailedForCurrentState(last, c, batchChange) {
+				return autoMergeFailedReason(last), nil
+			}
+		}
+	}
+
+	bulkGroupID, err := store.RandomID()
+	if err != nil {
+		return "", err
+	}
+	return "", tx.CreateChangesetJob(ctx, &btypes.ChangesetJob{
+		BulkGroup:     bulkGroupID,
+		ChangesetID:   c.ID,
+		BatchChangeID: batchChange.ID,
+		UserID:        batchChange.LastApplierID,
+		State:         btypes.ChangesetJobStateQueued,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		Payload: &btypes.ChangesetJobMergePayload{
+			Squash:          policy.Squash(),
+			HeadRefOid:      c.SyncState.HeadRefOid,
+			ChangesetSpecID: c.CurrentSpecID,
+		},
+	})
+}
+
+// mergeFailedForCurrentState returns true if the failed merge job was created
+// after the batch change was last applied, for the head commit and changeset
+// spec the changeset still has.
+func mergeFailedForCurrentState(job *btypes.ChangesetJob, c *btypes.Changeset, batchChange *btypes.BatchChange) bool {
+	if !job.CreatedAt.After(batchChange.LastAppliedAt) {
+		return false
+	}
+	payload, ok := job.Payload.(*btypes.ChangesetJobMergePayload)
+	if !ok {
+		return true
+	}
+	return payload.HeadRefOid == c.SyncState.HeadRefOid && payload.ChangesetSpecID == c.CurrentSpecID
+}
+
+func autoMergeFailedReason(job *btypes.ChangesetJob) string {
+	if job.FailureMessage == nil || *job.FailureMessage == "" {
+		return "Merging the changeset failed. Update the changeset or apply the batch change again to retry."
+	}
+	return fmt.Sprintf("Merging the changeset failed: %s. Update the changeset or apply the batch change again to retry.", strings.TrimSuffix(*job.FailureMessage, "."))
+}
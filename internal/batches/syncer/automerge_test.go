@@ -0,0 +1,295 @@
+package syncer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestAutoMergeBlockedReason(t *testing.T) {
+	// 2021-04-05 is a Monday.
+	monday := time.Date(2021, 4, 5, 12, 0, 0, 0, time.UTC)
+
+	approvedBy := func(logins ...string) *github.PullRequest {
+		pr := &github.PullRequest{}
+		for _, login := range logins {
+			pr.TimelineItems = append(pr.TimelineItems, github.TimelineItem{
+				Type: "PullRequestReview",
+				Item: &github.PullRequestReview{Author: github.Actor{Login: login}, State: "APPROVED"},
+			})
+		}
+		return pr
+	}
+	changeset := func(externalState btypes.ChangesetExternalState, checkState btypes.ChangesetCheckState, reviewState btypes.ChangesetReviewState) *btypes.Changeset {
+		return &btypes.Changeset{
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ExternalState:       externalState,
+			ExternalCheckState:  checkState,
+			ExternalReviewState: reviewState,
+			Metadata:            approvedBy("alice"),
+		}
+	}
+	open := btypes.ChangesetExternalStateOpen
+
+	for name, tc := range map[string]struct {
+		policy    batcheslib.AutoMerge
+		changeset *btypes.Changeset
+		want      string
+	}{
+		"mergeable": {
+			changeset: changeset(open, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved),
+			want:      "",
+		},
+		"merged": {
+			changeset: changeset(btypes.ChangesetExternalStateMerged, btypes.ChangesetCheckStateFailed, btypes.ChangesetReviewStatePending),
+			want:      "",
+		},
+		"draft": {
+			changeset: changeset(btypes.ChangesetExternalStateDraft, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved),
+			want:      "The changeset is a draft.",
+		},
+		"checks pending": {
+			changeset: changeset(open, btypes.ChangesetCheckStatePending, btypes.ChangesetReviewStateApproved),
+			want:      "Checks are pending.",
+		},
+		"no checks": {
+			changeset: changeset(open, btypes.ChangesetCheckStateUnknown, btypes.ChangesetReviewStateApproved),
+			want:      "The changeset has no checks.",
+		},
+		"no checks allowed": {
+			policy:    batcheslib.AutoMerge{Checks: batcheslib.AutoMergeChecksPassedOrNone},
+			changeset: changeset(open, btypes.ChangesetCheckStateUnknown, btypes.ChangesetReviewStateApproved),
+			want:      "",
+		},
+		"checks failed but ignored": {
+			policy:    batcheslib.AutoMerge{Checks: batcheslib.AutoMergeChecksAny},
+			changeset: changeset(open, btypes.ChangesetCheckStateFailed, btypes.ChangesetReviewStateApproved),
+			want:      "",
+		},
+		"merge conflicts": {
+			changeset: func() *btypes.Changeset {
+				c := changeset(open, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved)
+				c.Metadata.(*github.PullRequest).Mergeable = "CONFLICTING"
+				return c
+			}(),
+			want: "The changeset has merge conflicts.",
+		},
+		"changes requested": {
+			changeset: changeset(open, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateChangesRequested),
+			want:      "Changes were requested.",
+		},
+		"not enough approvals": {
+			policy:    batcheslib.AutoMerge{Approvals: 2},
+			changeset: changeset(open, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved),
+			want:      "The changeset has 1 of 2 required approvals.",
+		},
+		"inside merge window": {
+			policy: batcheslib.AutoMerge{Windows: []batcheslib.AutoMergeWindow{
+				{Days: []string{"monday"}, Start: "09:00", End: "17:00"},
+			}},
+			changeset: changeset(open, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved),
+			want:      "",
+		},
+		"outside merge window": {
+			policy: batcheslib.AutoMerge{Windows: []batcheslib.AutoMergeWindow{
+				{Days: []string{"saturday", "sunday"}},
+			}},
+			changeset: changeset(open, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved),
+			want:      "Outside of the merge windows.",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, autoMergeBlockedReason(&tc.policy, tc.changeset, monday))
+		})
+	}
+}
+
+func TestApplyAutoMergePolicy(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2021, 4, 5, 12, 0, 0, 0, time.UTC)
+
+	newChangeset := func(checkState btypes.ChangesetCheckState) *btypes.Changeset {
+		return &btypes.Changeset{
+			ID:                   1,
+			OwnedByBatchChangeID: 2,
+			BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: 2}},
+			PublicationState:     btypes.ChangesetPublicationStatePublished,
+			ExternalState:        btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:   checkState,
+			Metadata:             &github.PullRequest{},
+		}
+	}
+	newStore := func(policy *batcheslib.AutoMerge) *fakeAutoMergeStore {
+		return &fakeAutoMergeStore{
+			batchChange: &btypes.BatchChange{ID: 2, BatchSpecID: 3, LastApplierID: 4},
+			batchSpec:   &btypes.BatchSpec{ID: 3, Spec: &batcheslib.BatchSpec{AutoMerge: policy}},
+		}
+	}
+
+	t.Run("enqueues merge", func(t *testing.T) {
+		s := newStore(&batcheslib.AutoMerge{Method: batcheslib.AutoMergeMethodSquash})
+		c := newChangeset(btypes.ChangesetCheckStatePassed)
+		c.AutoMergeBlockedReason = pointers.Ptr("Checks are pending.")
+
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+
+		require.Len(t, s.jobs, 1)
+		job := s.jobs[0]
+		assert.Equal(t, btypes.ChangesetJobTypeMerge, job.JobType)
+		assert.Equal(t, btypes.ChangesetJobStateQueued, job.State)
+		assert.Equal(t, int64(1), job.ChangesetID)
+		assert.Equal(t, int64(2), job.BatchChangeID)
+		assert.Equal(t, int32(4), job.UserID)
+		assert.Equal(t, &btypes.ChangesetJobMergePayload{Squash: true}, job.Payload)
+		assert.NotEmpty(t, job.BulkGroup)
+
+		assert.Nil(t, c.AutoMergeBlockedReason)
+		assert.Equal(t, 1, s.reasonUpdates)
+	})
+
+	t.Run("merge already pending", func(t *testing.T) {
+		s := newStore(&batcheslib.AutoMerge{})
+		s.lastJob = &btypes.ChangesetJob{State: btypes.ChangesetJobStateErrored}
+
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, newChangeset(btypes.ChangesetCheckStatePassed), now))
+		assert.Empty(t, s.jobs)
+		assert.Equal(t, 0, s.reasonUpdates)
+	})
+
+	t.Run("merge failed", func(t *testing.T) {
+		s := newStore(&batcheslib.AutoMerge{})
+		s.batchChange.LastAppliedAt = now.Add(-2 * time.Hour)
+		s.lastJob = &btypes.ChangesetJob{
+			State:          btypes.ChangesetJobStateFailed,
+			FailureMessage: pointers.Ptr("changeset is not mergeable"),
+			CreatedAt:      now.Add(-time.Hour),
+		}
+		c := newChangeset(btypes.ChangesetCheckStatePassed)
+
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+		assert.Empty(t, s.jobs)
+		assert.Equal(t, pointers.Ptr("Merging the changeset failed: changeset is not mergeable. Update the changeset or apply the batch change again to retry."), c.AutoMergeBlockedReason)
+		assert.Equal(t, 1, s.reasonUpdates)
+	})
+
+	t.Run("merge failed before the batch change was applied again", func(t *testing.T) {
+		s := newStore(&batcheslib.AutoMerge{})
+		s.batchChange.LastAppliedAt = now.Add(-time.Hour)
+		s.lastJob = &btypes.ChangesetJob{
+			State:     btypes.ChangesetJobStateFailed,
+			CreatedAt: now.Add(-2 * time.Hour),
+		}
+
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, newChangeset(btypes.ChangesetCheckStatePassed), now))
+		assert.Len(t, s.jobs, 1)
+	})
+
+	t.Run("merge failed before the changeset was updated", func(t *testing.T) {
+		for name, update := range map[string]func(c *btypes.Changeset){
+			"new head commit": func(c *btypes.Changeset) { c.SyncState.HeadRefOid = "new-head" },
+			"new spec":        func(c *btypes.Changeset) { c.CurrentSpecID = 6 },
+		} {
+			t.Run(name, func(t *testing.T) {
+				s := newStore(&batcheslib.AutoMerge{})
+				s.batchChange.LastAppliedAt = now.Add(-2 * time.Hour)
+				s.lastJob = &btypes.ChangesetJob{
+					State:     btypes.ChangesetJobStateFailed,
+					CreatedAt: now.Add(-time.Hour),
+					Payload:   &btypes.ChangesetJobMergePayload{HeadRefOid: "old-head", ChangesetSpecID: 5},
+				}
+				c := newChangeset(btypes.ChangesetCheckStatePassed)
+				c.SyncState.HeadRefOid = "old-head"
+				c.CurrentSpecID = 5
+
+				require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+				assert.Empty(t, s.jobs)
+
+				update(c)
+				require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+				require.Len(t, s.jobs, 1)
+				assert.Equal(t, &btypes.ChangesetJobMergePayload{
+					HeadRefOid:      c.SyncState.HeadRefOid,
+					ChangesetSpecID: c.CurrentSpecID,
+				}, s.jobs[0].Payload)
+				assert.Nil(t, c.AutoMergeBlockedReason)
+			})
+		}
+	})
+
+	t.Run("records reason", func(t *testing.T) {
+		s := newStore(&batcheslib.AutoMerge{})
+		c := newChangeset(btypes.ChangesetCheckStateFailed)
+
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+		assert.Empty(t, s.jobs)
+		assert.Equal(t, pointers.Ptr("Checks have failed."), c.AutoMergeBlockedReason)
+		assert.Equal(t, 1, s.reasonUpdates)
+
+		// The reason is only written when it changes.
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+		assert.Equal(t, 1, s.reasonUpdates)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		s := newStore(nil)
+		c := newChangeset(btypes.ChangesetCheckStatePassed)
+		c.AutoMergeBlockedReason = pointers.Ptr("Checks are pending.")
+
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+		assert.Empty(t, s.jobs)
+		assert.Nil(t, c.AutoMergeBlockedReason)
+		assert.Equal(t, 1, s.reasonUpdates)
+	})
+
+	t.Run("detached changeset", func(t *testing.T) {
+		s := newStore(&batcheslib.AutoMerge{})
+		c := newChangeset(btypes.ChangesetCheckStatePassed)
+		c.BatchChanges = []btypes.BatchChangeAssoc{{BatchChangeID: 2, Detach: true}}
+
+		require.NoError(t, ApplyAutoMergePolicy(ctx, s, c, now))
+		assert.Empty(t, s.jobs)
+		assert.Equal(t, 0, s.reasonUpdates)
+	})
+}
+
+type fakeAutoMergeStore struct {
+	batchChange   *btypes.BatchChange
+	batchSpec     *btypes.BatchSpec
+	lastJob       *btypes.ChangesetJob
+	jobs          []*btypes.ChangesetJob
+	reasonUpdates int
+}
+
+func (s *fakeAutoMergeStore) GetBatchChange(context.Context, store.GetBatchChangeOpts) (*btypes.BatchChange, error) {
+	return s.batchChange, nil
+}
+
+func (s *fakeAutoMergeStore) GetBatchSpec(context.Context, store.GetBatchSpecOpts) (*btypes.BatchSpec, error) {
+	return s.batchSpec, nil
+}
+
+func (s *fakeAutoMergeStore) GetChangesetJob(context.Context, store.GetChangesetJobOpts) (*btypes.ChangesetJob, error) {
+	if s.lastJob == nil {
+		return nil, store.ErrNoResults
+	}
+	return s.lastJob, nil
+}
+
+func (s *fakeAutoMergeStore) CreateChangesetJob(_ context.Context, cs ...*btypes.ChangesetJob) error {
+	s.jobs = append(s.jobs, cs...)
+	return nil
+}
+
+func (s *fakeAutoMergeStore) UpdateChangesetAutoMergeBlockedReason(context.Context, *btypes.Changeset) error {
+	s.reasonUpdates++
+	return nil
+}
@@ -0,0 +1,335 @@
+package syncer
+
+import (
+	"context"
+	"io"
+	"sort"
+
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// rebaseStore is the subset of the store that rebaseConflictedChangeset needs.
+type rebaseStore interface {
+	batchSpecStore
+	GetBatchSpecWorkspace(ctx context.Context, opts store.GetBatchSpecWorkspaceOpts) (*btypes.BatchSpecWorkspace, error)
+	GetBatchSpecWorkspaceExecutionJob(ctx context.Context, opts store.GetBatchSpecWorkspaceExecutionJobOpts) (*btypes.BatchSpecWorkspaceExecutionJob, error)
+	ListBatchSpecWorkspaceFiles(ctx context.Context, opts store.ListBatchSpecWorkspaceFileOpts) ([]*btypes.BatchSpecWorkspaceFile, int64, error)
+	RebaseBatchSpecWorkspace(ctx context.Context, ws *btypes.BatchSpecWorkspace) error
+	DatabaseDB() database.DB
+}
+
+// rebaseConflictedChangeset regenerates changesets that the code host reports
+// to have merge conflicts with their base branch. The workspace the changeset
+// was created from is executed again against the current head of the base
+// branch. Once the execution completes, the changeset is moved to the new
+// changeset spec and the reconciler force-pushes the new commit.
+//
+// Only changesets of batch changes that were run server-side are rebased, and
+// only if the batch spec doesn't opt out of it. A workspace is executed at
+// most once per base commit, so that changesets whose conflicts can't be
+// resolved by running the steps again aren't rebased over and over.
+//
+// The results of the steps the rebase doesn't affect are reused from the
+// previous execution of the workspace, see reusableStepResults.
+func rebaseConflictedChangeset(ctx context.Context, tx rebaseStore, client gitserver.Client, repo *types.Repo, c *btypes.Changeset) error {
+	if !c.Published() || c.IsDeleted() || c.CurrentSpecID == 0 || !state.IsConflicted(c) {
+		return nil
+	}
+	if c.ExternalState != btypes.ChangesetExternalStateOpen && c.ExternalState != btypes.ChangesetExternalStateDraft {
+		return nil
+	}
+
+	_, batchSpec, err := loadOwningBatchSpec(ctx, tx, c)
+	if err != nil || batchSpec == nil {
+		return err
+	}
+	if !batchSpec.CreatedFromRaw || !batchSpec.Spec.AutoRebaseEnabled() {
+		return nil
+	}
+
+	workspace, err := tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ChangesetSpecID: c.CurrentSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return errors.Wrap(err, "getting batch spec workspace")
+	}
+	if workspace.BatchSpecID != batchSpec.ID {
+		return nil
+	}
+
+	job, err := tx.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: workspace.ID, ExcludeRank: true})
+	if err != nil && err != store.ErrNoResults {
+		return errors.Wrap(err, "getting batch spec workspace execution job")
+	}
+	if job != nil && (job.State == btypes.BatchSpecWorkspaceExecutionJobStateQueued || job.State == btypes.BatchSpecWorkspaceExecutionJobStateProcessing) {
+		return nil
+	}
+
+	commit, err := client.ResolveRevision(ctx, repo.Name, workspace.Branch, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		if errors.HasType[*gitdomain.RevisionNotFoundError](err) {
+			return nil
+		}
+		return errors.Wrap(err, "resolving base branch")
+	}
+	if string(commit) == workspace.Commit {
+		return nil
+	}
+
+	results, err := reusableStepResults(ctx, tx, client, repo, batchSpec, workspace, job, commit)
+	if err != nil {
+		return errors.Wrap(err, "finding reusable step results")
+	}
+	workspace.Commit = string(commit)
+	workspace.StepCacheResults = results
+
+	return tx.RebaseBatchSpecWorkspace(ctx, workspace)
+}
+
+// reusableStepResults returns the results of the previous execution of the
+// workspace for the steps that rebasing it onto commit doesn't affect, keyed
+// like BatchSpecWorkspace.StepCacheResults.
+//
+// The cache keys of the execution cache include the base commit, so they never
+// match after a rebase. Instead, we look at the inputs a step depends on: the
+// steps up to it, the file matches and path of the workspace, the workspace
+// files and secrets the steps use, and the content of the repository. Only the
+// last three can differ from the previous execution:
+//
+//   - A step is assumed to read the files the workspace matched and the files
+//     it and the steps before it changed. If the base branch changed any of
+//     them, the step is affected.
+//   - Steps that mount workspace files are affected if a workspace file was
+//     updated after the previous execution started.
+//   - Steps are affected if a secret they use was updated or deleted after
+//     the previous execution started. Only the metadata of the secrets is read.
+//
+// Results are reused up to the first affected step, and never for the last
+// step, so that the workspace is executed and produces changeset specs for the
+// new base commit.
+func reusableStepResults(ctx context.Context, tx rebaseStore, client gitserver.Client, repo *types.Repo, batchSpec *btypes.BatchSpec, ws *btypes.BatchSpecWorkspace, job *btypes.BatchSpecWorkspaceExecutionJob, commit api.CommitID) (map[int]btypes.StepCacheResult, error) {
+	if job == nil || job.State != btypes.BatchSpecWorkspaceExecutionJobStateCompleted || job.StartedAt.IsZero() {
+		return nil, nil
+	}
+
+	results, err := previousStepResults(ws, job)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+
+	skippedSteps, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, string(repo.Name), ws.FileMatches)
+	if err != nil {
+		return nil, err
+	}
+	// Find the last step that is not statically skipped.
+	latestStepIdx := -1
+	for i := len(batchSpec.Spec.Steps) - 1; i >= 0; i-- {
+		if _, ok := skippedSteps[i]; !ok {
+			latestStepIdx = i
+			break
+		}
+	}
+
+	changed, err := changedPaths(ctx, client, repo.Name, ws.Commit, string(commit))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range ws.FileMatches {
+		if _, ok := changed[path]; ok {
+			return nil, nil
+		}
+	}
+	updatedSecrets, err := updatedSecretKeys(ctx, tx, batchSpec, job)
+	if err != nil {
+		return nil, err
+	}
+	var mountsUpdated bool
+	for _, step := range batchSpec.Spec.Steps {
+		if len(step.Mount) > 0 {
+			if mountsUpdated, err = workspaceFilesUpdated(ctx, tx, batchSpec, job); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	reusable := map[int]btypes.StepCacheResult{}
+	next := 0
+	for _, res := range results {
+		idx := res.Value.StepIndex
+		if idx >= latestStepIdx {
+			break
+		}
+		// Only a contiguous run of results from the first step on can be used.
+		for ; next < idx; next++ {
+			if _, ok := skippedSteps[next]; !ok {
+				return reusable, nil
+			}
+		}
+		next = idx + 1
+
+		step := batchSpec.Spec.Steps[idx]
+		if len(step.Mount) > 0 && mountsUpdated {
+			break
+		}
+		if usesSecret(step, updatedSecrets) {
+			break
+		}
+		touched, err := diffPaths(res.Value.Diff)
+		if err != nil {
+			return nil, err
+		}
+		if intersects(touched, changed) {
+			break
+		}
+
+		reusable[idx+1] = res
+	}
+	return reusable, nil
+}
+
+// previousStepResults returns the step results of the previous execution of
+// the workspace, ordered by step. They are the results the execution logged,
+// along with the cached results it started from.
+func previousStepResults(ws *btypes.BatchSpecWorkspace, job *btypes.BatchSpecWorkspaceExecutionJob) ([]btypes.StepCacheResult, error) {
+	byStep := map[int]btypes.StepCacheResult{}
+	for _, res := range ws.StepCacheResults {
+		if res.Value != nil {
+			byStep[res.Value.StepIndex] = res
+		}
+	}
+	logged, err := store.StepResultsFromExecutionLogs(job.ExecutionLogs)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range logged {
+		value := m.Value
+		byStep[value.StepIndex] = btypes.StepCacheResult{Key: m.Key, Value: &value}
+	}
+
+	results := make([]btypes.StepCacheResult, 0, len(byStep))
+	for _, res := range byStep {
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Value.StepIndex < results[j].Value.StepIndex })
+	return results, nil
+}
+
+// changedPaths returns the paths the base branch changed between the base and
+// head commits.
+func changedPaths(ctx context.Context, client gitserver.Client, repo api.RepoName, base, head string) (map[string]struct{}, error) {
+	it, err := client.ChangedFiles(ctx, repo, base, head)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing files changed on the base branch")
+	}
+	defer it.Close()
+
+	paths := map[string]struct{}{}
+	for {
+		f, err := it.Next()
+		if err == io.EOF {
+			return paths, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "listing files changed on the base branch")
+		}
+		paths[f.Path] = struct{}{}
+	}
+}
+
+// diffPaths returns the paths of the files a step result diff changes, with
+// both names of renamed files.
+func diffPaths(rawDiff []byte) (map[string]struct{}, error) {
+	fileDiffs, err := diff.ParseMultiFileDiff(rawDiff)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing step result diff")
+	}
+	paths := map[string]struct{}{}
+	for _, fd := range fileDiffs {
+		for _, name := range []string{fd.OrigName, fd.NewName} {
+			if name != "/dev/null" {
+				paths[name] = struct{}{}
+			}
+		}
+	}
+	return paths, nil
+}
+
+func intersects(a, b map[string]struct{}) bool {
+	for k := range a {
+		if _, ok := b[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// updatedSecretKeys returns the keys of the secrets the batch spec uses that
+// were updated or deleted after the job started.
+func updatedSecretKeys(ctx context.Context, tx rebaseStore, batchSpec *btypes.BatchSpec, job *btypes.BatchSpecWorkspaceExecutionJob) (map[string]struct{}, error) {
+	required := batchSpec.Spec.RequiredEnvVars()
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	// 🚨 SECURITY: Secrets are listed as the user that created the batch
+	// spec. We don't read their values.
+	ctx = actor.WithActor(ctx, actor.FromUser(batchSpec.UserID))
+	secrets, _, err := tx.DatabaseDB().ExecutorSecrets(keyring.Default().ExecutorSecretKey).List(ctx, database.ExecutorSecretScopeBatches, database.ExecutorSecretsListOpts{
+		NamespaceUserID: batchSpec.NamespaceUserID,
+		NamespaceOrgID:  batchSpec.NamespaceOrgID,
+		Keys:            required,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing secrets")
+	}
+
+	updated := make(map[string]struct{}, len(required))
+	for _, key := range required {
+		updated[key] = struct{}{}
+	}
+	for _, secret := range secrets {
+		if !secret.UpdatedAt.After(job.StartedAt) {
+			delete(updated, secret.Key)
+		}
+	}
+	return updated, nil
+}
+
+func usesSecret(step batcheslib.Step, secrets map[string]struct{}) bool {
+	for _, v := range step.Env.OuterVars() {
+		if _, ok := secrets[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceFilesUpdated returns whether a workspace file of the batch spec was
+// updated after the job started.
+func workspaceFilesUpdated(ctx context.Context, tx rebaseStore, batchSpec *btypes.BatchSpec, job *btypes.BatchSpecWorkspaceExecutionJob) (bool, error) {
+	files, _, err := tx.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		return false, errors.Wrap(err, "listing workspace files")
+	}
+	for _, f := range files {
+		if f.UpdatedAt.After(job.StartedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,328 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestRebaseConflictedChangeset(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	newStore := func() *fakeRebaseStore {
+		return &fakeRebaseStore{
+			fakeAutoMergeStore: fakeAutoMergeStore{
+				batchChange: &btypes.BatchChange{ID: 2, BatchSpecID: 3},
+				batchSpec: &btypes.BatchSpec{
+					ID:             3,
+					UserID:         4,
+					CreatedFromRaw: true,
+					Spec:           &batcheslib.BatchSpec{Name: "test", Steps: []batcheslib.Step{{Run: "echo 1"}}},
+				},
+			},
+			workspace: &btypes.BatchSpecWorkspace{
+				ID:               6,
+				BatchSpecID:      3,
+				RepoID:           1,
+				Branch:           "refs/heads/main",
+				Commit:           "old",
+				ChangesetSpecIDs: []int64{5},
+			},
+		}
+	}
+	newClient := func(commit api.CommitID) *gitserver.MockClient {
+		client := gitserver.NewMockClient()
+		client.ResolveRevisionFunc.SetDefaultReturn(commit, nil)
+		return client
+	}
+
+	t.Run("requeues workspace at new base commit", func(t *testing.T) {
+		s := newStore()
+		s.job = &btypes.BatchSpecWorkspaceExecutionJob{State: btypes.BatchSpecWorkspaceExecutionJobStateCompleted}
+		client := newClient("new")
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, client, repo, newConflictedChangeset()))
+
+		require.Len(t, s.rebased, 1)
+		assert.Equal(t, "new", s.rebased[0].Commit)
+		assert.Empty(t, s.rebased[0].StepCacheResults)
+		assert.Equal(t, "refs/heads/main", client.ResolveRevisionFunc.History()[0].Arg2)
+	})
+
+	t.Run("base commit unchanged", func(t *testing.T) {
+		s := newStore()
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient("old"), repo, newConflictedChangeset()))
+		assert.Empty(t, s.rebased)
+	})
+
+	t.Run("base branch deleted", func(t *testing.T) {
+		s := newStore()
+		client := gitserver.NewMockClient()
+		client.ResolveRevisionFunc.SetDefaultReturn("", &gitdomain.RevisionNotFoundError{Repo: repo.Name, Spec: "refs/heads/main"})
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, client, repo, newConflictedChangeset()))
+		assert.Empty(t, s.rebased)
+	})
+
+	t.Run("not conflicted", func(t *testing.T) {
+		s := newStore()
+		c := newConflictedChangeset()
+		c.Metadata = &github.PullRequest{Mergeable: "MERGEABLE"}
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient("new"), repo, c))
+		assert.Empty(t, s.rebased)
+	})
+
+	t.Run("auto-rebase disabled", func(t *testing.T) {
+		s := newStore()
+		s.batchSpec.Spec.AutoRebase = pointers.Ptr(false)
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient("new"), repo, newConflictedChangeset()))
+		assert.Empty(t, s.rebased)
+	})
+
+	t.Run("batch spec not run server-side", func(t *testing.T) {
+		s := newStore()
+		s.batchSpec.CreatedFromRaw = false
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient("new"), repo, newConflictedChangeset()))
+		assert.Empty(t, s.rebased)
+	})
+
+	t.Run("workspace already executing", func(t *testing.T) {
+		s := newStore()
+		s.job = &btypes.BatchSpecWorkspaceExecutionJob{State: btypes.BatchSpecWorkspaceExecutionJobStateQueued}
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient("new"), repo, newConflictedChangeset()))
+		assert.Empty(t, s.rebased)
+	})
+}
+
+func TestRebaseConflictedChangesetReusesStepResults(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	startedAt := time.Now().Add(-time.Hour)
+
+	// Every step adds a file, the results hold the diff of the steps up to
+	// them.
+	stepFiles := []string{"a.txt", "b.txt", "c.txt"}
+	stepResult := func(idx int) btypes.StepCacheResult {
+		var d strings.Builder
+		for _, f := range stepFiles[:idx+1] {
+			fmt.Fprintf(&d, "diff --git %[1]s %[1]s\nnew file mode 100644\nindex 0000000..1111111\n--- /dev/null\n+++ %[1]s\n@@ -0,0 +1 @@\n+step\n", f)
+		}
+		return btypes.StepCacheResult{
+			Key:   fmt.Sprintf("key-step-%d", idx),
+			Value: &execution.AfterStepResult{StepIndex: idx, Diff: []byte(d.String())},
+		}
+	}
+	// executionLogs returns the logs of an execution that ran the given steps.
+	executionLogs := func(steps ...int) []executor.ExecutionLogEntry {
+		var logs []executor.ExecutionLogEntry
+		for _, idx := range steps {
+			res := stepResult(idx)
+			event := batcheslib.LogEvent{
+				Operation: batcheslib.LogEventOperationCacheAfterStepResult,
+				Status:    batcheslib.LogEventStatusSuccess,
+				Metadata:  &batcheslib.CacheAfterStepResultMetadata{Key: res.Key, Value: *res.Value},
+			}
+			out, err := json.Marshal(event)
+			require.NoError(t, err)
+			logs = append(logs, executor.ExecutionLogEntry{Key: fmt.Sprintf("step.docker.step.%d.post", idx), Out: "stdout: " + string(out) + "\n"})
+		}
+		return logs
+	}
+
+	newStore := func() *fakeRebaseStore {
+		return &fakeRebaseStore{
+			fakeAutoMergeStore: fakeAutoMergeStore{
+				batchChange: &btypes.BatchChange{ID: 2, BatchSpecID: 3},
+				batchSpec: &btypes.BatchSpec{
+					ID:             3,
+					UserID:         4,
+					CreatedFromRaw: true,
+					Spec: &batcheslib.BatchSpec{Name: "test", Steps: []batcheslib.Step{
+						{Run: "touch a.txt"},
+						{Run: "touch b.txt"},
+						{Run: "touch c.txt"},
+					}},
+				},
+			},
+			workspace: &btypes.BatchSpecWorkspace{
+				ID:               6,
+				BatchSpecID:      3,
+				RepoID:           1,
+				Branch:           "refs/heads/main",
+				Commit:           "old",
+				ChangesetSpecIDs: []int64{5},
+				FileMatches:      []string{"README.md"},
+			},
+			job: &btypes.BatchSpecWorkspaceExecutionJob{
+				State:         btypes.BatchSpecWorkspaceExecutionJobStateCompleted,
+				StartedAt:     startedAt,
+				ExecutionLogs: executionLogs(0, 1, 2),
+			},
+			db: dbmocks.NewMockDB(),
+		}
+	}
+	newClient := func(changed ...string) *gitserver.MockClient {
+		client := gitserver.NewMockClient()
+		client.ResolveRevisionFunc.SetDefaultReturn("new", nil)
+		client.ChangedFilesFunc.SetDefaultHook(func(context.Context, api.RepoName, string, string) (gitserver.ChangedFilesIterator, error) {
+			var files []gitdomain.PathStatus
+			for _, path := range changed {
+				files = append(files, gitdomain.PathStatus{Path: path, Status: gitdomain.StatusModified})
+			}
+			return gitserver.NewChangedFilesIteratorFromSlice(files), nil
+		})
+		return client
+	}
+	// reused returns the keys of the step results the rebased workspace
+	// starts from.
+	reused := func(t *testing.T, s *fakeRebaseStore) []string {
+		t.Helper()
+		require.Len(t, s.rebased, 1)
+		var keys []string
+		for idx := 1; idx <= len(stepFiles); idx++ {
+			if res, ok := s.rebased[0].StepCacheResult(idx); ok {
+				keys = append(keys, res.Key)
+			}
+		}
+		return keys
+	}
+
+	t.Run("base changed other files", func(t *testing.T) {
+		s := newStore()
+		client := newClient("main.go")
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, client, repo, newConflictedChangeset()))
+		// The last step is always executed again.
+		assert.Equal(t, []string{"key-step-0", "key-step-1"}, reused(t, s))
+		assert.Equal(t, "old", client.ChangedFilesFunc.History()[0].Arg2)
+		assert.Equal(t, "new", client.ChangedFilesFunc.History()[0].Arg3)
+	})
+
+	t.Run("base changed a file of a step", func(t *testing.T) {
+		s := newStore()
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient("b.txt"), repo, newConflictedChangeset()))
+		assert.Equal(t, []string{"key-step-0"}, reused(t, s))
+	})
+
+	t.Run("base changed a matched file", func(t *testing.T) {
+		s := newStore()
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient("README.md"), repo, newConflictedChangeset()))
+		assert.Empty(t, reused(t, s))
+	})
+
+	t.Run("results cached before the execution", func(t *testing.T) {
+		s := newStore()
+		s.workspace.SetStepCacheResult(1, stepResult(0))
+		s.job.ExecutionLogs = executionLogs(1, 2)
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient(), repo, newConflictedChangeset()))
+		assert.Equal(t, []string{"key-step-0", "key-step-1"}, reused(t, s))
+	})
+
+	t.Run("secret updated", func(t *testing.T) {
+		s := newStore()
+		require.NoError(t, json.Unmarshal([]byte(`["SECRET"]`), &s.batchSpec.Spec.Steps[1].Env))
+		secrets := dbmocks.NewMockExecutorSecretStore()
+		secrets.ListFunc.SetDefaultReturn([]*database.ExecutorSecret{{Key: "SECRET", UpdatedAt: startedAt.Add(time.Minute)}}, 0, nil)
+		s.db.ExecutorSecretsFunc.SetDefaultReturn(secrets)
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient(), repo, newConflictedChangeset()))
+		assert.Equal(t, []string{"key-step-0"}, reused(t, s))
+		assert.Equal(t, []string{"SECRET"}, secrets.ListFunc.History()[0].Arg2.Keys)
+	})
+
+	t.Run("workspace file updated", func(t *testing.T) {
+		s := newStore()
+		s.batchSpec.Spec.Steps[0].Mount = []batcheslib.Mount{{Path: "script.sh", Mountpoint: "/tmp/script.sh"}}
+		s.files = []*btypes.BatchSpecWorkspaceFile{{Path: "script.sh", UpdatedAt: startedAt.Add(time.Minute)}}
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient(), repo, newConflictedChangeset()))
+		assert.Empty(t, reused(t, s))
+	})
+
+	t.Run("previous execution failed", func(t *testing.T) {
+		s := newStore()
+		s.job.State = btypes.BatchSpecWorkspaceExecutionJobStateFailed
+
+		require.NoError(t, rebaseConflictedChangeset(ctx, s, newClient(), repo, newConflictedChangeset()))
+		assert.Empty(t, reused(t, s))
+	})
+}
+
+func newConflictedChangeset() *btypes.Changeset {
+	return &btypes.Changeset{
+		ID:                   1,
+		OwnedByBatchChangeID: 2,
+		CurrentSpecID:        5,
+		BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: 2}},
+		PublicationState:     btypes.ChangesetPublicationStatePublished,
+		ExternalState:        btypes.ChangesetExternalStateOpen,
+		Metadata:             &github.PullRequest{Mergeable: "CONFLICTING"},
+	}
+}
+
+type fakeRebaseStore struct {
+	fakeAutoMergeStore
+	workspace *btypes.BatchSpecWorkspace
+	job       *btypes.BatchSpecWorkspaceExecutionJob
+	files     []*btypes.BatchSpecWorkspaceFile
+	db        *dbmocks.MockDB
+	rebased   []*btypes.BatchSpecWorkspace
+}
+
+func (s *fakeRebaseStore) GetBatchSpecWorkspace(_ context.Context, opts store.GetBatchSpecWorkspaceOpts) (*btypes.BatchSpecWorkspace, error) {
+	for _, id := range s.workspace.ChangesetSpecIDs {
+		if id == opts.ChangesetSpecID {
+			return s.workspace, nil
+		}
+	}
+	return nil, store.ErrNoResults
+}
+
+func (s *fakeRebaseStore) GetBatchSpecWorkspaceExecutionJob(context.Context, store.GetBatchSpecWorkspaceExecutionJobOpts) (*btypes.BatchSpecWorkspaceExecutionJob, error) {
+	if s.job == nil {
+		return nil, store.ErrNoResults
+	}
+	return s.job, nil
+}
+
+func (s *fakeRebaseStore) ListBatchSpecWorkspaceFiles(context.Context, store.ListBatchSpecWorkspaceFileOpts) ([]*btypes.BatchSpecWorkspaceFile, int64, error) {
+	return s.files, 0, nil
+}
+
+func (s *fakeRebaseStore) DatabaseDB() database.DB {
+	return s.db
+}
+
+func (s *fakeRebaseStore) RebaseBatchSpecWorkspace(_ context.Context, ws *btypes.BatchSpecWorkspace) error {
+	s.rebased = append(s.rebased, ws)
+	return nil
+}
@@ -535,6 +535,7 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 	if err != nil {
 		return err
 	}
+	wasMerged := c.ExternalState == btypes.ChangesetExternalStateMerged
 	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
 
 	tx, err := syncStore.Transact(ctx)
@@ -551,5 +552,21 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
-	return tx.UpsertChangesetEvents(ctx, events...)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	// Changesets that depend on this one may be published now that it's been
+	// merged.
+	if !wasMerged && c.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := tx.EnqueueChangesetDependents(ctx, c.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := ApplyAutoMergePolicy(ctx, tx, c, tx.Clock()()); err != nil {
+		return err
+	}
+
+	return rebaseConflictedChangeset(ctx, tx, client, repo, c)
 }
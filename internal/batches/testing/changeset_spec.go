@@ -39,6 +39,12 @@ type TestSpecOpts struct {
 	BaseRev string
 	BaseRef string
 
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+	Milestone     string
+
 	Typ btypes.ChangesetSpecType
 }
 
@@ -75,6 +81,11 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		DiffStatAdded:     TestChangsetSpecDiffStat.Added,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
+		Reviewers:         opts.Reviewers,
+		TeamReviewers:     opts.TeamReviewers,
+		Labels:            opts.Labels,
+		Assignees:         opts.Assignees,
+		Milestone:         opts.Milestone,
 	}
 
 	return spec
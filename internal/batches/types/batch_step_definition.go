@@ -0,0 +1,31 @@
+package types
+
+import (
+	"time"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// BatchStepDefinition is a version of a reusable step published in a
+// namespace, which steps of batch specs reference in their uses field.
+type BatchStepDefinition struct {
+	ID int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	Name    string
+	Version string
+
+	RawDefinition string
+	Definition    *batcheslib.StepDefinition
+
+	CreatorID int32
+	CreatedAt time.Time
+}
+
+// Clone returns a clone of a BatchStepDefinition.
+func (d *BatchStepDefinition) Clone() *BatchStepDefinition {
+	clone := *d
+	return &clone
+}
@@ -313,6 +313,11 @@ type Changeset struct {
 
 	// DetachedAt is the time when the changeset became "detached".
 	DetachedAt time.Time
+
+	// AutoMergeBlockedReason is set by the syncer when the changeset is not
+	// merged automatically under the auto-merge policy of the batch change
+	// that owns it.
+	AutoMergeBlockedReason *string
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -0,0 +1,41 @@
+package types
+
+import "fmt"
+
+// A ChangesetDependency records that a changeset of a batch change must not be
+// published until another changeset of the same batch change has been merged.
+type ChangesetDependency struct {
+	ChangesetID          int64
+	DependsOnChangesetID int64
+	BatchChangeID        int64
+
+	// Held is true if the reconciler held the publication of the changeset
+	// because the dependency wasn't merged yet.
+	Held bool
+}
+
+// ChangesetDependencyState summarizes the dependencies of a changeset that
+// haven't been merged yet.
+type ChangesetDependencyState struct {
+	// Unmerged is the number of dependencies that haven't been merged.
+	Unmerged int
+	// Abandoned is the number of unmerged dependencies that were closed or
+	// deleted on the code host, or whose repository was archived. They won't
+	// be merged unless they're reopened.
+	Abandoned int
+	// Held is true if the reconciler held the publication of the changeset
+	// because of its unmerged dependencies.
+	Held bool
+}
+
+// BlockedReason returns the reason the publication of the changeset is
+// held, or an empty string if it isn't.
+func (s ChangesetDependencyState) BlockedReason() string {
+	if !s.Held || s.Unmerged == 0 {
+		return ""
+	}
+	if s.Abandoned > 0 {
+		return fmt.Sprintf("%d of the changesets this changeset depends on were closed or deleted without being merged. Reopen and merge them, or remove the dependency from the batch spec, to publish this changeset.", s.Abandoned)
+	}
+	return fmt.Sprintf("Waiting for %d of the changesets this changeset depends on to be merged.", s.Unmerged)
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangesetDependencyState_BlockedReason(t *testing.T) {
+	for name, tc := range map[string]struct {
+		state ChangesetDependencyState
+		want  string
+	}{
+		"not held": {
+			state: ChangesetDependencyState{Unmerged: 2, Abandoned: 1},
+			want:  "",
+		},
+		"held, but all merged": {
+			state: ChangesetDependencyState{Held: true},
+			want:  "",
+		},
+		"held by open dependencies": {
+			state: ChangesetDependencyState{Unmerged: 2, Held: true},
+			want:  "Waiting for 2 of the changesets this changeset depends on to be merged.",
+		},
+		"held by abandoned dependencies": {
+			state: ChangesetDependencyState{Unmerged: 2, Abandoned: 1, Held: true},
+			want:  "1 of the changesets this changeset depends on were closed or deleted without being merged. Reopen and merge them, or remove the dependency from the batch spec, to publish this changeset.",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.state.BlockedReason())
+		})
+	}
+}
@@ -61,6 +61,12 @@ type ChangesetJobReenqueuePayload struct{}
 
 type ChangesetJobMergePayload struct {
 	Squash bool `json:"squash,omitempty"`
+
+	// HeadRefOid and ChangesetSpecID are the head commit and current spec of
+	// the changeset when an auto-merge job was enqueued. A failed auto-merge
+	// is retried once either of them changed.
+	HeadRefOid      string `json:"headRefOid,omitempty"`
+	ChangesetSpecID int64  `json:"changesetSpecID,omitempty"`
 }
 
 type ChangesetJobClosePayload struct{}
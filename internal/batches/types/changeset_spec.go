@@ -36,6 +36,12 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		Title:      spec.Title,
 		Body:       spec.Body,
 		Published:  spec.Published,
+
+		Reviewers:     spec.Reviewers,
+		TeamReviewers: spec.TeamReviewers,
+		Labels:        spec.Labels,
+		Assignees:     spec.Assignees,
+		Milestone:     spec.Milestone,
 	}
 
 	if spec.IsImportingExisting() {
@@ -115,6 +121,14 @@ type ChangesetSpec struct {
 	CommitAuthorName  string
 	CommitAuthorEmail string
 
+	// Reviewers, TeamReviewers, Labels, Assignees and Milestone are applied
+	// to the changeset on the code host, unless they are empty.
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+	Milestone     string
+
 	ForkNamespace *string
 }
 
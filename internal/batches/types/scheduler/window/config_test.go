@@ -248,6 +248,61 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// 2021-04-05 is a Monday.
+	mondayAt := func(hour int) time.Time {
+		return time.Date(2021, 4, 5, hour, 0, 0, 0, time.UTC)
+	}
+	weekdays := newWeekdaySet(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		at   time.Time
+		want bool
+	}{
+		"no windows": {
+			cfg:  &Configuration{},
+			at:   mondayAt(3),
+			want: true,
+		},
+		"inside window": {
+			cfg: &Configuration{windows: []Window{
+				{days: weekdays, start: timeOfDayPtr(9, 0), end: timeOfDayPtr(17, 0), rate: makeUnlimitedRate()},
+			}},
+			at:   mondayAt(12),
+			want: true,
+		},
+		"outside window": {
+			cfg: &Configuration{windows: []Window{
+				{days: weekdays, start: timeOfDayPtr(9, 0), end: timeOfDayPtr(17, 0), rate: makeUnlimitedRate()},
+			}},
+			at:   mondayAt(17),
+			want: false,
+		},
+		"wrong day": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Saturday), rate: makeUnlimitedRate()},
+			}},
+			at:   mondayAt(12),
+			want: false,
+		},
+		"later zero window takes precedence": {
+			cfg: &Configuration{windows: []Window{
+				{days: weekdays, rate: makeUnlimitedRate()},
+				{days: weekdays, start: timeOfDayPtr(11, 0), end: timeOfDayPtr(13, 0), rate: rate{n: 0}},
+			}},
+			at:   mondayAt(12),
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(tc.at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
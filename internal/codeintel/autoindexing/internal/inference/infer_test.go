@@ -58,6 +58,15 @@ func TestInferRepositoryAndRevision(t *testing.T) {
 				repoName: "npm/myscope/mypackage",
 				revision: "v1.0.0",
 			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "scip-dotnet",
+					Name:    "Newtonsoft.Json",
+					Version: "13.0",
+				},
+				repoName: "nuget/newtonsoft.json",
+				revision: "13.0.0",
+			},
 		}
 
 		for _, testCase := range testCases {
@@ -9,4 +9,5 @@ const (
 	PythonPackagesScheme = shared.PythonPackagesScheme
 	RustPackagesScheme   = shared.RustPackagesScheme
 	RubyPackagesScheme   = shared.RubyPackagesScheme
+	NuGetPackagesScheme  = shared.NuGetPackagesScheme
 )
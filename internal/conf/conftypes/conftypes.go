@@ -19,6 +19,11 @@ type ServiceConnections struct {
 	// talked to.
 	GitServers []string `json:"gitServers"`
 
+	// GitServersRebalance is set while repositories are being moved between
+	// gitserver shards after the set of gitserver instances changed. It is
+	// nil when every repository is on the shard GitServers assigns it to.
+	GitServersRebalance *GitServersRebalance `json:"gitServersRebalance,omitempty"`
+
 	// PostgresDSN is the PostgreSQL DB data source name.
 	// eg: "postgres://sg@pgsql/sourcegraph?sslmode=false"
 	PostgresDSN string `json:"postgresDSN"`
@@ -50,6 +55,7 @@ type ServiceConnections struct {
 func (sc *ServiceConnections) ToProto() *proto.ServiceConnections {
 	return &proto.ServiceConnections{
 		GitServers:           sc.GitServers,
+		GitServersRebalance:  sc.GitServersRebalance.ToProto(),
 		PostgresDsn:          sc.PostgresDSN,
 		CodeIntelPostgresDsn: sc.CodeIntelPostgresDSN,
 		CodeInsightsDsn:      sc.CodeInsightsDSN,
@@ -64,6 +70,7 @@ func (sc *ServiceConnections) ToProto() *proto.ServiceConnections {
 func (sc *ServiceConnections) FromProto(in *proto.ServiceConnections) {
 	*sc = ServiceConnections{
 		GitServers:           in.GetGitServers(),
+		GitServersRebalance:  gitServersRebalanceFromProto(in.GetGitServersRebalance()),
 		PostgresDSN:          in.GetPostgresDsn(),
 		CodeIntelPostgresDSN: in.GetCodeIntelPostgresDsn(),
 		CodeInsightsDSN:      in.GetCodeInsightsDsn(),
@@ -75,6 +82,44 @@ func (sc *ServiceConnections) FromProto(in *proto.ServiceConnections) {
 	}
 }
 
+// GitServersRebalance describes an in-progress move of repositories from one
+// set of gitserver shards to another.
+//
+// Repositories are moved in the order of the hash used to shard them. The
+// ones whose hash is below Cursor have been moved and are served by
+// Addresses, all others are still served by PreviousAddresses.
+type GitServersRebalance struct {
+	// Addresses is the set of gitserver addresses repositories are moved to.
+	Addresses []string `json:"addresses"`
+	// PreviousAddresses is the set of gitserver addresses repositories are
+	// moved from.
+	PreviousAddresses []string `json:"previousAddresses"`
+	// Cursor is the hash of the first repository which hasn't been moved yet.
+	Cursor uint64 `json:"cursor"`
+}
+
+func (r *GitServersRebalance) ToProto() *proto.GitServersRebalance {
+	if r == nil {
+		return nil
+	}
+	return &proto.GitServersRebalance{
+		Addresses:         r.Addresses,
+		PreviousAddresses: r.PreviousAddresses,
+		Cursor:            r.Cursor,
+	}
+}
+
+func gitServersRebalanceFromProto(in *proto.GitServersRebalance) *GitServersRebalance {
+	if in == nil {
+		return nil
+	}
+	return &GitServersRebalance{
+		Addresses:         in.GetAddresses(),
+		PreviousAddresses: in.GetPreviousAddresses(),
+		Cursor:            in.GetCursor(),
+	}
+}
+
 // RawUnified is the unparsed variant of conf.Unified.
 type RawUnified struct {
 	ID                 int32
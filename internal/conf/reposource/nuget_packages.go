@@ -0,0 +1,130 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const nugetPackagesPrefix = "nuget/"
+
+type NuGetVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewNuGetVersionedPackage(name PackageName, version string) *NuGetVersionedPackage {
+	return &NuGetVersionedPackage{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParseNuGetVersionedPackage parses a string in a '<id>(@<version>)?' format into a
+// NuGetVersionedPackage.
+//
+// NuGet package IDs and versions are case-insensitive, so they are lower-cased to
+// make sure every package maps to a single repository. Versions are normalized
+// like NuGet does, so that 1.0 and 1.0.0 are the same version.
+func ParseNuGetVersionedPackage(dependency string) *NuGetVersionedPackage {
+	var dep NuGetVersionedPackage
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = PackageName(strings.ToLower(strings.TrimSpace(dependency)))
+	} else {
+		dep.Name = PackageName(strings.ToLower(strings.TrimSpace(dependency[:i])))
+		dep.Version = normalizeNuGetVersion(strings.ToLower(strings.TrimSpace(dependency[i+1:])))
+	}
+	return &dep
+}
+
+// normalizeNuGetVersion returns the normalized form of a NuGet version: leading
+// zeros are removed, the patch number is added if it's missing, a zero
+// revision number is dropped and build metadata is removed. Versions which
+// aren't valid NuGet versions are returned unchanged.
+//
+// See https://learn.microsoft.com/en-us/nuget/concepts/package-versioning#normalized-version-numbers.
+func normalizeNuGetVersion(version string) string {
+	release, _, _ := strings.Cut(version, "+")
+	numbers, label, hasLabel := strings.Cut(release, "-")
+
+	parts := strings.Split(numbers, ".")
+	if len(parts) > 4 {
+		return version
+	}
+	for i, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return version
+		}
+		if parts[i] = strings.TrimLeft(part, "0"); parts[i] == "" {
+			parts[i] = "0"
+		}
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	if len(parts) == 4 && parts[3] == "0" {
+		parts = parts[:3]
+	}
+
+	normalized := strings.Join(parts, ".")
+	if hasLabel {
+		normalized += "-" + label
+	}
+	return normalized
+}
+
+func ParseNuGetPackageFromName(name PackageName) *NuGetVersionedPackage {
+	return ParseNuGetVersionedPackage(string(name))
+}
+
+// ParseNuGetPackageFromRepoName is a convenience function to parse a repo name in a
+// 'nuget/<id>(@<version>)?' format into a NuGetVersionedPackage.
+func ParseNuGetPackageFromRepoName(name api.RepoName) (*NuGetVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), nugetPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid NuGet dependency repo name, missing %s prefix '%s'", nugetPackagesPrefix, name)
+	}
+	return ParseNuGetVersionedPackage(dependency), nil
+}
+
+func (p *NuGetVersionedPackage) Scheme() string {
+	return "scip-dotnet"
+}
+
+func (p *NuGetVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *NuGetVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *NuGetVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *NuGetVersionedPackage) Description() string { return "" }
+
+func (p *NuGetVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(nugetPackagesPrefix + p.Name)
+}
+
+// GitTagFromVersion returns the normalized version. Unlike the versions of
+// other ecosystems, NuGet versions are used as tags as they are.
+func (p *NuGetVersionedPackage) GitTagFromVersion() string {
+	return p.Version
+}
+
+func (p *NuGetVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*NuGetVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
@@ -0,0 +1,74 @@
+package reposource
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseNuGetVersionedPackage(t *testing.T) {
+	table := []struct {
+		dependency string
+		name       PackageName
+		version    string
+		repoName   api.RepoName
+	}{
+		{"Newtonsoft.Json@13.0.3", "newtonsoft.json", "13.0.3", "nuget/newtonsoft.json"},
+		{"Serilog@3.1.0-DEV-02077", "serilog", "3.1.0-dev-02077", "nuget/serilog"},
+		{"Serilog@3.1", "serilog", "3.1.0", "nuget/serilog"},
+		{" xunit ", "xunit", "", "nuget/xunit"},
+	}
+	for _, entry := range table {
+		dep := ParseNuGetVersionedPackage(entry.dependency)
+		assert.Equal(t, entry.name, dep.Name, entry.dependency)
+		assert.Equal(t, entry.version, dep.Version, entry.dependency)
+		assert.Equal(t, entry.repoName, dep.RepoName(), entry.dependency)
+	}
+}
+
+func TestParseNuGetPackageFromRepoName(t *testing.T) {
+	dep, err := ParseNuGetPackageFromRepoName("nuget/Newtonsoft.Json")
+	require.NoError(t, err)
+	assert.Equal(t, PackageName("newtonsoft.json"), dep.Name)
+
+	_, err = ParseNuGetPackageFromRepoName("npm/newtonsoft.json")
+	assert.Error(t, err)
+}
+
+func TestNuGetVersionedPackage_Less(t *testing.T) {
+	deps := []VersionedPackage{
+		ParseNuGetVersionedPackage("xunit@2.4.2"),
+		ParseNuGetVersionedPackage("newtonsoft.json@9.0.1"),
+		ParseNuGetVersionedPackage("newtonsoft.json@13.0.3"),
+		ParseNuGetVersionedPackage("xunit@2.6.1"),
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Less(deps[j]) })
+
+	var have []string
+	for _, dep := range deps {
+		have = append(have, dep.VersionedPackageSyntax())
+	}
+	assert.Equal(t, []string{"xunit@2.6.1", "xunit@2.4.2", "newtonsoft.json@13.0.3", "newtonsoft.json@9.0.1"}, have)
+}
+
+func TestNormalizeNuGetVersion(t *testing.T) {
+	for version, want := range map[string]string{
+		"1":                "1.0.0",
+		"1.0":              "1.0.0",
+		"1.0.0":            "1.0.0",
+		"1.0.0.0":          "1.0.0",
+		"1.0.0.4":          "1.0.0.4",
+		"01.02.003":        "1.2.3",
+		"1.0-beta.1":       "1.0.0-beta.1",
+		"1.0.0+sha.abc123": "1.0.0",
+		"1.0.0-rc.1+build": "1.0.0-rc.1",
+		"1.0.0.0.0":        "1.0.0.0.0",
+		"latest":           "latest",
+	} {
+		assert.Equal(t, want, normalizeNuGetVersion(version), version)
+	}
+}
@@ -214,6 +214,7 @@ var ExternalServiceKinds = map[string]ExternalServiceKind{
 	extsvc.KindGoPackages:      {CodeHost: true, JSONSchema: schema.GoModulesSchemaJSON},
 	extsvc.KindJVMPackages:     {CodeHost: true, JSONSchema: schema.JVMPackagesSchemaJSON},
 	extsvc.KindNpmPackages:     {CodeHost: true, JSONSchema: schema.NpmPackagesSchemaJSON},
+	extsvc.KindNuGetPackages:   {CodeHost: true, JSONSchema: schema.NuGetPackagesSchemaJSON},
 	extsvc.KindOther:           {CodeHost: true, JSONSchema: schema.OtherExternalServiceSchemaJSON},
 	extsvc.KindPagure:          {CodeHost: true, JSONSchema: schema.PagureSchemaJSON},
 	extsvc.KindPerforce:        {CodeHost: true, JSONSchema: schema.PerforceSchemaJSON},
@@ -221,6 +222,7 @@ var ExternalServiceKinds = map[string]ExternalServiceKind{
 	extsvc.KindPythonPackages:  {CodeHost: true, JSONSchema: schema.PythonPackagesSchemaJSON},
 	extsvc.KindRustPackages:    {CodeHost: true, JSONSchema: schema.RustPackagesSchemaJSON},
 	extsvc.KindRubyPackages:    {CodeHost: true, JSONSchema: schema.RubyPackagesSchemaJSON},
+	extsvc.KindSubversion:      {CodeHost: true, JSONSchema: schema.SubversionSchemaJSON},
 }
 
 // ExternalServiceKind describes a kind of external service.
@@ -421,6 +423,12 @@ func MakeValidateExternalServiceConfigFunc(
 				return nil, err
 			}
 			err = validateOtherExternalServiceConnection(&c)
+		case extsvc.KindSubversion:
+			var c schema.SubversionConnection
+			if err = jsoniter.Unmarshal(normalized, &c); err != nil {
+				return nil, err
+			}
+			err = validateSubversionConnection(&c)
 		}
 
 		return normalized, errors.Append(errs, err)
@@ -460,6 +468,31 @@ func validateOtherExternalServiceConnection(c *schema.OtherExternalServiceConnec
 	return nil
 }
 
+// validateSubversionConnection makes sure that the repositories of c can't
+// escape the Subversion server it points to.
+func validateSubversionConnection(c *schema.SubversionConnection) error {
+	// We ignore the error because this already validated by JSON Schema.
+	baseURL, _ := url.Parse(c.Url)
+	if baseURL.Scheme == "file" && dotcom.SourcegraphDotComMode() {
+		return errors.New(`"url" can't be a file:// URL on Sourcegraph.com`)
+	}
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		baseURL.Path += "/"
+	}
+
+	for i, repo := range c.Repos {
+		repoURL, err := baseURL.Parse(repo)
+		if err != nil {
+			return errors.Errorf("repos.%d: %s", i, err)
+		}
+		if repoURL.Scheme != baseURL.Scheme || repoURL.Host != baseURL.Host || !strings.HasPrefix(repoURL.Path, baseURL.Path) {
+			return errors.Errorf("repos.%d: %q is not relative to %q", i, repo, c.Url)
+		}
+	}
+
+	return nil
+}
+
 func validateGitHubConnection(db DB, githubValidators []GitHubValidatorFunc, id int64, c *schema.GitHubConnection) error {
 	var err error
 	for _, validate := range githubValidators {
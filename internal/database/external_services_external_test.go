@@ -103,6 +103,18 @@ func TestExternalServicesStore_ValidateConfig(t *testing.T) {
 			config:  `{"url": "https://dev.azure.com", "token": "token", "username": "username", "orgs":[]}`,
 			wantErr: "<nil>",
 		},
+		{
+			name:    "0 errors - Subversion",
+			kind:    extsvc.KindSubversion,
+			config:  `{"url": "https://svn.example.com/svn", "repos": ["project", "legacy/project"], "layout": {"trunk": "trunk"}}`,
+			wantErr: "<nil>",
+		},
+		{
+			name:    "1 error - Subversion repo outside of url",
+			kind:    extsvc.KindSubversion,
+			config:  `{"url": "https://svn.example.com/svn", "repos": ["../other"]}`,
+			wantErr: `repos.0: "../other" is not relative to "https://svn.example.com/svn"`,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
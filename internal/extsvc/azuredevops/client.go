@@ -40,6 +40,11 @@ type Client interface {
 	GetPullRequest(ctx context.Context, args PullRequestCommonArgs) (PullRequest, error)
 	GetPullRequestStatuses(ctx context.Context, args PullRequestCommonArgs) ([]PullRequestBuildStatus, error)
 	UpdatePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestUpdateInput) (PullRequest, error)
+	AddPullRequestReviewers(ctx context.Context, args PullRequestCommonArgs, reviewers []Reviewer) ([]Reviewer, error)
+	RemovePullRequestReviewer(ctx context.Context, args PullRequestCommonArgs, reviewerID string) error
+	ListPullRequestLabels(ctx context.Context, args PullRequestCommonArgs) ([]Label, error)
+	AddPullRequestLabel(ctx context.Context, args PullRequestCommonArgs, name string) (Label, error)
+	RemovePullRequestLabel(ctx context.Context, args PullRequestCommonArgs, name string) error
 	CreatePullRequestCommentThread(ctx context.Context, args PullRequestCommonArgs, input PullRequestCommentInput) (PullRequestCommentResponse, error)
 	CompletePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestCompleteInput) (PullRequest, error)
 	GetRepo(ctx context.Context, args OrgProjectRepoArgs) (Repository, error)
@@ -48,6 +53,7 @@ type Client interface {
 	GetRepositoryBranch(ctx context.Context, args OrgProjectRepoArgs, branchName string) (Ref, error)
 	GetProject(ctx context.Context, org, project string) (Project, error)
 	GetAuthorizedProfile(ctx context.Context) (Profile, error)
+	GetIdentity(ctx context.Context, org, name string) (Identity, error)
 	ListAuthorizedUserOrganizations(ctx context.Context, profile Profile) ([]Org, error)
 	SetWaitForRateLimit(wait bool)
 }
@@ -168,6 +174,12 @@ func (c *client) do(ctx context.Context, req *http.Request, urlOverride string,
 		}
 	}
 
+	// Some endpoints, such as those deleting resources, respond without a
+	// body.
+	if len(bs) == 0 {
+		return resp.Header.Get(continuationTokenHeader), nil
+	}
+
 	return resp.Header.Get(continuationTokenHeader), json.Unmarshal(bs, result)
 }
 
@@ -167,3 +167,94 @@ func (c *client) CompletePullRequest(ctx context.Context, args PullRequestCommon
 
 	return pr, nil
 }
+
+// AddPullRequestReviewers requests reviews of the specified PR from the given
+// reviewers, which are identified by their ID. It returns the added reviewers.
+func (c *client) AddPullRequestReviewers(ctx context.Context, args PullRequestCommonArgs, reviewers []Reviewer) ([]Reviewer, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/reviewers", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	data, err := json.Marshal(reviewers)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request")
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListReviewersResponse
+	if _, err = c.do(ctx, req, "", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+// RemovePullRequestReviewer removes the reviewer with the given ID from the
+// specified PR.
+func (c *client) RemovePullRequestReviewer(ctx context.Context, args PullRequestCommonArgs, reviewerID string) error {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/reviewers/%s", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID, reviewerID)}
+
+	req, err := http.NewRequest("DELETE", reqURL.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, "", nil)
+	return err
+}
+
+// ListPullRequestLabels returns the labels of the specified PR.
+func (c *client) ListPullRequestLabels(ctx context.Context, args PullRequestCommonArgs) ([]Label, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/labels", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListLabelsResponse
+	if _, err = c.do(ctx, req, "", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+// AddPullRequestLabel adds the label with the given name to the specified PR.
+// The label is created if it doesn't exist yet.
+func (c *client) AddPullRequestLabel(ctx context.Context, args PullRequestCommonArgs, name string) (Label, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/labels", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	data, err := json.Marshal(Label{Name: name})
+	if err != nil {
+		return Label{}, errors.Wrap(err, "marshalling request")
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return Label{}, err
+	}
+
+	var label Label
+	if _, err = c.do(ctx, req, "", &label); err != nil {
+		return Label{}, err
+	}
+
+	return label, nil
+}
+
+// RemovePullRequestLabel removes the label with the given name from the
+// specified PR.
+func (c *client) RemovePullRequestLabel(ctx context.Context, args PullRequestCommonArgs, name string) error {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/labels/%s", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID, name)}
+
+	req, err := http.NewRequest("DELETE", reqURL.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, "", nil)
+	return err
+}
@@ -88,6 +88,7 @@ type CreatePullRequestInput struct {
 	Title             string                        `json:"title"`
 	Description       string                        `json:"description"`
 	Reviewers         []Reviewer                    `json:"reviewers"`
+	Labels            []Label                       `json:"labels,omitempty"`
 	ForkSource        *ForkRef                      `json:"forkSource"`
 	IsDraft           bool                          `json:"isDraft"`
 	CompletionOptions *PullRequestCompletionOptions `json:"completionOptions"`
@@ -114,6 +115,35 @@ type Reviewer struct {
 	UniqueName  string `json:"uniqueName"`
 }
 
+type ListReviewersResponse struct {
+	Value []Reviewer `json:"value"`
+	Count int        `json:"count"`
+}
+
+// Label is a label (also called tag) of a pull request.
+type Label struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Active bool   `json:"active,omitempty"`
+}
+
+type ListLabelsResponse struct {
+	Value []Label `json:"value"`
+	Count int     `json:"count"`
+}
+
+// Identity is a user or group.
+type Identity struct {
+	ID                  string `json:"id"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	IsContainer         bool   `json:"isContainer"`
+}
+
+type ListIdentitiesResponse struct {
+	Value []Identity `json:"value"`
+	Count int        `json:"count"`
+}
+
 type PullRequestCommonArgs struct {
 	PullRequestID string
 	Org           string
@@ -3,6 +3,7 @@ package azuredevops
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -42,6 +43,46 @@ func (c *client) GetAuthorizedProfile(ctx context.Context) (Profile, error) {
 	return p, nil
 }
 
+// VisualStudioIdentitiesURL is the base URL of the identities API of Azure
+// DevOps Services.
+const VisualStudioIdentitiesURL = "https://vssps.dev.azure.com/"
+
+// GetIdentity returns the user or group with the given name in the
+// organization (or, on Azure DevOps Server, collection). Users can be
+// identified by their email address or account name, groups by their name.
+// See https://learn.microsoft.com/en-us/rest/api/azure/devops/ims/identities/read-identities?view=azure-devops-rest-7.0
+func (c *client) GetIdentity(ctx context.Context, org, name string) (Identity, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/_apis/identities", org)}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	queryParams := req.URL.Query()
+	queryParams.Set("searchFilter", "General")
+	queryParams.Set("filterValue", name)
+	queryParams.Set("queryMembership", "None")
+	req.URL.RawQuery = queryParams.Encode()
+
+	// Azure DevOps Services serves identities from a different host, Azure
+	// DevOps Server from the collection.
+	apiURL := ""
+	if c.IsAzureDevOpsServices() {
+		apiURL = VisualStudioIdentitiesURL
+	}
+
+	var resp ListIdentitiesResponse
+	if _, err := c.do(ctx, req, apiURL, &resp); err != nil {
+		return Identity{}, err
+	}
+	if len(resp.Value) == 0 {
+		return Identity{}, errors.Errorf("no identity found for %q", name)
+	}
+
+	return resp.Value[0], nil
+}
+
 func (c *client) ListAuthorizedUserOrganizations(ctx context.Context, profile Profile) ([]Org, error) {
 	if MockVisualStudioAppURL == "" && !c.IsAzureDevOpsServices() {
 		return nil, errors.New("ListAuthorizedUserOrganizations can only be used with Azure DevOps Services")
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -17,6 +19,9 @@ type PullRequestInput struct {
 	Description  string
 	SourceBranch string
 	Reviewers    []Account
+	// ReviewerIDs are added to Reviewers. They are either account IDs or
+	// UUIDs, which are enclosed in braces.
+	ReviewerIDs []string
 
 	// The following fields are optional.
 	//
@@ -199,12 +204,18 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 		Repository *repository `json:"repository,omitempty"`
 	}
 
+	type reviewer struct {
+		UUID      string `json:"uuid,omitempty"`
+		AccountID string `json:"account_id,omitempty"`
+	}
+
 	type request struct {
-		Title             string  `json:"title"`
-		Description       string  `json:"description,omitempty"`
-		Source            source  `json:"source"`
-		Destination       *source `json:"destination,omitempty"`
-		CloseSourceBranch bool    `json:"close_source_branch,omitempty"`
+		Title             string     `json:"title"`
+		Description       string     `json:"description,omitempty"`
+		Source            source     `json:"source"`
+		Destination       *source    `json:"destination,omitempty"`
+		CloseSourceBranch bool       `json:"close_source_branch,omitempty"`
+		Reviewers         []reviewer `json:"reviewers,omitempty"`
 	}
 
 	req := request{
@@ -225,6 +236,21 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 			Branch: branch{Name: *input.DestinationBranch},
 		}
 	}
+	for _, r := range input.Reviewers {
+		req.Reviewers = append(req.Reviewers, reviewer{UUID: r.UUID})
+	}
+	for _, id := range input.ReviewerIDs {
+		if slices.ContainsFunc(input.Reviewers, func(a Account) bool { return a.UUID == id || (a.AccountID != "" && a.AccountID == id) }) {
+			continue
+		}
+		r := reviewer{AccountID: id}
+		if strings.HasPrefix(id, "{") {
+			r = reviewer{UUID: id}
+		}
+		if !slices.Contains(req.Reviewers, r) {
+			req.Reviewers = append(req.Reviewers, r)
+		}
+	}
 
 	return json.Marshal(&req)
 }
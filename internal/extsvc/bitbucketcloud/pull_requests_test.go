@@ -2,6 +2,7 @@ package bitbucketcloud
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -389,3 +390,20 @@ func TestClient_UpdatePullRequest(t *testing.T) {
 		assertGolden(t, updated)
 	})
 }
+
+func TestPullRequestInput_MarshalJSON(t *testing.T) {
+	input := PullRequestInput{
+		Title:        "title",
+		SourceBranch: "branch",
+		Reviewers:    []Account{{UUID: "{a}"}},
+		ReviewerIDs:  []string{"{a}", "{b}", "623316f53fbb880068413f6b"},
+	}
+
+	data, err := json.Marshal(&input)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"title": "title",
+		"source": {"branch": {"name": "branch"}},
+		"reviewers": [{"uuid": "{a}"}, {"uuid": "{b}"}, {"account_id": "623316f53fbb880068413f6b"}]
+	}`, string(data))
+}
@@ -70,6 +70,7 @@ func TestClient_Repos(t *testing.T) {
 				Nickname:    "Sourcegraph Testing",
 				DisplayName: "Sourcegraph Testing",
 				UUID:        "{4b85b785-1433-4092-8512-20302f4a03be}",
+				AccountID:   "623316f53fbb880068413f6b",
 			},
 		},
 		"sourcegraph": {
@@ -96,6 +97,7 @@ func TestClient_Repos(t *testing.T) {
 				Nickname:    "Sourcegraph Testing",
 				DisplayName: "Sourcegraph Testing",
 				UUID:        "{4b85b785-1433-4092-8512-20302f4a03be}",
+				AccountID:   "623316f53fbb880068413f6b",
 			},
 		},
 	}
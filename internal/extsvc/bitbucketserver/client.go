@@ -12,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -505,6 +506,8 @@ func (e ErrAlreadyExists) Error() string {
 }
 
 // CreatePullRequest creates the given PullRequest returning an error in case of failure.
+// The users in pr.Reviewers are requested as reviewers in addition to the
+// default reviewers of the repository.
 func (c *Client) CreatePullRequest(ctx context.Context, pr *PullRequest) error {
 	for _, namedRef := range [...]struct {
 		name string
@@ -551,8 +554,15 @@ func (c *Client) CreatePullRequest(ctx context.Context, pr *PullRequest) error {
 		// return errors.Wrap(err, "fetching default reviewers")
 	}
 
-	reviewers := make([]reviewer, 0, len(defaultReviewers))
-	for _, r := range defaultReviewers {
+	names := defaultReviewers
+	for _, r := range pr.Reviewers {
+		if r.User != nil && !slices.Contains(names, r.User.Name) {
+			names = append(names, r.User.Name)
+		}
+	}
+
+	reviewers := make([]reviewer, 0, len(names))
+	for _, r := range names {
 		reviewers = append(reviewers, reviewer{User: struct {
 			Name string `json:"name"`
 		}{Name: r}})
@@ -304,3 +304,92 @@ func (c *client) SetCommitMessage(ctx context.Context, changeID string, input Se
 	}
 	return nil
 }
+
+// AddReviewer adds a user or group as a reviewer of a Gerrit change.
+func (c *client) AddReviewer(ctx context.Context, changeID string, input AddReviewerPayload) error {
+	pathStr, err := url.JoinPath("a/changes", url.PathEscape(changeID), "reviewers")
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	reqURL := url.URL{Path: pathStr}
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result AddReviewerResult
+	resp, err := c.do(ctx, req, &result)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	// Reviewers that can't be added are reported in the body of a successful
+	// response.
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+	return nil
+}
+
+// RemoveReviewer removes a user from the reviewers of a Gerrit change. It is
+// not an error if the user isn't a reviewer of the change.
+func (c *client) RemoveReviewer(ctx context.Context, changeID, accountID string) error {
+	pathStr, err := url.JoinPath("a/changes", url.PathEscape(changeID), "reviewers", url.PathEscape(accountID))
+	if err != nil {
+		return err
+	}
+
+	reqURL := url.URL{Path: pathStr}
+	req, err := http.NewRequest("DELETE", reqURL.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.do(ctx, req, nil); err != nil {
+		if httpErr := (&httpError{}); errors.As(err, &httpErr) && httpErr.NotFound() {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// SetHashtags adds and removes hashtags of a Gerrit change, returning the
+// resulting hashtags.
+func (c *client) SetHashtags(ctx context.Context, changeID string, input SetHashtagsPayload) ([]string, error) {
+	pathStr, err := url.JoinPath("a/changes", url.PathEscape(changeID), "hashtags")
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := url.URL{Path: pathStr}
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var hashtags []string
+	resp, err := c.do(ctx, req, &hashtags)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return hashtags, nil
+}
@@ -41,6 +41,7 @@ type Client interface {
 	Authenticator() auth.Authenticator
 	GetAuthenticatedUserAccount(ctx context.Context) (*Account, error)
 	GetGroup(ctx context.Context, groupName string) (Group, error)
+	ListGroupMembers(ctx context.Context, groupName string) ([]Account, error)
 	ListProjects(ctx context.Context, opts ListProjectsArgs) (projects ListProjectsResponse, nextPage bool, err error)
 	GetChange(ctx context.Context, changeID string) (*Change, error)
 	AbandonChange(ctx context.Context, changeID string) (*Change, error)
@@ -53,6 +54,9 @@ type Client interface {
 	SetReadyForReview(ctx context.Context, changeID string) error
 	MoveChange(ctx context.Context, changeID string, input MoveChangePayload) (*Change, error)
 	SetCommitMessage(ctx context.Context, changeID string, input SetCommitMessagePayload) error
+	AddReviewer(ctx context.Context, changeID string, input AddReviewerPayload) error
+	RemoveReviewer(ctx context.Context, changeID, accountID string) error
+	SetHashtags(ctx context.Context, changeID string, input SetHashtagsPayload) ([]string, error)
 	GetSSHInfo(ctx context.Context) (hostname string, port int, _ error)
 }
 
@@ -166,6 +170,22 @@ func (c *client) GetGroup(ctx context.Context, groupName string) (Group, error)
 	return respGetGroup, nil
 }
 
+// ListGroupMembers returns the accounts that are direct members of the group.
+func (c *client) ListGroupMembers(ctx context.Context, groupName string) ([]Account, error) {
+	urlMembers := url.URL{Path: fmt.Sprintf("a/groups/%s/members", url.PathEscape(groupName))}
+
+	req, err := http.NewRequest("GET", urlMembers.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []Account
+	if _, err = c.do(ctx, req, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
 func (c *client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) { //nolint:unparam // http.Response is never used, but it makes sense API wise.
 	resp, err := c.doHTTP(ctx, req)
 	if err != nil {
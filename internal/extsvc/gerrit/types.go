@@ -169,6 +169,22 @@ type SetCommitMessagePayload struct {
 	Message string `json:"message"`
 }
 
+type AddReviewerPayload struct {
+	// Reviewer is the account or group to add, identified by its ID, name,
+	// email or username.
+	Reviewer string `json:"reviewer"`
+}
+
+type AddReviewerResult struct {
+	Input string `json:"input"`
+	Error string `json:"error,omitempty"`
+}
+
+type SetHashtagsPayload struct {
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
 type Pagination struct {
 	PerPage int
 	// Either Skip or Page should be set. If Skip is non-zero, it takes precedence.
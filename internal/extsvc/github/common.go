@@ -242,6 +242,9 @@ type PullRequest struct {
 	BaseRefName    string
 	Number         int64
 	ReviewDecision string
+	// Mergeable is MERGEABLE, CONFLICTING or UNKNOWN, the latter while
+	// GitHub is still computing whether the pull request has conflicts.
+	Mergeable      string
 	Author         Actor
 	BaseRepository PullRequestRepo
 	HeadRepository PullRequestRepo
@@ -1465,6 +1468,7 @@ fragment pr on PullRequest {
   headRefName
   baseRefName
   reviewDecision
+  mergeable
   %s
   author {
     ...actor
@@ -1809,6 +1813,10 @@ type Repository struct {
 	// empirically it's in kibibytes (meaning: multiples of 1024 bytes, not
 	// 1000).
 	DiskUsageKibibytes int `json:"DiskUsage,omitempty"`
+
+	// Parent is the repository this repository was forked from. It is only
+	// populated for forks, and only by the API calls that request it.
+	Parent *ParentRepository `json:",omitempty"`
 }
 
 // PublicRepository is a reduced set of fields from a GitHub repository
@@ -1872,6 +1880,13 @@ type restRepository struct {
 	// docs, in kilobytes, but empirically it's in kibibytes (meaning:
 	// multiples of 1024 bytes, not 1000).
 	DiskUsageKibibytes int `json:"size"`
+	// Parent is only returned when fetching a single repository.
+	Parent *restParentRepository `json:"parent"`
+}
+
+type restParentRepository struct {
+	FullName string `json:"full_name"`
+	Fork     bool   `json:"fork"`
 }
 
 // restPublicRepository is a reduced set of fields from a GitHub repository
@@ -1929,6 +1944,12 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		Visibility:         Visibility(restRepo.Visibility),
 		DiskUsageKibibytes: restRepo.DiskUsageKibibytes,
 	}
+	if restRepo.Parent != nil {
+		repo.Parent = &ParentRepository{
+			NameWithOwner: restRepo.Parent.FullName,
+			IsFork:        restRepo.Parent.Fork,
+		}
+	}
 
 	return &repo
 }
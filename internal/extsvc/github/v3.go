@@ -204,6 +204,22 @@ func (c *V3Client) delete(ctx context.Context, requestURI string) (*httpResponse
 	return c.request(ctx, req, struct{}{})
 }
 
+func (c *V3Client) deleteWithPayload(ctx context.Context, requestURI string, payload any) (*httpResponseState, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling payload")
+	}
+
+	req, err := http.NewRequest("DELETE", requestURI, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	return c.request(ctx, req, &struct{}{})
+}
+
 func (c *V3Client) request(ctx context.Context, req *http.Request, result any) (*httpResponseState, error) {
 	// Include node_id (GraphQL ID) in response. See
 	// https://developer.github.com/changes/2017-12-19-graphql-node-id/.
@@ -901,6 +917,121 @@ func (c *V3Client) UpdateRef(ctx context.Context, owner, repo, ref, commit strin
 	return &updatedRef, nil
 }
 
+// SetIssueMilestone sets the milestone of an issue to the milestone with the
+// given number, or removes it if number is nil. Pull requests are issues too,
+// so this can be used with pull request numbers.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#update-an-issue
+func (c *V3Client) SetIssueMilestone(ctx context.Context, owner, repo string, number int64, milestone *int64) error {
+	payload := struct {
+		Milestone *int64 `json:"milestone"`
+	}{Milestone: milestone}
+	_, err := c.patch(ctx, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// AddIssueLabels adds the given labels to an issue or pull request, creating
+// labels that don't exist yet. Its other labels are left untouched.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#add-labels-to-an-issue
+func (c *V3Client) AddIssueLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	payload := struct {
+		Labels []string `json:"labels"`
+	}{Labels: labels}
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, number), payload, &[]any{})
+	return err
+}
+
+// RemoveIssueLabel removes the given label from an issue or pull request. It
+// is not an error if the issue doesn't have the label.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#remove-a-label-from-an-issue
+func (c *V3Client) RemoveIssueLabel(ctx context.Context, owner, repo string, number int64, label string) error {
+	_, err := c.delete(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", owner, repo, number, url.PathEscape(label)))
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// AddIssueAssignees adds the given users to the assignees of an issue or pull
+// request. Its other assignees are left untouched.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#add-assignees-to-an-issue
+func (c *V3Client) AddIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	payload := struct {
+		Assignees []string `json:"assignees"`
+	}{Assignees: assignees}
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// RemoveIssueAssignees removes the given users from the assignees of an issue
+// or pull request.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#remove-assignees-from-an-issue
+func (c *V3Client) RemoveIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	payload := struct {
+		Assignees []string `json:"assignees"`
+	}{Assignees: assignees}
+	_, err := c.deleteWithPayload(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload)
+	return err
+}
+
+// Milestone is a GitHub milestone.
+type Milestone struct {
+	Number int64  `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// ListMilestones lists the open and closed milestones of a repository.
+//
+// The page is the page of results to return, and is 1-indexed (so the first call should
+// be for page 1).
+//
+// API docs: https://docs.github.com/en/rest/issues/milestones#list-milestones
+func (c *V3Client) ListMilestones(ctx context.Context, owner, repo string, page int) (milestones []*Milestone, hasNextPage bool, _ error) {
+	path := fmt.Sprintf("repos/%s/%s/milestones?state=all&page=%d&per_page=100", owner, repo, page)
+	respState, err := c.get(ctx, path, &milestones)
+	if err != nil {
+		return nil, false, err
+	}
+	return milestones, respState.hasNextPage(), nil
+}
+
+// RequestReviewers requests a review of a pull request from the given users
+// and teams. Teams are identified by their slug. Reviews that have already
+// been requested are left untouched.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#request-reviewers-for-a-pull-request
+func (c *V3Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	payload := struct {
+		Reviewers     []string `json:"reviewers,omitempty"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{Reviewers: reviewers, TeamReviewers: teamReviewers}
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// RemoveRequestedReviewers withdraws the review requests of a pull request
+// from the given users and teams. Teams are identified by their slug. Reviews
+// that have already been submitted are left untouched.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#remove-requested-reviewers-from-a-pull-request
+func (c *V3Client) RemoveRequestedReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	payload := struct {
+		Reviewers     []string `json:"reviewers"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{Reviewers: reviewers, TeamReviewers: teamReviewers}
+	if payload.Reviewers == nil {
+		// The reviewers are required, even if only teams are removed.
+		payload.Reviewers = []string{}
+	}
+	_, err := c.deleteWithPayload(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload)
+	return err
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
@@ -1423,3 +1423,63 @@ func TestListPublicRepositories(t *testing.T) {
 		assert.Equal(t, "2", repositories[1].ID)
 	})
 }
+
+func TestV3Client_PullRequestMetadata(t *testing.T) {
+	rcache.SetupForTest(t)
+	ratelimit.SetupForTest(t)
+
+	ctx := context.Background()
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`[{"number": 3, "title": "v1.0", "state": "open"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/repos/o/r/issues/12/labels":
+			w.Write([]byte(`[{"name": "automated"}]`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	srvURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	apiURL, _ := APIRoot(srvURL)
+	client := NewV3Client(logtest.Scoped(t), "test", apiURL, nil, srv.Client())
+
+	milestone := int64(3)
+	require.NoError(t, client.SetIssueMilestone(ctx, "o", "r", 12, &milestone))
+	require.NoError(t, client.SetIssueMilestone(ctx, "o", "r", 12, nil))
+	require.NoError(t, client.AddIssueLabels(ctx, "o", "r", 12, []string{"automated"}))
+	require.NoError(t, client.RemoveIssueLabel(ctx, "o", "r", 12, "needs review"))
+	require.NoError(t, client.AddIssueAssignees(ctx, "o", "r", 12, []string{"alice"}))
+	require.NoError(t, client.RemoveIssueAssignees(ctx, "o", "r", 12, []string{"bob"}))
+
+	milestones, hasNextPage, err := client.ListMilestones(ctx, "o", "r", 1)
+	require.NoError(t, err)
+	assert.False(t, hasNextPage)
+	assert.Equal(t, []*Milestone{{Number: 3, Title: "v1.0", State: "open"}}, milestones)
+
+	require.NoError(t, client.RequestReviewers(ctx, "o", "r", 12, []string{"alice"}, nil))
+	require.NoError(t, client.RemoveRequestedReviewers(ctx, "o", "r", 12, nil, []string{"frontend"}))
+
+	assert.Equal(t, []string{
+		`PATCH /api/v3/repos/o/r/issues/12 {"milestone":3}`,
+		`PATCH /api/v3/repos/o/r/issues/12 {"milestone":null}`,
+		`POST /api/v3/repos/o/r/issues/12/labels {"labels":["automated"]}`,
+		`DELETE /api/v3/repos/o/r/issues/12/labels/needs%20review `,
+		`POST /api/v3/repos/o/r/issues/12/assignees {"assignees":["alice"]}`,
+		`DELETE /api/v3/repos/o/r/issues/12/assignees {"assignees":["bob"]}`,
+		`GET /api/v3/repos/o/r/milestones?state=all&page=1&per_page=100 `,
+		`POST /api/v3/repos/o/r/pulls/12/requested_reviewers {"reviewers":["alice"]}`,
+		`DELETE /api/v3/repos/o/r/pulls/12/requested_reviewers {"reviewers":[],"team_reviewers":["frontend"]}`,
+	}, requests)
+}
@@ -703,6 +703,64 @@ func (c *V4Client) UpdateRef(ctx context.Context, owner, repo, ref, commit strin
 	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).UpdateRef(ctx, owner, repo, ref, commit)
 }
 
+// SetIssueMilestone sets or removes the milestone of an issue or pull request.
+func (c *V4Client) SetIssueMilestone(ctx context.Context, owner, repo string, number int64, milestone *int64) error {
+	logger := c.log.Scoped("SetIssueMilestone")
+	// Milestones are referenced by their ID in the GraphQL API, whereas the
+	// REST API accepts their numbers.
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).SetIssueMilestone(ctx, owner, repo, number, milestone)
+}
+
+// AddIssueLabels adds labels to an issue or pull request.
+func (c *V4Client) AddIssueLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	logger := c.log.Scoped("AddIssueLabels")
+	// Labels are referenced by their ID in the GraphQL API, whereas the REST
+	// API accepts their names.
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).AddIssueLabels(ctx, owner, repo, number, labels)
+}
+
+// RemoveIssueLabel removes a label from an issue or pull request.
+func (c *V4Client) RemoveIssueLabel(ctx context.Context, owner, repo string, number int64, label string) error {
+	logger := c.log.Scoped("RemoveIssueLabel")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RemoveIssueLabel(ctx, owner, repo, number, label)
+}
+
+// AddIssueAssignees adds assignees to an issue or pull request.
+func (c *V4Client) AddIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	logger := c.log.Scoped("AddIssueAssignees")
+	// The GraphQL API requires the IDs of users, which would take another
+	// request to look up.
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).AddIssueAssignees(ctx, owner, repo, number, assignees)
+}
+
+// RemoveIssueAssignees removes assignees from an issue or pull request.
+func (c *V4Client) RemoveIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	logger := c.log.Scoped("RemoveIssueAssignees")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RemoveIssueAssignees(ctx, owner, repo, number, assignees)
+}
+
+// ListMilestones lists the open and closed milestones of a repository.
+func (c *V4Client) ListMilestones(ctx context.Context, owner, repo string, page int) ([]*Milestone, bool, error) {
+	logger := c.log.Scoped("ListMilestones")
+	// We technically don't need to use the REST API for this but it's just a bit easier.
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).ListMilestones(ctx, owner, repo, page)
+}
+
+// RemoveRequestedReviewers withdraws review requests of a pull request.
+func (c *V4Client) RemoveRequestedReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	logger := c.log.Scoped("RemoveRequestedReviewers")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RemoveRequestedReviewers(ctx, owner, repo, number, reviewers, teamReviewers)
+}
+
+// RequestReviewers requests a review of a pull request from the given users
+// and teams.
+func (c *V4Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	logger := c.log.Scoped("RequestReviewers")
+	// The GraphQL API requires the IDs of users and teams, which would take
+	// another request to look up.
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RequestReviewers(ctx, owner, repo, number, reviewers, teamReviewers)
+}
+
 type RecentCommittersParams struct {
 	// Repository name
 	Name string
@@ -48,10 +48,14 @@ type MergeRequest struct {
 	WorkInProgress          bool              `json:"work_in_progress"`
 	Draft                   bool              `json:"draft"`
 	ForceRemoveSourceBranch bool              `json:"force_remove_source_branch"`
+	HasConflicts            bool              `json:"has_conflicts"`
 	// We only get a partial User object back from the REST API. For example, it lacks
 	// `Email` and `Identities`. If we need more, we need to issue an additional API
 	// request. Otherwise, we should use a different type here.
 	Author User `json:"author"`
+	// Assignees and Reviewers are partial User objects, just like Author.
+	Assignees []User `json:"assignees"`
+	Reviewers []User `json:"reviewers"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -114,6 +118,11 @@ type CreateMergeRequestOpts struct {
 	Title              string `json:"title"`
 	Description        string `json:"description,omitempty"`
 	RemoveSourceBranch bool   `json:"remove_source_branch,omitempty"`
+	// Labels is a comma-separated list of label names.
+	Labels      string  `json:"labels,omitempty"`
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
+	MilestoneID ID      `json:"milestone_id,omitempty"`
 	// TODO: other fields at
 	// https://docs.gitlab.com/ee/api/merge_requests.html#create-mr as needed.
 }
@@ -223,6 +232,16 @@ type UpdateMergeRequestOpts struct {
 	Description        string                       `json:"description,omitempty"`
 	StateEvent         UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
 	RemoveSourceBranch bool                         `json:"remove_source_branch,omitempty"`
+	// AddLabels and RemoveLabels are comma-separated lists of label names to
+	// add to or remove from the current labels.
+	AddLabels    string `json:"add_labels,omitempty"`
+	RemoveLabels string `json:"remove_labels,omitempty"`
+	// AssigneeIDs and ReviewerIDs replace the current assignees and reviewers
+	// if set. An empty list unassigns all of them.
+	AssigneeIDs *[]int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs *[]int32 `json:"reviewer_ids,omitempty"`
+	// MilestoneID replaces the current milestone if set. Zero unsets it.
+	MilestoneID *ID `json:"milestone_id,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
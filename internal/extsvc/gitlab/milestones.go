@@ -0,0 +1,44 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Milestone is a GitLab project milestone.
+type Milestone struct {
+	ID    ID     `json:"id"`
+	IID   ID     `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// ErrMilestoneNotFound is returned by GetMilestoneByTitle if the project has
+// no milestone with the given title.
+var ErrMilestoneNotFound = errors.New("milestone not found")
+
+// GetMilestoneByTitle returns the milestone of the project with the given
+// title.
+func (c *Client) GetMilestoneByTitle(ctx context.Context, project *Project, title string) (*Milestone, error) {
+	if MockGetMilestoneByTitle != nil {
+		return MockGetMilestoneByTitle(c, ctx, project, title)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/milestones?title=%s", project.ID, url.QueryEscape(title)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to get a milestone")
+	}
+
+	var milestones []*Milestone
+	if _, _, err := c.do(ctx, req, &milestones); err != nil {
+		return nil, errors.Wrap(err, "sending request to get a milestone")
+	}
+	if len(milestones) == 0 {
+		return nil, ErrMilestoneNotFound
+	}
+	return milestones[0], nil
+}
@@ -53,5 +53,9 @@ var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project,
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
 
+// MockGetMilestoneByTitle, if non-nil, will be called instead of
+// Client.GetMilestoneByTitle
+var MockGetMilestoneByTitle func(c *Client, ctx context.Context, project *Project, title string) (*Milestone, error)
+
 // MockGetVersion, if non-nil, will be called instead of Client.GetVersion
 var MockGetVersion func(ctx context.Context) (string, error)
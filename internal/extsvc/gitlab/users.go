@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/peterhellberg/link"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type User struct {
@@ -84,3 +87,19 @@ func (c *Client) GetUser(ctx context.Context, id string) (*AuthUser, error) {
 	}
 	return &usr, nil
 }
+
+// ErrUserNotFound is returned by GetUserByUsername if there is no user with
+// the given username.
+var ErrUserNotFound = errors.New("user not found")
+
+// GetUserByUsername returns the user with the given username.
+func (c *Client) GetUserByUsername(ctx context.Context, username string) (*AuthUser, error) {
+	users, _, err := c.ListUsers(ctx, "users?username="+url.QueryEscape(username))
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, errors.Wrap(ErrUserNotFound, username)
+	}
+	return users[0], nil
+}
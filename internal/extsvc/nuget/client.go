@@ -0,0 +1,167 @@
+// Package nuget is a client for the NuGet v3 server API, as described in
+// https://learn.microsoft.com/en-us/nuget/api/overview.
+//
+// The entrypoint of every NuGet v3 feed is its service index, which lists the
+// resources the feed provides. Package archives (.nupkg files) are downloaded
+// from the PackageBaseAddress resource.
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultServiceIndexURL is the service index of nuget.org.
+const DefaultServiceIndexURL = "https://api.nuget.org/v3/index.json"
+
+// packageBaseAddressType is the type of the service index resource packages are
+// downloaded from.
+const packageBaseAddressType = "PackageBaseAddress/3.0.0"
+
+type Client struct {
+	serviceIndexURL string
+	// credentials are sent with basic authentication, in the form
+	// "username:password".
+	credentials string
+
+	uncachedClient httpcli.Doer
+	cachedClient   httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+
+	mu sync.Mutex
+	// packageBaseAddress is resolved from the service index the first time a
+	// package is downloaded.
+	packageBaseAddress string
+}
+
+func NewClient(urn string, serviceIndexURL string, credentials string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	cached, err := httpfactory.Doer()
+	if err != nil {
+		return nil, err
+	}
+	if serviceIndexURL == "" {
+		serviceIndexURL = DefaultServiceIndexURL
+	}
+	return &Client{
+		serviceIndexURL: serviceIndexURL,
+		credentials:     credentials,
+		uncachedClient:  uncached,
+		cachedClient:    cached,
+		limiter:         ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("NuGetClient"), urn)),
+	}, nil
+}
+
+// GetPackageContents downloads the .nupkg archive of the given package version.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (body io.ReadCloser, err error) {
+	baseAddress, err := c.getPackageBaseAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The package base address requires lower-cased IDs and versions.
+	id := strings.ToLower(string(dep.PackageSyntax()))
+	version := strings.ToLower(dep.PackageVersion())
+	url := fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", strings.TrimSuffix(baseAddress, "/"), id, version, id, version)
+
+	return c.get(ctx, c.uncachedClient, url)
+}
+
+type serviceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+func (c *Client) getPackageBaseAddress(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.packageBaseAddress != "" {
+		return c.packageBaseAddress, nil
+	}
+
+	body, err := c.get(ctx, c.cachedClient, c.serviceIndexURL)
+	if err != nil {
+		return "", errors.Wrap(err, "fetching NuGet service index")
+	}
+	defer body.Close()
+
+	var index serviceIndex
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return "", errors.Wrap(err, "decoding NuGet service index")
+	}
+	for _, r := range index.Resources {
+		if r.Type == packageBaseAddressType {
+			c.packageBaseAddress = r.ID
+			return r.ID, nil
+		}
+	}
+	return "", errors.Errorf("NuGet service index %q has no %s resource", c.serviceIndexURL, packageBaseAddressType)
+}
+
+func (c *Client) get(ctx context.Context, doer httpcli.Doer, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-nuget-syncer (sourcegraph.com)")
+	if c.credentials != "" {
+		username, password, _ := strings.Cut(c.credentials, ":")
+		req.SetBasicAuth(username, password)
+	}
+
+	return c.do(doer, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			bs = []byte(errors.Wrap(err, "failed to read body").Error())
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,82 @@
+package nuget
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	serviceIndexRequests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "sourcegraph" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/index.json":
+			serviceIndexRequests++
+			_, _ = io.WriteString(w, `{
+  "version": "3.0.0",
+  "resources": [
+    {"@id": "`+srv.URL+`/v3/registration/", "@type": "RegistrationsBaseUrl"},
+    {"@id": "`+srv.URL+`/v3-flatcontainer/", "@type": "PackageBaseAddress/3.0.0"}
+  ]
+}`)
+		case "/v3-flatcontainer/newtonsoft.json/13.0.3/newtonsoft.json.13.0.3.nupkg":
+			_, _ = io.WriteString(w, "nupkg")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &serviceIndexRequests
+}
+
+func newTestClient(t *testing.T, serviceIndexURL, credentials string) *Client {
+	t.Helper()
+	client, err := NewClient("nuget_urn", serviceIndexURL, credentials, httpcli.TestExternalClientFactory)
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("nuget", rate.NewLimiter(100, 10))
+	return client
+}
+
+func TestGetPackageContents(t *testing.T) {
+	ctx := context.Background()
+	srv, serviceIndexRequests := newTestServer(t)
+	client := newTestClient(t, srv.URL+"/v3/index.json", "sourcegraph:token")
+
+	for i := 0; i < 2; i++ {
+		body, err := client.GetPackageContents(ctx, reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@13.0.3"))
+		require.NoError(t, err)
+		contents, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.NoError(t, body.Close())
+		require.Equal(t, "nupkg", string(contents))
+	}
+	// The package base address is only resolved once.
+	require.Equal(t, 1, *serviceIndexRequests)
+
+	_, err := client.GetPackageContents(ctx, reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@0.0.0"))
+	require.True(t, errcode.IsNotFound(err), "unexpected error: %v", err)
+}
+
+func TestGetPackageContents_Unauthorized(t *testing.T) {
+	srv, _ := newTestServer(t)
+	client := newTestClient(t, srv.URL+"/v3/index.json", "")
+
+	_, err := client.GetPackageContents(context.Background(), reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@13.0.3"))
+	require.ErrorContains(t, err, "status code 401")
+}
@@ -204,6 +204,12 @@ const (
 	// VariantRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	VariantRubyPackages
 
+	// VariantSubversion is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories.
+	VariantSubversion
+
+	// VariantNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet (.NET) packages.
+	VariantNuGetPackages
+
 	// VariantOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	VariantOther
 )
@@ -228,6 +234,7 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantGoPackages:      {AsKind: "GOMODULES", AsType: "goModules", ConfigPrototype: func() any { return &schema.GoModulesConnection{} }},
 	VariantJVMPackages:     {AsKind: "JVMPACKAGES", AsType: "jvmPackages", ConfigPrototype: func() any { return &schema.JVMPackagesConnection{} }},
 	VariantNpmPackages:     {AsKind: "NPMPACKAGES", AsType: "npmPackages", ConfigPrototype: func() any { return &schema.NpmPackagesConnection{} }},
+	VariantNuGetPackages:   {AsKind: "NUGETPACKAGES", AsType: "nugetPackages", ConfigPrototype: func() any { return &schema.NuGetPackagesConnection{} }},
 	VariantOther:           {AsKind: "OTHER", AsType: "other", ConfigPrototype: func() any { return &schema.OtherExternalServiceConnection{} }},
 	VariantPagure:          {AsKind: "PAGURE", AsType: "pagure", ConfigPrototype: func() any { return &schema.PagureConnection{} }},
 	VariantPerforce:        {AsKind: "PERFORCE", AsType: "perforce", ConfigPrototype: func() any { return &schema.PerforceConnection{} }},
@@ -235,6 +242,7 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantPythonPackages:  {AsKind: "PYTHONPACKAGES", AsType: "pythonPackages", ConfigPrototype: func() any { return &schema.PythonPackagesConnection{} }},
 	VariantRubyPackages:    {AsKind: "RUBYPACKAGES", AsType: "rubyPackages", ConfigPrototype: func() any { return &schema.RubyPackagesConnection{} }},
 	VariantRustPackages:    {AsKind: "RUSTPACKAGES", AsType: "rustPackages", ConfigPrototype: func() any { return &schema.RustPackagesConnection{} }},
+	VariantSubversion:      {AsKind: "SUBVERSION", AsType: "subversion", ConfigPrototype: func() any { return &schema.SubversionConnection{} }},
 }
 
 func (v Variant) AsKind() string {
@@ -298,8 +306,10 @@ var (
 	KindRustPackages    = VariantRustPackages.AsKind()
 	KindRubyPackages    = VariantRubyPackages.AsKind()
 	KindNpmPackages     = VariantNpmPackages.AsKind()
+	KindNuGetPackages   = VariantNuGetPackages.AsKind()
 	KindPagure          = VariantPagure.AsKind()
 	KindAzureDevOps     = VariantAzureDevOps.AsKind()
+	KindSubversion      = VariantSubversion.AsKind()
 	KindOther           = VariantOther.AsKind()
 )
 
@@ -352,6 +362,9 @@ var (
 	// TypeNpmPackages is the (api.ExternalRepoSpec).ServiceType value for Npm packages (JavaScript/TypeScript ecosystem libraries).
 	TypeNpmPackages = VariantNpmPackages.AsType()
 
+	// TypeNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages (.NET ecosystem libraries).
+	TypeNuGetPackages = VariantNuGetPackages.AsType()
+
 	// TypeGoModules is the (api.ExternalRepoSpec).ServiceType value for Go modules.
 	TypeGoModules = VariantGoPackages.AsType()
 
@@ -364,6 +377,9 @@ var (
 	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	TypeRubyPackages = VariantRubyPackages.AsType()
 
+	// TypeSubversion is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories.
+	TypeSubversion = VariantSubversion.AsType()
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = VariantOther.AsType()
 )
@@ -663,6 +679,12 @@ func GetLimitFromConfig(config any, kind string) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.NuGetPackagesConnection:
+		limit = GetDefaultRateLimit(KindNuGetPackages)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.AzureDevOpsConnection:
 		limit = GetDefaultRateLimit(KindAzureDevOps)
 		if c != nil && c.RateLimit != nil {
@@ -713,6 +735,10 @@ func GetDefaultRateLimit(kind string) rate.Limit {
 	case KindRubyPackages:
 		// The rubygems.org API allows 10 rps https://guides.rubygems.org/rubygems-org-rate-limits/
 		return rate.Limit(10)
+	case KindNuGetPackages:
+		// The nuget.org API doesn't document an enforced rate limit, but asks
+		// clients to back off when they receive 429 responses.
+		return rate.Limit(10)
 	case KindAzureDevOps:
 		return rate.Inf
 	default:
@@ -821,6 +847,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
 		rawURL = c.Url
+	case *schema.SubversionConnection:
+		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
 	case *schema.AWSCodeCommitConnection:
@@ -842,6 +870,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return VariantRustPackages.AsKind(), nil
 	case *schema.RubyPackagesConnection:
 		return VariantRubyPackages.AsKind(), nil
+	case *schema.NuGetPackagesConnection:
+		return KindNuGetPackages, nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
 	default:
@@ -67,6 +67,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantPhabricator.ConfigPrototype().(*schema.PhabricatorConnection); !ok {
 		t.Errorf("wrong type for Phabricator configuration prototype: %T", y)
 	}
+	if y, ok := VariantNuGetPackages.ConfigPrototype().(*schema.NuGetPackagesConnection); !ok {
+		t.Errorf("wrong type for NuGet Packages configuration prototype: %T", y)
+	}
 	if y, ok := VariantPythonPackages.ConfigPrototype().(*schema.PythonPackagesConnection); !ok {
 		t.Errorf("wrong type for Python Packages configuration prototype: %T", y)
 	}
@@ -76,6 +79,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantRustPackages.ConfigPrototype().(*schema.RustPackagesConnection); !ok {
 		t.Errorf("wrong type for Rust Packages configuration prototype: %T", y)
 	}
+	if y, ok := VariantSubversion.ConfigPrototype().(*schema.SubversionConnection); !ok {
+		t.Errorf("wrong type for Subversion configuration prototype: %T", y)
+	}
 }
 
 func TestExtractToken(t *testing.T) {
@@ -415,6 +421,11 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			config: `{"url": "ssh://user@host.xz:2333/"}`,
 			want:   "ssh://user@host.xz:2333/",
 		},
+		{
+			kind:   VariantSubversion.AsKind(),
+			config: `{"url": "https://svn.example.com/svn"}`,
+			want:   "https://svn.example.com/svn/",
+		},
 	} {
 		t.Run(tc.kind, func(t *testing.T) {
 			have, err := UniqueCodeHostIdentifier(tc.kind, tc.config)
@@ -215,12 +215,30 @@ type BlameOptions struct {
 	NewestCommit     api.CommitID `json:",omitempty" url:",omitempty"`
 	IgnoreWhitespace bool         `json:",omitempty" url:",omitempty"`
 	Range            *BlameRange  `json:",omitempty" url:",omitempty"`
+
+	// IgnoreRevs skips the commits listed in the .git-blame-ignore-revs file
+	// of the repository at NewestCommit, such as formatting commits.
+	IgnoreRevs bool `json:",omitempty" url:",omitempty"`
+	// DetectMoves attributes lines moved or copied within the file to the
+	// commit they were originally written in.
+	DetectMoves bool `json:",omitempty" url:",omitempty"`
+	// DetectCopies attributes lines moved or copied from other files modified
+	// in the same commit to the commit they were originally written in.
+	DetectCopies bool `json:",omitempty" url:",omitempty"`
+	// ReverseFrom, if set, makes blame walk history forward from ReverseFrom
+	// to NewestCommit. The file is annotated as of ReverseFrom, and each hunk
+	// reports the last commit in which its lines were still present.
+	ReverseFrom api.CommitID `json:",omitempty" url:",omitempty"`
 }
 
 func (o *BlameOptions) Attrs() []attribute.KeyValue {
 	kvs := []attribute.KeyValue{
 		attribute.String("newestCommit", string(o.NewestCommit)),
 		attribute.Bool("ignoreWhitespace", o.IgnoreWhitespace),
+		attribute.Bool("ignoreRevs", o.IgnoreRevs),
+		attribute.Bool("detectMoves", o.DetectMoves),
+		attribute.Bool("detectCopies", o.DetectCopies),
+		attribute.String("reverseFrom", string(o.ReverseFrom)),
 	}
 	if o.Range != nil {
 		kvs = append(kvs, o.Range.Attrs()...)
@@ -251,6 +269,9 @@ type ListRefsOpts struct {
 	PointsAtCommit []api.CommitID
 	// If set, only return refs that contain the given commit sha in their history.
 	Contains api.CommitID
+	// If true, the signatures of annotated tags are verified against the
+	// keyring of gitserver, if signature verification is enabled.
+	VerifyTagSignatures bool
 }
 
 // ArchiveOptions contains options for the Archive func.
@@ -498,6 +498,12 @@ func (c *clientImplementor) StreamBlameFile(ctx context.Context, repo api.RepoNa
 		Commit:           string(opt.NewestCommit),
 		Path:             []byte(path), // The file path might not be utf-8 encoded.
 		IgnoreWhitespace: opt.IgnoreWhitespace,
+		IgnoreRevs:       opt.IgnoreRevs,
+		DetectMoves:      opt.DetectMoves,
+		DetectCopies:     opt.DetectCopies,
+	}
+	if opt.ReverseFrom != "" {
+		req.ReverseFrom = pointers.Ptr(string(opt.ReverseFrom))
 	}
 	if opt.Range != nil {
 		req.Range = &proto.BlameRange{
@@ -1402,6 +1408,8 @@ func (c *clientImplementor) ListRefs(ctx context.Context, repo api.RepoName, opt
 		RepoName:  string(repo),
 		HeadsOnly: opt.HeadsOnly,
 		TagsOnly:  opt.TagsOnly,
+
+		VerifyTagSignatures: opt.VerifyTagSignatures,
 	}
 
 	for _, c := range opt.PointsAtCommit {
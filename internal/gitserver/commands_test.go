@@ -203,6 +203,38 @@ func TestClient_StreamBlameFile(t *testing.T) {
 
 		require.NoError(t, hr.Close())
 	})
+	t.Run("passes blame options", func(t *testing.T) {
+		source := NewTestClientSource(t, []string{"gitserver"}, func(o *TestClientSourceOptions) {
+			o.ClientFunc = func(cc *grpc.ClientConn) proto.GitserverServiceClient {
+				c := NewMockGitserverServiceClient()
+				c.BlameFunc.SetDefaultHook(func(_ context.Context, req *proto.BlameRequest, _ ...grpc.CallOption) (proto.GitserverService_BlameClient, error) {
+					require.Equal(t, "deadbeef", req.GetCommit())
+					require.True(t, req.GetIgnoreWhitespace())
+					require.True(t, req.GetIgnoreRevs())
+					require.True(t, req.GetDetectMoves())
+					require.True(t, req.GetDetectCopies())
+					require.Equal(t, "cafebabe", req.GetReverseFrom())
+					bc := NewMockGitserverService_BlameClient()
+					bc.RecvFunc.PushReturn(nil, io.EOF)
+					return bc, nil
+				})
+				return c
+			}
+		})
+
+		c := NewTestClient(t).WithClientSource(source)
+
+		r, err := c.StreamBlameFile(context.Background(), "repo", "file", &BlameOptions{
+			NewestCommit:     "deadbeef",
+			IgnoreWhitespace: true,
+			IgnoreRevs:       true,
+			DetectMoves:      true,
+			DetectCopies:     true,
+			ReverseFrom:      "cafebabe",
+		})
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+	})
 	t.Run("checks for subrepo permissions on the path", func(t *testing.T) {
 		source := NewTestClientSource(t, []string{"gitserver"}, func(o *TestClientSourceOptions) {
 			o.ClientFunc = func(cc *grpc.ClientConn) proto.GitserverServiceClient {
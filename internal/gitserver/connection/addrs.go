@@ -35,8 +35,10 @@ var (
 // NewGitserverAddresses fetches the current set of gitserver addresses
 // and pinned repos for gitserver.
 func NewGitserverAddresses(cfg conftypes.UnifiedQuerier) GitserverAddresses {
+	sc := cfg.ServiceConnections()
 	addrs := GitserverAddresses{
-		Addresses: cfg.ServiceConnections().GitServers,
+		Addresses: sc.GitServers,
+		Rebalance: sc.GitServersRebalance,
 	}
 	s := cfg.SiteConfig()
 	if s.ExperimentalFeatures != nil {
@@ -53,6 +55,11 @@ type GitserverAddresses struct {
 	// ensures that, even if the number of gitservers changes, these repos will
 	// not be moved.
 	PinnedServers map[string]string
+
+	// Rebalance is set while repositories are being moved to a new set of
+	// gitserver shards. Repos which haven't been moved yet keep being served
+	// by the shard they were on before.
+	Rebalance *conftypes.GitServersRebalance
 }
 
 // AddrForRepo returns the gitserver address to use for the given repo name.
@@ -68,25 +75,78 @@ func (g *GitserverAddresses) AddrForRepo(ctx context.Context, repoName api.RepoN
 		return pinnedAddr
 	}
 
+	hash := RepoHash(repoName)
+	if r := g.Rebalance; r != nil {
+		if hash < r.Cursor {
+			return AddrForHash(hash, r.Addresses)
+		}
+		return AddrForHash(hash, r.PreviousAddresses)
+	}
+	return AddrForHash(hash, g.Addresses)
+}
+
+// TargetAddrForRepo returns the gitserver address the given repo is assigned
+// to once the ongoing rebalance, if any, completes. Outside of a rebalance
+// this is the same as AddrForRepo.
+func (g *GitserverAddresses) TargetAddrForRepo(ctx context.Context, repoName api.RepoName) string {
+	if g.Rebalance == nil {
+		return g.AddrForRepo(ctx, repoName)
+	}
+
+	name := string(api.UndeletedRepoName(repoName))
+	if pinnedAddr, ok := g.PinnedServers[name]; ok {
+		return pinnedAddr
+	}
+	return AddrForHash(RepoHash(repoName), g.Rebalance.Addresses)
+}
+
+// RepoHash returns the hash used to assign the given repo to a gitserver
+// shard. Rebalancing moves repos in the order of this hash.
+func RepoHash(repoName api.RepoName) uint64 {
 	// We use the normalize function here, because that's what we did previously.
 	// Ideally, this would not be required, but it would reshuffle GitHub.com repos
 	// with uppercase characters in the name. So until we have a better migration
 	// strategy, we keep this old behavior in.
-	return addrForKey(string(protocol.NormalizeRepo(api.RepoName(name))), g.Addresses)
+	return hashKey(string(protocol.NormalizeRepo(api.UndeletedRepoName(repoName))))
+}
+
+// AddrForHash returns the address out of addrs which a repo with the given
+// RepoHash is assigned to.
+func AddrForHash(hash uint64, addrs []string) string {
+	serverIndex := hash % uint64(len(addrs))
+	return addrs[serverIndex]
 }
 
 // addrForKey returns the gitserver address to use for the given string key,
 // which is hashed for sharding purposes.
 func addrForKey(key string, addrs []string) string {
+	return AddrForHash(hashKey(key), addrs)
+}
+
+func hashKey(key string) uint64 {
 	sum := md5.Sum([]byte(key))
-	serverIndex := binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs))
-	return addrs[serverIndex]
+	return binary.BigEndian.Uint64(sum[:])
+}
+
+// allAddresses returns every gitserver address a repo can currently be routed
+// to, which includes the shards a rebalance moves repos away from.
+func (g *GitserverAddresses) allAddresses() []string {
+	if g.Rebalance == nil {
+		return g.Addresses
+	}
+	addrs := slices.Clone(g.Addresses)
+	for _, addr := range slices.Concat(g.Rebalance.Addresses, g.Rebalance.PreviousAddresses) {
+		if !slices.Contains(addrs, addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
 }
 
 type GitserverConns struct {
 	GitserverAddresses
 
-	// invariant: there is one conn for every gitserver address
+	// invariant: there is one conn for every address returned by allAddresses
 	grpcConns map[string]connAndErr
 }
 
@@ -185,9 +245,10 @@ func (a *atomicGitServerConns) update(cfg *conf.Unified) {
 		before = &GitserverConns{}
 	}
 
-	if slices.Equal(before.Addresses, after.Addresses) {
+	if slices.Equal(before.allAddresses(), after.allAddresses()) {
 		// No change in addresses. Reuse the old connections.
-		// We still update newAddrs in case the pinned repos have changed.
+		// We still update newAddrs in case the pinned repos or the rebalance
+		// progress have changed.
 		after.grpcConns = before.grpcConns
 		a.conns.Store(&after)
 		return
@@ -201,8 +262,9 @@ func (a *atomicGitServerConns) update(cfg *conf.Unified) {
 	// Open connections for each address
 	clientLogger := log.Scoped("gitserver.client")
 
-	after.grpcConns = make(map[string]connAndErr, len(after.Addresses))
-	for _, addr := range after.Addresses {
+	allAddrs := after.allAddresses()
+	after.grpcConns = make(map[string]connAndErr, len(allAddrs))
+	for _, addr := range allAddrs {
 		conn, err := defaults.Dial(
 			addr,
 			clientLogger,
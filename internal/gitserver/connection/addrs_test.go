@@ -89,6 +89,91 @@ func TestGitserverAddresses_AddrForRepo_PinnedRepos(t *testing.T) {
 	require.Equal(t, "gitserver1", addrs.AddrForRepo(ctx, "repo1"))
 }
 
+func TestGitserverAddresses_AddrForRepo_Rebalance(t *testing.T) {
+	ctx := context.Background()
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+
+	// The repo is assigned to a different shard before and after the
+	// rebalance.
+	previous := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	target := []string{"gitserver-1", "gitserver-2", "gitserver-3", "gitserver-4"}
+	require.Equal(t, "gitserver-2", AddrForHash(RepoHash(repo), previous))
+	require.Equal(t, "gitserver-1", AddrForHash(RepoHash(repo), target))
+
+	addrsAt := func(cursor uint64) GitserverAddresses {
+		return GitserverAddresses{
+			Addresses: target,
+			Rebalance: &conftypes.GitServersRebalance{
+				Addresses:         target,
+				PreviousAddresses: previous,
+				Cursor:            cursor,
+			},
+			PinnedServers: map[string]string{"repo1": "gitserver-2"},
+		}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		cursor     uint64
+		repo       api.RepoName
+		want       string
+		wantTarget string
+	}{
+		{
+			name:       "not started",
+			cursor:     0,
+			repo:       repo,
+			want:       "gitserver-2",
+			wantTarget: "gitserver-1",
+		},
+		{
+			name:       "repo is next",
+			cursor:     RepoHash(repo),
+			repo:       repo,
+			want:       "gitserver-2",
+			wantTarget: "gitserver-1",
+		},
+		{
+			name:       "repo moved",
+			cursor:     RepoHash(repo) + 1,
+			repo:       repo,
+			want:       "gitserver-1",
+			wantTarget: "gitserver-1",
+		},
+		{
+			name:       "deleted repo is routed like the original",
+			cursor:     RepoHash(repo) + 1,
+			repo:       "DELETED-123123.123123-github.com/sourcegraph/sourcegraph",
+			want:       "gitserver-1",
+			wantTarget: "gitserver-1",
+		},
+		{
+			name:       "pinned repos are never moved",
+			cursor:     0,
+			repo:       "repo1",
+			want:       "gitserver-2",
+			wantTarget: "gitserver-2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			addrs := addrsAt(tc.cursor)
+			require.Equal(t, tc.want, addrs.AddrForRepo(ctx, tc.repo))
+			require.Equal(t, tc.wantTarget, addrs.TargetAddrForRepo(ctx, tc.repo))
+		})
+	}
+
+	t.Run("connections to all shards", func(t *testing.T) {
+		addrs := GitserverAddresses{
+			Addresses: []string{"gitserver-1"},
+			Rebalance: &conftypes.GitServersRebalance{
+				Addresses:         []string{"gitserver-1"},
+				PreviousAddresses: []string{"gitserver-1", "gitserver-2"},
+			},
+		}
+		require.Equal(t, []string{"gitserver-1", "gitserver-2"}, addrs.allAddresses())
+	})
+}
+
 func newConfig(addrs []string, pinned map[string]string) *conf.Unified {
 	return &conf.Unified{
 		ServiceConnectionConfig: conftypes.ServiceConnections{
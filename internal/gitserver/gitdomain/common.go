@@ -56,6 +56,10 @@ type Submodule struct {
 	// CommitID is the pinned commit ID of the submodule (in the
 	// submodule repository's commit ID space).
 	CommitID api.CommitID
+
+	// RepoName is the name of the Sourcegraph repository that URL resolves
+	// to. It is empty if URL doesn't match any known code host connection.
+	RepoName api.RepoName
 }
 
 // ObjectInfo holds information about a Git object and is returned in (fs.FileInfo).Sys for blobs
@@ -160,6 +164,9 @@ type Commit struct {
 	Message   Message      `json:"Message,omitempty"`
 	// Parents are the commit IDs of this commit's parent commits.
 	Parents []api.CommitID `json:"Parents,omitempty"`
+	// SignatureVerification is the result of verifying the signature of the
+	// commit. It is nil if gitserver didn't verify the signature.
+	SignatureVerification *SignatureVerification `json:"SignatureVerification,omitempty"`
 }
 
 func (c *Commit) ToProto() *proto.GitCommit {
@@ -182,6 +189,7 @@ func (c *Commit) ToProto() *proto.GitCommit {
 			Email: []byte(c.Committer.Email),
 			Date:  timestamppb.New(c.Committer.Date),
 		},
+		SignatureVerification: c.SignatureVerification.ToProto(),
 	}
 }
 
@@ -208,7 +216,132 @@ func CommitFromProto(p *proto.GitCommit) *Commit {
 			Email: string(p.GetCommitter().GetEmail()),
 			Date:  p.GetCommitter().GetDate().AsTime(),
 		},
-		Parents: parents,
+		Parents:               parents,
+		SignatureVerification: SignatureVerificationFromProto(p.GetSignatureVerification()),
+	}
+}
+
+// SignatureStatus is the outcome of verifying the signature of a commit.
+type SignatureStatus int
+
+const (
+	SignatureStatusUnknown SignatureStatus = iota
+	// SignatureStatusUnsigned means the commit has no signature.
+	SignatureStatusUnsigned
+	// SignatureStatusVerified means the signature is good and was made by a
+	// key in the keyring.
+	SignatureStatusVerified
+	// SignatureStatusUnverified means the signature can't be checked, e.g.
+	// because the key isn't in the keyring.
+	SignatureStatusUnverified
+	// SignatureStatusInvalid means the signature doesn't match the signed
+	// content.
+	SignatureStatusInvalid
+	// SignatureStatusUntrusted means the signature is good, but was made by a
+	// key that isn't trusted, e.g. an SSH key no principal is allowed to sign
+	// with.
+	SignatureStatusUntrusted
+	// SignatureStatusExpired means the signature is good, but it or the key
+	// that made it has expired.
+	SignatureStatusExpired
+	// SignatureStatusRevoked means the signature is good, but was made by a
+	// revoked key.
+	SignatureStatusRevoked
+)
+
+func SignatureStatusFromProto(s proto.CommitSignatureVerification_Status) SignatureStatus {
+	switch s {
+	case proto.CommitSignatureVerification_STATUS_UNSIGNED:
+		return SignatureStatusUnsigned
+	case proto.CommitSignatureVerification_STATUS_VERIFIED:
+		return SignatureStatusVerified
+	case proto.CommitSignatureVerification_STATUS_UNVERIFIED:
+		return SignatureStatusUnverified
+	case proto.CommitSignatureVerification_STATUS_INVALID:
+		return SignatureStatusInvalid
+	case proto.CommitSignatureVerification_STATUS_UNTRUSTED:
+		return SignatureStatusUntrusted
+	case proto.CommitSignatureVerification_STATUS_EXPIRED:
+		return SignatureStatusExpired
+	case proto.CommitSignatureVerification_STATUS_REVOKED:
+		return SignatureStatusRevoked
+	default:
+		return SignatureStatusUnknown
+	}
+}
+
+func (s SignatureStatus) ToProto() proto.CommitSignatureVerification_Status {
+	switch s {
+	case SignatureStatusUnsigned:
+		return proto.CommitSignatureVerification_STATUS_UNSIGNED
+	case SignatureStatusVerified:
+		return proto.CommitSignatureVerification_STATUS_VERIFIED
+	case SignatureStatusUnverified:
+		return proto.CommitSignatureVerification_STATUS_UNVERIFIED
+	case SignatureStatusInvalid:
+		return proto.CommitSignatureVerification_STATUS_INVALID
+	case SignatureStatusUntrusted:
+		return proto.CommitSignatureVerification_STATUS_UNTRUSTED
+	case SignatureStatusExpired:
+		return proto.CommitSignatureVerification_STATUS_EXPIRED
+	case SignatureStatusRevoked:
+		return proto.CommitSignatureVerification_STATUS_REVOKED
+	default:
+		return proto.CommitSignatureVerification_STATUS_UNSPECIFIED
+	}
+}
+
+func (s SignatureStatus) String() string {
+	switch s {
+	case SignatureStatusUnsigned:
+		return "unsigned"
+	case SignatureStatusVerified:
+		return "verified"
+	case SignatureStatusUnverified:
+		return "unverified"
+	case SignatureStatusInvalid:
+		return "invalid"
+	case SignatureStatusUntrusted:
+		return "untrusted"
+	case SignatureStatusExpired:
+		return "expired"
+	case SignatureStatusRevoked:
+		return "revoked"
+	default:
+		return "unknown"
+	}
+}
+
+// SignatureVerification is the result of verifying the GPG, SSH or X.509
+// signature of a commit or tag against the keyring configured for gitserver.
+type SignatureVerification struct {
+	Status SignatureStatus `json:"Status"`
+	// Signer is the user ID, SSH principal or certificate subject of the key
+	// that made the signature.
+	Signer string `json:"Signer,omitempty"`
+	// Key is the fingerprint of the key that made the signature.
+	Key string `json:"Key,omitempty"`
+}
+
+func (v *SignatureVerification) ToProto() *proto.CommitSignatureVerification {
+	if v == nil {
+		return nil
+	}
+	return &proto.CommitSignatureVerification{
+		Status: v.Status.ToProto(),
+		Signer: v.Signer,
+		Key:    v.Key,
+	}
+}
+
+func SignatureVerificationFromProto(p *proto.CommitSignatureVerification) *SignatureVerification {
+	if p == nil {
+		return nil
+	}
+	return &SignatureVerification{
+		Status: SignatureStatusFromProto(p.GetStatus()),
+		Signer: p.GetSigner(),
+		Key:    p.GetKey(),
 	}
 }
 
@@ -417,6 +550,9 @@ type Ref struct {
 	CreatedDate time.Time
 	// IsHead indicates whether this is the head reference.
 	IsHead bool
+	// SignatureVerification is the result of verifying the signature of an
+	// annotated tag. It is nil if gitserver didn't verify the signature.
+	SignatureVerification *SignatureVerification
 }
 
 func RefFromProto(r *proto.GitRef) Ref {
@@ -428,6 +564,8 @@ func RefFromProto(r *proto.GitRef) Ref {
 		RefOID:      api.CommitID(r.GetRefOid()),
 		CreatedDate: r.GetCreatedAt().AsTime(),
 		IsHead:      r.GetIsHead(),
+
+		SignatureVerification: SignatureVerificationFromProto(r.GetSignatureVerification()),
 	}
 }
 
@@ -440,6 +578,8 @@ func (r *Ref) ToProto() *proto.GitRef {
 		CreatedAt:    timestamppb.New(r.CreatedDate),
 		RefType:      r.Type.ToProto(),
 		IsHead:       r.IsHead,
+
+		SignatureVerification: r.SignatureVerification.ToProto(),
 	}
 }
 
@@ -599,6 +739,7 @@ func FSFileInfoToProto(fi fs.FileInfo) *v1.FileInfo {
 			Url:       s.URL,
 			CommitSha: string(s.CommitID),
 			Path:      []byte(s.Path),
+			RepoName:  string(s.RepoName),
 		}
 	case ObjectInfo:
 		p.BlobOid = s.OID().String()
@@ -613,6 +754,7 @@ func ProtoFileInfoToFS(fi *v1.FileInfo) fs.FileInfo {
 			URL:      sm.GetUrl(),
 			Path:     string(sm.GetPath()),
 			CommitID: api.CommitID(sm.GetCommitSha()),
+			RepoName: api.RepoName(sm.GetRepoName()),
 		}
 	} else {
 		oid, _ := decodeOID(fi.GetBlobOid())
@@ -227,6 +227,30 @@ func TestRoundTripCommit(t *testing.T) {
 	}
 }
 
+func TestRoundTripSignatureVerification(t *testing.T) {
+	diff := ""
+
+	err := quick.Check(func(status uint8, signer, key string) bool {
+		original := &SignatureVerification{
+			Status: SignatureStatusFromProto(proto.CommitSignatureVerification_Status(status % 8)),
+			Signer: signer,
+			Key:    key,
+		}
+		converted := SignatureVerificationFromProto(original.ToProto())
+		if diff = cmp.Diff(original, converted); diff != "" {
+			return false
+		}
+		return true
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if got := SignatureVerificationFromProto((*SignatureVerification)(nil).ToProto()); got != nil {
+		t.Fatalf("expected nil verification, got %v", got)
+	}
+}
+
 type fuzzTime time.Time
 
 func (fuzzTime) Generate(rand *rand.Rand, _ int) reflect.Value {
@@ -404,3 +428,30 @@ func TestRoundTripFileInfo(t *testing.T) {
 		t.Fatalf("unexpected diff (-want +got):\n%s", diff)
 	}
 }
+
+func TestRoundTripSubmoduleFileInfo(t *testing.T) {
+	diff := ""
+
+	err := quick.Check(func(name, url, path, commit, repoName string) bool {
+		original := &fileutil.FileInfo{
+			Name_: name,
+			Mode_: ModeSubmodule,
+			Sys_: Submodule{
+				URL:      url,
+				Path:     path,
+				CommitID: api.CommitID(commit),
+				RepoName: api.RepoName(repoName),
+			},
+		}
+		converted := ProtoFileInfoToFS(FSFileInfoToProto(original))
+		if diff = cmp.Diff(original, converted); diff != "" {
+			return false
+		}
+
+		return true
+	}, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
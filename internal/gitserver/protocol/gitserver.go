@@ -254,17 +254,25 @@ func (i *IsRepoCloneableResponse) FromProto(p *proto.IsRepoCloneableResponse) {
 
 // RepoCloneProgress is information about the clone progress of a repo
 type RepoCloneProgress struct {
-	CloneInProgress bool   // whether the repository is currently being cloned
-	CloneProgress   string // a progress message from the running clone command.
-	Cloned          bool   // whether the repository has been cloned successfully
+	CloneInProgress bool                // whether the repository is currently being cloned
+	CloneProgress   string              // a progress message from the running clone command.
+	Cloned          bool                // whether the repository has been cloned successfully
+	PartialClone    *PartialCloneStatus // set if the repository is a partial clone
 }
 
 func (r *RepoCloneProgress) ToProto() *proto.RepoCloneProgressResponse {
-	return &proto.RepoCloneProgressResponse{
+	res := &proto.RepoCloneProgressResponse{
 		CloneInProgress: r.CloneInProgress,
 		CloneProgress:   r.CloneProgress,
 		Cloned:          r.Cloned,
 	}
+	if r.PartialClone != nil {
+		res.PartialClone = &proto.PartialCloneStatus{
+			Filter:         r.PartialClone.Filter,
+			MissingObjects: r.PartialClone.MissingObjects,
+		}
+	}
+	return res
 }
 
 func (r *RepoCloneProgress) FromProto(p *proto.RepoCloneProgressResponse) {
@@ -273,6 +281,19 @@ func (r *RepoCloneProgress) FromProto(p *proto.RepoCloneProgressResponse) {
 		CloneProgress:   p.GetCloneProgress(),
 		Cloned:          p.GetCloned(),
 	}
+	if pc := p.GetPartialClone(); pc != nil {
+		r.PartialClone = &PartialCloneStatus{
+			Filter:         pc.GetFilter(),
+			MissingObjects: pc.GetMissingObjects(),
+		}
+	}
+}
+
+// PartialCloneStatus describes a repository that is a partial clone, which
+// fetches objects omitted from the clone from the code host on demand.
+type PartialCloneStatus struct {
+	Filter         string // the object filter the repository was cloned with, such as blob:none
+	MissingObjects int64  // number of objects in the tree of HEAD missing after the last fetch
 }
 
 // CreateCommitFromPatchRequest is the request information needed for creating
@@ -43,6 +43,7 @@ func TestSearchRequestProtoRoundtrip(t *testing.T) {
 			Operands: []Node{
 				&AuthorMatches{Expr: "abc", IgnoreCase: true},
 				&CommitAfter{Time: time.Date(2021, 12, 3, 12, 3, 45, 0, time.UTC)},
+				&CommitSigned{Signed: SignedInvalid},
 			},
 		},
 		Limit: 42,
@@ -28,6 +28,8 @@ func NodeFromProto(p *proto.QueryNode) (Node, error) {
 		return &CommitBefore{Time: v.CommitBefore.GetTimestamp().AsTime()}, nil
 	case *proto.QueryNode_CommitAfter:
 		return &CommitAfter{Time: v.CommitAfter.GetTimestamp().AsTime()}, nil
+	case *proto.QueryNode_CommitSigned:
+		return &CommitSigned{Signed: SignedFilterFromProto(v.CommitSigned.GetSigned())}, nil
 	case *proto.QueryNode_MessageMatches:
 		return &MessageMatches{Expr: v.MessageMatches.GetExpr(), IgnoreCase: v.MessageMatches.IgnoreCase}, nil
 	case *proto.QueryNode_DiffMatches:
@@ -144,6 +146,89 @@ func (c *CommitAfter) ToProto() *proto.QueryNode {
 	}
 }
 
+// SignedFilter is the kind of commit signature a CommitSigned predicate
+// matches.
+type SignedFilter int
+
+const (
+	// SignedYes matches commits with a signature verified against the keyring
+	// of gitserver.
+	SignedYes SignedFilter = iota + 1
+	// SignedNo matches commits without a signature.
+	SignedNo
+	// SignedInvalid matches commits with a signature that doesn't match the
+	// commit, has expired, was made by a revoked key or can't be checked.
+	SignedInvalid
+	// SignedUntrusted matches commits with a good signature made by a key that
+	// isn't trusted.
+	SignedUntrusted
+)
+
+func SignedFilterFromProto(s proto.CommitSignedNode_Signed) SignedFilter {
+	switch s {
+	case proto.CommitSignedNode_SIGNED_YES:
+		return SignedYes
+	case proto.CommitSignedNode_SIGNED_NO:
+		return SignedNo
+	case proto.CommitSignedNode_SIGNED_INVALID:
+		return SignedInvalid
+	case proto.CommitSignedNode_SIGNED_UNTRUSTED:
+		return SignedUntrusted
+	default:
+		return 0
+	}
+}
+
+func (s SignedFilter) ToProto() proto.CommitSignedNode_Signed {
+	switch s {
+	case SignedYes:
+		return proto.CommitSignedNode_SIGNED_YES
+	case SignedNo:
+		return proto.CommitSignedNode_SIGNED_NO
+	case SignedInvalid:
+		return proto.CommitSignedNode_SIGNED_INVALID
+	case SignedUntrusted:
+		return proto.CommitSignedNode_SIGNED_UNTRUSTED
+	default:
+		return proto.CommitSignedNode_SIGNED_UNSPECIFIED
+	}
+}
+
+func (s SignedFilter) String() string {
+	switch s {
+	case SignedYes:
+		return "yes"
+	case SignedNo:
+		return "no"
+	case SignedInvalid:
+		return "invalid"
+	case SignedUntrusted:
+		return "untrusted"
+	default:
+		return "unknown"
+	}
+}
+
+// CommitSigned is a predicate that matches if the signature of the commit
+// verifies as Signed.
+type CommitSigned struct {
+	Signed SignedFilter
+}
+
+func (c *CommitSigned) String() string {
+	return fmt.Sprintf("%T(%s)", c, c.Signed)
+}
+
+func (c *CommitSigned) ToProto() *proto.QueryNode {
+	return &proto.QueryNode{
+		Value: &proto.QueryNode_CommitSigned{
+			CommitSigned: &proto.CommitSignedNode{
+				Signed: c.Signed.ToProto(),
+			},
+		},
+	}
+}
+
 // MessageMatches is a predicate that matches if the commit message matches
 // the provided regex pattern.
 type MessageMatches struct {
@@ -379,6 +464,7 @@ func RegisterGob() {
 		gob.Register(&CommitterMatches{})
 		gob.Register(&CommitBefore{})
 		gob.Register(&CommitAfter{})
+		gob.Register(&CommitSigned{})
 		gob.Register(&MessageMatches{})
 		gob.Register(&DiffMatches{})
 		gob.Register(&DiffModifiesFile{})
@@ -246,7 +246,7 @@ func estimatedNodeCost(n Node) float64 {
 		return sum
 	case *Boolean:
 		return 0
-	case *CommitBefore, *CommitAfter:
+	case *CommitBefore, *CommitAfter, *CommitSigned:
 		return 1
 	case *AuthorMatches, *CommitterMatches:
 		return 5
@@ -0,0 +1,222 @@
+package rebalance
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/connection"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	reposCopiedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_rebalance_repos_copied_total",
+		Help: "Number of repos copied to their new gitserver shard during rebalancing.",
+	})
+	reposCopyFailedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_rebalance_repos_copy_failed_total",
+		Help: "Number of repos which failed to be copied to their new gitserver shard during rebalancing. They stay on their previous shard until a later attempt succeeds.",
+	})
+)
+
+// Rebalancer moves repositories to the gitserver shards they are assigned to
+// after the set of gitserver instances changed.
+type Rebalancer struct {
+	Logger log.Logger
+	Store  Store
+
+	// ListRepos returns the names of all repositories.
+	ListRepos func(ctx context.Context) ([]api.RepoName, error)
+	// CopyRepo makes the gitserver at target copy repo from the gitserver at
+	// source. It returns a gitdomain.RepoNotExistError if source doesn't have
+	// repo, for example because it wasn't cloned yet.
+	CopyRepo func(ctx context.Context, repo api.RepoName, target, source string) error
+
+	// BatchSize is the number of repositories whose assignment is flipped at
+	// once.
+	BatchSize int
+	// Concurrency is the number of repositories copied at the same time.
+	Concurrency int
+}
+
+// Run records the assignment of repositories to the gitserver instances at
+// current if none has been recorded yet. Otherwise, it starts a rebalance if
+// the gitserver instances changed, and moves repositories until the ongoing
+// rebalance completes.
+//
+// Repos pinned to a gitserver instance are never moved.
+func (r *Rebalancer) Run(ctx context.Context, current []string, pinned map[string]string) error {
+	if len(current) == 0 {
+		return nil
+	}
+
+	state, err := r.Store.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		_, err := r.Store.Initialize(ctx, current)
+		return err
+	}
+
+	if !state.InProgress() {
+		if slices.Equal(state.Addresses, current) {
+			return nil
+		}
+
+		r.Logger.Info("gitserver instances changed, starting rebalance",
+			log.Strings("previous", state.Addresses),
+			log.Strings("target", current),
+		)
+		if err := r.Store.Start(ctx, current); err != nil {
+			return err
+		}
+		state = &State{Addresses: state.Addresses, TargetAddresses: current}
+	}
+
+	if err := r.move(ctx, state, pinned); err != nil {
+		return err
+	}
+
+	r.Logger.Info("gitserver rebalance complete", log.Strings("addresses", state.TargetAddresses))
+	return nil
+}
+
+type hashedRepo struct {
+	name api.RepoName
+	hash uint64
+}
+
+// move copies all repositories which haven't been moved yet to their new
+// shard, flipping their assignment batch by batch.
+//
+// A repository is only flipped once it was copied, otherwise its new shard
+// would have to clone it from the code host. If a copy fails, the cursor stops
+// at the repository so that it and all repositories after it keep being
+// served by their previous shard, and an error is returned. The next run
+// resumes from there.
+func (r *Rebalancer) move(ctx context.Context, state *State, pinned map[string]string) error {
+	// We only use the routing of a rebalance that hasn't started: the source
+	// is where a repo was before, the target where it will be.
+	addrs := connection.GitserverAddresses{
+		Addresses:     state.TargetAddresses,
+		PinnedServers: pinned,
+		Rebalance: &conftypes.GitServersRebalance{
+			Addresses:         state.TargetAddresses,
+			PreviousAddresses: state.Addresses,
+		},
+	}
+
+	names, err := r.ListRepos(ctx)
+	if err != nil {
+		return err
+	}
+
+	repos := make([]hashedRepo, 0, len(names))
+	for _, name := range names {
+		if hash := connection.RepoHash(name); hash >= state.Cursor {
+			repos = append(repos, hashedRepo{name: name, hash: hash})
+		}
+	}
+	slices.SortFunc(repos, func(a, b hashedRepo) int {
+		switch {
+		case a.hash < b.hash:
+			return -1
+		case a.hash > b.hash:
+			return 1
+		}
+		return 0
+	})
+
+	batchSize := max(r.BatchSize, 1)
+	for len(repos) > 0 {
+		n := min(batchSize, len(repos))
+		// Repos with the same hash are flipped together, so they must be
+		// copied in the same batch.
+		for n < len(repos) && repos[n].hash == repos[n-1].hash {
+			n++
+		}
+		batch := repos[:n]
+		repos = repos[n:]
+
+		failed := r.copyBatch(ctx, addrs, batch)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(failed) > 0 {
+			// Flip the repos before the first failed copy, which were all
+			// copied.
+			first := slices.Min(failed)
+			if err := r.Store.Advance(ctx, first); err != nil {
+				return err
+			}
+			return errors.Newf("failed to copy %d repos to their new gitserver shard", len(failed))
+		}
+
+		last := batch[len(batch)-1].hash
+		if last == math.MaxUint64 {
+			// The cursor can't move past the last hash, but there can't be any
+			// more repos either.
+			break
+		}
+		if err := r.Store.Advance(ctx, last+1); err != nil {
+			return err
+		}
+	}
+
+	return r.Store.Complete(ctx)
+}
+
+// copyBatch copies the repos in batch which move to a different shard and
+// returns the hashes of the repos which couldn't be copied.
+func (r *Rebalancer) copyBatch(ctx context.Context, addrs connection.GitserverAddresses, batch []hashedRepo) []uint64 {
+	var (
+		mu     sync.Mutex
+		failed []uint64
+	)
+	p := pool.New().WithMaxGoroutines(max(r.Concurrency, 1))
+	for _, repo := range batch {
+		source := addrs.AddrForRepo(ctx, repo.name)
+		target := addrs.TargetAddrForRepo(ctx, repo.name)
+		if source == target {
+			continue
+		}
+
+		p.Go(func() {
+			err := r.CopyRepo(ctx, repo.name, target, source)
+			if gitdomain.IsRepoNotExist(err) {
+				// The repo isn't cloned on its previous shard, so there is
+				// nothing to copy. The new shard clones it once it is routed
+				// there.
+				return
+			}
+			if err != nil {
+				mu.Lock()
+				failed = append(failed, repo.hash)
+				mu.Unlock()
+				reposCopyFailedCounter.Inc()
+				r.Logger.Warn("failed to copy repo to its new gitserver shard",
+					log.String("repo", string(repo.name)),
+					log.String("source", source),
+					log.String("target", target),
+					log.Error(err),
+				)
+				return
+			}
+			reposCopiedCounter.Inc()
+		})
+	}
+	p.Wait()
+	return failed
+}
@@ -0,0 +1,202 @@
+package rebalance
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/connection"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRebalancer(t *testing.T) {
+	ctx := context.Background()
+
+	previous := []string{"gitserver-1", "gitserver-2"}
+	current := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	var repos []api.RepoName
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		repos = append(repos, api.RepoName("github.com/sourcegraph/"+name))
+	}
+
+	type copied struct {
+		repo           api.RepoName
+		target, source string
+	}
+	// failing is the repo whose copy fails, notCloned the repo which isn't
+	// cloned on its previous shard.
+	var failing, notCloned api.RepoName
+	newRebalancer := func(store Store) (*Rebalancer, func() []copied) {
+		var (
+			mu     sync.Mutex
+			copies []copied
+		)
+		r := &Rebalancer{
+			Logger: logtest.Scoped(t),
+			Store:  store,
+			ListRepos: func(context.Context) ([]api.RepoName, error) {
+				return repos, nil
+			},
+			CopyRepo: func(_ context.Context, repo api.RepoName, target, source string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				copies = append(copies, copied{repo: repo, target: target, source: source})
+				switch repo {
+				case failing:
+					return errors.New("boom")
+				case notCloned:
+					return &gitdomain.RepoNotExistError{Repo: repo}
+				}
+				return nil
+			},
+			BatchSize:   3,
+			Concurrency: 2,
+		}
+		return r, func() []copied {
+			mu.Lock()
+			defer mu.Unlock()
+			return copies
+		}
+	}
+
+	// wantCopies returns the repos which move when going from previous to
+	// current and have a hash of at least cursor.
+	wantCopies := func(cursor uint64, pinned map[string]string) []copied {
+		var want []copied
+		for _, repo := range repos {
+			if _, ok := pinned[string(repo)]; ok {
+				continue
+			}
+			hash := connection.RepoHash(repo)
+			source, target := connection.AddrForHash(hash, previous), connection.AddrForHash(hash, current)
+			if hash >= cursor && source != target {
+				want = append(want, copied{repo: repo, target: target, source: source})
+			}
+		}
+		return want
+	}
+
+	t.Run("initializes the assignment", func(t *testing.T) {
+		store := &fakeStore{}
+		r, copies := newRebalancer(store)
+		require.NoError(t, r.Run(ctx, current, nil))
+		require.Equal(t, &State{Addresses: current}, store.state)
+		require.Empty(t, copies())
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		store := &fakeStore{state: &State{Addresses: current}}
+		r, copies := newRebalancer(store)
+		require.NoError(t, r.Run(ctx, current, nil))
+		require.Equal(t, &State{Addresses: current}, store.state)
+		require.Empty(t, copies())
+		require.Zero(t, store.advanced)
+	})
+
+	t.Run("moves repos", func(t *testing.T) {
+		store := &fakeStore{state: &State{Addresses: previous}}
+		r, copies := newRebalancer(store)
+		pinned := map[string]string{"github.com/sourcegraph/b": "gitserver-1"}
+		require.NoError(t, r.Run(ctx, current, pinned))
+
+		want := wantCopies(0, pinned)
+		require.NotEmpty(t, want)
+		require.ElementsMatch(t, want, copies())
+		require.Equal(t, &State{Addresses: current}, store.state)
+		// The assignment is flipped after every batch of three repos.
+		require.Equal(t, 4, store.advanced)
+	})
+
+	t.Run("skips repos which are not cloned", func(t *testing.T) {
+		want := wantCopies(0, nil)
+		notCloned = want[0].repo
+		t.Cleanup(func() { notCloned = "" })
+
+		store := &fakeStore{state: &State{Addresses: previous}}
+		r, copies := newRebalancer(store)
+		require.NoError(t, r.Run(ctx, current, nil))
+		require.ElementsMatch(t, want, copies())
+		require.Equal(t, &State{Addresses: current}, store.state)
+	})
+
+	t.Run("keeps repos whose copy failed on their previous shard", func(t *testing.T) {
+		want := wantCopies(0, nil)
+		failing = want[len(want)/2].repo
+		t.Cleanup(func() { failing = "" })
+
+		store := &fakeStore{state: &State{Addresses: previous}}
+		r, _ := newRebalancer(store)
+		require.Error(t, r.Run(ctx, current, nil))
+		// The failed repo and all repos after it are still routed to their
+		// previous shard.
+		require.Equal(t, &State{Addresses: previous, TargetAddresses: current, Cursor: connection.RepoHash(failing)}, store.state)
+
+		// The next run retries from the failed repo.
+		failing = ""
+		r, copies := newRebalancer(store)
+		require.NoError(t, r.Run(ctx, current, nil))
+		require.ElementsMatch(t, wantCopies(connection.RepoHash(want[len(want)/2].repo), nil), copies())
+		require.Equal(t, &State{Addresses: current}, store.state)
+	})
+
+	t.Run("resumes from the cursor", func(t *testing.T) {
+		hashes := make([]uint64, 0, len(repos))
+		for _, repo := range repos {
+			hashes = append(hashes, connection.RepoHash(repo))
+		}
+		cursor := hashes[len(hashes)/2]
+
+		store := &fakeStore{state: &State{Addresses: previous, TargetAddresses: current, Cursor: cursor}}
+		r, copies := newRebalancer(store)
+		require.NoError(t, r.Run(ctx, current, nil))
+
+		require.ElementsMatch(t, wantCopies(cursor, nil), copies())
+		require.Equal(t, &State{Addresses: current}, store.state)
+	})
+}
+
+type fakeStore struct {
+	state    *State
+	advanced int
+}
+
+func (s *fakeStore) Get(context.Context) (*State, error) {
+	if s.state == nil {
+		return nil, nil
+	}
+	state := *s.state
+	return &state, nil
+}
+
+func (s *fakeStore) Initialize(ctx context.Context, addresses []string) (*State, error) {
+	if s.state == nil {
+		s.state = &State{Addresses: addresses}
+	}
+	return s.Get(ctx)
+}
+
+func (s *fakeStore) Start(_ context.Context, target []string) error {
+	if s.state.InProgress() {
+		return ErrRebalanceInProgress
+	}
+	s.state.TargetAddresses = target
+	s.state.Cursor = 0
+	return nil
+}
+
+func (s *fakeStore) Advance(_ context.Context, cursor uint64) error {
+	s.state.Cursor = cursor
+	s.advanced++
+	return nil
+}
+
+func (s *fakeStore) Complete(context.Context) error {
+	s.state = &State{Addresses: s.state.TargetAddresses}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Package rebalance moves repositories between gitserver shards when the set
+// of gitserver instances changes, so that they don't have to be cloned from
+// the code host again.
+//
+// Which shard a repository lives on is decided by hashing its name (see
+// connection.RepoHash). When instances are added or removed, the database
+// keeps the previous set of addresses as the committed assignment, and
+// clients keep routing every repository there. The rebalancer then walks all
+// repositories in the order of their hash, has the new shard copy each moved
+// repository from the old one while the old one keeps serving it, and
+// advances a cursor past the copied repositories. Advancing the cursor flips
+// the assignment of those repositories to the new shard. The cursor never moves
+// past a repository which couldn't be copied, so removed instances must keep
+// running until the rebalance completes.
+package rebalance
+
+import (
+	"slices"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+// State is the assignment of repositories to gitserver shards.
+type State struct {
+	// Addresses is the set of gitserver addresses repositories were last
+	// fully assigned to.
+	Addresses []string
+	// TargetAddresses is the set of gitserver addresses repositories are
+	// being moved to. It is nil if no rebalance is in progress.
+	TargetAddresses []string
+	// Cursor is the hash of the first repository which hasn't been moved to
+	// TargetAddresses yet.
+	Cursor uint64
+}
+
+// InProgress returns true if repositories are being moved.
+func (s *State) InProgress() bool {
+	return s.TargetAddresses != nil
+}
+
+// ServiceConnection returns the routing information clients need while the
+// gitserver instances at current are being rebalanced, or nil if repos are
+// on the shards current assigns them to.
+//
+// If the gitserver instances changed but the rebalance hasn't started yet,
+// all repos keep being routed to the shards they are on.
+func (s *State) ServiceConnection(current []string) *conftypes.GitServersRebalance {
+	if s == nil {
+		// The assignment hasn't been recorded yet, so we can't know where
+		// repos are.
+		return nil
+	}
+
+	if s.InProgress() {
+		return &conftypes.GitServersRebalance{
+			Addresses:         s.TargetAddresses,
+			PreviousAddresses: s.Addresses,
+			Cursor:            s.Cursor,
+		}
+	}
+
+	if slices.Equal(s.Addresses, current) {
+		return nil
+	}
+
+	return &conftypes.GitServersRebalance{
+		Addresses:         current,
+		PreviousAddresses: s.Addresses,
+		Cursor:            0,
+	}
+}
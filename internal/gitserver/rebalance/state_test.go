@@ -0,0 +1,66 @@
+package rebalance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+func TestState_ServiceConnection(t *testing.T) {
+	previous := []string{"gitserver-1", "gitserver-2"}
+	current := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	for _, tc := range []struct {
+		name  string
+		state *State
+		want  *conftypes.GitServersRebalance
+	}{
+		{
+			name:  "not initialized",
+			state: nil,
+			want:  nil,
+		},
+		{
+			name:  "unchanged",
+			state: &State{Addresses: current},
+			want:  nil,
+		},
+		{
+			name:  "changed but not started",
+			state: &State{Addresses: previous},
+			want: &conftypes.GitServersRebalance{
+				Addresses:         current,
+				PreviousAddresses: previous,
+				Cursor:            0,
+			},
+		},
+		{
+			name:  "in progress",
+			state: &State{Addresses: previous, TargetAddresses: current, Cursor: 42},
+			want: &conftypes.GitServersRebalance{
+				Addresses:         current,
+				PreviousAddresses: previous,
+				Cursor:            42,
+			},
+		},
+		{
+			name: "changed again while in progress",
+			state: &State{
+				Addresses:       previous,
+				TargetAddresses: []string{"gitserver-1"},
+				Cursor:          42,
+			},
+			want: &conftypes.GitServersRebalance{
+				Addresses:         []string{"gitserver-1"},
+				PreviousAddresses: previous,
+				Cursor:            42,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.state.ServiceConnection(current))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package rebalance
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Store persists the assignment of repositories to gitserver shards.
+type Store interface {
+	// Get returns the current assignment, or nil if it hasn't been
+	// initialized yet.
+	Get(ctx context.Context) (*State, error)
+	// Initialize records addresses as the assignment if none has been
+	// recorded yet, and returns the current assignment.
+	Initialize(ctx context.Context, addresses []string) (*State, error)
+	// Start starts moving repositories to target. It fails if a rebalance is
+	// already in progress.
+	Start(ctx context.Context, target []string) error
+	// Advance flips the assignment of all repositories whose hash is lower
+	// than cursor to the target addresses.
+	Advance(ctx context.Context, cursor uint64) error
+	// Complete makes the target addresses the assignment of all
+	// repositories.
+	Complete(ctx context.Context) error
+}
+
+type store struct {
+	*basestore.Store
+}
+
+// NewStore returns a Store using the given database handle.
+func NewStore(handle basestore.TransactableHandle) Store {
+	return &store{Store: basestore.NewWithHandle(handle)}
+}
+
+var ErrRebalanceInProgress = errors.New("gitserver rebalance already in progress")
+
+func (s *store) Get(ctx context.Context) (*State, error) {
+	q := sqlf.Sprintf(`SELECT addresses, target_addresses, rebalance_cursor FROM gitserver_shard_assignment WHERE id = 1`)
+
+	var (
+		state  State
+		cursor int64
+	)
+	err := s.QueryRow(ctx, q).Scan(pq.Array(&state.Addresses), pq.Array(&state.TargetAddresses), &cursor)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// The cursor is an unsigned hash stored in a signed column.
+	state.Cursor = uint64(cursor)
+
+	return &state, nil
+}
+
+func (s *store) Initialize(ctx context.Context, addresses []string) (*State, error) {
+	q := sqlf.Sprintf(`INSERT INTO gitserver_shard_assignment (id, addresses) VALUES (1, %s) ON CONFLICT (id) DO NOTHING`, pq.Array(addresses))
+	if err := s.Exec(ctx, q); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx)
+}
+
+func (s *store) Start(ctx context.Context, target []string) error {
+	q := sqlf.Sprintf(`
+UPDATE gitserver_shard_assignment
+SET target_addresses = %s, rebalance_cursor = 0, updated_at = NOW()
+WHERE id = 1 AND target_addresses IS NULL
+`, pq.Array(target))
+
+	res, err := s.ExecResult(ctx, q)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRebalanceInProgress
+	}
+	return nil
+}
+
+func (s *store) Advance(ctx context.Context, cursor uint64) error {
+	q := sqlf.Sprintf(`
+UPDATE gitserver_shard_assignment
+SET rebalance_cursor = %s, updated_at = NOW()
+WHERE id = 1 AND target_addresses IS NOT NULL
+`, int64(cursor))
+	return s.Exec(ctx, q)
+}
+
+func (s *store) Complete(ctx context.Context) error {
+	q := sqlf.Sprintf(`
+UPDATE gitserver_shard_assignment
+SET addresses = target_addresses, target_addresses = NULL, rebalance_cursor = 0, updated_at = NOW()
+WHERE id = 1 AND target_addresses IS NOT NULL
+`)
+	return s.Exec(ctx, q)
+}
@@ -0,0 +1,53 @@
+package rebalance
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	s := NewStore(basestore.NewHandleWithDB(logger, dbtest.NewDB(t), sql.TxOptions{}))
+
+	state, err := s.Get(ctx)
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	previous := []string{"gitserver-1", "gitserver-2"}
+	current := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	state, err = s.Initialize(ctx, previous)
+	require.NoError(t, err)
+	require.Equal(t, &State{Addresses: previous}, state)
+
+	// Initializing again keeps the recorded assignment.
+	state, err = s.Initialize(ctx, current)
+	require.NoError(t, err)
+	require.Equal(t, &State{Addresses: previous}, state)
+
+	require.NoError(t, s.Start(ctx, current))
+	require.ErrorIs(t, s.Start(ctx, current), ErrRebalanceInProgress)
+
+	// Cursors beyond the range of a signed integer survive the round trip.
+	require.NoError(t, s.Advance(ctx, math.MaxUint64-1))
+	state, err = s.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &State{Addresses: previous, TargetAddresses: current, Cursor: math.MaxUint64 - 1}, state)
+
+	require.NoError(t, s.Complete(ctx))
+	state, err = s.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &State{Addresses: current}, state)
+}
@@ -19,9 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GitserverRepositoryService_DeleteRepository_FullMethodName = "/gitserver.v1.GitserverRepositoryService/DeleteRepository"
-	GitserverRepositoryService_FetchRepository_FullMethodName  = "/gitserver.v1.GitserverRepositoryService/FetchRepository"
-	GitserverRepositoryService_ListRepositories_FullMethodName = "/gitserver.v1.GitserverRepositoryService/ListRepositories"
+	GitserverRepositoryService_DeleteRepository_FullMethodName        = "/gitserver.v1.GitserverRepositoryService/DeleteRepository"
+	GitserverRepositoryService_FetchRepository_FullMethodName         = "/gitserver.v1.GitserverRepositoryService/FetchRepository"
+	GitserverRepositoryService_ListRepositories_FullMethodName        = "/gitserver.v1.GitserverRepositoryService/ListRepositories"
+	GitserverRepositoryService_ExportRepository_FullMethodName        = "/gitserver.v1.GitserverRepositoryService/ExportRepository"
+	GitserverRepositoryService_CopyRepositoryFromShard_FullMethodName = "/gitserver.v1.GitserverRepositoryService/CopyRepositoryFromShard"
 )
 
 // GitserverRepositoryServiceClient is the client API for GitserverRepositoryService service.
@@ -36,6 +38,19 @@ type GitserverRepositoryServiceClient interface {
 	FetchRepository(ctx context.Context, in *FetchRepositoryRequest, opts ...grpc.CallOption) (*FetchRepositoryResponse, error)
 	// ListRepositories returns a list of all repositories on disk.
 	ListRepositories(ctx context.Context, in *ListRepositoriesRequest, opts ...grpc.CallOption) (*ListRepositoriesResponse, error)
+	// ExportRepository streams a git bundle of all refs of a repository on disk.
+	// It is used by other gitserver shards to copy the repository when shards
+	// are rebalanced.
+	//
+	// If the repository is not cloned, an error with a RepoNotFoundPayload is
+	// returned.
+	ExportRepository(ctx context.Context, in *ExportRepositoryRequest, opts ...grpc.CallOption) (GitserverRepositoryService_ExportRepositoryClient, error)
+	// CopyRepositoryFromShard copies a repository from the gitserver shard at
+	// source_address to this shard, using ExportRepository. The source shard
+	// keeps serving the repository while it is copied.
+	//
+	// If the repository is already cloned on this shard, nothing is done.
+	CopyRepositoryFromShard(ctx context.Context, in *CopyRepositoryFromShardRequest, opts ...grpc.CallOption) (*CopyRepositoryFromShardResponse, error)
 }
 
 type gitserverRepositoryServiceClient struct {
@@ -73,6 +88,47 @@ func (c *gitserverRepositoryServiceClient) ListRepositories(ctx context.Context,
 	return out, nil
 }
 
+func (c *gitserverRepositoryServiceClient) ExportRepository(ctx context.Context, in *ExportRepositoryRequest, opts ...grpc.CallOption) (GitserverRepositoryService_ExportRepositoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverRepositoryService_ServiceDesc.Streams[0], GitserverRepositoryService_ExportRepository_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverRepositoryServiceExportRepositoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverRepositoryService_ExportRepositoryClient interface {
+	Recv() (*ExportRepositoryResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverRepositoryServiceExportRepositoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverRepositoryServiceExportRepositoryClient) Recv() (*ExportRepositoryResponse, error) {
+	m := new(ExportRepositoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitserverRepositoryServiceClient) CopyRepositoryFromShard(ctx context.Context, in *CopyRepositoryFromShardRequest, opts ...grpc.CallOption) (*CopyRepositoryFromShardResponse, error) {
+	out := new(CopyRepositoryFromShardResponse)
+	err := c.cc.Invoke(ctx, GitserverRepositoryService_CopyRepositoryFromShard_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GitserverRepositoryServiceServer is the server API for GitserverRepositoryService service.
 // All implementations must embed UnimplementedGitserverRepositoryServiceServer
 // for forward compatibility
@@ -85,6 +141,19 @@ type GitserverRepositoryServiceServer interface {
 	FetchRepository(context.Context, *FetchRepositoryRequest) (*FetchRepositoryResponse, error)
 	// ListRepositories returns a list of all repositories on disk.
 	ListRepositories(context.Context, *ListRepositoriesRequest) (*ListRepositoriesResponse, error)
+	// ExportRepository streams a git bundle of all refs of a repository on disk.
+	// It is used by other gitserver shards to copy the repository when shards
+	// are rebalanced.
+	//
+	// If the repository is not cloned, an error with a RepoNotFoundPayload is
+	// returned.
+	ExportRepository(*ExportRepositoryRequest, GitserverRepositoryService_ExportRepositoryServer) error
+	// CopyRepositoryFromShard copies a repository from the gitserver shard at
+	// source_address to this shard, using ExportRepository. The source shard
+	// keeps serving the repository while it is copied.
+	//
+	// If the repository is already cloned on this shard, nothing is done.
+	CopyRepositoryFromShard(context.Context, *CopyRepositoryFromShardRequest) (*CopyRepositoryFromShardResponse, error)
 	mustEmbedUnimplementedGitserverRepositoryServiceServer()
 }
 
@@ -101,6 +170,12 @@ func (UnimplementedGitserverRepositoryServiceServer) FetchRepository(context.Con
 func (UnimplementedGitserverRepositoryServiceServer) ListRepositories(context.Context, *ListRepositoriesRequest) (*ListRepositoriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRepositories not implemented")
 }
+func (UnimplementedGitserverRepositoryServiceServer) ExportRepository(*ExportRepositoryRequest, GitserverRepositoryService_ExportRepositoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRepository not implemented")
+}
+func (UnimplementedGitserverRepositoryServiceServer) CopyRepositoryFromShard(context.Context, *CopyRepositoryFromShardRequest) (*CopyRepositoryFromShardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyRepositoryFromShard not implemented")
+}
 func (UnimplementedGitserverRepositoryServiceServer) mustEmbedUnimplementedGitserverRepositoryServiceServer() {
 }
 
@@ -169,6 +244,45 @@ func _GitserverRepositoryService_ListRepositories_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GitserverRepositoryService_ExportRepository_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRepositoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverRepositoryServiceServer).ExportRepository(m, &gitserverRepositoryServiceExportRepositoryServer{stream})
+}
+
+type GitserverRepositoryService_ExportRepositoryServer interface {
+	Send(*ExportRepositoryResponse) error
+	grpc.ServerStream
+}
+
+type gitserverRepositoryServiceExportRepositoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverRepositoryServiceExportRepositoryServer) Send(m *ExportRepositoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitserverRepositoryService_CopyRepositoryFromShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyRepositoryFromShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitserverRepositoryServiceServer).CopyRepositoryFromShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GitserverRepositoryService_CopyRepositoryFromShard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitserverRepositoryServiceServer).CopyRepositoryFromShard(ctx, req.(*CopyRepositoryFromShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GitserverRepositoryService_ServiceDesc is the grpc.ServiceDesc for GitserverRepositoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -188,8 +302,18 @@ var GitserverRepositoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRepositories",
 			Handler:    _GitserverRepositoryService_ListRepositories_Handler,
 		},
+		{
+			MethodName: "CopyRepositoryFromShard",
+			Handler:    _GitserverRepositoryService_CopyRepositoryFromShard_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportRepository",
+			Handler:       _GitserverRepositoryService_ExportRepository_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gitserver.proto",
 }
 
@@ -445,27 +569,18 @@ type GitserverServiceClient interface {
 	// If no common merge base exists, an empty string is returned.
 	// See the following diagrams from git-merge-base docs on what octopus merge bases
 	// are:
-	// Given three commits A, B, and C, git merge-base A B C will compute the merge base between A and a hypothetical commit M, which is a merge between B and C. For example, with this topology:
-	//
-	//	 o---o---o---o---C
-	//	/
-	//
-	// /   o---o---o---B
-	// /   /
-	// ---2---1---o---o---o---A
+	// Given three commits A, B, and C, MergeBaseOctopus(A, B, C) will compute the
+	// best common ancestor of all commits.
+	// For example, with this topology:
 	//
-	// the result of git merge-base A B C is 1. This is because the equivalent topology with a merge commit M between B and C is:
+	//	       o---o---o---o---C
+	//	      /
+	//	     /   o---o---o---B
+	//	    /   /
+	//	---2---1---o---o---o---A
 	//
-	//	 o---o---o---o---o
-	//	/                 \
-	//
-	// /   o---o---o---o---M
-	// /   /
-	// ---2---1---o---o---o---A
-	//
-	// and the result of git merge-base A M is 1. Commit 2 is also a common ancestor between A and M, but 1 is a better common ancestor, because 2 is an ancestor of 1. Hence, 2 is not a merge base.
-	//
-	// The result of git merge-base --octopus A B C is 2, because 2 is the best common ancestor of all commits.
+	// The result of MergeBaseOctopus(A, B, C) is 2, because 2 is the
+	// best common ancestor of all commits.
 	//
 	// If the given repo is not cloned, it will be enqueued for cloning and a
 	// NotFound error will be returned, with a RepoNotFoundPayload in the details.
@@ -1225,27 +1340,18 @@ type GitserverServiceServer interface {
 	// If no common merge base exists, an empty string is returned.
 	// See the following diagrams from git-merge-base docs on what octopus merge bases
 	// are:
-	// Given three commits A, B, and C, git merge-base A B C will compute the merge base between A and a hypothetical commit M, which is a merge between B and C. For example, with this topology:
-	//
-	//	 o---o---o---o---C
-	//	/
-	//
-	// /   o---o---o---B
-	// /   /
-	// ---2---1---o---o---o---A
-	//
-	// the result of git merge-base A B C is 1. This is because the equivalent topology with a merge commit M between B and C is:
-	//
-	//	 o---o---o---o---o
-	//	/                 \
-	//
-	// /   o---o---o---o---M
-	// /   /
-	// ---2---1---o---o---o---A
-	//
-	// and the result of git merge-base A M is 1. Commit 2 is also a common ancestor between A and M, but 1 is a better common ancestor, because 2 is an ancestor of 1. Hence, 2 is not a merge base.
-	//
-	// The result of git merge-base --octopus A B C is 2, because 2 is the best common ancestor of all commits.
+	// Given three commits A, B, and C, MergeBaseOctopus(A, B, C) will compute the
+	// best common ancestor of all commits.
+	// For example, with this topology:
+	//
+	//	       o---o---o---o---C
+	//	      /
+	//	     /   o---o---o---B
+	//	    /   /
+	//	---2---1---o---o---o---A
+	//
+	// The result of MergeBaseOctopus(A, B, C) is 2, because 2 is the
+	// best common ancestor of all commits.
 	//
 	// If the given repo is not cloned, it will be enqueued for cloning and a
 	// NotFound error will be returned, with a RepoNotFoundPayload in the details.
@@ -2,6 +2,7 @@ package aggregation
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -70,6 +71,95 @@ func countPath(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+// PathPrefixCountFunc returns an AggregationCountFunc which groups file
+// matches by the first depth directories of their path. Files with fewer
+// directories are grouped by their parent directory, and files at the root of
+// a repository are grouped under "/".
+func PathPrefixCountFunc(depth int) AggregationCountFunc {
+	return func(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
+		fm, ok := r.(*result.FileMatch)
+		if !ok || fm.Path == "" {
+			return nil, nil
+		}
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  pathPrefix(fm.Path, depth),
+		}: r.ResultCount()}, nil
+	}
+}
+
+func pathPrefix(path string, depth int) string {
+	dirs := strings.Split(path, "/")
+	dirs = dirs[:len(dirs)-1]
+	if len(dirs) == 0 {
+		return "/"
+	}
+	if len(dirs) > depth {
+		dirs = dirs[:depth]
+	}
+	return strings.Join(dirs, "/") + "/"
+}
+
+func countLanguage(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
+	var language string
+	switch match := r.(type) {
+	case *result.FileMatch:
+		language = match.MostLikelyLanguage()
+	default:
+	}
+	if language != "" {
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  language,
+		}: r.ResultCount()}, nil
+	}
+	return nil, nil
+}
+
+// countOwner groups owner matches by owner. Other matches are not counted, so
+// queries need to select the owners of the files they match
+// (select:file.owners) for this mode to count anything.
+func countOwner(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
+	var owner string
+	switch match := r.(type) {
+	case *result.OwnerMatch:
+		owner = ownerLabel(match.ResolvedOwner)
+	default:
+	}
+	if owner != "" {
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  owner,
+		}: r.ResultCount()}, nil
+	}
+	return nil, nil
+}
+
+// ownerLabel returns the name an owner is displayed with: the team name for
+// teams, and the handle or else the email for people.
+func ownerLabel(o result.Owner) string {
+	switch owner := o.(type) {
+	case *result.OwnerPerson:
+		if owner.Handle != "" {
+			return owner.Handle
+		}
+		return owner.Email
+	case *result.OwnerTeam:
+		if owner.Team != nil {
+			return owner.Team.Name
+		}
+		if owner.Handle != "" {
+			return owner.Handle
+		}
+		return owner.Email
+	default:
+		return ""
+	}
+}
+
 func countAuthor(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
 	var author string
 	switch match := r.(type) {
@@ -173,6 +263,8 @@ func GetCountFuncForMode(query, patternType string, mode types.SearchAggregation
 		types.PATH_AGGREGATION_MODE:          countPath,
 		types.AUTHOR_AGGREGATION_MODE:        countAuthor,
 		types.REPO_METADATA_AGGREGATION_MODE: countRepoMetadata,
+		types.LANGUAGE_AGGREGATION_MODE:      countLanguage,
+		types.OWNER_AGGREGATION_MODE:         countOwner,
 	}
 
 	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
@@ -446,6 +446,170 @@ func TestPathAggregation(t *testing.T) {
 	}
 }
 
+func TestPathPrefixAggregation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		depth       int
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{
+			"No results",
+			1, streaming.SearchEvent{}, autogold.Expect(map[string]int{})},
+		{
+			"Files at the root",
+			1,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "file2.go", 1),
+				},
+			},
+			autogold.Expect(map[string]int{"/": 3}),
+		},
+		{
+			"Group by top level directory",
+			1,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/a/main.go", 1, "a", "b"),
+					contentMatch("myRepo", "cmd/b/main.go", 1, "c"),
+					contentMatch("myRepoB", "internal/c.go", 2, "d"),
+				},
+			},
+			autogold.Expect(map[string]int{"cmd/": 3, "internal/": 1}),
+		},
+		{
+			"Group by nested directory",
+			2,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/a/main.go", 1, "a", "b"),
+					contentMatch("myRepo", "cmd/b/main.go", 1, "c"),
+					contentMatch("myRepoB", "internal/c.go", 2, "d"),
+				},
+			},
+			autogold.Expect(map[string]int{"cmd/a/": 2, "cmd/b/": 1, "internal/": 1}),
+		},
+		{
+			"no path on repo match",
+			1,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Expect(map[string]int{}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc := PathPrefixCountFunc(tc.depth)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc, types.PATH_AGGREGATION_MODE, nil)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestLanguageAggregation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{
+			"No results",
+			types.LANGUAGE_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Expect(map[string]int{})},
+		{
+			"no language for commit",
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+				},
+			},
+			autogold.Expect(map[string]int{}),
+		},
+		{
+			"Count languages on multiple match types",
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					pathMatch("myRepo", "file1.go", 1),
+					symbolMatch("myRepo", "file2.go", 1, "c", "d"),
+					contentMatch("myRepoB", "file.py", 2, "a", "b"),
+				},
+			},
+			autogold.Expect(map[string]int{"Go": 3, "Python": 2}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc, tc.mode, nil)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestOwnerAggregation(t *testing.T) {
+	ownerMatch := func(repo string, repoID int32, owner result.Owner) result.Match {
+		return &result.OwnerMatch{
+			ResolvedOwner: owner,
+			Repo:          internaltypes.MinimalRepo{Name: api.RepoName(repo), ID: api.RepoID(repoID)},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{
+			"No results",
+			types.OWNER_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Expect(map[string]int{})},
+		{
+			"no owner on file match",
+			types.OWNER_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+				},
+			},
+			autogold.Expect(map[string]int{}),
+		},
+		{
+			"Count people and teams",
+			types.OWNER_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					ownerMatch("myRepo", 1, &result.OwnerPerson{Handle: "alice"}),
+					ownerMatch("myRepoB", 2, &result.OwnerPerson{Handle: "alice"}),
+					ownerMatch("myRepo", 1, &result.OwnerPerson{Email: "bob@example.com"}),
+					ownerMatch("myRepo", 1, &result.OwnerTeam{Handle: "team-handle", Team: &internaltypes.Team{Name: "search"}}),
+				},
+			},
+			autogold.Expect(map[string]int{"alice": 2, "bob@example.com": 1, "search": 1}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc, tc.mode, nil)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
 func TestCaptureGroupAggregation(t *testing.T) {
 	longCaptureGroup := "111111111|222222222|333333333|444444444|555555555|666666666|777777777|888888888|999999999|000000000|"
 	testCases := []struct {
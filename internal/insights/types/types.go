@@ -214,8 +214,13 @@ const (
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
 	REPO_METADATA_AGGREGATION_MODE SearchAggregationMode = "REPO_METADATA"
+	LANGUAGE_AGGREGATION_MODE      SearchAggregationMode = "LANGUAGE"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
 )
 
+// SearchAggregationModes are the modes offered by the search aggregations
+// GraphQL API. LANGUAGE and OWNER are only available to the stats mode of the
+// streaming search API.
 var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, REPO_METADATA_AGGREGATION_MODE}
 
 type AggregationNotAvailableReasonType string
@@ -0,0 +1,105 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/subversion"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A SubversionSource yields repositories from a single Subversion connection
+// configured in Sourcegraph via the external services configuration.
+type SubversionSource struct {
+	svc    *types.ExternalService
+	config *schema.SubversionConnection
+}
+
+// NewSubversionSource returns a new SubversionSource from the given external
+// service.
+func NewSubversionSource(ctx context.Context, svc *types.ExternalService) (*SubversionSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.SubversionConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return &SubversionSource{svc: svc, config: &c}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors
+// returned when gitserver clones the repositories.
+func (s SubversionSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Subversion repositories configured in the connection.
+func (s SubversionSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	baseURL, err := url.Parse(s.config.Url)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: errors.Wrap(err, "parsing url")}
+		return
+	}
+	// Credentials are added by gitserver when it clones a repository.
+	baseURL.User = nil
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		baseURL.Path += "/"
+	}
+
+	for _, path := range s.config.Repos {
+		if err := ctx.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+
+		repoURL, err := baseURL.Parse(path)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "parsing repository path %q", path)}
+			continue
+		}
+		results <- SourceResult{Source: s, Repo: s.makeRepo(baseURL, repoURL, path)}
+	}
+}
+
+func (s SubversionSource) makeRepo(baseURL, repoURL *url.URL, path string) *types.Repo {
+	urn := s.svc.URN()
+	// file:// URLs don't have a host, so we make sure the name doesn't start
+	// with a slash.
+	base := strings.TrimPrefix(baseURL.Host+baseURL.Path, "/")
+	path = strings.Trim(path, "/")
+	cloneURL := strings.TrimSuffix(repoURL.String(), "/")
+
+	return &types.Repo{
+		Name: api.RepoName(reposource.OtherRepoName(s.config.RepositoryPathPattern, base, path)),
+		URI:  reposource.OtherRepoName("", base, path),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          path,
+			ServiceType: extsvc.TypeSubversion,
+			ServiceID:   baseURL.String(),
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: &subversion.Repository{
+			URL:  cloneURL,
+			Path: path,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s SubversionSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
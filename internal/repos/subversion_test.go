@@ -0,0 +1,88 @@
+package repos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/subversion"
+	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSubversionSource_ListRepos(t *testing.T) {
+	type repo struct {
+		name     api.RepoName
+		cloneURL string
+		metadata *subversion.Repository
+	}
+
+	testCases := []struct {
+		name string
+		conf *schema.SubversionConnection
+		want []repo
+	}{
+		{
+			name: "https",
+			conf: &schema.SubversionConnection{
+				Url:      "https://svn.example.com/svn",
+				Username: "admin",
+				Password: "pa$$word",
+				Repos:    []string{"project", "legacy/project/"},
+			},
+			want: []repo{
+				{
+					name:     "svn.example.com/svn/project",
+					cloneURL: "https://svn.example.com/svn/project",
+					metadata: &subversion.Repository{URL: "https://svn.example.com/svn/project", Path: "project"},
+				},
+				{
+					name:     "svn.example.com/svn/legacy/project",
+					cloneURL: "https://svn.example.com/svn/legacy/project",
+					metadata: &subversion.Repository{URL: "https://svn.example.com/svn/legacy/project", Path: "legacy/project"},
+				},
+			},
+		},
+		{
+			name: "file with path pattern",
+			conf: &schema.SubversionConnection{
+				Url:                   "file:///srv/svn/",
+				Repos:                 []string{"project"},
+				RepositoryPathPattern: "svn/{repo}",
+			},
+			want: []repo{
+				{
+					name:     "svn/project",
+					cloneURL: "file:///srv/svn/project",
+					metadata: &subversion.Repository{URL: "file:///srv/svn/project", Path: "project"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := typestest.MakeExternalService(t, extsvc.VariantSubversion, tc.conf)
+
+			src, err := NewSubversionSource(context.Background(), svc)
+			require.NoError(t, err)
+
+			repos, err := ListAll(context.Background(), src)
+			require.NoError(t, err)
+
+			var have []repo
+			for _, r := range repos {
+				require.Equal(t, extsvc.TypeSubversion, r.ExternalRepo.ServiceType)
+				have = append(have, repo{
+					name:     r.Name,
+					cloneURL: r.Sources[svc.URN()].CloneURL,
+					metadata: r.Metadata.(*subversion.Repository),
+				})
+			}
+			require.Equal(t, tc.want, have)
+		})
+	}
+}
@@ -103,6 +103,14 @@ func (s *searchClient) Plan(
 		return nil, errors.Wrap(err, "failed to resolve user settings")
 	}
 
+	expandedQuery, err := query.ExpandMacros(searchQuery, searchType, settings.SearchMacros)
+	if err != nil {
+		return nil, &QueryError{Query: searchQuery, Err: err}
+	}
+	if expandedQuery != searchQuery {
+		tr.AddEvent("expanded macros", attribute.String("query", expandedQuery))
+	}
+
 	// Beta: create a step to replace each context in the query with its repository query if any.
 	searchContextsQueryEnabled := settings.ExperimentalFeatures != nil && getBoolPtr(settings.ExperimentalFeatures.SearchContextsQuery, true)
 	substituteContextsStep := query.SubstituteSearchContexts(func(context string) (string, error) {
@@ -116,7 +124,7 @@ func (s *searchClient) Plan(
 
 	var plan query.Plan
 	plan, err = query.Pipeline(
-		query.Init(searchQuery, searchType),
+		query.Init(expandedQuery, searchType),
 		query.With(searchContextsQueryEnabled, substituteContextsStep),
 	)
 	if err != nil {
@@ -247,6 +255,10 @@ func SearchTypeFromString(patternType string) (query.SearchType, error) {
 		return query.SearchTypeCodyContext, nil
 	case "keyword":
 		return query.SearchTypeKeyword, nil
+	case "treesitter":
+		return query.SearchTypeTreeSitter, nil
+	case "fuzzy":
+		return query.SearchTypeFuzzy, nil
 	default:
 		return -1, errors.Errorf("unrecognized patternType %q", patternType)
 	}
@@ -299,6 +311,10 @@ func overrideSearchType(input string, searchType query.SearchType) query.SearchT
 			searchType = query.SearchTypeCodyContext
 		case "keyword":
 			searchType = query.SearchTypeKeyword
+		case "treesitter":
+			searchType = query.SearchTypeTreeSitter
+		case "fuzzy":
+			searchType = query.SearchTypeFuzzy
 		}
 	})
 	return searchType
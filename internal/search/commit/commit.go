@@ -301,6 +301,17 @@ func queryParameterToPredicate(parameter query.Parameter, caseSensitive, diff bo
 		newPred = &gitprotocol.CommitAfter{Time: t}
 	case query.FieldMessage:
 		newPred = &gitprotocol.MessageMatches{Expr: parameter.Value, IgnoreCase: !caseSensitive}
+	case query.FieldSigned:
+		switch strings.ToLower(parameter.Value) { // field already validated
+		case "yes":
+			newPred = &gitprotocol.CommitSigned{Signed: gitprotocol.SignedYes}
+		case "no":
+			newPred = &gitprotocol.CommitSigned{Signed: gitprotocol.SignedNo}
+		case "invalid":
+			newPred = &gitprotocol.CommitSigned{Signed: gitprotocol.SignedInvalid}
+		case "untrusted":
+			newPred = &gitprotocol.CommitSigned{Signed: gitprotocol.SignedUntrusted}
+		}
 	case query.FieldContent:
 		if diff {
 			newPred = &gitprotocol.DiffMatches{Expr: parameter.Value, IgnoreCase: !caseSensitive}
@@ -346,6 +357,7 @@ func protocolMatchToCommitMatch(repo types.MinimalRepo, diff bool, in protocol.C
 			Parents: in.Parents,
 		},
 		Repo:           repo,
+		SourceRefs:     in.SourceRefs,
 		DiffPreview:    diffPreview,
 		Diff:           structuredDiff,
 		MessagePreview: messagePreview,
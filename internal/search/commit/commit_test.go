@@ -51,6 +51,7 @@ func TestQueryToGitQuery(t *testing.T) {
 				{Field: query.FieldAfter, Value: "2021-09-08"},
 				{Field: query.FieldFile, Value: "file"},
 				{Field: query.FieldMessage, Value: "message1"},
+				{Field: query.FieldSigned, Value: "yes"},
 			},
 			Pattern: query.Pattern{Value: "message2"},
 		},
@@ -58,6 +59,7 @@ func TestQueryToGitQuery(t *testing.T) {
 		output: protocol.NewAnd(
 			&protocol.CommitBefore{Time: time.Date(2021, 9, 10, 0, 0, 0, 0, time.UTC)},
 			&protocol.CommitAfter{Time: time.Date(2021, 9, 8, 0, 0, 0, 0, time.UTC)},
+			&protocol.CommitSigned{Signed: protocol.SignedYes},
 			&protocol.AuthorMatches{Expr: "author", IgnoreCase: true},
 			&protocol.CommitterMatches{Expr: "committer", IgnoreCase: true},
 			&protocol.MessageMatches{Expr: "message1", IgnoreCase: true},
@@ -7,6 +7,7 @@ import (
 )
 
 const (
+	Capture    = "capture"
 	Commit     = "commit"
 	Content    = "content"
 	File       = "file"
@@ -78,6 +79,13 @@ var validSelectors = object{
 }
 
 func SelectPathFromString(s string) (SelectPath, error) {
+	// Tree-sitter capture names are free-form and may contain dots, e.g.
+	// select:capture.function.name selects the nodes captured as
+	// @function.name.
+	if name, ok := strings.CutPrefix(s, Capture+"."); ok && name != "" {
+		return SelectPath{Capture, name}, nil
+	}
+
 	fields := strings.Split(s, ".")
 	cur := validSelectors
 	for _, field := range fields {
@@ -0,0 +1,337 @@
+package jobutil
+
+import (
+	"context"
+	"regexp/syntax" //nolint:depguard // zoekt requires this pkg
+	"sync"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/sourcegraph/zoekt"
+	zoektquery "github.com/sourcegraph/zoekt/query"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/internal/trace/policy"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileHasSymbolsJob creates a filter job to post-filter results for the
+// file:has.symbol() predicate.
+//
+// A file match is kept only if it defines at least one symbol matching every
+// include pattern and no symbol matching any exclude pattern.
+func NewFileHasSymbolsJob(child job.Job, include, exclude []string, caseSensitive bool) job.Job {
+	return &fileHasSymbolsJob{
+		child:         child,
+		include:       include,
+		exclude:       exclude,
+		caseSensitive: caseSensitive,
+	}
+}
+
+// maxConcurrentSymbolRequests is the maximum number of symbols requests sent
+// at the same time for the file matches of one event.
+const maxConcurrentSymbolRequests = 16
+
+type symbolSearchFunc func(context.Context, search.SymbolsParameters) (result.Symbols, bool, error)
+
+type fileHasSymbolsJob struct {
+	child job.Job
+
+	include       []string
+	exclude       []string
+	caseSensitive bool
+
+	// searchSymbols is used to look up symbols for a file in a repository
+	// zoekt hasn't indexed symbols for. If nil, symbols.DefaultClient is used.
+	searchSymbols symbolSearchFunc
+}
+
+func (j *fileHasSymbolsJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer finish(alert, err)
+
+	searchSymbols := j.searchSymbols
+	if searchSymbols == nil {
+		searchSymbols = func(ctx context.Context, args search.SymbolsParameters) (result.Symbols, bool, error) {
+			return symbols.DefaultClient.Search(ctx, args)
+		}
+	}
+
+	// We only list the indexed repositories once per search. If zoekt isn't
+	// available, every file is looked up with the symbols service.
+	listIndexed := sync.OnceValue(func() zoekt.ReposMap {
+		if clients.Zoekt == nil {
+			return nil
+		}
+		list, err := search.ListAllIndexed(ctx, clients.Zoekt)
+		if err != nil {
+			return nil
+		}
+		return list.ReposMap
+	})
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		keep := make([]bool, len(event.Results))
+		p := pool.New().WithMaxGoroutines(maxConcurrentSymbolRequests)
+		for _, c := range groupByCommit(event.Results) {
+			// We should quit early on context deadline exceeded.
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				mu.Lock()
+				errs = errors.Append(errs, ctx.Err())
+				mu.Unlock()
+				break
+			}
+
+			// If zoekt has indexed the symbols of the commit, we look up every
+			// pattern for all files of the commit with one zoekt query.
+			// Otherwise every file needs its own symbols request.
+			if branch := indexedSymbolsBranch(listIndexed(), c.repo, c.commit); branch != "" {
+				p.Go(func() {
+					matches, err := j.matchesIndexed(ctx, clients.Zoekt, branch, c)
+					if err != nil {
+						mu.Lock()
+						errs = errors.Append(errs, err)
+						mu.Unlock()
+						return
+					}
+					for k, i := range c.indices {
+						keep[i] = matches[k]
+					}
+				})
+				continue
+			}
+
+			for k, i := range c.indices {
+				fm := c.files[k]
+				p.Go(func() {
+					matches, err := j.matches(ctx, searchSymbols, fm)
+					if err != nil {
+						mu.Lock()
+						errs = errors.Append(errs, err)
+						mu.Unlock()
+						return
+					}
+					keep[i] = matches
+				})
+			}
+		}
+		p.Wait()
+
+		filtered := event.Results[:0]
+		for i, res := range event.Results {
+			if keep[i] {
+				filtered = append(filtered, res)
+			}
+		}
+		event.Results = filtered
+
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// commitFiles are the file matches of an event at one commit of a repository.
+type commitFiles struct {
+	repo   types.MinimalRepo
+	commit api.CommitID
+	files  []*result.FileMatch
+
+	// indices are the positions of files in the results of the event.
+	indices []int
+}
+
+// groupByCommit groups the file matches in results by repository and commit.
+// Results that are not files are dropped.
+func groupByCommit(results result.Matches) []*commitFiles {
+	type key struct {
+		repo   api.RepoID
+		commit api.CommitID
+	}
+	groups := map[key]*commitFiles{}
+	var ordered []*commitFiles
+	for i, res := range results {
+		// Filter out any result that is not a file
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		k := key{repo: fm.Repo.ID, commit: fm.CommitID}
+		c, ok := groups[k]
+		if !ok {
+			c = &commitFiles{repo: fm.Repo, commit: fm.CommitID}
+			groups[k] = c
+			ordered = append(ordered, c)
+		}
+		c.files = append(c.files, fm)
+		c.indices = append(c.indices, i)
+	}
+	return ordered
+}
+
+// indexedSymbolsBranch returns the branch zoekt has indexed the symbols of
+// repo at commit for, or an empty string if there is none.
+func indexedSymbolsBranch(indexed zoekt.ReposMap, repo types.MinimalRepo, commit api.CommitID) string {
+	r, ok := indexed[uint32(repo.ID)]
+	if !ok || !r.HasSymbols {
+		return ""
+	}
+	for _, b := range r.Branches {
+		if b.Version == string(commit) {
+			return b.Name
+		}
+	}
+	return ""
+}
+
+// matchesIndexed returns for each file of c whether it passes all include and
+// exclude patterns, using one zoekt query per pattern.
+func (j *fileHasSymbolsJob) matchesIndexed(ctx context.Context, z zoekt.Searcher, branch string, c *commitFiles) ([]bool, error) {
+	matches := make([]bool, len(c.files))
+	for i := range matches {
+		matches[i] = true
+	}
+
+	filter := func(pattern string, want bool) error {
+		found, err := j.indexedFilesWithSymbol(ctx, z, branch, c, pattern)
+		if err != nil {
+			return err
+		}
+		for i, fm := range c.files {
+			if _, ok := found[fm.Path]; ok != want {
+				matches[i] = false
+			}
+		}
+		return nil
+	}
+
+	for _, pattern := range j.exclude {
+		if err := filter(pattern, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, pattern := range j.include {
+		if err := filter(pattern, true); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// indexedFilesWithSymbol returns the paths of the files of c that define a
+// symbol matching pattern according to zoekt.
+func (j *fileHasSymbolsJob) indexedFilesWithSymbol(ctx context.Context, z zoekt.Searcher, branch string, c *commitFiles, pattern string) (map[string]struct{}, error) {
+	expr, err := syntax.Parse(pattern, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(c.files))
+	for _, fm := range c.files {
+		paths = append(paths, fm.Path)
+	}
+
+	q := zoektquery.NewAnd(
+		zoektquery.NewSingleBranchesRepos(branch, uint32(c.repo.ID)),
+		zoektquery.NewFileNameSet(paths...),
+		&zoektquery.Symbol{Expr: &zoektquery.Regexp{Regexp: expr, Content: true, CaseSensitive: j.caseSensitive}},
+	)
+	resp, err := z.Search(ctx, q, &zoekt.SearchOptions{
+		Trace:              policy.ShouldTrace(ctx),
+		MaxDocDisplayCount: len(paths),
+		ChunkMatches:       true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "zoekt symbol search")
+	}
+
+	found := make(map[string]struct{}, len(resp.Files))
+	for _, file := range resp.Files {
+		// The index may have moved on since we listed it.
+		if file.Version != string(c.commit) {
+			continue
+		}
+		found[file.FileName] = struct{}{}
+	}
+	return found, nil
+}
+
+// matches returns true if the file match passes all include and exclude
+// patterns.
+func (j *fileHasSymbolsJob) matches(ctx context.Context, searchSymbols symbolSearchFunc, fm *result.FileMatch) (bool, error) {
+	for _, pattern := range j.exclude {
+		found, err := fileHasSymbol(ctx, searchSymbols, fm, pattern, j.caseSensitive)
+		if err != nil || found {
+			return false, err
+		}
+	}
+	for _, pattern := range j.include {
+		found, err := fileHasSymbol(ctx, searchSymbols, fm, pattern, j.caseSensitive)
+		if err != nil || !found {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func fileHasSymbol(ctx context.Context, searchSymbols symbolSearchFunc, fm *result.FileMatch, pattern string, caseSensitive bool) (bool, error) {
+	res, _, err := searchSymbols(ctx, search.SymbolsParameters{
+		Repo:            fm.Repo.Name,
+		CommitID:        fm.CommitID,
+		Query:           pattern,
+		IsRegExp:        true,
+		IsCaseSensitive: caseSensitive,
+		IncludePatterns: []string{"^" + regexp.QuoteMeta(fm.Path) + "$"},
+		First:           1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(res) > 0, nil
+}
+
+func (j *fileHasSymbolsJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+func (j *fileHasSymbolsJob) Name() string {
+	return "FileHasSymbolsFilterJob"
+}
+
+func (j *fileHasSymbolsJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasSymbolsJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			attribute.StringSlice("includeSymbols", j.include),
+			attribute.StringSlice("excludeSymbols", j.exclude),
+			attribute.Bool("caseSensitive", j.caseSensitive),
+		)
+	}
+	return res
+}
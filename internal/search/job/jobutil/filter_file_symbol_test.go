@@ -0,0 +1,266 @@
+package jobutil
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/zoekt"
+	zoektquery "github.com/sourcegraph/zoekt/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileHasSymbolsJob(t *testing.T) {
+	r := func(ms ...result.Match) (res result.Matches) {
+		for _, m := range ms {
+			res = append(res, m)
+		}
+		return res
+	}
+
+	fm := func() *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Path:     "server.go",
+				CommitID: "commitID",
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		include     []string
+		exclude     []string
+		matches     result.Match
+		symbols     []string
+		outputEvent streaming.SearchEvent
+	}{{
+		name:        "include matches",
+		include:     []string{"NewServer"},
+		matches:     fm(),
+		symbols:     []string{"NewServer", "Serve"},
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include has no matches",
+		include:     []string{"NewClient"},
+		matches:     fm(),
+		symbols:     []string{"NewServer", "Serve"},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "exclude matches",
+		exclude:     []string{"NewServer"},
+		matches:     fm(),
+		symbols:     []string{"NewServer", "Serve"},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "exclude has no matches",
+		exclude:     []string{"NewClient"},
+		matches:     fm(),
+		symbols:     []string{"NewServer", "Serve"},
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "not every include matches",
+		include:     []string{"NewServer", "NewClient"},
+		matches:     fm(),
+		symbols:     []string{"NewServer", "Serve"},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "include and exclude each match",
+		include:     []string{"NewServer"},
+		exclude:     []string{"^Serve$"},
+		matches:     fm(),
+		symbols:     []string{"NewServer", "Serve"},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "not all matches are files",
+		include:     []string{"NewServer"},
+		matches:     &result.CommitMatch{},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: r(tc.matches)})
+				return nil, nil
+			})
+
+			searchSymbols := func(_ context.Context, args search.SymbolsParameters) (res result.Symbols, _ bool, _ error) {
+				require.Equal(t, []string{`^server\.go$`}, args.IncludePatterns)
+				re := regexp.MustCompile(args.Query)
+				for _, name := range tc.symbols {
+					if re.MatchString(name) {
+						res = append(res, result.Symbol{Name: name, Path: "server.go"})
+					}
+				}
+				return res, false, nil
+			}
+
+			var resultEvent streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				resultEvent = ev
+			})
+
+			j := NewFileHasSymbolsJob(childJob, tc.include, tc.exclude, false).(*fileHasSymbolsJob)
+			j.searchSymbols = searchSymbols
+			alert, err := j.Run(context.Background(), job.RuntimeClients{}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputEvent, resultEvent)
+		})
+	}
+
+	t.Run("keeps order of files", func(t *testing.T) {
+		file := func(path string) *result.FileMatch {
+			return &result.FileMatch{File: result.File{Path: path, CommitID: "commitID"}}
+		}
+		symbolsByPath := map[string][]string{
+			"a.go": {"NewServer"},
+			"b.go": {"NewClient"},
+			"c.go": {"NewServer"},
+			"d.go": {"NewServer"},
+		}
+
+		childJob := mockjob.NewMockJob()
+		childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: r(file("a.go"), file("b.go"), &result.CommitMatch{}, file("c.go"), file("d.go"))})
+			return nil, nil
+		})
+
+		j := NewFileHasSymbolsJob(childJob, []string{"NewServer"}, nil, false).(*fileHasSymbolsJob)
+		j.searchSymbols = func(_ context.Context, args search.SymbolsParameters) (res result.Symbols, _ bool, _ error) {
+			re := regexp.MustCompile(args.Query)
+			for path, names := range symbolsByPath {
+				if !regexp.MustCompile(args.IncludePatterns[0]).MatchString(path) {
+					continue
+				}
+				for _, name := range names {
+					if re.MatchString(name) {
+						res = append(res, result.Symbol{Name: name, Path: path})
+					}
+				}
+			}
+			return res, false, nil
+		}
+
+		var resultEvent streaming.SearchEvent
+		alert, err := j.Run(context.Background(), job.RuntimeClients{}, streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			resultEvent = ev
+		}))
+		require.Nil(t, alert)
+		require.NoError(t, err)
+		require.Equal(t, r(file("a.go"), file("c.go"), file("d.go")), resultEvent.Results)
+	})
+}
+
+func TestFileHasSymbolsJobIndexed(t *testing.T) {
+	file := func(repo api.RepoID, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{
+			Repo:     types.MinimalRepo{ID: repo, Name: api.RepoName(fmt.Sprintf("repo%d", repo))},
+			Path:     path,
+			CommitID: "indexed",
+		}}
+	}
+
+	// Repository 1 is indexed at the commit of the file matches, repository
+	// 2 isn't.
+	index := &fakeSymbolIndex{
+		repo:   1,
+		commit: "indexed",
+		symbols: map[string][]string{
+			"a.go": {"NewServer"},
+			"b.go": {"NewClient"},
+			"c.go": {"NewServer", "NewClient"},
+		},
+	}
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{file(1, "a.go"), file(1, "b.go"), file(2, "a.go"), file(1, "c.go")}})
+		return nil, nil
+	})
+
+	var unindexed []string
+	j := NewFileHasSymbolsJob(childJob, []string{"NewServer"}, []string{"NewClient"}, false).(*fileHasSymbolsJob)
+	j.searchSymbols = func(_ context.Context, args search.SymbolsParameters) (result.Symbols, bool, error) {
+		unindexed = append(unindexed, fmt.Sprintf("%s %s", args.Repo, args.Query))
+		if regexp.MustCompile(args.Query).MatchString("NewServer") {
+			return result.Symbols{{Name: "NewServer", Path: "a.go"}}, false, nil
+		}
+		return nil, false, nil
+	}
+
+	var resultEvent streaming.SearchEvent
+	alert, err := j.Run(context.Background(), job.RuntimeClients{Zoekt: index}, streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		resultEvent = ev
+	}))
+	require.Nil(t, alert)
+	require.NoError(t, err)
+	require.Equal(t, result.Matches{file(1, "a.go"), file(2, "a.go")}, resultEvent.Results)
+
+	// Every pattern is looked up once for all files of the indexed
+	// repository. Only the file of the unindexed repository goes to the
+	// symbols service.
+	require.Equal(t, 2, index.searches)
+	require.Equal(t, []string{"repo2 NewClient", "repo2 NewServer"}, unindexed)
+}
+
+// fakeSymbolIndex is a zoekt.Streamer that has indexed the symbols of one
+// repository at one commit.
+type fakeSymbolIndex struct {
+	zoekt.Streamer
+
+	repo    api.RepoID
+	commit  string
+	symbols map[string][]string
+
+	searches int
+}
+
+func (f *fakeSymbolIndex) List(context.Context, zoektquery.Q, *zoekt.ListOptions) (*zoekt.RepoList, error) {
+	return &zoekt.RepoList{ReposMap: zoekt.ReposMap{
+		uint32(f.repo): {
+			HasSymbols: true,
+			Branches:   []zoekt.RepositoryBranch{{Name: "HEAD", Version: f.commit}},
+		},
+	}}, nil
+}
+
+func (f *fakeSymbolIndex) Search(_ context.Context, q zoektquery.Q, _ *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
+	f.searches++
+
+	var (
+		paths  map[string]struct{}
+		symbol *regexp.Regexp
+	)
+	zoektquery.VisitAtoms(q, func(q zoektquery.Q) {
+		switch q := q.(type) {
+		case *zoektquery.FileNameSet:
+			paths = q.Set
+		case *zoektquery.Symbol:
+			symbol = regexp.MustCompile(q.Expr.(*zoektquery.Regexp).Regexp.String())
+		case *zoektquery.Regexp:
+			symbol = regexp.MustCompile(q.Regexp.String())
+		}
+	})
+
+	res := &zoekt.SearchResult{}
+	for path := range paths {
+		for _, name := range f.symbols[path] {
+			if symbol.MatchString(name) {
+				res.Files = append(res.Files, zoekt.FileMatch{FileName: path, Version: f.commit})
+				break
+			}
+		}
+	}
+	return res, nil
+}
@@ -71,6 +71,18 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 
 // NewBasicJob converts a query.Basic into its job tree representation.
 func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
+	// Search the query at both revisions if the user specified `rev:compare()`
+	if base, head, compare, ok := query.SplitRevCompare(b); ok {
+		baseJob, err := NewBasicJob(inputs, base)
+		if err != nil {
+			return nil, err
+		}
+		headJob, err := NewBasicJob(inputs, head)
+		if err != nil {
+			return nil, err
+		}
+		return NewLimitJob(b.MaxResults(inputs.DefaultLimit()), NewRevCompareJob(compare, baseJob, headJob)), nil
+	}
 
 	var children []job.Job
 	addJob := func(j job.Job) {
@@ -114,7 +126,9 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			numContextLines: int(inputs.ContextLines),
 		}
 
-		if resultTypes.Has(result.TypeFile | result.TypePath) {
+		// With submodules:only we only search the submodules of the
+		// repositories, not the repositories themselves.
+		if resultTypes.Has(result.TypeFile|result.TypePath) && b.Submodules() != query.Only {
 			// Create Global Text Search jobs.
 			if repoUniverseSearch {
 				searchJob, err := builder.newZoektGlobalSearch(search.TextRequest)
@@ -142,6 +156,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			}
 		}
 
+		if resultTypes.Has(result.TypeFile|result.TypePath) && b.Submodules() != query.No {
+			// Zoekt doesn't index the content of submodules, so searcher
+			// searches the submodules of all repositories, indexed or not.
+			addJob(NewSubmoduleSearchJob(b, inputs, resultTypes, repoOptions))
+		}
+
 		if resultTypes.Has(result.TypeSymbol) {
 			// Create Global Symbol Search jobs.
 			if repoUniverseSearch {
@@ -230,6 +250,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.symbol() post-search filter
+		if includeSymbols, excludeSymbols, ok := isSymbolFilterSearch(b); ok {
+			basicJob = NewFileHasSymbolsJob(basicJob, includeSymbols, excludeSymbols, b.IsCaseSensitive())
+		}
+	}
+
 	{ // Apply subrepo permissions checks
 		checker := authz.DefaultSubRepoPermsChecker
 		if authz.SubRepoEnabled(checker) {
@@ -305,6 +331,30 @@ func NewTextSearchJob(b query.Basic, inputs *search.Inputs, types result.Types,
 	}
 }
 
+// NewSubmoduleSearchJob returns a job which searches the submodules of the
+// repositories matched by options with searcher.
+func NewSubmoduleSearchJob(b query.Basic, inputs *search.Inputs, types result.Types, options search.RepoOptions) job.Job {
+	useFullDeadline := b.GetTimeout() != nil || b.Count() != nil || inputs.Protocol != search.Batch
+	patternInfo := toTextPatternInfo(b, types, inputs.Features, inputs.DefaultLimit())
+
+	searcherJob := &searcher.TextSearchJob{
+		PatternInfo:     patternInfo,
+		Indexed:         false,
+		UseFullDeadline: useFullDeadline,
+		Submodules:      query.Only,
+		Features:        *inputs.Features,
+		PathRegexps:     getPathRegexps(b, patternInfo),
+		NumContextLines: int(inputs.ContextLines),
+	}
+
+	return &repoPagerJob{
+		child:            &reposPartialJob{searcherJob},
+		repoOpts:         options,
+		containsRefGlobs: query.ContainsRefGlobs(b.ToParseTree()),
+		skipPartitioning: true,
+	}
+}
+
 // orderRacingJobs ensures that searcher and repo search jobs only ever run
 // sequentially after a Zoekt search has returned all its results.
 func orderRacingJobs(j job.Job) job.Job {
@@ -398,7 +448,26 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 			}
 		}
 
-		if resultTypes.Has(result.TypeStructural) {
+		if resultTypes.Has(result.TypeStructural) && f.ToBasic().IsTreeSitter() {
+			patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, searchInputs.Features, searchInputs.DefaultLimit())
+
+			// Tree-sitter queries are always run by searcher, Zoekt can't
+			// narrow down the files to parse.
+			treeSitterSearchJob := &searcher.TextSearchJob{
+				PatternInfo:     patternInfo,
+				Indexed:         false,
+				UseFullDeadline: useFullDeadline,
+				Features:        *searchInputs.Features,
+				NumContextLines: int(searchInputs.ContextLines),
+			}
+
+			addJob(&repoPagerJob{
+				child:            &reposPartialJob{treeSitterSearchJob},
+				repoOpts:         repoOptions,
+				containsRefGlobs: query.ContainsRefGlobs(f.ToBasic().ToParseTree()),
+				skipPartitioning: true,
+			})
+		} else if resultTypes.Has(result.TypeStructural) {
 			patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, searchInputs.Features, searchInputs.DefaultLimit())
 			searcherArgs := &search.SearcherParameters{
 				PatternInfo:     patternInfo,
@@ -595,6 +664,13 @@ func isContributorSearch(b query.Basic) (include, exclude []string, ok bool) {
 	return nil, nil, false
 }
 
+func isSymbolFilterSearch(b query.Basic) (include, exclude []string, ok bool) {
+	if includeSymbols, excludeSymbols := b.FileHasSymbol(); len(includeSymbols) > 0 || len(excludeSymbols) > 0 {
+		return includeSymbols, excludeSymbols, true
+	}
+	return nil, nil, false
+}
+
 func contributorsAsRegexp(contributors []string, isCaseSensitive bool) (res []*regexp.Regexp) {
 	for _, pattern := range contributors {
 		if isCaseSensitive {
@@ -702,6 +778,7 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, feat *search.Fea
 	return &search.TextPatternInfo{
 		Query:                        protocol.FromJobNode(b.Pattern),
 		IsStructuralPat:              b.IsStructural(),
+		IsTreeSitterPat:              b.IsTreeSitter(),
 		IsCaseSensitive:              b.IsCaseSensitive(),
 		FileMatchLimit:               int32(count),
 		Languages:                    langAliasInclude,
@@ -709,6 +786,7 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, feat *search.Fea
 		ExcludePaths:                 query.UnionRegExps(filesExclude),
 		IncludeLangs:                 langInclude,
 		ExcludeLangs:                 langExclude,
+		FileMetrics:                  b.FileMetricFilters(),
 		PatternMatchesPath:           resultTypes.Has(result.TypePath),
 		PatternMatchesContent:        resultTypes.Has(result.TypeFile),
 		PathPatternsAreCaseSensitive: b.IsCaseSensitive(),
@@ -734,7 +812,7 @@ const defaultResultTypes = result.TypeFile | result.TypePath | result.TypeRepo
 // computeResultTypes returns result types based three inputs: `type:...` in the query,
 // the `pattern`, and top-level `searchType` (coming from a GQL value).
 func computeResultTypes(b query.Basic, searchType query.SearchType, defaultTypes result.Types) result.Types {
-	if searchType == query.SearchTypeStructural && !b.IsEmptyPattern() {
+	if (searchType == query.SearchTypeStructural || searchType == query.SearchTypeTreeSitter) && !b.IsEmptyPattern() {
 		return result.TypeStructural
 	}
 
@@ -873,6 +951,7 @@ func (b *jobBuilder) newZoektGlobalSearch(typ search.IndexedRequestType) (job.Jo
 		Features:        *b.features,
 		PatternType:     b.patternType,
 		NumContextLines: b.numContextLines,
+		FileMetrics:     b.query.FileMetricFilters(),
 	}
 
 	switch typ {
@@ -906,6 +985,7 @@ func (b *jobBuilder) newZoektSearch(typ search.IndexedRequestType) (job.Job, err
 		Features:        *b.features,
 		PatternType:     b.patternType,
 		NumContextLines: b.numContextLines,
+		FileMetrics:     b.query.FileMetricFilters(),
 	}
 
 	switch typ {
@@ -959,7 +1039,7 @@ func jobMode(b query.Basic, repoOptions search.RepoOptions, resultTypes result.T
 		return
 	}
 
-	isGlobalSearch := isGlobal(repoOptions) && inputs.PatternType != query.SearchTypeStructural
+	isGlobalSearch := isGlobal(repoOptions) && inputs.PatternType != query.SearchTypeStructural && inputs.PatternType != query.SearchTypeTreeSitter
 
 	hasGlobalSearchResultType := resultTypes.Has(result.TypeFile | result.TypePath | result.TypeSymbol)
 	isIndexedSearch := b.Index() != query.No
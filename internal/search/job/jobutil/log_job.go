@@ -127,6 +127,9 @@ func (l *LogJob) logEvent(ctx context.Context, clients job.RuntimeClients, durat
 			types = append(types, "regexp")
 		} else if q.IsStructural() {
 			types = append(types, "structural")
+		} else if q.IsTreeSitter() {
+			// There is no latency event for tree-sitter searches yet.
+			return
 		} else if l.inputs.Query.Exists(query.FieldFile) {
 			// No search pattern specified and file: is specified.
 			types = append(types, "file")
@@ -0,0 +1,341 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxRevComparePending is the maximum number of matches a RevCompareJob holds
+// back because the other revision hasn't been searched far enough yet to tell
+// whether they changed.
+var maxRevComparePending = 10000
+
+// NewRevCompareJob creates a job for the rev:compare() and rev:compare.time()
+// predicates. base and head are the same query searched at the two revisions
+// in compare. The job only sends matches that differ between both sides:
+// matches only found by head are sent as returned by head with
+// result.ChangeAdded, matches only found by base are sent as returned by base
+// with result.ChangeRemoved.
+//
+// Matches are compared as they arrive. A match is sent as soon as the other
+// side has either sent a match with the same key, or has completed.
+func NewRevCompareJob(compare query.RevCompare, base, head job.Job) job.Job {
+	return &RevCompareJob{
+		compare: compare,
+		base:    base,
+		head:    head,
+	}
+}
+
+type RevCompareJob struct {
+	compare query.RevCompare
+	base    job.Job
+	head    job.Job
+}
+
+func (j *RevCompareJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	d := newRevCompareDiffer()
+	var overflow atomic.Bool
+	send := func(matches result.Matches, ok bool) {
+		if !ok {
+			overflow.Store(true)
+			cancel()
+			return
+		}
+		if len(matches) > 0 {
+			stream.Send(streaming.SearchEvent{Results: matches})
+		}
+	}
+	sideStream := func(side *revCompareSide) streaming.Sender {
+		return streaming.StreamFunc(func(event streaming.SearchEvent) {
+			send(d.add(side, event.Results))
+			stream.Send(streaming.SearchEvent{Stats: event.Stats})
+		})
+	}
+
+	var (
+		p          = pool.New().WithContext(ctx)
+		maxAlerter search.MaxAlerter
+	)
+	p.Go(func(ctx context.Context) error {
+		alert, err := j.base.Run(ctx, clients, sideStream(d.base))
+		maxAlerter.Add(alert)
+		if err == nil {
+			send(d.complete(d.base), true)
+		}
+		return err
+	})
+	p.Go(func(ctx context.Context) error {
+		alert, err := j.head.Run(ctx, clients, sideStream(d.head))
+		maxAlerter.Add(alert)
+		if err == nil {
+			send(d.complete(d.head), true)
+		}
+		return err
+	})
+	err = p.Wait()
+
+	if overflow.Load() {
+		return maxAlerter.Alert, errors.Newf("rev:compare() found more than %d results that it could not compare yet, narrow down the search with repo: or file: filters", maxRevComparePending)
+	}
+	return maxAlerter.Alert, err
+}
+
+func (j *RevCompareJob) Name() string {
+	return "RevCompareJob"
+}
+
+func (j *RevCompareJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			attribute.Stringer("base", j.compare.Base),
+			attribute.Stringer("head", j.compare.Head),
+		)
+	}
+	return res
+}
+
+func (j *RevCompareJob) Children() []job.Describer {
+	return []job.Describer{j.base, j.head}
+}
+
+func (j *RevCompareJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.base = job.Map(j.base, fn)
+	cp.head = job.Map(j.head, fn)
+	return &cp
+}
+
+// revCompareDiffer compares the matches of both sides of a rev:compare()
+// search while they are streamed. File matches are compared chunk by chunk and
+// symbol by symbol, so a file present on both sides is returned with only the
+// chunks and symbols that changed.
+type revCompareDiffer struct {
+	mu         sync.Mutex
+	base, head *revCompareSide
+	pending    int
+}
+
+type revCompareSide struct {
+	change result.Change
+	done   bool
+
+	// parts are the parts of all matches received from this side, by the
+	// revisionless key of the match.
+	parts map[result.Key]map[string]struct{}
+
+	// pending are the matches received from this side that can't be compared
+	// yet, because the other side neither sent a match with the same key nor
+	// completed. order is the order they were received in.
+	pending map[result.Key]result.Match
+	order   []result.Key
+}
+
+func newRevCompareDiffer() *revCompareDiffer {
+	newSide := func(change result.Change) *revCompareSide {
+		return &revCompareSide{
+			change:  change,
+			parts:   map[result.Key]map[string]struct{}{},
+			pending: map[result.Key]result.Match{},
+		}
+	}
+	return &revCompareDiffer{
+		base: newSide(result.ChangeRemoved),
+		head: newSide(result.ChangeAdded),
+	}
+}
+
+func (d *revCompareDiffer) other(side *revCompareSide) *revCompareSide {
+	if side == d.base {
+		return d.head
+	}
+	return d.base
+}
+
+// add adds matches received from side and returns the matches that can be
+// sent because they changed. ok is false if too many matches are pending.
+func (d *revCompareDiffer) add(side *revCompareSide, matches result.Matches) (changed result.Matches, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	other := d.other(side)
+	for _, m := range matches {
+		key := revisionlessKey(m)
+		parts, ok := side.parts[key]
+		if !ok {
+			parts = map[string]struct{}{}
+			side.parts[key] = parts
+		}
+		for _, part := range matchParts(m) {
+			parts[part] = struct{}{}
+		}
+
+		otherParts, seen := other.parts[key]
+		switch {
+		case seen:
+			if pending, ok := other.pending[key]; ok {
+				delete(other.pending, key)
+				d.pending--
+				if c := subtractParts(pending, parts, other.change); c != nil {
+					changed = append(changed, c)
+				}
+			}
+			fallthrough
+		case other.done:
+			if c := subtractParts(m, otherParts, side.change); c != nil {
+				changed = append(changed, c)
+			}
+		default:
+			if pending, ok := side.pending[key]; ok {
+				side.pending[key] = mergeParts(pending, m)
+				continue
+			}
+			if d.pending >= maxRevComparePending {
+				return changed, false
+			}
+			side.pending[key] = m
+			side.order = append(side.order, key)
+			d.pending++
+		}
+	}
+	return changed, true
+}
+
+// complete marks side as completed and returns the pending matches of the
+// other side, which can't be found by side anymore.
+func (d *revCompareDiffer) complete(side *revCompareSide) (changed result.Matches) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	side.done = true
+	other := d.other(side)
+	for _, key := range other.order {
+		m, ok := other.pending[key]
+		if !ok {
+			continue
+		}
+		if c := subtractParts(m, side.parts[key], other.change); c != nil {
+			changed = append(changed, c)
+		}
+	}
+	d.pending -= len(other.pending)
+	other.pending = map[result.Key]result.Match{}
+	other.order = nil
+	return changed
+}
+
+func revisionlessKey(m result.Match) result.Key {
+	key := m.Key()
+	key.Rev = ""
+	if _, ok := m.(*result.FileMatch); ok {
+		// The commit of a commit match is its identity, but file matches
+		// are expected to be found at different commits.
+		key.Commit = ""
+	}
+	return key
+}
+
+func chunkPart(c result.ChunkMatch) string {
+	return "chunk:" + c.Content
+}
+
+func symbolPart(s *result.SymbolMatch) string {
+	return "symbol:" + s.Symbol.Kind + ":" + s.Symbol.Name
+}
+
+// matchParts returns the parts of m that are compared. Matches other than file
+// matches with chunks or symbols are compared as a whole, which is the empty
+// part.
+func matchParts(m result.Match) []string {
+	fm, ok := m.(*result.FileMatch)
+	if !ok || fm.IsPathMatch() {
+		return []string{""}
+	}
+	parts := make([]string, 0, len(fm.ChunkMatches)+len(fm.Symbols))
+	for _, c := range fm.ChunkMatches {
+		parts = append(parts, chunkPart(c))
+	}
+	for _, s := range fm.Symbols {
+		parts = append(parts, symbolPart(s))
+	}
+	return parts
+}
+
+// subtractParts returns m marked with change without the parts in exclude, or
+// nil if no part of m is left.
+func subtractParts(m result.Match, exclude map[string]struct{}, change result.Change) result.Match {
+	switch v := m.(type) {
+	case *result.FileMatch:
+		cp := *v
+		cp.Change = change
+		if v.IsPathMatch() {
+			if _, ok := exclude[""]; ok {
+				return nil
+			}
+			return &cp
+		}
+
+		cp.ChunkMatches = nil
+		cp.Symbols = nil
+		for _, c := range v.ChunkMatches {
+			if _, ok := exclude[chunkPart(c)]; !ok {
+				cp.ChunkMatches = append(cp.ChunkMatches, c)
+			}
+		}
+		for _, s := range v.Symbols {
+			if _, ok := exclude[symbolPart(s)]; !ok {
+				cp.Symbols = append(cp.Symbols, s)
+			}
+		}
+		if cp.IsPathMatch() {
+			return nil
+		}
+		return &cp
+	case *result.CommitMatch:
+		if _, ok := exclude[""]; ok {
+			return nil
+		}
+		cp := *v
+		cp.Change = change
+		return &cp
+	default:
+		if _, ok := exclude[""]; ok {
+			return nil
+		}
+		return m
+	}
+}
+
+// mergeParts returns the pending match with the chunks and symbols of m added,
+// for when one side sends several matches with the same key.
+func mergeParts(pending, m result.Match) result.Match {
+	pfm, ok := pending.(*result.FileMatch)
+	fm, ok2 := m.(*result.FileMatch)
+	if !ok || !ok2 {
+		return pending
+	}
+	cp := *pfm
+	cp.ChunkMatches = append(append(result.ChunkMatches(nil), pfm.ChunkMatches...), fm.ChunkMatches...)
+	cp.Symbols = append(append([]*result.SymbolMatch(nil), pfm.Symbols...), fm.Symbols...)
+	return &cp
+}
@@ -0,0 +1,151 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRevCompareJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "repo"}
+
+	fm := func(commit api.CommitID, path string, chunks ...string) *result.FileMatch {
+		m := &result.FileMatch{
+			File: result.File{Repo: repo, CommitID: commit, Path: path},
+		}
+		for _, c := range chunks {
+			m.ChunkMatches = append(m.ChunkMatches, result.ChunkMatch{Content: c})
+		}
+		return m
+	}
+
+	sendJob := func(matches ...result.Match) job.Job {
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: matches})
+			return nil, nil
+		})
+		return j
+	}
+
+	changed := func(m *result.FileMatch, change result.Change) *result.FileMatch {
+		m.Change = change
+		return m
+	}
+
+	compare := query.RevCompare{
+		Base: query.RevisionSpecifier{RevSpec: "a"},
+		Head: query.RevisionSpecifier{RevSpec: "b"},
+	}
+
+	base := sendJob(
+		fm("a", "unchanged.go", "grpc.Dial(x)"),
+		fm("a", "edited.go", "grpc.Dial(x)", "grpc.Dial(y)"),
+		fm("a", "deleted.go", "grpc.Dial(z)"),
+		fm("a", "path-only.go"),
+	)
+	head := sendJob(
+		fm("b", "unchanged.go", "grpc.Dial(x)"),
+		fm("b", "edited.go", "grpc.Dial(x)", "grpc.Dial(w)"),
+		fm("b", "created.go", "grpc.Dial(v)"),
+		fm("b", "path-only.go"),
+	)
+
+	var (
+		mu  sync.Mutex
+		got result.Matches
+	)
+	stream := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, ev.Results...)
+	})
+
+	j := NewRevCompareJob(compare, base, head)
+	alert, err := j.Run(context.Background(), job.RuntimeClients{}, stream)
+	require.Nil(t, alert)
+	require.NoError(t, err)
+
+	want := result.Matches{
+		changed(fm("b", "edited.go", "grpc.Dial(w)"), result.ChangeAdded),
+		changed(fm("b", "created.go", "grpc.Dial(v)"), result.ChangeAdded),
+		changed(fm("a", "edited.go", "grpc.Dial(y)"), result.ChangeRemoved),
+		changed(fm("a", "deleted.go", "grpc.Dial(z)"), result.ChangeRemoved),
+	}
+	require.ElementsMatch(t, want, got)
+
+	t.Run("streams before both sides complete", func(t *testing.T) {
+		release := make(chan struct{})
+		base := mockjob.NewMockJob()
+		base.RunFunc.SetDefaultHook(func(ctx context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{fm("a", "deleted.go", "grpc.Dial(z)")}})
+			select {
+			case <-release:
+			case <-time.After(10 * time.Second):
+				return nil, errors.New("timed out waiting for results to be streamed")
+			}
+			s.Send(streaming.SearchEvent{Results: result.Matches{fm("a", "late.go", "grpc.Dial(u)")}})
+			return nil, nil
+		})
+		head := sendJob(fm("b", "created.go", "grpc.Dial(v)"))
+
+		// deleted.go can only be sent before base completes if results are
+		// streamed as soon as head completes.
+		var (
+			mu   sync.Mutex
+			got  result.Matches
+			once sync.Once
+		)
+		stream := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, ev.Results...)
+			if len(got) > 0 {
+				once.Do(func() { close(release) })
+			}
+		})
+
+		alert, err := NewRevCompareJob(compare, base, head).Run(context.Background(), job.RuntimeClients{}, stream)
+		require.Nil(t, alert)
+		require.NoError(t, err)
+		require.ElementsMatch(t, result.Matches{
+			changed(fm("b", "created.go", "grpc.Dial(v)"), result.ChangeAdded),
+			changed(fm("a", "deleted.go", "grpc.Dial(z)"), result.ChangeRemoved),
+			changed(fm("a", "late.go", "grpc.Dial(u)"), result.ChangeRemoved),
+		}, got)
+	})
+
+	t.Run("too many pending results", func(t *testing.T) {
+		old := maxRevComparePending
+		maxRevComparePending = 1
+		t.Cleanup(func() { maxRevComparePending = old })
+
+		block := func(matches ...result.Match) job.Job {
+			j := mockjob.NewMockJob()
+			j.RunFunc.SetDefaultHook(func(ctx context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: matches})
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+			return j
+		}
+		base := block(fm("a", "one.go", "x"), fm("a", "two.go", "y"))
+		head := block()
+
+		_, err := NewRevCompareJob(compare, base, head).Run(context.Background(), job.RuntimeClients{}, streaming.NewNullStream())
+		require.ErrorContains(t, err, "rev:compare() found more than 1 results")
+	})
+}
@@ -1,5 +1,13 @@
 package query
 
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+)
+
 var empty = struct{}{}
 
 // All field names.
@@ -19,6 +27,7 @@ const (
 	FieldVisibility         = "visibility"
 	FieldRev                = "rev"
 	FieldContext            = "context"
+	FieldSubmodules         = "submodules"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -26,6 +35,7 @@ const (
 	FieldAuthor    = "author"
 	FieldCommitter = "committer"
 	FieldMessage   = "message"
+	FieldSigned    = "signed"
 
 	// Temporary experimental fields:
 	FieldIndex     = "index"
@@ -64,6 +74,7 @@ var allFields = map[string]struct{}{
 	FieldMessage:            empty,
 	"m":                     empty,
 	"msg":                   empty,
+	FieldSigned:             empty,
 	FieldIndex:              empty,
 	FieldCount:              empty,
 	FieldTimeout:            empty,
@@ -71,6 +82,7 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldSubmodules:         empty,
 }
 
 var aliases = map[string]string{
@@ -94,3 +106,110 @@ func resolveFieldAlias(field string) string {
 	}
 	return field
 }
+
+// File metrics that file: filters can compare, like file:size>100KB or
+// file:lines<50.
+const (
+	FileMetricSize  = "size"
+	FileMetricLines = "lines"
+)
+
+// FileMetricFilter is a file: filter that compares the size of files in bytes
+// or their number of lines to a value, like file:size>100KB.
+type FileMetricFilter struct {
+	Metric string // FileMetricSize or FileMetricLines
+	Op     string // One of <, <=, >, >= and =
+	Value  int64
+	// Negated is true for -file: filters, which keep the files that don't
+	// satisfy the comparison.
+	Negated bool
+}
+
+var (
+	fileMetricRegexp = regexp.MustCompile(`^(size|lines)(<=|>=|<|>|=)([0-9]+)([a-zA-Z]*)$`)
+	fileSizeUnits    = map[string]int64{
+		"":   1,
+		"b":  1,
+		"kb": 1 << 10,
+		"mb": 1 << 20,
+		"gb": 1 << 30,
+	}
+)
+
+// parseFileMetricFilter parses the value of a file: filter as a file metric
+// filter. Quoted values are always file path patterns.
+func parseFileMetricFilter(value string, negated bool, annotation Annotation) (FileMetricFilter, bool) {
+	if annotation.Labels.IsSet(Quoted | IsPredicate) {
+		return FileMetricFilter{}, false
+	}
+	match := fileMetricRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return FileMetricFilter{}, false
+	}
+	metric, op, unit := match[1], match[2], strings.ToLower(match[4])
+
+	multiplier, ok := fileSizeUnits[unit]
+	if !ok || (metric == FileMetricLines && unit != "") {
+		return FileMetricFilter{}, false
+	}
+	v, err := strconv.ParseInt(match[3], 10, 64)
+	if err != nil || v > (1<<62)/multiplier {
+		return FileMetricFilter{}, false
+	}
+
+	return FileMetricFilter{
+		Metric:  metric,
+		Op:      op,
+		Value:   v * multiplier,
+		Negated: negated,
+	}, true
+}
+
+// Matches returns true if content, the whole content of a file, satisfies the
+// filter.
+func (f FileMetricFilter) Matches(content []byte) bool {
+	switch f.Metric {
+	case FileMetricSize:
+		return f.Compare(int64(len(content)))
+	case FileMetricLines:
+		return f.Compare(CountLines(content))
+	}
+	return false
+}
+
+// Compare returns true if v, the size or number of lines of a file, satisfies
+// the filter.
+func (f FileMetricFilter) Compare(v int64) bool {
+	var ok bool
+	switch f.Op {
+	case "<":
+		ok = v < f.Value
+	case "<=":
+		ok = v <= f.Value
+	case ">":
+		ok = v > f.Value
+	case ">=":
+		ok = v >= f.Value
+	case "=":
+		ok = v == f.Value
+	}
+	return ok != f.Negated
+}
+
+// CountLines returns the number of lines of content. A last line without a
+// trailing newline counts as a line.
+func CountLines(content []byte) int64 {
+	n := int64(bytes.Count(content, []byte{'\n'}))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		n++
+	}
+	return n
+}
+
+func (f FileMetricFilter) String() string {
+	s := f.Metric + f.Op + strconv.FormatInt(f.Value, 10)
+	if f.Negated {
+		return "-" + s
+	}
+	return s
+}
@@ -0,0 +1,155 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/grafana/regexp"
+)
+
+// fuzzyMinLength is the minimum number of characters a term needs for fuzzy
+// search to tolerate a typo in it. Shorter terms match too much text when any
+// one character may differ, so they are searched as they are.
+const fuzzyMinLength = 4
+
+// fuzzyTwoEditsMinLength is the minimum number of characters a term needs for
+// fuzzy search to tolerate two typos in it.
+const fuzzyTwoEditsMinLength = 8
+
+// fuzzyTwoEditsMaxLength and fuzzyMaxLength bound the size of the regular
+// expressions generated for a term, which grow quadratically with its length
+// for one edit and cubically for two edits. Longer terms tolerate one typo
+// less.
+const (
+	fuzzyTwoEditsMaxLength = 16
+	fuzzyMaxLength         = 64
+)
+
+// substituteFuzzy converts each literal pattern into a pattern that also
+// matches the strings up to two edits away from it (inserted, deleted,
+// substituted or two transposed characters). The number of edits tolerated
+// depends on the length of the term. Whitespace separated terms are AND-ed so
+// that each of them may contain typos.
+//
+// Every term becomes (or "term" /one edit/ /two edits/), where "term" is
+// labeled Boost and /one edit/ is labeled Fuzzy. Zoekt scores a file that
+// matches the term exactly above a file that matches with one typo, which it
+// scores above a file that matches with two typos. This is the ranking penalty
+// for the edit distance.
+//
+// Quoted patterns, regular expressions and negated patterns are left as they
+// are.
+func substituteFuzzy(nodes []Node) []Node {
+	nodes = substituteConcat(func(patterns []Pattern) []Node {
+		return NewOperator(patternsToNodes(patterns), And)
+	})(nodes)
+
+	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
+		pattern := Pattern{Value: value, Negated: negated, Annotation: annotation}
+		if negated || annotation.Labels.IsSet(Regexp|Quoted|IsContent) {
+			return pattern
+		}
+
+		edits := fuzzyEdits(value)
+		if edits == 0 {
+			return pattern
+		}
+
+		regexpAnnotation := annotation
+		regexpAnnotation.Labels.Unset(Literal)
+		regexpAnnotation.Labels.Set(Regexp)
+		fuzzyAnnotation := regexpAnnotation
+		fuzzyAnnotation.Labels.Set(Fuzzy)
+		annotation.Labels.Set(Boost)
+
+		operands := []Node{
+			Pattern{Value: value, Annotation: annotation},
+			Pattern{Value: fuzzyRegexpPattern(value, 1), Annotation: fuzzyAnnotation},
+		}
+		if edits > 1 {
+			operands = append(operands, Pattern{Value: fuzzyRegexpPattern(value, 2), Annotation: regexpAnnotation})
+		}
+		return Operator{Kind: Or, Operands: operands}
+	})
+}
+
+func patternsToNodes(patterns []Pattern) []Node {
+	nodes := make([]Node, 0, len(patterns))
+	for _, p := range patterns {
+		nodes = append(nodes, p)
+	}
+	return nodes
+}
+
+// fuzzyEdits returns the number of typos fuzzy search tolerates in term, 0 for
+// terms that should only match exactly.
+func fuzzyEdits(term string) int {
+	n := utf8.RuneCountInString(term)
+	switch {
+	case n < fuzzyMinLength || n > fuzzyMaxLength || strings.ContainsFunc(term, unicode.IsSpace):
+		return 0
+	case n >= fuzzyTwoEditsMinLength && n <= fuzzyTwoEditsMaxLength:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// fuzzyRegexpPattern returns a regular expression that matches term and every
+// string at most edits edits away from it.
+func fuzzyRegexpPattern(term string, edits int) string {
+	tokens := make([]string, 0, len(term))
+	for _, r := range term {
+		tokens = append(tokens, regexp.QuoteMeta(string(r)))
+	}
+
+	variants := [][]string{tokens}
+	for i := 0; i < edits; i++ {
+		var next [][]string
+		for _, v := range variants {
+			next = append(next, fuzzyEditTokens(v)...)
+		}
+		variants = next
+	}
+
+	seen := map[string]struct{}{}
+	alternatives := make([]string, 0, len(variants))
+	for _, v := range variants {
+		alternative := strings.Join(v, "")
+		if _, ok := seen[alternative]; ok {
+			continue
+		}
+		seen[alternative] = struct{}{}
+		alternatives = append(alternatives, alternative)
+	}
+
+	return "(?:" + strings.Join(alternatives, "|") + ")"
+}
+
+// fuzzyEditTokens returns the regular expressions, as lists of tokens, that
+// match the strings matched by tokens with one more edit.
+func fuzzyEditTokens(tokens []string) [][]string {
+	concat := func(parts ...[]string) []string {
+		out := make([]string, 0, len(tokens)+1)
+		for _, p := range parts {
+			out = append(out, p...)
+		}
+		return out
+	}
+
+	var variants [][]string
+	for i := range tokens {
+		// Substitution or deletion of tokens[i].
+		variants = append(variants, concat(tokens[:i], []string{".?"}, tokens[i+1:]))
+		// Insertion before tokens[i].
+		variants = append(variants, concat(tokens[:i], []string{"."}, tokens[i:]))
+		// Transposition of tokens[i] and tokens[i+1].
+		if i+1 < len(tokens) && tokens[i] != tokens[i+1] {
+			variants = append(variants, concat(tokens[:i], []string{tokens[i+1], tokens[i]}, tokens[i+2:]))
+		}
+	}
+	// Insertion at the end.
+	variants = append(variants, concat(tokens, []string{"."}))
+	return variants
+}
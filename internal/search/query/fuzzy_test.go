@@ -0,0 +1,101 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grafana/regexp"
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstituteFuzzy(t *testing.T) {
+	test := func(input string) string {
+		plan, err := Pipeline(Init(input, SearchTypeFuzzy))
+		if err != nil {
+			return err.Error()
+		}
+		return plan.ToQ().String()
+	}
+
+	autogold.Expect(`(or "getUsr" "(?:.?etUsr|.getUsr|egtUsr|g.?tUsr|g.etUsr|gteUsr|ge.?Usr|ge.tUsr|geUtsr|get.?sr|get.Usr|getsUr|getU.?r|getU.sr|getUrs|getUs.?|getUs.r|getUsr.)")`).Equal(t, test("getUsr"))
+	autogold.Expect(`(and "repo:foo" (or "b.Id" "(?:.?\\.Id|.b\\.Id|\\.bId|b.?Id|b.\\.Id|bI\\.d|b\\..?d|b\\..Id|b\\.dI|b\\.I.?|b\\.I.d|b\\.Id.)") "err")`).Equal(t, test("repo:foo b.Id err"))
+
+	t.Run("exact patterns", func(t *testing.T) {
+		autogold.Expect(`"foo"`).Equal(t, test("foo"))
+		autogold.Expect(`"exact phrase"`).Equal(t, test(`"exact phrase"`))
+		autogold.Expect(`"getUs.r"`).Equal(t, test("/getUs.r/"))
+		autogold.Expect(`(not "getUsr")`).Equal(t, test("NOT getUsr"))
+		autogold.Expect(`"getUsr"`).Equal(t, test(`content:getUsr`))
+	})
+
+	t.Run("two edits", func(t *testing.T) {
+		plan, err := Pipeline(Init("getUserName", SearchTypeFuzzy))
+		require.NoError(t, err)
+		operator := plan[0].Pattern.(Operator)
+		require.Len(t, operator.Operands, 3)
+
+		exact, oneEdit, twoEdits := operator.Operands[0].(Pattern), operator.Operands[1].(Pattern), operator.Operands[2].(Pattern)
+		require.Equal(t, "getUserName", exact.Value)
+		require.True(t, exact.Annotation.Labels.IsSet(Boost))
+		require.True(t, oneEdit.Annotation.Labels.IsSet(Regexp))
+		require.True(t, oneEdit.Annotation.Labels.IsSet(Fuzzy))
+		require.True(t, twoEdits.Annotation.Labels.IsSet(Regexp))
+		require.False(t, twoEdits.Annotation.Labels.IsSet(Fuzzy))
+	})
+}
+
+func TestFuzzyRegexpPattern(t *testing.T) {
+	re := regexp.MustCompile("^" + fuzzyRegexpPattern("getUser", 1) + "$")
+
+	for _, s := range []string{
+		"getUser",  // exact
+		"getUsr",   // deletion
+		"getUsers", // insertion
+		"xgetUser", // insertion at the start
+		"getUsor",  // substitution
+		"gteUser",  // transposition
+	} {
+		require.True(t, re.MatchString(s), s)
+	}
+
+	for _, s := range []string{
+		"getUs",     // two deletions
+		"gtUsers",   // deletion and insertion
+		"teguser",   // unrelated
+		"getUser!!", // two insertions
+	} {
+		require.False(t, re.MatchString(s), s)
+	}
+
+	t.Run("two edits", func(t *testing.T) {
+		re := regexp.MustCompile("^" + fuzzyRegexpPattern("getUserName", 2) + "$")
+
+		for _, s := range []string{
+			"getUserName",   // exact
+			"getUsrName",    // deletion
+			"getUsrNme",     // two deletions
+			"gteUserNames",  // transposition and insertion
+			"getUsorNama",   // two substitutions
+			"xgetUserNamex", // two insertions
+		} {
+			require.True(t, re.MatchString(s), s)
+		}
+
+		for _, s := range []string{
+			"getUsNme",    // three deletions
+			"teguserName", // unrelated
+		} {
+			require.False(t, re.MatchString(s), s)
+		}
+	})
+}
+
+func TestFuzzyEdits(t *testing.T) {
+	require.Equal(t, 0, fuzzyEdits("get"))
+	require.Equal(t, 0, fuzzyEdits("get user"))
+	require.Equal(t, 1, fuzzyEdits("getUser"))
+	require.Equal(t, 2, fuzzyEdits("getUserName"))
+	require.Equal(t, 1, fuzzyEdits("getUserNameFromTheDatabase"))
+	require.Equal(t, 0, fuzzyEdits(strings.Repeat("a", fuzzyMaxLength+1)))
+}
@@ -23,6 +23,11 @@ const (
 	Boost
 	// IsContent is set on patterns that come from content:
 	IsContent
+	// TreeSitter is set on patterns that are tree-sitter queries.
+	TreeSitter
+	// Fuzzy is set on the regexp patterns generated for fuzzy search terms
+	// that match the term with one typo. They rank below the exact term.
+	Fuzzy
 )
 
 var allLabels = map[labels]string{
@@ -40,6 +45,8 @@ var allLabels = map[labels]string{
 	QuotesAsLiterals:          "QuotesAsLiterals",
 	Boost:                     "Boost",
 	IsContent:                 "IsContent",
+	TreeSitter:                "TreeSitter",
+	Fuzzy:                     "Fuzzy",
 }
 
 func (l *labels) IsSet(label labels) bool {
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Macros maps macro names to the query they stand for. A query refers to a
+// macro as @name, for example `@backend lang:go foo` with the macro backend
+// defined as `repo:^github\.com/sourcegraph/ -file:_test\.go$`.
+type Macros map[string]string
+
+// ExpandMacros substitutes every macro reference in the input string for the
+// query of the macro. Macros may refer to other macros. References to unknown
+// names are left as they are, so that patterns like @Override are still
+// searchable, and so are references inside quoted values and regular
+// expression literals like /a @b/.
+//
+// Expansion happens on the input string, before the query is parsed and
+// validated. Macro queries are checked to parse on their own for searchType so
+// that errors point at the macro that is invalid instead of the query the user
+// typed.
+func ExpandMacros(in string, searchType SearchType, macros Macros) (string, error) {
+	if len(macros) == 0 || !strings.Contains(in, "@") {
+		return in, nil
+	}
+	e := macroExpander{searchType: searchType, macros: macros, expanded: map[string]string{}}
+	return e.expand(in, nil)
+}
+
+type macroExpander struct {
+	searchType SearchType
+	macros     Macros
+
+	// expanded caches the expansion of macros, which may be referenced more
+	// than once.
+	expanded map[string]string
+}
+
+// expand expands the macro references in in. stack is the chain of macros
+// being expanded, used to detect cycles.
+func (e *macroExpander) expand(in string, stack []string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(in); {
+		r := rune(in[i])
+		tokenStart := i == 0 || isMacroBoundary(rune(in[i-1]))
+		switch {
+		case (r == '"' || r == '\'') && (tokenStart || in[i-1] == ':'), r == '/' && tokenStart:
+			// Quoted values and regular expression literals are copied as
+			// they are. A quote that doesn't start one, like an
+			// apostrophe in a word, doesn't quote anything.
+			if n := scanDelimitedToken(in[i:], r); n > 0 {
+				b.WriteString(in[i : i+n])
+				i += n
+				continue
+			}
+		case r == '@' && tokenStart:
+			name := scanMacroName(in[i+1:])
+			end := i + 1 + len(name)
+			if _, ok := e.macros[name]; ok && (end == len(in) || isMacroBoundary(rune(in[end]))) {
+				expansion, err := e.expandMacro(name, stack)
+				if err != nil {
+					return "", err
+				}
+				b.WriteString(expansion)
+				i = end
+				continue
+			}
+		}
+		b.WriteByte(in[i])
+		i++
+	}
+	return b.String(), nil
+}
+
+func (e *macroExpander) expandMacro(name string, stack []string) (string, error) {
+	for j, seen := range stack {
+		if seen == name {
+			cycle := append(stack[j:], name)
+			return "", errors.Errorf("macro @%s refers to itself: @%s", name, strings.Join(cycle, " -> @"))
+		}
+	}
+	if expansion, ok := e.expanded[name]; ok {
+		return expansion, nil
+	}
+
+	expansion, err := e.expand(e.macros[name], append(stack, name))
+	if err != nil {
+		return "", err
+	}
+	nodes, err := Parse(expansion, e.searchType)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid macro @%s", name)
+	}
+	if len(nodes) == 1 {
+		if operator, ok := nodes[0].(Operator); ok && operator.Kind == Or {
+			// Preserve the meaning of the macro when it is combined with
+			// other terms, which bind tighter than or.
+			expansion = "(" + expansion + ")"
+		}
+	}
+	e.expanded[name] = expansion
+	return expansion, nil
+}
+
+func isMacroBoundary(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')'
+}
+
+// scanDelimitedToken returns the length of the value delimited by delimiter
+// that in starts with, or 0 if the parser wouldn't read one there.
+func scanDelimitedToken(in string, delimiter rune) int {
+	_, advance, err := ScanDelimited([]byte(in), false, delimiter)
+	if err != nil {
+		return 0
+	}
+	if advance < len(in) && !unicode.IsSpace(rune(in[advance])) && in[advance] != ')' {
+		return 0
+	}
+	return advance
+}
+
+func scanMacroName(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_' || r == '-' || r == '.')
+	})
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+)
+
+func TestExpandMacros(t *testing.T) {
+	macros := Macros{
+		"backend": `repo:^github\.com/sourcegraph/ -file:_test\.go$`,
+		"go":      `@backend lang:go`,
+		"either":  `repo:a or repo:b`,
+		"quoted":  `"@backend"`,
+		"loop":    `@loop2 foo`,
+		"loop2":   `@loop`,
+		"broken":  `repo:"a`,
+		"uses":    `@broken`,
+		"b":       `repo:b`,
+	}
+
+	test := func(input string) string {
+		expanded, err := ExpandMacros(input, SearchTypeStandard, macros)
+		if err != nil {
+			return err.Error()
+		}
+		plan, err := Pipeline(Init(expanded, SearchTypeStandard))
+		if err != nil {
+			return err.Error()
+		}
+		return plan.ToQ().String()
+	}
+
+	autogold.Expect(`(and "repo:^github\\.com/sourcegraph/" "-file:_test\\.go$" "foo")`).Equal(t, test("@backend foo"))
+	autogold.Expect(`(and "repo:^github\\.com/sourcegraph/" "-file:_test\\.go$" "lang:go" "foo")`).Equal(t, test("foo @go"))
+	autogold.Expect(`(or (and "repo:a" "foo") (and "repo:b" "foo"))`).Equal(t, test("@either foo"))
+	autogold.Expect(`(and "repo:^github\\.com/sourcegraph/" "-file:_test\\.go$" "\"@backend\"")`).Equal(t, test("(@backend) @quoted"))
+
+	t.Run("not a reference", func(t *testing.T) {
+		autogold.Expect(`"@Override"`).Equal(t, test("@Override"))
+		autogold.Expect(`"foo@backend"`).Equal(t, test("foo@backend"))
+		autogold.Expect(`"@backend.go"`).Equal(t, test("@backend.go"))
+		autogold.Expect(`"@backend"`).Equal(t, test(`content:"@backend"`))
+		autogold.Expect(`(and "repo:foo@backend" "bar")`).Equal(t, test("repo:foo@backend bar"))
+		autogold.Expect(`"a@b"`).Equal(t, test("/a@b/"))
+		autogold.Expect(`"a @backend"`).Equal(t, test("/a @backend/"))
+		autogold.Expect(`"'@backend' foo"`).Equal(t, test("'@backend' foo"))
+	})
+
+	t.Run("apostrophe", func(t *testing.T) {
+		autogold.Expect(`(and "repo:^github\\.com/sourcegraph/" "-file:_test\\.go$" "don't")`).Equal(t, test("don't @backend"))
+		autogold.Expect(`(and "repo:^github\\.com/sourcegraph/" "-file:_test\\.go$" "lang:go" "it's 'quoted @backend'")`).Equal(t, test("it's @go 'quoted @backend'"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		autogold.Expect("macro @loop refers to itself: @loop -> @loop2 -> @loop").Equal(t, test("@loop"))
+		autogold.Expect(`invalid macro @broken: unterminated literal: expected "`).Equal(t, test("@uses"))
+	})
+}
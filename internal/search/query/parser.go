@@ -85,7 +85,7 @@ func (node Pattern) String() string {
 func (node Pattern) IsRegExp() bool {
 	// NOTE: Structural tech debt. We want the patterns to be treated like
 	// literals and not passed down as regex to searcher.
-	return !node.Annotation.Labels.IsSet(Literal | Structural)
+	return !node.Annotation.Labels.IsSet(Literal | Structural | TreeSitter)
 }
 
 // RegExpPattern returns the pattern value as a regex string. If node.IsRegExp
@@ -1151,11 +1151,11 @@ func (p *parser) parseAnd() ([]Node, error) {
 	switch p.leafParser {
 	case SearchTypeRegex:
 		left, err = p.parseLeaves(Regexp)
-	case SearchTypeLiteral, SearchTypeStructural:
+	case SearchTypeLiteral, SearchTypeStructural, SearchTypeTreeSitter:
 		left, err = p.parseLeaves(Literal)
 	case SearchTypeStandard, SearchTypeLucky:
 		left, err = p.parseLeaves(Literal | Standard)
-	case SearchTypeKeyword:
+	case SearchTypeKeyword, SearchTypeFuzzy:
 		left, err = p.parseLeaves(Literal | Standard | QuotesAsLiterals)
 	default:
 		left, err = p.parseLeaves(Literal | Standard)
@@ -1229,7 +1229,7 @@ func Parse(in string, searchType SearchType) ([]Node, error) {
 	}
 
 	switch searchType {
-	case SearchTypeKeyword:
+	case SearchTypeKeyword, SearchTypeFuzzy:
 		parser.heuristics = balancedPattern | emptyParens
 	default:
 		parser.heuristics = balancedPattern | emptyParens | parensAsPatterns
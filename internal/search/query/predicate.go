@@ -41,6 +41,7 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.description":       func() Predicate { return &RepoHasDescriptionPredicate{} },
 		"has.meta":              func() Predicate { return &RepoHasMetaPredicate{} },
 		"has.topic":             func() Predicate { return &RepoHasTopicPredicate{} },
+		"has.symbol":            func() Predicate { return &RepoHasSymbolPredicate{} },
 
 		// Deprecated predicates
 		"has.tag":  func() Predicate { return &RepoHasTagPredicate{} },
@@ -53,9 +54,12 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 		"has.contributor":  func() Predicate { return &FileHasContributorPredicate{} },
+		"has.symbol":       func() Predicate { return &FileHasSymbolPredicate{} },
 	},
 	FieldRev: {
-		"at.time": func() Predicate { return &RevAtTimePredicate{} },
+		"at.time":      func() Predicate { return &RevAtTimePredicate{} },
+		"compare":      func() Predicate { return &RevComparePredicate{} },
+		"compare.time": func() Predicate { return &RevCompareTimePredicate{} },
 	},
 }
 
@@ -508,6 +512,28 @@ func (p *RepoHasTopicPredicate) Unmarshal(params string, negated bool) (err erro
 func (p *RepoHasTopicPredicate) Field() string { return FieldRepo }
 func (p *RepoHasTopicPredicate) Name() string  { return "has.topic" }
 
+/* repo:has.symbol(pattern) */
+
+type RepoHasSymbolPredicate struct {
+	Pattern string
+	Negated bool
+}
+
+func (f *RepoHasSymbolPredicate) Unmarshal(params string, negated bool) error {
+	if _, err := syntax.Parse(params, syntax.Perl); err != nil {
+		return errors.Errorf("has.symbol argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("has.symbol argument should not be empty")
+	}
+	f.Pattern = params
+	f.Negated = negated
+	return nil
+}
+
+func (f *RepoHasSymbolPredicate) Field() string { return FieldRepo }
+func (f *RepoHasSymbolPredicate) Name() string  { return "has.symbol" }
+
 // RepoContainsPredicate represents the `repo:contains(file:a content:b)` predicate.
 // DEPRECATED: this syntax is deprecated in favor of `repo:contains.file`.
 type RepoContainsPredicate struct {
@@ -640,6 +666,28 @@ func (f *FileHasContributorPredicate) Unmarshal(params string, negated bool) err
 func (f FileHasContributorPredicate) Field() string { return FieldFile }
 func (f FileHasContributorPredicate) Name() string  { return "has.contributor" }
 
+/* file:has.symbol(pattern) */
+
+type FileHasSymbolPredicate struct {
+	Pattern string
+	Negated bool
+}
+
+func (f *FileHasSymbolPredicate) Unmarshal(params string, negated bool) error {
+	if _, err := syntax.Parse(params, syntax.Perl); err != nil {
+		return errors.Errorf("file:has.symbol argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("file:has.symbol argument should not be empty")
+	}
+	f.Pattern = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasSymbolPredicate) Field() string { return FieldFile }
+func (f FileHasSymbolPredicate) Name() string  { return "has.symbol" }
+
 type RevAtTimePredicate struct {
 	RevAtTime
 }
@@ -670,3 +718,73 @@ func (f *RevAtTimePredicate) Unmarshal(params string, negated bool) error {
 func (f RevAtTimePredicate) Field() string { return FieldRev }
 
 func (f RevAtTimePredicate) Name() string { return "at.time" }
+
+/* rev:compare(base, head) */
+
+// RevComparePredicate represents the `rev:compare(base, head)` predicate, which
+// searches each repository at two revisions and only returns the matches that
+// were added or removed between them.
+type RevComparePredicate struct {
+	RevCompare
+}
+
+func (f *RevComparePredicate) Unmarshal(params string, negated bool) error {
+	if negated {
+		return &NegatedPredicateError{f.Field() + ":" + f.Name()}
+	}
+
+	elems := strings.Split(params, ",")
+	if len(elems) != 2 {
+		return errors.New("rev:compare() expects exactly two revisions")
+	}
+	base, head := strings.TrimSpace(elems[0]), strings.TrimSpace(elems[1])
+	if base == "" || head == "" {
+		return errors.New("rev:compare() revisions should not be empty")
+	}
+	f.Base = RevisionSpecifier{RevSpec: base}
+	f.Head = RevisionSpecifier{RevSpec: head}
+	return nil
+}
+
+func (f RevComparePredicate) Field() string { return FieldRev }
+func (f RevComparePredicate) Name() string  { return "compare" }
+
+/* rev:compare.time(since, until[, revspec]) */
+
+// RevCompareTimePredicate represents the `rev:compare.time(since, until)`
+// predicate. It behaves like rev:compare(), where each side is resolved like
+// rev:at.time() against an optional base revision (HEAD by default).
+type RevCompareTimePredicate struct {
+	RevCompare
+}
+
+func (f *RevCompareTimePredicate) Unmarshal(params string, negated bool) error {
+	if negated {
+		return &NegatedPredicateError{f.Field() + ":" + f.Name()}
+	}
+
+	elems := strings.Split(params, ",")
+	if len(elems) != 2 && len(elems) != 3 {
+		return errors.New("unexpected number of arguments to rev:compare.time()")
+	}
+
+	revSpec := "HEAD"
+	if len(elems) == 3 {
+		revSpec = strings.TrimSpace(elems[2])
+	}
+
+	since, err := gitdomain.ParseGitDate(strings.TrimSpace(elems[0]), time.Now)
+	if err != nil {
+		return err
+	}
+	until, err := gitdomain.ParseGitDate(strings.TrimSpace(elems[1]), time.Now)
+	if err != nil {
+		return err
+	}
+	f.Base = RevisionSpecifier{RevAtTime: &RevAtTime{RevSpec: revSpec, Timestamp: since}}
+	f.Head = RevisionSpecifier{RevAtTime: &RevAtTime{RevSpec: revSpec, Timestamp: until}}
+	return nil
+}
+
+func (f RevCompareTimePredicate) Field() string { return FieldRev }
+func (f RevCompareTimePredicate) Name() string  { return "compare.time" }
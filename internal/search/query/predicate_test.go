@@ -111,6 +111,100 @@ func TestRevAtTimePredicate(t *testing.T) {
 	})
 }
 
+func TestRevComparePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *RevComparePredicate
+		}
+
+		valid := []test{
+			{`two revs`, `v1.0.0,v2.0.0`, &RevComparePredicate{RevCompare{Base: RevisionSpecifier{RevSpec: "v1.0.0"}, Head: RevisionSpecifier{RevSpec: "v2.0.0"}}}},
+			{`weird spaces`, ` main~10 , main `, &RevComparePredicate{RevCompare{Base: RevisionSpecifier{RevSpec: "main~10"}, Head: RevisionSpecifier{RevSpec: "main"}}}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RevComparePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`one rev`, `main`, nil},
+			{`empty rev`, `main,`, nil},
+			{`too many commas`, `a, b, c`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RevComparePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestRevCompareTimePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *RevCompareTimePredicate
+		}
+
+		atTime := func(rev string, year int) RevisionSpecifier {
+			return RevisionSpecifier{RevAtTime: &RevAtTime{RevSpec: rev, Timestamp: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)}}
+		}
+
+		valid := []test{
+			{`dates only`, `2023-01-01, 2024-01-01`, &RevCompareTimePredicate{RevCompare{Base: atTime("HEAD", 2023), Head: atTime("HEAD", 2024)}}},
+			{`dates and rev`, `2023-01-01, 2024-01-01, main`, &RevCompareTimePredicate{RevCompare{Base: atTime("main", 2023), Head: atTime("main", 2024)}}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RevCompareTimePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`one date`, `2024-01-01`, nil},
+			{`invalid date`, `2024-13-01, 2024-01-01`, nil},
+			{`too many commas`, `a, b, c, d`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RevCompareTimePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
 func TestParseAsPredicate(t *testing.T) {
 	tests := []struct {
 		input  string
@@ -415,3 +509,52 @@ func TestFileHasContributorPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestHasSymbolPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected Predicate
+		}
+
+		valid := []test{
+			{`repo literal`, `NewServer`, false, &RepoHasSymbolPredicate{Pattern: "NewServer"}},
+			{`repo regex`, `^New.*Server$`, false, &RepoHasSymbolPredicate{Pattern: "^New.*Server$"}},
+			{`repo negated`, `NewServer`, true, &RepoHasSymbolPredicate{Pattern: "NewServer", Negated: true}},
+			{`file literal`, `NewServer`, false, &FileHasSymbolPredicate{Pattern: "NewServer"}},
+			{`file negated`, `NewServer`, true, &FileHasSymbolPredicate{Pattern: "NewServer", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := DefaultPredicateRegistry.Get(tc.expected.Field(), "has.symbol")
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`repo empty`, ``, false, &RepoHasSymbolPredicate{}},
+			{`repo invalid regex`, `([)`, false, &RepoHasSymbolPredicate{}},
+			{`file empty`, ``, false, &FileHasSymbolPredicate{}},
+			{`file invalid regex`, `([)`, false, &FileHasSymbolPredicate{}},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.expected.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
@@ -101,8 +101,12 @@ func For(searchType SearchType) step {
 		processType = succeeds(escapeParensHeuristic, substituteConcat(fuzzyRegexp))
 	case SearchTypeStructural:
 		processType = succeeds(labelStructural, ellipsesForHoles, substituteConcat(space))
+	case SearchTypeTreeSitter:
+		processType = succeeds(labelTreeSitter, substituteConcat(space))
 	case SearchTypeKeyword:
 		processType = succeeds(substituteConcatForKeyword(and))
+	case SearchTypeFuzzy:
+		processType = succeeds(substituteFuzzy)
 	}
 	normalize := succeeds(LowercaseFieldNames, SubstituteAliases(searchType), SubstituteCountAll)
 	return Sequence(normalize, processType)
@@ -162,6 +162,13 @@ func ParseRevisionSpecifier(spec string) (RevisionSpecifier, error) {
 	return RevisionSpecifier{RevSpec: spec}, nil
 }
 
+// RevCompare is a pair of revisions that a query is searched at, as specified
+// by the rev:compare() and rev:compare.time() predicates.
+type RevCompare struct {
+	Base RevisionSpecifier
+	Head RevisionSpecifier
+}
+
 type RevAtTime struct {
 	RevSpec   string
 	Timestamp time.Time
@@ -584,7 +584,31 @@ func ConcatRevFilters(b Basic) Basic {
 	if revision == "" {
 		return b
 	}
+	return concatRevision(b, revision)
+}
 
+// SplitRevCompare returns the queries to run at the base and head revisions of
+// a rev:compare() or rev:compare.time() predicate. ok is false if b contains
+// neither predicate. The queries search for all results, because the result
+// limit of b applies to the results that differ between both revisions.
+// Invariant: Guaranteed to succeed on a validated Basic query.
+func SplitRevCompare(b Basic) (base, head Basic, compare RevCompare, ok bool) {
+	VisitTypedPredicate(toNodes(b.Parameters), func(pred *RevComparePredicate) {
+		compare, ok = pred.RevCompare, true
+	})
+	VisitTypedPredicate(toNodes(b.Parameters), func(pred *RevCompareTimePredicate) {
+		compare, ok = pred.RevCompare, true
+	})
+	if !ok {
+		return b, b, compare, false
+	}
+	b = b.MapCountAll()
+	return concatRevision(b, compare.Base.String()), concatRevision(b, compare.Head.String()), compare, true
+}
+
+// concatRevision removes rev: filters from parameters and attaches revision as
+// @rev to the repo: filters.
+func concatRevision(b Basic, revision string) Basic {
 	// Remove any rev: fields
 	nodes := MapField(toNodes(b.Parameters), FieldRev, func(value string, negated bool, ann Annotation) Node {
 		return nil
@@ -615,6 +639,21 @@ func labelStructural(nodes []Node) []Node {
 	})
 }
 
+// labelTreeSitter converts Literal labels to TreeSitter labels. Like
+// structural queries, tree-sitter queries are parsed the same as literal
+// queries.
+func labelTreeSitter(nodes []Node) []Node {
+	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
+		annotation.Labels.Unset(Literal)
+		annotation.Labels.Set(TreeSitter)
+		return Pattern{
+			Value:      value,
+			Negated:    negated,
+			Annotation: annotation,
+		}
+	})
+}
+
 // ellipsesForHoles substitutes ellipses ... for :[_] holes in structural search queries.
 func ellipsesForHoles(nodes []Node) []Node {
 	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
@@ -495,6 +495,25 @@ func TestConcatRevFiltersTopLevelAnd(t *testing.T) {
 	}
 }
 
+func TestSplitRevCompare(t *testing.T) {
+	test := func(input string) string {
+		plan, err := Pipeline(InitRegexp(input))
+		if err != nil {
+			return err.Error()
+		}
+		base, head, _, ok := SplitRevCompare(plan[0])
+		if !ok {
+			return "not a comparison"
+		}
+		return "(" + toString(base.ToParseTree()) + ") (" + toString(head.ToParseTree()) + ")"
+	}
+
+	autogold.Expect(`("repo:foo@v1" "count:99999999") ("repo:foo@v2" "count:99999999")`).Equal(t, test("repo:foo rev:compare(v1, v2)"))
+	autogold.Expect(`("repo:foo@v1" "file:bar" "count:99999999" "baz") ("repo:foo@v2" "file:bar" "count:99999999" "baz")`).Equal(t, test("repo:foo file:bar rev:compare(v1, v2) baz"))
+	autogold.Expect(`("repo:foo@v1" "count:99999999") ("repo:foo@v2" "count:99999999")`).Equal(t, test("repo:foo count:5 rev:compare(v1, v2)"))
+	autogold.Expect("not a comparison").Equal(t, test("repo:foo rev:v1"))
+}
+
 func TestQueryField(t *testing.T) {
 	test := func(input, field string) string {
 		q, _ := ParseLiteral(input)
@@ -43,6 +43,8 @@ const (
 	SearchTypeStandard
 	SearchTypeCodyContext
 	SearchTypeKeyword
+	SearchTypeTreeSitter
+	SearchTypeFuzzy
 )
 
 func (s SearchType) String() string {
@@ -61,6 +63,10 @@ func (s SearchType) String() string {
 		return "codycontext"
 	case SearchTypeKeyword:
 		return "keyword"
+	case SearchTypeTreeSitter:
+		return "treesitter"
+	case SearchTypeFuzzy:
+		return "fuzzy"
 	default:
 		return fmt.Sprintf("unknown{%d}", s)
 	}
@@ -263,6 +269,21 @@ func (b Basic) MapCount(count int) Basic {
 	return Basic{Parameters: toParameters(parameters), Pattern: b.Pattern}
 }
 
+// MapCountAll returns a copy of a basic query with the count parameter
+// replaced by the equivalent of count:all. It is used for queries whose result
+// limit applies after their results are processed, like the queries of both
+// revisions of a rev:compare() search.
+func (b Basic) MapCountAll() Basic {
+	parameters := make([]Parameter, 0, len(b.Parameters)+1)
+	for _, p := range b.Parameters {
+		if p.Field != FieldCount {
+			parameters = append(parameters, p)
+		}
+	}
+	parameters = append(parameters, Parameter{Field: FieldCount, Value: countAllLimitStr})
+	return b.MapParameters(parameters)
+}
+
 func (b Basic) String() string {
 	return b.toString(func(nodes []Node) string {
 		return Q(nodes).String()
@@ -307,6 +328,10 @@ func (b Basic) IsStructural() bool {
 	return b.HasPatternLabel(Structural)
 }
 
+func (b Basic) IsTreeSitter() bool {
+	return b.HasPatternLabel(TreeSitter)
+}
+
 // PatternString returns the simple string pattern of a basic query. It assumes
 // there is only on pattern atom.
 func (b Basic) PatternString() string {
@@ -344,6 +369,12 @@ func (p Parameters) IncludeExcludeValues(field string) (include, exclude []strin
 			// Skip predicates
 			return
 		}
+		if field == FieldFile {
+			if _, ok := parseFileMetricFilter(v, negated, ann); ok {
+				// Skip file:size and file:lines filters
+				return
+			}
+		}
 
 		if negated {
 			exclude = append(exclude, v)
@@ -359,11 +390,13 @@ func (p Parameters) IncludeExcludeValues(field string) (include, exclude []strin
 // - repo:contains.path(foo) || repo:has.path(foo)
 // - repo:contains.content(c) || repo:has.content(c)
 // - repo:contains(file:foo content:bar)
+// - repo:has.symbol(s)
 // - repohasfile:f
 type RepoHasFileContentArgs struct {
 	// At least one of these strings should be non-empty
 	Path    string // optional
 	Content string // optional
+	Symbol  string // optional
 	Negated bool
 }
 
@@ -406,6 +439,13 @@ func (p Parameters) RepoHasFileContent() (res []RepoHasFileContentArgs) {
 		})
 	})
 
+	VisitTypedPredicate(nodes, func(pred *RepoHasSymbolPredicate) {
+		res = append(res, RepoHasFileContentArgs{
+			Symbol:  pred.Pattern,
+			Negated: pred.Negated,
+		})
+	})
+
 	return res
 }
 
@@ -416,6 +456,27 @@ func (p Parameters) FileContainsContent() (include []string) {
 	return include
 }
 
+func (p Parameters) FileHasSymbol() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasSymbolPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Pattern)
+		} else {
+			include = append(include, pred.Pattern)
+		}
+	})
+	return include, exclude
+}
+
+// FileMetricFilters returns the file:size and file:lines filters.
+func (p Parameters) FileMetricFilters() (filters []FileMetricFilter) {
+	VisitField(toNodes(p), FieldFile, func(v string, negated bool, ann Annotation) {
+		if f, ok := parseFileMetricFilter(v, negated, ann); ok {
+			filters = append(filters, f)
+		}
+	})
+	return filters
+}
+
 type RepoHasCommitAfterArgs struct {
 	TimeRef string
 	Negated bool
@@ -616,6 +677,16 @@ func (p Parameters) Archived() *YesNoOnly {
 	return p.yesNoOnlyValue(FieldArchived)
 }
 
+// Submodules returns whether text search descends into the submodules of the
+// searched repositories. It defaults to No.
+func (p Parameters) Submodules() YesNoOnly {
+	v := p.yesNoOnlyValue(FieldSubmodules)
+	if v == nil {
+		return No
+	}
+	return *v
+}
+
 func (p Parameters) Repositories() (repos []ParsedRepoFilter, negatedRepos []string) {
 	VisitField(toNodes(p), FieldRepo, func(value string, negated bool, a Annotation) {
 		if a.Labels.IsSet(IsPredicate) {
@@ -57,3 +57,73 @@ func TestRepoHasKVPs(t *testing.T) {
 
 	require.Equal(t, want, ps.RepoHasKVPs())
 }
+
+func TestFileMetricFilters(t *testing.T) {
+	ps := Parameters{
+		Parameter{Field: FieldFile, Value: "size>100KB"},
+		Parameter{Field: FieldFile, Value: "lines<=50", Negated: true},
+		Parameter{Field: FieldFile, Value: "size=2mb"},
+		Parameter{Field: FieldFile, Value: "lines>10KB"},
+		Parameter{Field: FieldFile, Value: "size>1", Annotation: Annotation{Labels: Quoted}},
+		Parameter{Field: FieldFile, Value: `\.go$`},
+	}
+
+	want := []FileMetricFilter{
+		{Metric: FileMetricSize, Op: ">", Value: 100 << 10},
+		{Metric: FileMetricLines, Op: "<=", Value: 50, Negated: true},
+		{Metric: FileMetricSize, Op: "=", Value: 2 << 20},
+	}
+	require.Equal(t, want, ps.FileMetricFilters())
+	// Zoekt evaluates the filters, so they don't disable indexed search.
+	require.Equal(t, Yes, ps.Index())
+
+	include, exclude := ps.IncludeExcludeValues(FieldFile)
+	require.Equal(t, []string{"lines>10KB", "size>1", `\.go$`}, include)
+	require.Empty(t, exclude)
+
+	content := []byte("a\nb\nc")
+	require.True(t, FileMetricFilter{Metric: FileMetricLines, Op: "=", Value: 3}.Matches(content))
+	require.True(t, FileMetricFilter{Metric: FileMetricLines, Op: "=", Value: 3}.Matches([]byte("a\nb\nc\n")))
+	require.True(t, FileMetricFilter{Metric: FileMetricSize, Op: ">=", Value: 5}.Matches(content))
+	require.False(t, FileMetricFilter{Metric: FileMetricSize, Op: "<", Value: 5}.Matches(content))
+	require.True(t, FileMetricFilter{Metric: FileMetricSize, Op: "<", Value: 5, Negated: true}.Matches(content))
+	require.True(t, FileMetricFilter{Metric: FileMetricLines, Op: "<", Value: 1}.Matches(nil))
+	require.True(t, FileMetricFilter{Metric: FileMetricSize, Op: ">", Value: 2 << 20}.Compare(3<<20))
+	require.Equal(t, int64(0), CountLines(nil))
+}
+
+func TestSubmodules(t *testing.T) {
+	require.Equal(t, No, Parameters{}.Submodules())
+	require.Equal(t, Yes, Parameters{}.Index())
+
+	// The superproject is still searched with the index, only its submodules
+	// are searched by searcher.
+	ps := Parameters{Parameter{Field: FieldSubmodules, Value: "yes"}}
+	require.Equal(t, Yes, ps.Submodules())
+	require.Equal(t, Yes, ps.Index())
+
+	ps = Parameters{Parameter{Field: FieldSubmodules, Value: "no"}}
+	require.Equal(t, No, ps.Submodules())
+	require.Equal(t, Yes, ps.Index())
+}
+
+func TestMapCountAll(t *testing.T) {
+	b := Basic{Parameters: Parameters{
+		{Field: FieldRepo, Value: "foo"},
+		{Field: FieldCount, Value: "5"},
+	}}
+
+	got := b.MapCountAll()
+	require.Equal(t, Parameters{
+		{Field: FieldRepo, Value: "foo"},
+		{Field: FieldCount, Value: countAllLimitStr},
+	}, got.Parameters)
+	require.Equal(t, CountAllLimit, got.MaxResults(0))
+
+	// The query we mapped still has its own limit.
+	require.Equal(t, 5, b.MaxResults(0))
+
+	// A query without a count: parameter also gets one.
+	got = Basic{Parameters: Parameters{{Field: FieldRepo, Value: "foo"}}}.MapCountAll()
+	require.Equal(t, CountAllLimit, got.MaxResults(0))
+}
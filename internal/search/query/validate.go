@@ -214,6 +214,14 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return nil
 	}
 
+	isSignedValue := func() error {
+		switch strings.ToLower(value) {
+		case "yes", "no", "invalid", "untrusted":
+			return nil
+		}
+		return errors.Errorf("invalid value %q for field %q. Valid values are: yes, no, invalid, untrusted", value, field)
+	}
+
 	isUnrecognizedField := func() error {
 		return errors.Errorf("unrecognized field %q", field)
 	}
@@ -279,10 +287,14 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldCommitter,
 		FieldMessage:
 		return satisfies(isValidRegexp)
+	case
+		FieldSigned:
+		return satisfies(isSingular, isNotNegated, isSignedValue)
 	case
 		FieldIndex,
 		FieldFork,
-		FieldArchived:
+		FieldArchived,
+		FieldSubmodules:
 		return satisfies(isSingular, isNotNegated, isYesNoOnly)
 	case
 		FieldCount:
@@ -346,7 +358,7 @@ func validateCommitParameters(nodes []Node) error {
 	var seenCommitParam string
 	var typeCommitExists bool
 	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
-		if field == FieldAuthor || field == FieldBefore || field == FieldAfter || field == FieldMessage {
+		if field == FieldAuthor || field == FieldBefore || field == FieldAfter || field == FieldMessage || field == FieldSigned {
 			seenCommitParam = field
 		}
 		if field == FieldType && (value == "commit" || value == "diff") {
@@ -383,6 +395,50 @@ func validateTypeStructural(nodes []Node) error {
 	return nil
 }
 
+// validateTreeSitter checks that tree-sitter queries only search file contents
+// (type:file) with a single tree-sitter query, and that select:capture.<name> is only used
+// with tree-sitter queries.
+func validateTreeSitter(nodes []Node) error {
+	var seenTreeSitter, seenCapture bool
+	var types []string
+	VisitPattern(nodes, func(_ string, _ bool, annotation Annotation) {
+		if annotation.Labels.IsSet(TreeSitter) {
+			seenTreeSitter = true
+		}
+	})
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		switch field {
+		case FieldType:
+			types = append(types, value)
+		case FieldSelect:
+			seenCapture = seenCapture || strings.HasPrefix(value, filter.Capture)
+		}
+	})
+
+	if !seenTreeSitter {
+		if seenCapture {
+			return errors.New("select:capture is only supported for tree-sitter search patterns")
+		}
+		return nil
+	}
+	for _, t := range types {
+		if t != "file" {
+			return errors.Errorf("this tree-sitter search query specifies `type:%s` and is not supported. Tree-sitter queries only apply to searching file contents", t)
+		}
+	}
+	combined := Exists(nodes, func(node Node) bool {
+		operator, ok := node.(Operator)
+		return ok && (operator.Kind == And || operator.Kind == Or) && len(operator.Operands) > 1 && Exists(operator.Operands, func(node Node) bool {
+			_, ok := node.(Pattern)
+			return ok
+		})
+	})
+	if combined {
+		return errors.New("tree-sitter search does not support combining patterns with `and` or `or`. Use alternations like `[(a) (b)]` inside the tree-sitter query instead")
+	}
+	return nil
+}
+
 func validateRefGlobs(nodes []Node) error {
 	if !ContainsRefGlobs(nodes) {
 		return nil
@@ -397,6 +453,60 @@ func validateRefGlobs(nodes []Node) error {
 	return nil
 }
 
+// validateFileMetrics validates that file:size and file:lines filters can be
+// executed. They are only evaluated for file contents and paths.
+func validateFileMetrics(nodes []Node) error {
+	var seenFileMetric bool
+	var types []string
+	VisitParameter(nodes, func(field, value string, negated bool, annotation Annotation) {
+		switch field {
+		case FieldFile:
+			_, ok := parseFileMetricFilter(value, negated, annotation)
+			seenFileMetric = seenFileMetric || ok
+		case FieldType:
+			types = append(types, value)
+		}
+	})
+	if !seenFileMetric {
+		return nil
+	}
+	for _, t := range types {
+		if t == "symbol" || t == "commit" || t == "diff" {
+			return errors.Errorf("file:size and file:lines filters are not supported for type:%s", t)
+		}
+	}
+	return nil
+}
+
+// validateSubmodules validates that a submodules: parameter can be executed.
+// Only the searcher text search job descends into submodules.
+func validateSubmodules(nodes []Node) error {
+	var submodulesValue, indexValue string
+	var types []string
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		switch field {
+		case FieldSubmodules:
+			submodulesValue = value
+		case FieldIndex:
+			indexValue = value
+		case FieldType:
+			types = append(types, value)
+		}
+	})
+	if submodulesValue == "" || parseYesNoOnly(submodulesValue) == No {
+		return nil
+	}
+	if parseYesNoOnly(indexValue) == Only {
+		return errors.Errorf("invalid index:%s (submodules:%s is not supported for indexed searches)", indexValue, submodulesValue)
+	}
+	for _, t := range types {
+		if t != "file" {
+			return errors.Errorf("submodules:%s is only supported for type:file, not type:%s", submodulesValue, t)
+		}
+	}
+	return nil
+}
+
 // validatePredicates validates predicate parameters with respect to their validation logic.
 func validatePredicate(field, value string, negated bool) error {
 	name, params := ParseAsPredicate(value)                // guaranteed to succeed
@@ -479,6 +589,9 @@ func validatePattern(nodes []Node) error {
 		if annotation.Labels.IsSet(Structural) && negated {
 			err = errors.New("the query contains a negated search pattern. Structural search does not support negated search patterns at the moment")
 		}
+		if annotation.Labels.IsSet(TreeSitter) && negated {
+			err = errors.New("the query contains a negated search pattern. Tree-sitter search does not support negated search patterns")
+		}
 	})
 	return err
 }
@@ -500,7 +613,10 @@ func validate(nodes []Node) error {
 		validateRepoHasFile,
 		validateCommitParameters,
 		validateTypeStructural,
+		validateTreeSitter,
 		validateRefGlobs,
+		validateFileMetrics,
+		validateSubmodules,
 	)
 }
 
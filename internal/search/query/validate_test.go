@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidation(t *testing.T) {
@@ -121,6 +122,53 @@ func TestValidation(t *testing.T) {
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents and is not currently supported for diff searches",
 			searchType: SearchTypeStructural,
 		},
+		{
+			input:      `NOT identifier`,
+			want:       "the query contains a negated search pattern. Tree-sitter search does not support negated search patterns",
+			searchType: SearchTypeTreeSitter,
+		},
+		{
+			input:      "(identifier) type:diff",
+			want:       "this tree-sitter search query specifies `type:diff` and is not supported. Tree-sitter queries only apply to searching file contents",
+			searchType: SearchTypeTreeSitter,
+		},
+		{
+			input:      "(identifier) or (string)",
+			want:       "tree-sitter search does not support combining patterns with `and` or `or`. Use alternations like `[(a) (b)]` inside the tree-sitter query instead",
+			searchType: SearchTypeTreeSitter,
+		},
+		{
+			input: "foo select:capture.name",
+			want:  "select:capture is only supported for tree-sitter search patterns",
+		},
+		{
+			input: "foo file:lines<50 type:symbol",
+			want:  "file:size and file:lines filters are not supported for type:symbol",
+		},
+		{
+			input: "foo submodules:yes index:only",
+			want:  "invalid index:only (submodules:yes is not supported for indexed searches)",
+		},
+		{
+			input: "foo submodules:only type:commit",
+			want:  "submodules:only is only supported for type:file, not type:commit",
+		},
+		{
+			input: "foo submodules:maybe",
+			want:  `invalid value "maybe" for field "submodules". Valid values are: yes, only, no`,
+		},
+		{
+			input: "foo type:commit signed:maybe",
+			want:  `invalid value "maybe" for field "signed". Valid values are: yes, no, invalid, untrusted`,
+		},
+		{
+			input: "foo type:commit -signed:yes",
+			want:  `field "signed" does not support negation`,
+		},
+		{
+			input: "foo signed:yes",
+			want:  `your query contains the field 'signed', which requires type:commit or type:diff in the query`,
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
@@ -137,6 +185,11 @@ func TestValidation(t *testing.T) {
 	}
 }
 
+func TestValidateTreeSitter_typeFile(t *testing.T) {
+	_, err := Pipeline(Init("(identifier) type:file", SearchTypeTreeSitter))
+	require.NoError(t, err)
+}
+
 func TestIsCaseSensitive(t *testing.T) {
 	cases := []struct {
 		name  string
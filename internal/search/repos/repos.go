@@ -36,6 +36,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	searchzoekt "github.com/sourcegraph/sourcegraph/internal/search/zoekt"
 	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -912,6 +913,9 @@ func (r *Resolver) filterRepoHasFileContent(
 				return false, nil
 			}
 
+			if arg.Symbol != "" {
+				return repoHasSymbolAtCommit(ctx, repo, commitID, arg, op.CaseSensitiveRepoFilters)
+			}
 			return r.repoHasFileContentAtCommit(ctx, searcherGRPCConnectionCache, repo, commitID, arg)
 		}
 
@@ -999,6 +1003,23 @@ func (r *Resolver) repoHasFileContentAtCommit(ctx context.Context, searcherGRPCC
 	return foundMatches, err
 }
 
+// repoHasSymbolAtCommit asks the symbols service whether the repo defines at
+// least one symbol matching args.Symbol at the given commit.
+func repoHasSymbolAtCommit(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID, args query.RepoHasFileContentArgs, caseSensitive bool) (bool, error) {
+	res, _, err := symbols.DefaultClient.Search(ctx, search.SymbolsParameters{
+		Repo:            repo.Name,
+		CommitID:        commitID,
+		Query:           args.Symbol,
+		IsRegExp:        true,
+		IsCaseSensitive: caseSensitive,
+		First:           1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(res) > 0, nil
+}
+
 // computeExcludedRepos computes the ExcludedRepos that the given RepoOptions would not match. This is
 // used to show in the search UI what repos are excluded precisely.
 func computeExcludedRepos(ctx context.Context, db database.DB, op search.RepoOptions) (ex ExcludedRepos, err error) {
@@ -44,6 +44,9 @@ type CommitMatch struct {
 	// * when sub-repo permissions filtering has been enabled,
 	// * when ownership filtering clause is used, and search result is commits.
 	ModifiedFiles []string
+
+	// Change is set for matches of rev:compare() searches.
+	Change Change
 }
 
 func (cm *CommitMatch) Body() MatchedString {
@@ -97,6 +97,9 @@ type FileMatch struct {
 
 	LimitHit bool
 
+	// Change is set for matches of rev:compare() searches.
+	Change Change `json:"-"`
+
 	// Debug is optionally set with a debug message explaining the result.
 	//
 	// Note: this is a pointer since usually this is unset. Pointer is 8 bytes
@@ -152,8 +155,10 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 			return fm
 		}
 		return nil
-	case filter.Content:
-		// Only return file match if line matches exist
+	case filter.Content, filter.Capture:
+		// Only return file match if line matches exist. For capture
+		// selects, searcher only returns the ranges of the selected
+		// capture.
 		if len(fm.ChunkMatches) > 0 {
 			fm.Symbols = nil
 			fm.PathMatches = nil
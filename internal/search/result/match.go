@@ -38,6 +38,17 @@ var (
 	_ Match = (*OwnerMatch)(nil)
 )
 
+// Change is how a match differs between the base and head revisions searched
+// by a rev:compare() search.
+type Change string
+
+const (
+	// ChangeAdded is set on matches only found at the head revision.
+	ChangeAdded Change = "added"
+	// ChangeRemoved is set on matches only found at the base revision.
+	ChangeRemoved Change = "removed"
+)
+
 // Match ranks are used for sorting the different match types.
 // Match types with lower ranks will be sorted before match types
 // with higher ranks.
@@ -45,6 +45,13 @@ func Search(
 		return MockSearch(ctx, repo, repoID, commit, p, fetchTimeout, onMatch)
 	}
 
+	// Tree-sitter searches select a capture by its full path, e.g.
+	// capture.function.name, other searches only need the result type.
+	sel := p.Select.Root()
+	if p.IsTreeSitterPat {
+		sel = p.Select.String()
+	}
+
 	r := (&protocol.Request{
 		Repo:   repo,
 		RepoID: repoID,
@@ -57,14 +64,16 @@ func Search(
 			IncludeLangs:                 p.IncludeLangs,
 			ExcludeLangs:                 p.ExcludeLangs,
 			CombyRule:                    p.CombyRule,
-			Select:                       p.Select.Root(),
+			Select:                       sel,
 			Limit:                        int(p.FileMatchLimit),
 			IsStructuralPat:              p.IsStructuralPat,
+			IsTreeSitterPat:              p.IsTreeSitterPat,
 			IsCaseSensitive:              p.IsCaseSensitive,
 			PathPatternsAreCaseSensitive: p.PathPatternsAreCaseSensitive,
 			PatternMatchesContent:        p.PatternMatchesContent,
 			PatternMatchesPath:           p.PatternMatchesPath,
 			Languages:                    p.Languages,
+			FileMetrics:                  p.FileMetrics,
 		},
 		Indexed:         indexed,
 		FetchTimeout:    fetchTimeout,
@@ -2,6 +2,7 @@ package searcher
 
 import (
 	"context"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
@@ -50,6 +52,12 @@ type TextSearchJob struct {
 	// to true if the user requests a specific timeout or maximum result size.
 	UseFullDeadline bool
 
+	// Submodules controls whether the submodules of the searched
+	// repositories are searched as well, at the commits pinned by their
+	// superprojects. With query.Only, only the submodules are searched. The
+	// zero value is equivalent to query.No.
+	Submodules query.YesNoOnly
+
 	Features search.Features
 }
 
@@ -91,44 +99,67 @@ func (s *TextSearchJob) Run(ctx context.Context, clients job.RuntimeClients, str
 	}
 	textSearchLimiter.SetLimit(len(eps) * 32)
 
+	// searchedSubmodules tracks the submodule revisions we searched, since
+	// several of the searched repositories may share a submodule.
+	var searchedSubmodules sync.Map
+
 	g, ctx := errgroup.WithContext(ctx)
-	g.Go(func() error {
-		for _, repoAllRevs := range s.Repos {
-			repo := repoAllRevs.Repo // capture repo
-			if len(repoAllRevs.Revs) == 0 {
-				continue
-			}
 
-			for _, rev := range repoAllRevs.Revs {
-				rev := rev // capture rev
-				limitCtx, limitDone, err := textSearchLimiter.Acquire(ctx)
+	// searchRev searches rev of repo and then schedules the search of its
+	// submodules, which run concurrently with all other searches. depth is 0
+	// for the repositories of the job and increases for nested submodules.
+	var searchRev func(repo types.MinimalRepo, rev string, depth int) error
+	searchRev = func(repo types.MinimalRepo, rev string, depth int) error {
+		limitCtx, limitDone, err := textSearchLimiter.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+
+		g.Go(func() error {
+			submodules, err := func() ([]submoduleRevision, error) {
+				// We release the limiter before scheduling the search of
+				// the submodules, which acquire it themselves.
+				ctx, done := limitCtx, limitDone
+				defer done()
+
+				if depth > 0 || s.Submodules != query.Only {
+					if err := s.searchRepoRev(ctx, clients, repo, rev, fetchTimeout, stream); err != nil {
+						return nil, err
+					}
+				}
+				if s.Submodules == "" || s.Submodules == query.No || depth >= maxSubmoduleDepth {
+					return nil, nil
+				}
+				submodules, err := listSubmodules(ctx, clients.DB, clients.Gitserver, repo.Name, rev)
 				if err != nil {
+					clients.Logger.Warn("listing submodules failed", log.Error(err), log.String("repo", string(repo.Name)), log.String("rev", rev))
+					return nil, nil
+				}
+				return submodules, nil
+			}()
+			if err != nil {
+				return err
+			}
+
+			for _, sm := range submodules {
+				if _, loaded := searchedSubmodules.LoadOrStore(sm, struct{}{}); loaded {
+					continue
+				}
+				if err := searchRev(sm.Repo, string(sm.Commit), depth+1); err != nil {
 					return err
 				}
+			}
+			return nil
+		})
+		return nil
+	}
 
-				g.Go(func() error {
-					ctx, done := limitCtx, limitDone
-					defer done()
-
-					repoLimitHit, err := s.searchFilesInRepo(ctx, clients.Gitserver, clients.SearcherURLs, clients.SearcherGRPCConnectionCache, repo, repo.Name, rev, s.Indexed, s.PatternInfo, s.NumContextLines, fetchTimeout, stream)
-					if err != nil {
-						tr.SetAttributes(
-							repo.Name.Attr(),
-							trace.Error(err),
-							attribute.Bool("timeout", errcode.IsTimeout(err)),
-							attribute.Bool("temporary", errcode.IsTemporary(err)))
-						clients.Logger.Warn("searchFilesInRepo failed", log.Error(err), log.String("repo", string(repo.Name)))
-					}
-					// non-diff search reports timeout through err, so pass false for timedOut
-					status, err := search.HandleRepoSearchResult(repo.ID, []string{rev}, repoLimitHit, false, err)
-					stream.Send(streaming.SearchEvent{
-						Stats: streaming.Stats{
-							Status:     status,
-							IsLimitHit: repoLimitHit,
-						},
-					})
+	g.Go(func() error {
+		for _, repoAllRevs := range s.Repos {
+			for _, rev := range repoAllRevs.Revs {
+				if err := searchRev(repoAllRevs.Repo, rev, 0); err != nil {
 					return err
-				})
+				}
 			}
 		}
 
@@ -138,6 +169,29 @@ func (s *TextSearchJob) Run(ctx context.Context, clients job.RuntimeClients, str
 	return nil, g.Wait()
 }
 
+// searchRepoRev searches rev of repo and reports the outcome on stream. Errors
+// are only returned if the search as a whole should fail.
+func (s *TextSearchJob) searchRepoRev(ctx context.Context, clients job.RuntimeClients, repo types.MinimalRepo, rev string, fetchTimeout time.Duration, stream streaming.Sender) error {
+	repoLimitHit, err := s.searchFilesInRepo(ctx, clients.Gitserver, clients.SearcherURLs, clients.SearcherGRPCConnectionCache, repo, repo.Name, rev, s.Indexed, s.PatternInfo, s.NumContextLines, fetchTimeout, stream)
+	if err != nil {
+		trace.FromContext(ctx).SetAttributes(
+			repo.Name.Attr(),
+			trace.Error(err),
+			attribute.Bool("timeout", errcode.IsTimeout(err)),
+			attribute.Bool("temporary", errcode.IsTemporary(err)))
+		clients.Logger.Warn("searchFilesInRepo failed", log.Error(err), log.String("repo", string(repo.Name)))
+	}
+	// non-diff search reports timeout through err, so pass false for timedOut
+	status, err := search.HandleRepoSearchResult(repo.ID, []string{rev}, repoLimitHit, false, err)
+	stream.Send(streaming.SearchEvent{
+		Stats: streaming.Stats{
+			Status:     status,
+			IsLimitHit: repoLimitHit,
+		},
+	})
+	return err
+}
+
 func (s *TextSearchJob) Name() string {
 	return "SearcherTextSearchJob"
 }
@@ -156,6 +210,9 @@ func (s *TextSearchJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
 		res = append(res,
 			attribute.Bool("indexed", s.Indexed),
 		)
+		if s.Submodules != "" && s.Submodules != query.No {
+			res = append(res, attribute.String("submodules", string(s.Submodules)))
+		}
 	}
 	return res
 }
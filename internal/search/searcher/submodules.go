@@ -0,0 +1,144 @@
+package searcher
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/go-git/go-git/v5/plumbing/format/config"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxSubmoduleDepth is how deep text search descends into nested submodules.
+const maxSubmoduleDepth = 3
+
+// submoduleRevision is a submodule at the commit pinned by its superproject.
+type submoduleRevision struct {
+	Repo   types.MinimalRepo
+	Commit api.CommitID
+}
+
+// listSubmodules returns the submodules of repo at rev which resolve to
+// repositories on this instance that are visible to the actor in ctx.
+// Submodules pointing elsewhere are skipped.
+func listSubmodules(ctx context.Context, db database.DB, client gitserver.Client, repo api.RepoName, rev string) ([]submoduleRevision, error) {
+	commit, err := client.ResolveRevision(ctx, repo, rev, gitserver.ResolveRevisionOptions{EnsureRevision: false})
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := client.NewFileReader(ctx, repo, commit, ".gitmodules")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer r.Close()
+
+	var modconf config.Config
+	if err := config.NewDecoder(r).Decode(&modconf); err != nil {
+		return nil, errors.Wrap(err, "parsing .gitmodules")
+	}
+
+	// Submodules usually live in few directories, so we read each directory
+	// containing a submodule once to learn the pinned commits and the
+	// repositories gitserver resolved the submodule URLs to.
+	paths := make(map[string]struct{})
+	dirs := make(map[string]struct{})
+	for _, sub := range modconf.Section("submodule").Subsections {
+		p := sub.Option("path")
+		if p == "" {
+			continue
+		}
+		p = path.Clean(p)
+		paths[p] = struct{}{}
+		dirs[path.Dir(p)] = struct{}{}
+	}
+
+	var pinned []gitdomain.Submodule
+	for dir := range dirs {
+		if dir == "." {
+			dir = ""
+		}
+		sms, err := readSubmodules(ctx, client, repo, commit, dir, paths)
+		if err != nil {
+			return nil, err
+		}
+		pinned = append(pinned, sms...)
+	}
+	if len(pinned) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(pinned))
+	for _, sm := range pinned {
+		names = append(names, string(sm.RepoName))
+	}
+	// Repositories the actor can't see are not returned.
+	repos, err := db.Repos().ListMinimalRepos(ctx, database.ReposListOptions{Names: names})
+	if err != nil {
+		return nil, err
+	}
+	reposByName := make(map[api.RepoName]types.MinimalRepo, len(repos))
+	for _, r := range repos {
+		reposByName[r.Name] = r
+	}
+
+	var submodules []submoduleRevision
+	for _, sm := range pinned {
+		r, ok := reposByName[sm.RepoName]
+		if !ok {
+			continue
+		}
+		submodules = append(submodules, submoduleRevision{Repo: r, Commit: sm.CommitID})
+	}
+	sort.Slice(submodules, func(i, j int) bool {
+		if submodules[i].Repo.Name != submodules[j].Repo.Name {
+			return submodules[i].Repo.Name < submodules[j].Repo.Name
+		}
+		return submodules[i].Commit < submodules[j].Commit
+	})
+	return submodules, nil
+}
+
+// readSubmodules returns the submodules in dir of repo at commit whose path is
+// in paths and which resolve to a repository on this instance.
+func readSubmodules(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID, dir string, paths map[string]struct{}) ([]gitdomain.Submodule, error) {
+	it, err := client.ReadDir(ctx, repo, commit, dir, false)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// .gitmodules can list submodules that were since removed.
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer it.Close()
+
+	var submodules []gitdomain.Submodule
+	for {
+		fi, err := it.Next()
+		if err == io.EOF {
+			return submodules, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := paths[fi.Name()]; !ok {
+			continue
+		}
+		sm, ok := fi.Sys().(gitdomain.Submodule)
+		if !ok || sm.RepoName == "" {
+			continue
+		}
+		submodules = append(submodules, sm)
+	}
+}
@@ -0,0 +1,91 @@
+package searcher
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestListSubmodules(t *testing.T) {
+	gitmodules := `[submodule "lib"]
+	path = vendor/lib
+	url = https://github.com/sourcegraph/lib
+[submodule "external"]
+	path = external
+	url = https://example.com/external.git
+[submodule "private"]
+	path = private
+	url = https://github.com/sourcegraph/private
+[submodule "removed"]
+	path = removed
+	url = https://github.com/sourcegraph/removed
+`
+	submodules := map[string]gitdomain.Submodule{
+		"vendor/lib": {Path: "vendor/lib", CommitID: "libcommit", RepoName: "github.com/sourcegraph/lib"},
+		"external":   {Path: "external", CommitID: "extcommit"},
+		"private":    {Path: "private", CommitID: "privatecommit", RepoName: "github.com/sourcegraph/private"},
+	}
+
+	gs := gitserver.NewMockClient()
+	gs.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+	gs.NewFileReaderFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, commit api.CommitID, name string) (io.ReadCloser, error) {
+		require.Equal(t, api.CommitID("deadbeef"), commit)
+		require.Equal(t, ".gitmodules", name)
+		return io.NopCloser(strings.NewReader(gitmodules)), nil
+	})
+	gs.ReadDirFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, dir string, recurse bool) (gitserver.ReadDirIterator, error) {
+		require.False(t, recurse)
+		var fis []fs.FileInfo
+		for p, sm := range submodules {
+			if d := path.Dir(p); d == dir || (d == "." && dir == "") {
+				fis = append(fis, &fileutil.FileInfo{Name_: p, Mode_: gitdomain.ModeSubmodule, Sys_: sm})
+			}
+		}
+		if dir == "" {
+			fis = append(fis, &fileutil.FileInfo{Name_: "README.md"})
+		}
+		return gitserver.NewReadDirIteratorFromSlice(fis), nil
+	})
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]types.MinimalRepo, error) {
+		require.ElementsMatch(t, []string{"github.com/sourcegraph/lib", "github.com/sourcegraph/private"}, opts.Names)
+		// Repositories the actor can't see are not returned.
+		return []types.MinimalRepo{{ID: 2, Name: "github.com/sourcegraph/lib"}}, nil
+	})
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	got, err := listSubmodules(context.Background(), db, gs, "github.com/sourcegraph/superproject", "HEAD")
+	require.NoError(t, err)
+	require.Equal(t, []submoduleRevision{{
+		Repo:   types.MinimalRepo{ID: 2, Name: "github.com/sourcegraph/lib"},
+		Commit: "libcommit",
+	}}, got)
+
+	// Each directory is read once, and all repositories are looked up at once.
+	require.Len(t, gs.ReadDirFunc.History(), 2)
+	require.Len(t, repos.ListMinimalReposFunc.History(), 1)
+
+	t.Run("no .gitmodules", func(t *testing.T) {
+		gs.NewFileReaderFunc.PushReturn(nil, &os.PathError{Op: "open", Path: ".gitmodules", Err: os.ErrNotExist})
+
+		got, err := listSubmodules(context.Background(), db, gs, "github.com/sourcegraph/superproject", "HEAD")
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+}
@@ -0,0 +1,150 @@
+package smartsearch
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	conf.ContributeValidator(func(c conftypes.SiteConfigQuerier) conf.Problems {
+		return conf.NewSiteProblems(validateSiteRules(c.SiteConfig().SearchSmartSearchRules)...)
+	})
+}
+
+// validateSiteRules returns a problem for each rule in "search.smartSearchRules"
+// that siteRules ignores.
+func validateSiteRules(configured []*schema.SmartSearchRule) (problems []string) {
+	for i, c := range configured {
+		if c == nil {
+			continue
+		}
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("`search.smartSearchRules[%d].pattern` is invalid: %s", i, err))
+		}
+	}
+	return problems
+}
+
+// siteRules converts the rules configured in "search.smartSearchRules" to
+// narrowing and widening rules. A configured rule replaces every search term
+// that matches its pattern with its replacement query, as long as the query
+// satisfies its conditions. Rules with an invalid pattern are ignored, they
+// are reported by site configuration validation.
+func siteRules(configured []*schema.SmartSearchRule) (narrow, widen []rule) {
+	for _, c := range configured {
+		if c == nil {
+			continue
+		}
+		re, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			continue
+		}
+
+		r := rule{
+			description: c.Description,
+			transform:   []transform{ruleConditions(c.Conditions), replacePatterns(re, c.Replacement)},
+		}
+		if c.Kind == "widen" {
+			widen = append(widen, r)
+		} else {
+			narrow = append(narrow, r)
+		}
+	}
+	return narrow, widen
+}
+
+// ruleConditions returns a transform that leaves the query unchanged if it
+// satisfies conditions, and does not apply otherwise.
+func ruleConditions(conditions *schema.SmartSearchRuleConditions) transform {
+	return func(b query.Basic) *query.Basic {
+		if conditions == nil {
+			return &b
+		}
+		for _, field := range conditions.IfFilters {
+			if !b.Parameters.Exists(field) {
+				return nil
+			}
+		}
+		for _, field := range conditions.UnlessFilters {
+			if b.Parameters.Exists(field) {
+				return nil
+			}
+		}
+		return &b
+	}
+}
+
+// replacePatterns returns a transform that replaces patterns matching re with
+// the query replacement, in which $1, ${name} etc. are expanded to the groups
+// of the match. Filters of the replacement are added to the query. Quoted,
+// regular expression and negated patterns are left as they are.
+func replacePatterns(re *regexp.Regexp, replacement string) transform {
+	return func(b query.Basic) *query.Basic {
+		rawPatternTree, err := query.Parse(query.StringHuman([]query.Node{b.Pattern}), query.SearchTypeStandard)
+		if err != nil {
+			return nil
+		}
+
+		filterParams := []query.Node{}
+		changed := false
+		newPattern := query.MapPattern(rawPatternTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+			pattern := query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			}
+			if negated || annotation.Labels.IsSet(query.Quoted|query.Regexp) {
+				return pattern
+			}
+
+			match := re.FindStringSubmatchIndex(value)
+			if match == nil {
+				return pattern
+			}
+			replacementTree, err := query.Parse(string(re.ExpandString(nil, replacement, value, match)), query.SearchTypeStandard)
+			if err != nil {
+				return pattern
+			}
+			params, replacementPattern, err := query.PartitionSearchPattern(replacementTree)
+			if err != nil {
+				return pattern
+			}
+
+			changed = true
+			for _, param := range params {
+				filterParams = append(filterParams, param)
+			}
+			// Like in patternsToCodeHostFilters, the filters are added
+			// after mapping so that they don't end up in concat nodes.
+			// A replacement without pattern removes the term.
+			return replacementPattern
+		})
+
+		if !changed {
+			return nil
+		}
+
+		newParseTree := query.NewOperator(append(newPattern, filterParams...), query.And)
+		newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newParseTree)
+		if err != nil {
+			return nil
+		}
+
+		newBasic, err := query.ToBasicQuery(newNodes)
+		if err != nil {
+			return nil
+		}
+
+		return &query.Basic{
+			Parameters: slices.Concat(b.Parameters, newBasic.Parameters),
+			Pattern:    newBasic.Pattern,
+		}
+	}
+}
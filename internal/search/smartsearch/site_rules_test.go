@@ -0,0 +1,92 @@
+package smartsearch
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func Test_siteRules(t *testing.T) {
+	narrow, widen := siteRules([]*schema.SmartSearchRule{
+		{
+			Description: "search service repository",
+			Pattern:     `^(payments|billing)$`,
+			Replacement: `repo:^github\.com/acme/$1-service$`,
+			Conditions:  &schema.SmartSearchRuleConditions{UnlessFilters: []string{"repo"}},
+		},
+		{
+			Description: "search Go tests",
+			Pattern:     `^gotest$`,
+			Replacement: `lang:go file:_test\.go$ func Test`,
+			Kind:        "widen",
+		},
+		{
+			Description: "search handlers",
+			Pattern:     `^handler$`,
+			Replacement: `Handler`,
+			Conditions:  &schema.SmartSearchRuleConditions{IfFilters: []string{"lang"}},
+		},
+		{
+			Description: "invalid pattern",
+			Pattern:     `(`,
+		},
+	})
+
+	var descriptions []string
+	for _, r := range append(narrow, widen...) {
+		descriptions = append(descriptions, r.description)
+	}
+	autogold.Expect([]string{"search service repository", "search handlers", "search Go tests"}).Equal(t, descriptions)
+
+	services, handlers, tests := narrow[0].transform, narrow[1].transform, widen[0].transform
+	t.Run("replace pattern", func(t *testing.T) {
+		autogold.Expect(`{
+  "Input": "payments charge",
+  "Query": "repo:^github\\.com/acme/payments-service$ charge"
+}`).Equal(t, apply(`payments charge`, services))
+		autogold.Expect(`{
+  "Input": "type:symbol gotest",
+  "Query": "type:symbol lang:go file:_test\\.go$ func Test"
+}`).Equal(t, apply(`type:symbol gotest`, tests))
+	})
+
+	t.Run("skip pattern", func(t *testing.T) {
+		autogold.Expect(`{
+  "Input": "paymentservice",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, apply(`paymentservice`, services))
+		autogold.Expect(`{
+  "Input": "\"payments\" charge",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, apply(`"payments" charge`, services))
+		autogold.Expect(`{
+  "Input": "charge NOT payments",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, apply(`charge NOT payments`, services))
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+		autogold.Expect(`{
+  "Input": "repo:foo payments",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, apply(`repo:foo payments`, services))
+		autogold.Expect(`{
+  "Input": "handler",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, apply(`handler`, handlers))
+		autogold.Expect(`{
+  "Input": "lang:go handler",
+  "Query": "lang:go Handler"
+}`).Equal(t, apply(`lang:go handler`, handlers))
+	})
+}
+
+func Test_validateSiteRules(t *testing.T) {
+	problems := validateSiteRules([]*schema.SmartSearchRule{
+		{Description: "valid", Pattern: `^payments$`, Replacement: `repo:payments`},
+		{Description: "invalid pattern", Pattern: `(`},
+	})
+	autogold.Expect([]string{"`search.smartSearchRules[1].pattern` is invalid: error parsing regexp: missing closing ): `(`"}).Equal(t, problems)
+}
@@ -3,10 +3,12 @@ package smartsearch
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	alertobserver "github.com/sourcegraph/sourcegraph/internal/search/alert"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
@@ -32,11 +34,16 @@ type newJob func(query.Basic) (job.Job, error)
 // that apply various rules, transforming the original input plan into various
 // queries that alter its interpretation (e.g., search literally for quotes or
 // not, attempt to search the pattern as a regexp, and so on). There is no
-// random choice when applying rules.
+// random choice when applying rules. Rules configured in the site
+// configuration are tried after the built-in ones.
 func NewSmartSearchJob(initialJob job.Job, newJob newJob, plan query.Plan) *FeelingLuckySearchJob {
+	narrow, widen := siteRules(conf.Get().SearchSmartSearchRules)
+	narrow = append(slices.Clip(rulesNarrow), narrow...)
+	widen = append(slices.Clip(rulesWiden), widen...)
+
 	generators := make([]next, 0, len(plan))
 	for _, b := range plan {
-		generators = append(generators, NewGenerator(b, rulesNarrow, rulesWiden))
+		generators = append(generators, NewGenerator(b, narrow, widen))
 	}
 
 	newGeneratedJob := func(autoQ *autoQuery) job.Job {
@@ -51,6 +51,7 @@ type FrontendStreamDecoder struct {
 	OnProgress func(*api.Progress)
 	OnMatches  func([]EventMatch)
 	OnFilters  func([]*EventFilter)
+	OnStats    func(*EventStats)
 	OnAlert    func(*EventAlert)
 	OnError    func(*EventError)
 	OnUnknown  func(event, data []byte)
@@ -94,6 +95,15 @@ func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
 				return errors.Errorf("failed to decode filters payload: %w", err)
 			}
 			rr.OnFilters(d)
+		} else if bytes.Equal(event, []byte("stats")) {
+			if rr.OnStats == nil {
+				continue
+			}
+			var d EventStats
+			if err := json.Unmarshal(data, &d); err != nil {
+				return errors.Errorf("failed to decode stats payload: %w", err)
+			}
+			rr.OnStats(&d)
 		} else if bytes.Equal(event, []byte("alert")) {
 			if rr.OnAlert == nil {
 				continue
@@ -31,6 +31,7 @@ type EventContentMatch struct {
 	LineMatches     []EventLineMatch `json:"lineMatches,omitempty"`
 	ChunkMatches    []ChunkMatch     `json:"chunkMatches,omitempty"`
 	Language        string           `json:"language,omitempty"`
+	Change          string           `json:"change,omitempty"`
 	Debug           string           `json:"debug,omitempty"`
 }
 
@@ -53,6 +54,7 @@ type EventPathMatch struct {
 	Branches        []string   `json:"branches,omitempty"`
 	Commit          string     `json:"commit,omitempty"`
 	Language        string     `json:"language,omitempty"`
+	Change          string     `json:"change,omitempty"`
 	Debug           string     `json:"debug,omitempty"`
 }
 
@@ -130,6 +132,7 @@ type EventSymbolMatch struct {
 	Branches        []string   `json:"branches,omitempty"`
 	Commit          string     `json:"commit,omitempty"`
 	Language        string     `json:"language,omitempty"`
+	Change          string     `json:"change,omitempty"`
 
 	Symbols []Symbol `json:"symbols"`
 }
@@ -166,6 +169,7 @@ type EventCommitMatch struct {
 	RepoStars       int        `json:"repoStars,omitempty"`
 	RepoLastFetched *time.Time `json:"repoLastFetched,omitempty"`
 	Content         string     `json:"content"`
+	Change          string     `json:"change,omitempty"`
 	// [line, character, length]
 	Ranges [][3]int32 `json:"ranges"`
 }
@@ -215,6 +219,24 @@ type EventFilter struct {
 	Kind       string `json:"kind"`
 }
 
+// EventStats is the group-by counts of a search run in stats mode. It is sent
+// once, after the search completed, in place of streaming matches.
+type EventStats struct {
+	// Mode is the aggregation mode the matches were grouped by.
+	Mode   string            `json:"mode"`
+	Groups []EventStatsGroup `json:"groups"`
+
+	// OtherResultCount and OtherGroupCount are the number of results and
+	// groups which did not fit in Groups.
+	OtherResultCount int `json:"otherResultCount"`
+	OtherGroupCount  int `json:"otherGroupCount"`
+}
+
+type EventStatsGroup struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
 // EventAlert is GQL.SearchAlert. It replaces when sent to match existing
 // behaviour.
 type EventAlert struct {
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExportFormat is a machine-readable format search results can be streamed
+// in instead of text/event-stream.
+type ExportFormat string
+
+const (
+	ExportFormatJSONL ExportFormat = "jsonl"
+	ExportFormatCSV   ExportFormat = "csv"
+)
+
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch f := ExportFormat(strings.ToLower(s)); f {
+	case ExportFormatJSONL, ExportFormatCSV:
+		return f, nil
+	default:
+		return "", errors.Errorf("unsupported export format %q", s)
+	}
+}
+
+// ExportRow is a row of an export. All match types are exported with the same
+// columns, the columns which do not apply to a match type are empty.
+type ExportRow struct {
+	// Type is the match type, or "diff" for commit matches of diff searches.
+	// Errors and alerts of the search are exported as rows of type "error"
+	// and "alert".
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Commit     string `json:"commit"`
+	Path       string `json:"path"`
+	// Line is the 1-based line number of the match, or 0 if the match is not
+	// in a line of a file.
+	Line    int    `json:"line"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Author  string `json:"author"`
+	Date    string `json:"date"`
+	URL     string `json:"url"`
+	Content string `json:"content"`
+}
+
+// exportColumns is the header of CSV exports, in the order of the fields of
+// ExportRow.
+var exportColumns = []string{"type", "repository", "commit", "path", "line", "name", "kind", "author", "date", "url", "content"}
+
+func (r ExportRow) record() []string {
+	line := ""
+	if r.Line > 0 {
+		line = strconv.Itoa(r.Line)
+	}
+	return []string{r.Type, r.Repository, r.Commit, r.Path, line, r.Name, r.Kind, r.Author, r.Date, r.URL, r.Content}
+}
+
+// ExportRows returns the rows m is exported as. Content matches are exported
+// as a row per chunk or line match, and symbol matches as a row per symbol.
+func ExportRows(m EventMatch) []ExportRow {
+	switch v := m.(type) {
+	case *EventContentMatch:
+		file := ExportRow{Type: "content", Repository: v.Repository, Commit: v.Commit, Path: v.Path}
+		rows := make([]ExportRow, 0, len(v.ChunkMatches)+len(v.LineMatches))
+		for _, cm := range v.ChunkMatches {
+			row := file
+			row.Line = cm.ContentStart.Line + 1
+			row.Content = cm.Content
+			rows = append(rows, row)
+		}
+		for _, lm := range v.LineMatches {
+			row := file
+			row.Line = int(lm.LineNumber) + 1
+			row.Content = lm.Line
+			rows = append(rows, row)
+		}
+		if len(rows) == 0 {
+			rows = append(rows, file)
+		}
+		return rows
+
+	case *EventPathMatch:
+		return []ExportRow{{Type: "path", Repository: v.Repository, Commit: v.Commit, Path: v.Path}}
+
+	case *EventSymbolMatch:
+		rows := make([]ExportRow, 0, len(v.Symbols))
+		for _, s := range v.Symbols {
+			rows = append(rows, ExportRow{
+				Type:       "symbol",
+				Repository: v.Repository,
+				Commit:     v.Commit,
+				Path:       v.Path,
+				Line:       int(s.Line),
+				Name:       s.Name,
+				Kind:       s.Kind,
+				URL:        s.URL,
+			})
+		}
+		return rows
+
+	case *EventCommitMatch:
+		row := ExportRow{
+			Type:       "commit",
+			Repository: v.Repository,
+			Commit:     v.OID,
+			Author:     v.AuthorName,
+			Date:       v.AuthorDate.UTC().Format(time.RFC3339),
+			URL:        v.URL,
+			Content:    v.Message,
+		}
+		// The content of commit matches is a markdown code block, which
+		// is a diff for diff searches.
+		if diff, ok := strings.CutPrefix(v.Content, "```diff\n"); ok {
+			row.Type = "diff"
+			row.Content = strings.TrimSuffix(diff, "\n```")
+		}
+		return []ExportRow{row}
+
+	case *EventRepoMatch:
+		return []ExportRow{{Type: "repo", Repository: v.Repository, Content: v.Description}}
+
+	case *EventPersonMatch:
+		return []ExportRow{{Type: "person", Name: v.Handle, Content: v.Email}}
+
+	case *EventTeamMatch:
+		return []ExportRow{{Type: "team", Name: v.Name, Content: v.Email}}
+
+	default:
+		return nil
+	}
+}
+
+// ExportWriter streams search results as JSON Lines or CSV. Rows are written
+// as matches are appended, Flush sends them to the client.
+type ExportWriter struct {
+	flush func()
+
+	// Exactly one of csv and json is set, depending on the format.
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+// NewExportWriter creates an ExportWriter that sets the headers for a
+// download in format. Like with NewWriter, users should only interact with
+// the *ExportWriter once it is created.
+func NewExportWriter(w http.ResponseWriter, format ExportFormat) (*ExportWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("http flushing not supported")
+	}
+
+	e := &ExportWriter{flush: flusher.Flush}
+	switch format {
+	case ExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		e.csv = csv.NewWriter(w)
+		if err := e.csv.Write(exportColumns); err != nil {
+			return nil, err
+		}
+	case ExportFormatJSONL:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		e.json = json.NewEncoder(w)
+	default:
+		return nil, errors.Errorf("unsupported export format %q", format)
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="search-results.`+string(format)+`"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Transfer-Encoding", "chunked")
+	// See NewWriter.
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	return e, nil
+}
+
+// Append writes the rows of m.
+func (e *ExportWriter) Append(m EventMatch) error {
+	for _, row := range ExportRows(m) {
+		if err := e.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRow writes a single row.
+func (e *ExportWriter) WriteRow(row ExportRow) error {
+	if e.csv != nil {
+		return e.csv.Write(row.record())
+	}
+	return e.json.Encode(row)
+}
+
+// Flush sends the rows written so far to the client.
+func (e *ExportWriter) Flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	e.flush()
+	return nil
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportRows(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		match EventMatch
+		want  []ExportRow
+	}{{
+		name: "content",
+		match: &EventContentMatch{
+			Repository: "github.com/a/b",
+			Commit:     "deadbeef",
+			Path:       "main.go",
+			ChunkMatches: []ChunkMatch{
+				{Content: "package main", ContentStart: Location{Line: 0}},
+				{Content: "func main() {\n}", ContentStart: Location{Line: 2}},
+			},
+		},
+		want: []ExportRow{
+			{Type: "content", Repository: "github.com/a/b", Commit: "deadbeef", Path: "main.go", Line: 1, Content: "package main"},
+			{Type: "content", Repository: "github.com/a/b", Commit: "deadbeef", Path: "main.go", Line: 3, Content: "func main() {\n}"},
+		},
+	}, {
+		name: "line matches",
+		match: &EventContentMatch{
+			Repository:  "github.com/a/b",
+			Path:        "main.go",
+			LineMatches: []EventLineMatch{{Line: "package main", LineNumber: 0}},
+		},
+		want: []ExportRow{
+			{Type: "content", Repository: "github.com/a/b", Path: "main.go", Line: 1, Content: "package main"},
+		},
+	}, {
+		name:  "path",
+		match: &EventPathMatch{Repository: "github.com/a/b", Path: "README.md"},
+		want: []ExportRow{
+			{Type: "path", Repository: "github.com/a/b", Path: "README.md"},
+		},
+	}, {
+		name: "symbol",
+		match: &EventSymbolMatch{
+			Repository: "github.com/a/b",
+			Path:       "main.go",
+			Symbols: []Symbol{
+				{Name: "main", Kind: "FUNCTION", Line: 3, URL: "/github.com/a/b/-/blob/main.go?L3"},
+			},
+		},
+		want: []ExportRow{
+			{Type: "symbol", Repository: "github.com/a/b", Path: "main.go", Line: 3, Name: "main", Kind: "FUNCTION", URL: "/github.com/a/b/-/blob/main.go?L3"},
+		},
+	}, {
+		name: "commit",
+		match: &EventCommitMatch{
+			Repository: "github.com/a/b",
+			OID:        "deadbeef",
+			AuthorName: "alice",
+			AuthorDate: date,
+			URL:        "/github.com/a/b/-/commit/deadbeef",
+			Message:    "fix bug\n\nDetails.",
+			Content:    "```COMMIT_EDITMSG\nfix bug\n```",
+		},
+		want: []ExportRow{
+			{Type: "commit", Repository: "github.com/a/b", Commit: "deadbeef", Author: "alice", Date: "2024-01-02T03:04:05Z", URL: "/github.com/a/b/-/commit/deadbeef", Content: "fix bug\n\nDetails."},
+		},
+	}, {
+		name: "diff",
+		match: &EventCommitMatch{
+			Repository: "github.com/a/b",
+			OID:        "deadbeef",
+			AuthorName: "alice",
+			AuthorDate: date,
+			Message:    "fix bug",
+			Content:    "```diff\nmain.go main.go\n@@ -1 +1 @@\n-a\n+b\n```",
+		},
+		want: []ExportRow{
+			{Type: "diff", Repository: "github.com/a/b", Commit: "deadbeef", Author: "alice", Date: "2024-01-02T03:04:05Z", Content: "main.go main.go\n@@ -1 +1 @@\n-a\n+b"},
+		},
+	}, {
+		name:  "repo",
+		match: &EventRepoMatch{Repository: "github.com/a/b", Description: "A repository"},
+		want: []ExportRow{
+			{Type: "repo", Repository: "github.com/a/b", Content: "A repository"},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, ExportRows(tc.match))
+		})
+	}
+}
+
+func TestExportWriter(t *testing.T) {
+	t.Parallel()
+
+	match := &EventContentMatch{
+		Repository:   "github.com/a/b",
+		Path:         "main.go",
+		ChunkMatches: []ChunkMatch{{Content: `fmt.Println("a, b")`, ContentStart: Location{Line: 4}}},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w, err := NewExportWriter(rec, ExportFormatCSV)
+		require.NoError(t, err)
+		require.NoError(t, w.Append(match))
+		require.NoError(t, w.Append(&EventPathMatch{Repository: "github.com/a/b", Path: "README.md"}))
+		require.NoError(t, w.Flush())
+
+		require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		require.Equal(t, `attachment; filename="search-results.csv"`, rec.Header().Get("Content-Disposition"))
+		require.Equal(t, "type,repository,commit,path,line,name,kind,author,date,url,content\n"+
+			`content,github.com/a/b,,main.go,5,,,,,,"fmt.Println(""a, b"")"`+"\n"+
+			"path,github.com/a/b,,README.md,,,,,,,\n", rec.Body.String())
+	})
+
+	t.Run("JSONL", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w, err := NewExportWriter(rec, ExportFormatJSONL)
+		require.NoError(t, err)
+		require.NoError(t, w.Append(match))
+		require.NoError(t, w.WriteRow(ExportRow{Type: "error", Content: "timeout"}))
+		require.NoError(t, w.Flush())
+
+		require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+		require.Equal(t, `{"type":"content","repository":"github.com/a/b","commit":"","path":"main.go","line":5,"name":"","kind":"","author":"","date":"","url":"","content":"fmt.Println(\"a, b\")"}`+"\n"+
+			`{"type":"error","repository":"","commit":"","path":"","line":0,"name":"","kind":"","author":"","date":"","url":"","content":"timeout"}`+"\n", rec.Body.String())
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := ParseExportFormat("xml")
+		require.Error(t, err)
+
+		format, err := ParseExportFormat("CSV")
+		require.NoError(t, err)
+		require.Equal(t, ExportFormatCSV, format)
+	})
+}
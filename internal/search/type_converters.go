@@ -57,6 +57,7 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
 		Language:     fm.MostLikelyLanguage(),
+		Change:       string(fm.Change),
 	}
 
 	if r, ok := repoCache[fm.Repo.ID]; ok {
@@ -143,6 +144,7 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 		LineMatches:  eventLineMatches,
 		ChunkMatches: eventChunkMatches,
 		Language:     fm.MostLikelyLanguage(),
+		Change:       string(fm.Change),
 	}
 
 	if fm.InputRev != nil {
@@ -186,6 +188,7 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searc
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
 		Language:     fm.MostLikelyLanguage(),
+		Change:       string(fm.Change),
 		Symbols:      symbols,
 	}
 
@@ -251,6 +254,7 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 		CommitterName: commit.Commit.Committer.Name,
 		CommitterDate: commit.Commit.Committer.Date,
 		Content:       hls.Value,
+		Change:        string(commit.Change),
 		Ranges:        ranges,
 	}
 
@@ -165,6 +165,12 @@ type ZoektParameters struct {
 	Select          filter.SelectPath
 	NumContextLines int
 
+	// FileMetrics are the file:size and file:lines filters. Zoekt doesn't
+	// index the size and number of lines of files, so lines are counted on
+	// the whole content of the files zoekt returns, and sizes are read from
+	// gitserver unless we have the whole content anyway.
+	FileMetrics []query.FileMetricFilter
+
 	// Features are feature flags that can affect behaviour of searcher.
 	Features Features
 
@@ -217,8 +223,14 @@ func (o *ZoektParameters) ToSearchOptions(ctx context.Context) (searchOpts *zoek
 		searchOpts.TotalMaxMatchCount = limit
 	}
 
+	for _, m := range o.FileMetrics {
+		if m.Metric == query.FileMetricLines {
+			searchOpts.Whole = true
+		}
+	}
+
 	// If we're searching repos, ignore the other options and only check one file per repo
-	if o.Select.Root() == filter.Repository {
+	if o.Select.Root() == filter.Repository && len(o.FileMetrics) == 0 {
 		searchOpts.ShardRepoMaxMatchCount = 1
 		return searchOpts
 	}
@@ -268,6 +280,7 @@ type TextPatternInfo struct {
 
 	// Parameters for the search
 	IsStructuralPat bool
+	IsTreeSitterPat bool
 	CombyRule       string
 	IsCaseSensitive bool
 	FileMatchLimit  int32
@@ -280,6 +293,9 @@ type TextPatternInfo struct {
 	IncludeLangs []string
 	ExcludeLangs []string
 
+	// FileMetrics are the file:size and file:lines filters.
+	FileMetrics []query.FileMetricFilter
+
 	PathPatternsAreCaseSensitive bool
 
 	PatternMatchesContent bool
@@ -301,6 +317,9 @@ func (p *TextPatternInfo) Fields() []attribute.KeyValue {
 	if p.IsStructuralPat {
 		add(attribute.Bool("isStructural", p.IsStructuralPat))
 	}
+	if p.IsTreeSitterPat {
+		add(attribute.Bool("isTreeSitter", p.IsTreeSitterPat))
+	}
 	if p.CombyRule != "" {
 		add(attribute.String("combyRule", p.CombyRule))
 	}
@@ -330,6 +349,13 @@ func (p *TextPatternInfo) Fields() []attribute.KeyValue {
 	if len(p.Languages) > 0 {
 		add(attribute.StringSlice("languages", p.Languages))
 	}
+	if len(p.FileMetrics) > 0 {
+		fileMetrics := make([]string, 0, len(p.FileMetrics))
+		for _, m := range p.FileMetrics {
+			fileMetrics = append(fileMetrics, m.String())
+		}
+		add(attribute.StringSlice("fileMetrics", fileMetrics))
+	}
 	return res
 }
 
@@ -343,6 +369,9 @@ func (p *TextPatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsTreeSitterPat {
+		args = append(args, "treesitter")
+	}
 	if p.IsCaseSensitive {
 		args = append(args, "case")
 	}
@@ -369,6 +398,9 @@ func (p *TextPatternInfo) String() string {
 	for _, inc := range p.IncludePaths {
 		args = append(args, fmt.Sprintf("%s:%q", path, inc))
 	}
+	for _, m := range p.FileMetrics {
+		args = append(args, fmt.Sprintf("file:%s", m))
+	}
 
 	return fmt.Sprintf("TextPatternInfo{%s}", strings.Join(args, ","))
 }
@@ -504,6 +536,9 @@ func (op *RepoOptions) Attributes() []attribute.KeyValue {
 			if arg.Content != "" {
 				nondefault = append(nondefault, attribute.String("content", arg.Content))
 			}
+			if arg.Symbol != "" {
+				nondefault = append(nondefault, attribute.String("symbol", arg.Symbol))
+			}
 			if arg.Negated {
 				nondefault = append(nondefault, attribute.Bool("negated", arg.Negated))
 			}
@@ -595,6 +630,9 @@ func (op *RepoOptions) String() string {
 			if arg.Content != "" {
 				fmt.Fprintf(&b, "HasFileContent[%d].content: %s\n", i, arg.Content)
 			}
+			if arg.Symbol != "" {
+				fmt.Fprintf(&b, "HasFileContent[%d].symbol: %s\n", i, arg.Symbol)
+			}
 			if arg.Negated {
 				fmt.Fprintf(&b, "HasFileContent[%d].negated: %t\n", i, arg.Negated)
 			}
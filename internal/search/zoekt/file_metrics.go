@@ -0,0 +1,142 @@
+package zoekt
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/sourcegraph/conc/iter"
+	"github.com/sourcegraph/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// skippedContentPrefix starts the content zoekt returns for files it skipped
+// while indexing, like files over its size limit or binary files. The marker
+// says nothing about the size or number of lines of the file.
+var skippedContentPrefix = []byte("NOT-INDEXED: ")
+
+// fileMetricsFilter evaluates file:size and file:lines filters on the files
+// zoekt returns. Zoekt doesn't index the size and number of lines of files:
+// lines are counted on the whole content of the files, which we only request
+// from zoekt for file:lines filters. The size of files without whole content
+// and the metrics of files zoekt skipped while indexing are read from
+// gitserver.
+type fileMetricsFilter struct {
+	filters   []query.FileMetricFilter
+	gitserver gitserver.Client
+}
+
+func newFileMetricsFilter(filters []query.FileMetricFilter, gitserverClient gitserver.Client) *fileMetricsFilter {
+	if len(filters) == 0 {
+		return nil
+	}
+	return &fileMetricsFilter{filters: filters, gitserver: gitserverClient}
+}
+
+// filter returns the files that satisfy all filters.
+func (f *fileMetricsFilter) filter(ctx context.Context, files []zoekt.FileMatch, getRepoInputRev repoRevFunc) []zoekt.FileMatch {
+	if f == nil {
+		return files
+	}
+
+	keep := iter.Map(files, func(file *zoekt.FileMatch) bool {
+		repo, _ := getRepoInputRev(file)
+		return f.matches(ctx, repo.Name, file)
+	})
+
+	filtered := files[:0:0]
+	for i, file := range files {
+		if keep[i] {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+func (f *fileMetricsFilter) matches(ctx context.Context, repo api.RepoName, file *zoekt.FileMatch) bool {
+	if content := file.Content; content != nil && !bytes.HasPrefix(content, skippedContentPrefix) {
+		for _, m := range f.filters {
+			if !m.Matches(content) {
+				return false
+			}
+		}
+		return true
+	}
+
+	size, lines, err := f.readMetrics(ctx, repo, api.CommitID(file.Version), file.FileName)
+	if err != nil {
+		// We can't tell whether the file satisfies the filters, so we don't
+		// return it.
+		return false
+	}
+	for _, m := range f.filters {
+		v := size
+		if m.Metric == query.FileMetricLines {
+			v = lines
+		}
+		if !m.Compare(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// readMetrics returns the size and, if any filter needs it, the number of lines
+// of the file at path from gitserver.
+func (f *fileMetricsFilter) readMetrics(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (size, lines int64, err error) {
+	if !hasLinesMetric(f.filters) {
+		fi, err := f.gitserver.Stat(ctx, repo, commit, path)
+		if err != nil {
+			return 0, 0, err
+		}
+		return fi.Size(), 0, nil
+	}
+
+	r, err := f.gitserver.NewFileReader(ctx, repo, commit, path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer r.Close()
+
+	var c lineCounter
+	if _, err := io.Copy(&c, r); err != nil {
+		return 0, 0, err
+	}
+	return c.size, c.count(), nil
+}
+
+func hasLinesMetric(filters []query.FileMetricFilter) bool {
+	for _, m := range filters {
+		if m.Metric == query.FileMetricLines {
+			return true
+		}
+	}
+	return false
+}
+
+// lineCounter counts the bytes and lines written to it the same way
+// query.CountLines does, without holding on to the content.
+type lineCounter struct {
+	size     int64
+	newlines int64
+	last     byte
+}
+
+func (c *lineCounter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		c.size += int64(len(p))
+		c.newlines += int64(bytes.Count(p, []byte{'\n'}))
+		c.last = p[len(p)-1]
+	}
+	return len(p), nil
+}
+
+func (c *lineCounter) count() int64 {
+	if c.size > 0 && c.last != '\n' {
+		return c.newlines + 1
+	}
+	return c.newlines
+}
@@ -0,0 +1,116 @@
+package zoekt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileMetricsFilter(t *testing.T) {
+	// large.txt is over the zoekt size limit, so zoekt only returns a marker
+	// instead of its content.
+	gitContent := map[string]string{
+		"small.txt": "a\nb\n",
+		"large.txt": strings.Repeat("line\n", 300_000),
+	}
+
+	gs := gitserver.NewMockClient()
+	gs.StatFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, path string) (fs.FileInfo, error) {
+		return &fileutil.FileInfo{Name_: path, Size_: int64(len(gitContent[path]))}, nil
+	})
+	gs.NewFileReaderFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, path string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(gitContent[path])), nil
+	})
+
+	getRepoInputRev := func(file *zoekt.FileMatch) (types.MinimalRepo, []string) {
+		return types.MinimalRepo{Name: api.RepoName(file.Repository)}, []string{""}
+	}
+
+	// files returns the files zoekt returns with or without the whole
+	// content of indexed files.
+	files := func(whole bool) []zoekt.FileMatch {
+		small := zoekt.FileMatch{Repository: "repo", FileName: "small.txt", Version: "deadbeef"}
+		if whole {
+			small.Content = []byte(gitContent["small.txt"])
+		}
+		large := zoekt.FileMatch{
+			Repository: "repo",
+			FileName:   "large.txt",
+			Version:    "deadbeef",
+			Content:    []byte("NOT-INDEXED: document size 1500000 larger than limit 1048576"),
+		}
+		return []zoekt.FileMatch{small, large}
+	}
+
+	names := func(files []zoekt.FileMatch) []string {
+		var names []string
+		for _, f := range files {
+			names = append(names, f.FileName)
+		}
+		return names
+	}
+
+	cases := []struct {
+		name    string
+		filters []query.FileMetricFilter
+		whole   bool
+		want    []string
+	}{{
+		name:    "size over the zoekt limit",
+		filters: []query.FileMetricFilter{{Metric: query.FileMetricSize, Op: ">", Value: 1 << 20}},
+		want:    []string{"large.txt"},
+	}, {
+		name:    "size under the zoekt limit",
+		filters: []query.FileMetricFilter{{Metric: query.FileMetricSize, Op: "<", Value: 1 << 20}},
+		want:    []string{"small.txt"},
+	}, {
+		name:    "lines of a skipped file",
+		filters: []query.FileMetricFilter{{Metric: query.FileMetricLines, Op: "<", Value: 10}},
+		whole:   true,
+		want:    []string{"small.txt"},
+	}, {
+		name: "lines and size",
+		filters: []query.FileMetricFilter{
+			{Metric: query.FileMetricLines, Op: ">=", Value: 300_000},
+			{Metric: query.FileMetricSize, Op: "=", Value: 1_500_000},
+		},
+		whole: true,
+		want:  []string{"large.txt"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFileMetricsFilter(tc.filters, gs)
+			got := f.filter(context.Background(), files(tc.whole), getRepoInputRev)
+			require.Equal(t, tc.want, names(got))
+		})
+	}
+
+	t.Run("no filters", func(t *testing.T) {
+		f := newFileMetricsFilter(nil, gs)
+		require.Nil(t, f)
+		require.Equal(t, []string{"small.txt", "large.txt"}, names(f.filter(context.Background(), files(false), getRepoInputRev)))
+	})
+}
+
+func TestLineCounter(t *testing.T) {
+	for _, content := range []string{"", "a", "a\n", "a\nb", "a\n\nb\n", strings.Repeat("x\n", 100_000) + "y"} {
+		var c lineCounter
+		_, err := io.Copy(&c, bytes.NewBufferString(content))
+		require.NoError(t, err)
+		require.Equal(t, query.CountLines([]byte(content)), c.count(), "content %q", content)
+		require.Equal(t, int64(len(content)), c.size)
+	}
+}
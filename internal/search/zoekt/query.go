@@ -122,6 +122,13 @@ func QueryForFileContentArgs(opt query.RepoHasFileContentArgs, caseSensitive boo
 		}
 		children = append(children, &zoekt.Regexp{Regexp: re, Content: true, CaseSensitive: caseSensitive})
 	}
+	if opt.Symbol != "" {
+		re, err := syntax.Parse(opt.Symbol, syntax.Perl)
+		if err != nil {
+			panic(err)
+		}
+		children = append(children, &zoekt.Symbol{Expr: &zoekt.Regexp{Regexp: re, Content: true, CaseSensitive: caseSensitive}})
+	}
 	q := zoekt.NewAnd(children...)
 	q = &zoekt.Type{Type: zoekt.TypeRepo, Child: q}
 	if opt.Negated {
@@ -179,6 +186,10 @@ func toZoektPattern(
 
 			if n.Annotation.Labels.IsSet(query.Boost) {
 				q = &zoekt.Boost{Child: q, Boost: 20}
+			} else if n.Annotation.Labels.IsSet(query.Fuzzy) {
+				// Fuzzy matches with one typo rank between exact matches,
+				// which are boosted, and matches with more typos.
+				q = &zoekt.Boost{Child: q, Boost: 5}
 			}
 
 			return q, nil
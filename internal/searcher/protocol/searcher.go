@@ -9,6 +9,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	proto "github.com/sourcegraph/sourcegraph/internal/searcher/v1"
 )
 
@@ -61,6 +62,12 @@ type PatternInfo struct {
 	// IsStructuralPat if true will treat the pattern as a Comby structural search pattern.
 	IsStructuralPat bool
 
+	// IsTreeSitterPat if true will treat the pattern as a tree-sitter query. Files are
+	// parsed with the tree-sitter grammar of their language and the nodes captured by the
+	// query are returned as matches. Select may name the only capture to return, e.g.
+	// "capture.name".
+	IsTreeSitterPat bool
+
 	// IsCaseSensitive if false will ignore the case of text and pattern
 	// when finding matches.
 	IsCaseSensitive bool
@@ -114,6 +121,10 @@ type PatternInfo struct {
 	// Languages represents the set of languages requested in the query. It is only used for
 	// structural search and is separate from IncludeLangs, which represents language filters.
 	Languages []string
+
+	// FileMetrics are the file:size and file:lines filters. Only files that satisfy all of
+	// them are searched.
+	FileMetrics []query.FileMetricFilter
 }
 
 func (p *PatternInfo) String() string {
@@ -126,6 +137,9 @@ func (p *PatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsTreeSitterPat {
+		args = append(args, "treesitter")
+	}
 	if p.IsCaseSensitive {
 		args = append(args, "case")
 	}
@@ -158,6 +172,9 @@ func (p *PatternInfo) String() string {
 	for _, inc := range p.IncludePaths {
 		args = append(args, fmt.Sprintf("%s:%q", path, inc))
 	}
+	for _, m := range p.FileMetrics {
+		args = append(args, fmt.Sprintf("file:%s", m))
+	}
 
 	return fmt.Sprintf("PatternInfo{%s}", strings.Join(args, ","))
 }
@@ -172,6 +189,7 @@ func (r *Request) ToProto() *proto.SearchRequest {
 		PatternInfo: &proto.PatternInfo{
 			Query:                        r.PatternInfo.Query.ToProto(),
 			IsStructural:                 r.PatternInfo.IsStructuralPat,
+			IsTreeSitter:                 r.PatternInfo.IsTreeSitterPat,
 			IsCaseSensitive:              r.PatternInfo.IsCaseSensitive,
 			ExcludePattern:               r.PatternInfo.ExcludePaths,
 			IncludePatterns:              r.PatternInfo.IncludePaths,
@@ -184,6 +202,7 @@ func (r *Request) ToProto() *proto.SearchRequest {
 			ExcludeLangs:                 r.PatternInfo.ExcludeLangs,
 			Select:                       r.PatternInfo.Select,
 			Languages:                    r.PatternInfo.Languages,
+			FileMetrics:                  fileMetricsToProto(r.PatternInfo.FileMetrics),
 		},
 		FetchTimeout:    durationpb.New(r.FetchTimeout),
 		NumContextLines: r.NumContextLines,
@@ -199,6 +218,7 @@ func (r *Request) FromProto(req *proto.SearchRequest) {
 		PatternInfo: PatternInfo{
 			Query:                        NodeFromProto(req.PatternInfo.Query),
 			IsStructuralPat:              req.PatternInfo.IsStructural,
+			IsTreeSitterPat:              req.PatternInfo.IsTreeSitter,
 			IsCaseSensitive:              req.PatternInfo.IsCaseSensitive,
 			ExcludePaths:                 req.PatternInfo.ExcludePattern,
 			IncludePaths:                 req.PatternInfo.IncludePatterns,
@@ -210,6 +230,7 @@ func (r *Request) FromProto(req *proto.SearchRequest) {
 			ExcludeLangs:                 req.PatternInfo.ExcludeLangs,
 			CombyRule:                    req.PatternInfo.CombyRule,
 			Select:                       req.PatternInfo.Select,
+			FileMetrics:                  fileMetricsFromProto(req.PatternInfo.FileMetrics),
 		},
 		FetchTimeout:    req.FetchTimeout.AsDuration(),
 		Indexed:         req.Indexed,
@@ -217,6 +238,38 @@ func (r *Request) FromProto(req *proto.SearchRequest) {
 	}
 }
 
+func fileMetricsToProto(filters []query.FileMetricFilter) []*proto.FileMetricFilter {
+	if len(filters) == 0 {
+		return nil
+	}
+	res := make([]*proto.FileMetricFilter, 0, len(filters))
+	for _, f := range filters {
+		res = append(res, &proto.FileMetricFilter{
+			Metric:  f.Metric,
+			Op:      f.Op,
+			Value:   f.Value,
+			Negated: f.Negated,
+		})
+	}
+	return res
+}
+
+func fileMetricsFromProto(filters []*proto.FileMetricFilter) []query.FileMetricFilter {
+	if len(filters) == 0 {
+		return nil
+	}
+	res := make([]query.FileMetricFilter, 0, len(filters))
+	for _, f := range filters {
+		res = append(res, query.FileMetricFilter{
+			Metric:  f.GetMetric(),
+			Op:      f.GetOp(),
+			Value:   f.GetValue(),
+			Negated: f.GetNegated(),
+		})
+	}
+	return res
+}
+
 func NodeFromProto(p *proto.QueryNode) QueryNode {
 	switch v := p.GetValue().(type) {
 	case *proto.QueryNode_Pattern:
@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
 )
 
@@ -35,6 +36,10 @@ func TestRequestProtoRoundtrip(t *testing.T) {
 			IncludeLangs:                 []string{},
 			CombyRule:                    "",
 			Select:                       "",
+			FileMetrics: []query.FileMetricFilter{
+				{Metric: query.FileMetricSize, Op: ">", Value: 1024},
+				{Metric: query.FileMetricLines, Op: "<=", Value: 50, Negated: true},
+			},
 		},
 		FetchTimeout:    1000,
 		Indexed:         false,
@@ -816,6 +816,13 @@ type PatternInfo struct {
 	// include_langs and exclude_langs represent the languages to filter on
 	IncludeLangs []string `protobuf:"bytes,17,rep,name=include_langs,json=includeLangs,proto3" json:"include_langs,omitempty"`
 	ExcludeLangs []string `protobuf:"bytes,18,rep,name=exclude_langs,json=excludeLangs,proto3" json:"exclude_langs,omitempty"`
+	// is_tree_sitter if true will treat the pattern as a tree-sitter query. Files
+	// are parsed with the tree-sitter grammar of their language and the nodes
+	// captured by the query are returned as matches.
+	IsTreeSitter bool `protobuf:"varint,19,opt,name=is_tree_sitter,json=isTreeSitter,proto3" json:"is_tree_sitter,omitempty"`
+	// file_metrics are the file:size and file:lines filters. Only files that
+	// satisfy all of them are searched.
+	FileMetrics []*FileMetricFilter `protobuf:"bytes,20,rep,name=file_metrics,json=fileMetrics,proto3" json:"file_metrics,omitempty"`
 }
 
 func (x *PatternInfo) Reset() {
@@ -948,6 +955,96 @@ func (x *PatternInfo) GetExcludeLangs() []string {
 	return nil
 }
 
+func (x *PatternInfo) GetIsTreeSitter() bool {
+	if x != nil {
+		return x.IsTreeSitter
+	}
+	return false
+}
+
+func (x *PatternInfo) GetFileMetrics() []*FileMetricFilter {
+	if x != nil {
+		return x.FileMetrics
+	}
+	return nil
+}
+
+// FileMetricFilter compares the size of files in bytes or their number of
+// lines to a value, like file:size>100KB.
+type FileMetricFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// metric is either "size" or "lines".
+	Metric string `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	// op is one of "<", "<=", ">", ">=" and "=".
+	Op    string `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	Value int64  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	// negated is true if the files that don't satisfy the comparison are kept.
+	Negated bool `protobuf:"varint,4,opt,name=negated,proto3" json:"negated,omitempty"`
+}
+
+func (x *FileMetricFilter) Reset() {
+	*x = FileMetricFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_searcher_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileMetricFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileMetricFilter) ProtoMessage() {}
+
+func (x *FileMetricFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_searcher_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileMetricFilter.ProtoReflect.Descriptor instead.
+func (*FileMetricFilter) Descriptor() ([]byte, []int) {
+	return file_searcher_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FileMetricFilter) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *FileMetricFilter) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *FileMetricFilter) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *FileMetricFilter) GetNegated() bool {
+	if x != nil {
+		return x.Negated
+	}
+	return false
+}
+
 // Done is the final SearchResponse message sent in the stream
 // of responses to Search.
 type SearchResponse_Done struct {
@@ -961,7 +1058,7 @@ type SearchResponse_Done struct {
 func (x *SearchResponse_Done) Reset() {
 	*x = SearchResponse_Done{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_searcher_proto_msgTypes[11]
+		mi := &file_searcher_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -974,7 +1071,7 @@ func (x *SearchResponse_Done) String() string {
 func (*SearchResponse_Done) ProtoMessage() {}
 
 func (x *SearchResponse_Done) ProtoReflect() protoreflect.Message {
-	mi := &file_searcher_proto_msgTypes[11]
+	mi := &file_searcher_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,7 +1187,7 @@ var file_searcher_proto_rawDesc = []byte{
 	0x65, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x01, 0x20,
 	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x76,
 	0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x63, 0x68, 0x69,
-	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0xdf, 0x04, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0xc7, 0x05, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
 	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x75,
 	0x63, 0x74, 0x75, 0x72, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73,
 	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x73,
@@ -1126,19 +1223,32 @@ var file_searcher_proto_rawDesc = []byte{
 	0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e,
 	0x63, 0x6c, 0x75, 0x64, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78,
 	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x73, 0x4a,
-	0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10,
-	0x04, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x32, 0x5b, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x12, 0x1a, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1b, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90,
-	0x02, 0x02, 0x30, 0x01, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x69, 0x73, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x73, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x54, 0x72, 0x65, 0x65, 0x53,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08,
+	0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22,
+	0x6a, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x32, 0x5b, 0x0a, 0x0f, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48,
+	0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1a, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x03, 0x90, 0x02, 0x02, 0x30, 0x01, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x72,
+	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1153,7 +1263,7 @@ func file_searcher_proto_rawDescGZIP() []byte {
 	return file_searcher_proto_rawDescData
 }
 
-var file_searcher_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_searcher_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_searcher_proto_goTypes = []interface{}{
 	(*SearchRequest)(nil),       // 0: searcher.v1.SearchRequest
 	(*SearchResponse)(nil),      // 1: searcher.v1.SearchResponse
@@ -1166,14 +1276,15 @@ var file_searcher_proto_goTypes = []interface{}{
 	(*AndNode)(nil),             // 8: searcher.v1.AndNode
 	(*OrNode)(nil),              // 9: searcher.v1.OrNode
 	(*PatternInfo)(nil),         // 10: searcher.v1.PatternInfo
-	(*SearchResponse_Done)(nil), // 11: searcher.v1.SearchResponse.Done
-	(*durationpb.Duration)(nil), // 12: google.protobuf.Duration
+	(*FileMetricFilter)(nil),    // 11: searcher.v1.FileMetricFilter
+	(*SearchResponse_Done)(nil), // 12: searcher.v1.SearchResponse.Done
+	(*durationpb.Duration)(nil), // 13: google.protobuf.Duration
 }
 var file_searcher_proto_depIdxs = []int32{
 	10, // 0: searcher.v1.SearchRequest.pattern_info:type_name -> searcher.v1.PatternInfo
-	12, // 1: searcher.v1.SearchRequest.fetch_timeout:type_name -> google.protobuf.Duration
+	13, // 1: searcher.v1.SearchRequest.fetch_timeout:type_name -> google.protobuf.Duration
 	2,  // 2: searcher.v1.SearchResponse.file_match:type_name -> searcher.v1.FileMatch
-	11, // 3: searcher.v1.SearchResponse.done_message:type_name -> searcher.v1.SearchResponse.Done
+	12, // 3: searcher.v1.SearchResponse.done_message:type_name -> searcher.v1.SearchResponse.Done
 	3,  // 4: searcher.v1.FileMatch.chunk_matches:type_name -> searcher.v1.ChunkMatch
 	5,  // 5: searcher.v1.ChunkMatch.content_start:type_name -> searcher.v1.Location
 	4,  // 6: searcher.v1.ChunkMatch.ranges:type_name -> searcher.v1.Range
@@ -1185,13 +1296,14 @@ var file_searcher_proto_depIdxs = []int32{
 	6,  // 12: searcher.v1.AndNode.children:type_name -> searcher.v1.QueryNode
 	6,  // 13: searcher.v1.OrNode.children:type_name -> searcher.v1.QueryNode
 	6,  // 14: searcher.v1.PatternInfo.query:type_name -> searcher.v1.QueryNode
-	0,  // 15: searcher.v1.SearcherService.Search:input_type -> searcher.v1.SearchRequest
-	1,  // 16: searcher.v1.SearcherService.Search:output_type -> searcher.v1.SearchResponse
-	16, // [16:17] is the sub-list for method output_type
-	15, // [15:16] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	11, // 15: searcher.v1.PatternInfo.file_metrics:type_name -> searcher.v1.FileMetricFilter
+	0,  // 16: searcher.v1.SearcherService.Search:input_type -> searcher.v1.SearchRequest
+	1,  // 17: searcher.v1.SearcherService.Search:output_type -> searcher.v1.SearchResponse
+	17, // [17:18] is the sub-list for method output_type
+	16, // [16:17] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_searcher_proto_init() }
@@ -1333,6 +1445,18 @@ func file_searcher_proto_init() {
 			}
 		}
 		file_searcher_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileMetricFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_searcher_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchResponse_Done); i {
 			case 0:
 				return &v.state
@@ -1360,7 +1484,7 @@ func file_searcher_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_searcher_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
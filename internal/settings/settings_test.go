@@ -205,6 +205,17 @@ func TestMergeSettings(t *testing.T) {
 		expected: &schema.Settings{
 			SearchScopes: []*schema.SearchScope{{Name: "test1"}, {Name: "test2"}},
 		},
+	}, {
+		name: "deep merge map",
+		left: &schema.Settings{
+			SearchMacros: map[string]string{"backend": "repo:a", "frontend": "repo:b"},
+		},
+		right: &schema.Settings{
+			SearchMacros: map[string]string{"backend": "repo:c"},
+		},
+		expected: &schema.Settings{
+			SearchMacros: map[string]string{"backend": "repo:c", "frontend": "repo:b"},
+		},
 	},
 	}
 
@@ -74,6 +74,8 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.PerforceConnection:
 		es.redactString(c.P4Passwd, "p4.passwd")
+	case *schema.SubversionConnection:
+		es.redactString(c.Password, "password")
 	case *schema.GitoliteConnection:
 		// Nothing to redact
 	case *schema.GoModulesConnection:
@@ -100,6 +102,8 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.NpmPackagesConnection:
 		es.redactString(c.Credentials, "credentials")
+	case *schema.NuGetPackagesConnection:
+		es.redactString(c.Credentials, "credentials")
 	case *schema.OtherExternalServiceConnection:
 		err = es.redactURL(c.Url, "url")
 		if err != nil {
@@ -214,6 +218,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 			return errCodeHostIdentityChanged{"p4.port", "p4.passwd"}
 		}
 		es.unredactString(c.P4Passwd, o.P4Passwd, "p4.passwd")
+	case *schema.SubversionConnection:
+		o := oldCfg.(*schema.SubversionConnection)
+		if c.Password == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "password"}
+		}
+		es.unredactString(c.Password, o.Password, "password")
 	case *schema.GerritConnection:
 		o := oldCfg.(*schema.GerritConnection)
 		es.unredactString(c.Password, o.Password, "password")
@@ -278,6 +288,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 			return errCodeHostIdentityChanged{"registry", "credentials"}
 		}
 		es.unredactString(c.Credentials, o.Credentials, "credentials")
+	case *schema.NuGetPackagesConnection:
+		o := oldCfg.(*schema.NuGetPackagesConnection)
+		if c.Credentials == RedactedSecret && c.Registry != o.Registry {
+			return errCodeHostIdentityChanged{"registry", "credentials"}
+		}
+		es.unredactString(c.Credentials, o.Credentials, "credentials")
 	case *schema.OtherExternalServiceConnection:
 		o := oldCfg.(*schema.OtherExternalServiceConnection)
 		err := es.unredactURL(c.Url, o.Url, "url")
@@ -67,6 +67,8 @@ var schemes = map[string]struct{}{
 	"rsync":    {},
 	"file":     {},
 	"perforce": {},
+	"svn":      {},
+	"svn+ssh":  {},
 	// This is not an officially supported git protocol, and it will not work
 	// without adding an override to the global git config for iap:// to https://.
 	// This has been added as a response to a customer issue where their GitLab
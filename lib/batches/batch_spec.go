@@ -2,6 +2,7 @@ package batches
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
@@ -38,15 +39,78 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	AutoMerge         *AutoMerge               `json:"autoMerge,omitempty" yaml:"autoMerge"`
+	AutoRebase        *bool                    `json:"autoRebase,omitempty" yaml:"autoRebase"`
+}
+
+// AutoRebaseEnabled returns true if changesets that have merge conflicts with
+// their base branch should be regenerated against the new base commit. This
+// is the default.
+func (s *BatchSpec) AutoRebaseEnabled() bool {
+	return s.AutoRebase == nil || *s.AutoRebase
 }
 
 type ChangesetTemplate struct {
-	Title     string                       `json:"title,omitempty" yaml:"title"`
-	Body      string                       `json:"body,omitempty" yaml:"body"`
-	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
-	Fork      *bool                        `json:"fork,omitempty" yaml:"fork"`
-	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
-	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Title         string                       `json:"title,omitempty" yaml:"title"`
+	Body          string                       `json:"body,omitempty" yaml:"body"`
+	Branch        string                       `json:"branch,omitempty" yaml:"branch"`
+	Fork          *bool                        `json:"fork,omitempty" yaml:"fork"`
+	Commit        ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
+	Published     *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Reviewers     *overridable.StringList      `json:"reviewers,omitempty" yaml:"reviewers"`
+	TeamReviewers *overridable.StringList      `json:"teamReviewers,omitempty" yaml:"teamReviewers"`
+	Labels        *overridable.StringList      `json:"labels,omitempty" yaml:"labels"`
+	Assignees     *overridable.StringList      `json:"assignees,omitempty" yaml:"assignees"`
+	Milestone     *overridable.String          `json:"milestone,omitempty" yaml:"milestone"`
+}
+
+// The check requirements of an AutoMerge policy.
+const (
+	// AutoMergeChecksPassed requires all checks to have passed. Changesets
+	// without checks are not merged.
+	AutoMergeChecksPassed = "passed"
+	// AutoMergeChecksPassedOrNone requires all checks to have passed, but
+	// also merges changesets without checks.
+	AutoMergeChecksPassedOrNone = "passedOrNone"
+	// AutoMergeChecksAny merges changesets regardless of their checks.
+	AutoMergeChecksAny = "any"
+)
+
+// The merge methods of an AutoMerge policy.
+const (
+	AutoMergeMethodMerge  = "merge"
+	AutoMergeMethodSquash = "squash"
+)
+
+// AutoMerge is the policy under which the changesets of a batch change are
+// merged automatically once they are mergeable.
+type AutoMerge struct {
+	Checks    string            `json:"checks,omitempty" yaml:"checks"`
+	Approvals int               `json:"approvals,omitempty" yaml:"approvals"`
+	Method    string            `json:"method,omitempty" yaml:"method"`
+	Windows   []AutoMergeWindow `json:"windows,omitempty" yaml:"windows"`
+}
+
+// RequiredChecks returns the check requirement of the policy, defaulting to
+// AutoMergeChecksPassed.
+func (am *AutoMerge) RequiredChecks() string {
+	if am.Checks == "" {
+		return AutoMergeChecksPassed
+	}
+	return am.Checks
+}
+
+// Squash returns true if changesets should be squash merged.
+func (am *AutoMerge) Squash() bool {
+	return am.Method == AutoMergeMethodSquash
+}
+
+// AutoMergeWindow is a window of time in UTC during which changesets may be
+// merged automatically.
+type AutoMergeWindow struct {
+	Days  []string `json:"days,omitempty" yaml:"days"`
+	Start string   `json:"start,omitempty" yaml:"start"`
+	End   string   `json:"end,omitempty" yaml:"end"`
 }
 
 type GitCommitAuthor struct {
@@ -75,6 +139,12 @@ type OnQueryOrRepository struct {
 	Repository                string   `json:"repository,omitempty" yaml:"repository"`
 	Branch                    string   `json:"branch,omitempty" yaml:"branch"`
 	Branches                  []string `json:"branches,omitempty" yaml:"branches"`
+	// Name is the name by which DependsOn of other entries refers to this one.
+	Name string `json:"name,omitempty" yaml:"name"`
+	// DependsOn lists the names of other entries, or the names of
+	// repositories, whose changesets must be merged before the changesets
+	// of this entry are published.
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn"`
 }
 
 var ErrConflictingBranches = NewValidationError(errors.New("both branch and branches specified"))
@@ -97,6 +167,12 @@ type Step struct {
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 	Mount     []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
 	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
+	// Uses references the step definition that the step runs, in the form
+	// parsed by ParseStepReference. It is resolved into the other fields of
+	// the step when the batch spec is created, see StepDefinition.Apply.
+	Uses string `json:"uses,omitempty" yaml:"uses,omitempty"`
+	// With are the inputs passed to the step definition referenced by Uses.
+	With map[string]string `json:"with,omitempty" yaml:"with,omitempty"`
 }
 
 func (s *Step) IfCondition() string {
@@ -168,6 +244,9 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 	}
 
 	for i, step := range spec.Steps {
+		if err := validateUsesStep(step); err != nil {
+			errs = errors.Append(errs, NewValidationError(errors.Wrapf(err, "step %d", i+1)))
+		}
 		for _, mount := range step.Mount {
 			if strings.Contains(mount.Path, invalidMountCharacters) {
 				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d mount path contains invalid characters", i+1)))
@@ -178,11 +257,86 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		}
 	}
 
+	if err := validateDependencies(spec.On); err != nil {
+		errs = errors.Append(errs, NewValidationError(err))
+	}
+
 	return &spec, errs
 }
 
 const invalidMountCharacters = ","
 
+// validateUsesStep checks that a step that uses a step definition doesn't
+// also define the fields that the step definition provides, and that only
+// such steps have inputs.
+func validateUsesStep(step Step) error {
+	if step.Uses == "" {
+		if len(step.With) > 0 {
+			return errors.New("with can only be used together with uses")
+		}
+		return nil
+	}
+	if step.Run != "" || step.Container != "" || len(step.Files) > 0 || len(step.Outputs) > 0 || len(step.Mount) > 0 {
+		return errors.New("a step with uses can't have run, container, files, outputs or mount")
+	}
+	_, err := ParseStepReference(step.Uses)
+	return err
+}
+
+// validateDependencies checks that the names of the on entries are unique and
+// that the dependencies between named entries don't form a cycle. Entries in
+// dependsOn that don't name an entry refer to repositories, which can't be
+// checked without resolving them.
+func validateDependencies(on []OnQueryOrRepository) error {
+	entries := make(map[string]int, len(on))
+	for i, o := range on {
+		if o.Name == "" {
+			continue
+		}
+		if _, ok := entries[o.Name]; ok {
+			return errors.Newf("the name %q is used by more than one entry in on", o.Name)
+		}
+		entries[o.Name] = i
+	}
+
+	// Depth-first search for a cycle, which has been found when we reach an
+	// entry that is still on the stack.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(on))
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case visiting:
+			cycle := append(path[slices.Index(path, on[i].Name):], on[i].Name)
+			return errors.Newf("the dependencies in on form a cycle: %s", strings.Join(cycle, " -> "))
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		for _, dep := range on[i].DependsOn {
+			j, ok := entries[dep]
+			if !ok {
+				continue
+			}
+			if err := visit(j, append(path, on[i].Name)); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		return nil
+	}
+	for i := range on {
+		if err := visit(i, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (on *OnQueryOrRepository) String() string {
 	if on.RepositoriesMatchingQuery != "" {
 		return on.RepositoriesMatchingQuery
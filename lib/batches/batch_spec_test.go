@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
 
@@ -88,6 +89,127 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("reviewers, labels, assignees and milestone", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  reviewers: [alice]
+  teamReviewers:
+    - "*": [frontend]
+    - github.com/sourcegraph/*: [backend]
+  labels: [automated]
+  assignees: [bob]
+  milestone:
+    - "*": v1.0
+    - github.com/other/*: v2.0
+`
+
+		have, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		tmpl := have.ChangesetTemplate
+		assert.Equal(t, []string{"alice"}, tmpl.Reviewers.Value("github.com/sourcegraph/sourcegraph"))
+		assert.Equal(t, []string{"backend"}, tmpl.TeamReviewers.Value("github.com/sourcegraph/sourcegraph"))
+		assert.Equal(t, []string{"frontend"}, tmpl.TeamReviewers.Value("github.com/other/repo"))
+		assert.Equal(t, []string{"automated"}, tmpl.Labels.Value("github.com/sourcegraph/sourcegraph"))
+		assert.Equal(t, []string{"bob"}, tmpl.Assignees.Value("github.com/sourcegraph/sourcegraph"))
+		assert.Equal(t, "v1.0", tmpl.Milestone.Value("github.com/sourcegraph/sourcegraph"))
+		assert.Equal(t, "v2.0", tmpl.Milestone.Value("github.com/other/repo"))
+	})
+
+	t.Run("invalid labels", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  labels: automated
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Error(t, err)
+	})
+
+	t.Run("auto-merge policy", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+autoMerge:
+  checks: passedOrNone
+  approvals: 2
+  method: squash
+  windows:
+    - days: [monday, tuesday]
+      start: "09:00"
+      end: "16:00"
+`
+
+		have, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		assert.Equal(t, &AutoMerge{
+			Checks:    AutoMergeChecksPassedOrNone,
+			Approvals: 2,
+			Method:    AutoMergeMethodSquash,
+			Windows: []AutoMergeWindow{
+				{Days: []string{"monday", "tuesday"}, Start: "09:00", End: "16:00"},
+			},
+		}, have.AutoMerge)
+		assert.True(t, have.AutoMerge.Squash())
+		assert.Equal(t, AutoMergeChecksPassed, (&AutoMerge{}).RequiredChecks())
+	})
+
+	t.Run("auto-rebase", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+autoRebase: false
+`
+
+		have, err := ParseBatchSpec([]byte(spec))
+		require.NoError(t, err)
+		assert.False(t, have.AutoRebaseEnabled())
+		assert.True(t, (&BatchSpec{}).AutoRebaseEnabled())
+	})
+
+	t.Run("invalid auto-merge method", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+autoMerge:
+  method: rebase
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Error(t, err)
+	})
+
 	t.Run("invalid version", func(t *testing.T) {
 		const spec = `
 version: 99
@@ -286,6 +408,128 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("uses", func(t *testing.T) {
+		const spec = `
+name: test-spec
+steps:
+  - uses: my-org/gofmt@1.0.0
+    with:
+      path: ./cmd
+    if: true
+changesetTemplate:
+  title: Test uses
+  body: Test a step definition
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		require.NoError(t, err)
+		assert.Equal(t, "my-org/gofmt@1.0.0", batchSpec.Steps[0].Uses)
+		assert.Equal(t, map[string]string{"path": "./cmd"}, batchSpec.Steps[0].With)
+	})
+
+	t.Run("uses with run", func(t *testing.T) {
+		const spec = `
+name: test-spec
+steps:
+  - uses: my-org/gofmt@1.0.0
+    run: gofmt -w .
+changesetTemplate:
+  title: Test uses
+  body: Test a step definition
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, "step 1: a step with uses can't have run, container, files, outputs or mount", err.Error())
+	})
+
+	t.Run("uses without version", func(t *testing.T) {
+		const spec = `
+name: test-spec
+steps:
+  - uses: my-org/gofmt
+changesetTemplate:
+  title: Test uses
+  body: Test a step definition
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Error(t, err)
+	})
+
+	t.Run("with without uses", func(t *testing.T) {
+		const spec = `
+name: test-spec
+steps:
+  - run: gofmt -w .
+    container: golang:1
+    with:
+      path: ./cmd
+changesetTemplate:
+  title: Test uses
+  body: Test a step definition
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, "step 1: with can only be used together with uses", err.Error())
+	})
+
+	t.Run("dependencies", func(t *testing.T) {
+		const spec = `
+name: test-spec
+on:
+  - repository: github.com/foo/lib
+    name: lib
+  - repositoriesMatchingQuery: lang:go github.com/foo/lib
+    name: consumers
+    dependsOn: [lib]
+  - repository: github.com/foo/app
+    dependsOn: [consumers, github.com/foo/other]
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		require.NoError(t, err)
+		assert.Equal(t, "lib", batchSpec.On[0].Name)
+		assert.Equal(t, []string{"lib"}, batchSpec.On[1].DependsOn)
+		assert.Equal(t, []string{"consumers", "github.com/foo/other"}, batchSpec.On[2].DependsOn)
+	})
+
+	t.Run("duplicate dependency names", func(t *testing.T) {
+		const spec = `
+name: test-spec
+on:
+  - repository: github.com/foo/lib
+    name: lib
+  - repository: github.com/foo/other-lib
+    name: lib
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, `the name "lib" is used by more than one entry in on`, err.Error())
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		const spec = `
+name: test-spec
+on:
+  - repository: github.com/foo/app
+    dependsOn: [a]
+  - repository: github.com/foo/a
+    name: a
+    dependsOn: [b]
+  - repository: github.com/foo/b
+    name: b
+    dependsOn: [a]
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, "the dependencies in on form a cycle: a -> b -> a", err.Error())
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -81,6 +81,17 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// Reviewers, TeamReviewers, Labels and Assignees are applied to the
+	// changeset on the code host. If empty, the changeset's respective
+	// attribute is left unchanged.
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
+	Labels        []string `json:"labels,omitempty"`
+	Assignees     []string `json:"assignees,omitempty"`
+	// Milestone is the title of the milestone the changeset is added to. If
+	// empty, the changeset's milestone is left unchanged.
+	Milestone string `json:"milestone,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -104,6 +115,11 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
 		Fork           *bool                  `json:"fork,omitempty"`
+		Reviewers      []string               `json:"reviewers,omitempty"`
+		TeamReviewers  []string               `json:"teamReviewers,omitempty"`
+		Labels         []string               `json:"labels,omitempty"`
+		Assignees      []string               `json:"assignees,omitempty"`
+		Milestone      string                 `json:"milestone,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -115,6 +131,11 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body:           c.Body,
 		Commits:        c.Commits,
 		Fork:           c.Fork,
+		Reviewers:      c.Reviewers,
+		TeamReviewers:  c.TeamReviewers,
+		Labels:         c.Labels,
+		Assignees:      c.Assignees,
+		Milestone:      c.Milestone,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
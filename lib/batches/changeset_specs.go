@@ -94,6 +94,14 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAu
 		return nil, err
 	}
 
+	var milestone string
+	if input.Template.Milestone != nil {
+		milestone, err = template.RenderChangesetTemplateField("milestone", input.Template.Milestone.Value(input.Repository.Name), tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO: As a next step, we should extend the ChangesetTemplateContext to also include
 	// TransformChanges.Group and then change validateGroups and groupFileDiffs to, for each group,
 	// render the branch name *before* grouping the diffs.
@@ -110,6 +118,20 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAu
 
 		fork := input.Template.Fork
 
+		var reviewers, teamReviewers, labels, assignees []string
+		if input.Template.Reviewers != nil {
+			reviewers = input.Template.Reviewers.Value(input.Repository.Name)
+		}
+		if input.Template.TeamReviewers != nil {
+			teamReviewers = input.Template.TeamReviewers.Value(input.Repository.Name)
+		}
+		if input.Template.Labels != nil {
+			labels = input.Template.Labels.Value(input.Repository.Name)
+		}
+		if input.Template.Assignees != nil {
+			assignees = input.Template.Assignees.Value(input.Repository.Name)
+		}
+
 		version := 1
 		if binaryDiffs {
 			version = 2
@@ -134,7 +156,12 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAu
 					Diff:        diff,
 				},
 			},
-			Published: PublishedValue{Val: published},
+			Published:     PublishedValue{Val: published},
+			Reviewers:     reviewers,
+			TeamReviewers: teamReviewers,
+			Labels:        labels,
+			Assignees:     assignees,
+			Milestone:     milestone,
 		}
 	}
 
@@ -146,6 +146,26 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "reviewers, labels, assignees and milestone",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.Reviewers = parseStringListField(t, `[{"*": ["alice"]}, {"github.com/sourcegraph/*": ["bob"]}]`)
+				input.Template.TeamReviewers = parseStringListField(t, `["frontend"]`)
+				input.Template.Labels = parseStringListField(t, `[{"github.com/other/*": ["other"]}]`)
+				input.Template.Assignees = parseStringListField(t, `["carol", "dave"]`)
+				input.Template.Milestone = parseStringField(t, `[{"*": "${{ batch_change.name }}"}, {"github.com/other/*": "other"}]`)
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Reviewers = []string{"bob"}
+					s.TeamReviewers = []string{"frontend"}
+					s.Assignees = []string{"carol", "dave"}
+					s.Milestone = "the name"
+				}),
+			},
+			wantErr: "",
+		},
 		{
 			name:   "publish with fallback author",
 			input:  defaultInput,
@@ -350,3 +370,23 @@ func parsePublishedFieldString(t *testing.T, input string) *overridable.BoolOrSt
 	}
 	return &result
 }
+
+func parseStringListField(t *testing.T, input string) *overridable.StringList {
+	t.Helper()
+
+	var result overridable.StringList
+	if err := json.Unmarshal([]byte(input), &result); err != nil {
+		t.Fatalf("failed to parse %q as overridable.StringList: %s", input, err)
+	}
+	return &result
+}
+
+func parseStringField(t *testing.T, input string) *overridable.String {
+	t.Helper()
+
+	var result overridable.String
+	if err := json.Unmarshal([]byte(input), &result); err != nil {
+		t.Fatalf("failed to parse %q as overridable.String: %s", input, err)
+	}
+	return &result
+}
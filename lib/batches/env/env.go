@@ -3,6 +3,7 @@ package env
 
 import (
 	"encoding/json"
+	"sort"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -155,6 +156,41 @@ func (e Environment) Resolve(outer []string) (map[string]string, error) {
 	return resolved, nil
 }
 
+// FromStatic returns an environment with the given static variables, ordered
+// by name.
+func FromStatic(vars map[string]string) Environment {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	e := Environment{vars: make([]variable, 0, len(vars))}
+	for _, name := range names {
+		value := vars[name]
+		e.vars = append(e.vars, variable{name: name, value: &value})
+	}
+	return e
+}
+
+// Merge returns a copy of the environment with the variables of other added.
+// Variables that exist in both environments take their value from other.
+func (e Environment) Merge(other Environment) Environment {
+	replaced := make(map[string]struct{}, len(other.vars))
+	for _, v := range other.vars {
+		replaced[v.name] = struct{}{}
+	}
+
+	merged := Environment{vars: make([]variable, 0, len(e.vars)+len(other.vars))}
+	for _, v := range e.vars {
+		if _, ok := replaced[v.name]; !ok {
+			merged.vars = append(merged.vars, v)
+		}
+	}
+	merged.vars = append(merged.vars, other.vars...)
+	return merged
+}
+
 // Equal verifies if two environments are equal.
 func (e Environment) Equal(other Environment) bool {
 	return cmp.Equal(e.mapify(), other.mapify())
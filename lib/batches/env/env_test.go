@@ -291,3 +291,67 @@ func TestEnvironment_OuterVars(t *testing.T) {
 		})
 	}
 }
+
+func TestFromStatic(t *testing.T) {
+	have, err := json.Marshal(FromStatic(map[string]string{"b": "2", "a": "1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":"1","b":"2"}`; string(have) != want {
+		t.Errorf("unexpected environment: have=%q want=%q", string(have), want)
+	}
+}
+
+func TestEnvironment_Merge(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in    Environment
+		other Environment
+		want  string
+	}{
+		"no variables": {
+			in:    Environment{},
+			other: Environment{},
+			want:  `{}`,
+		},
+		"replaced variables": {
+			in: Environment{vars: []variable{
+				{name: "foo", value: pointers.Ptr("bar")},
+				{name: "quux", value: nil},
+			}},
+			other: Environment{vars: []variable{
+				{name: "quux", value: pointers.Ptr("baz")},
+			}},
+			want: `{"foo":"bar","quux":"baz"}`,
+		},
+		"outer variables": {
+			in: Environment{vars: []variable{
+				{name: "foo", value: pointers.Ptr("bar")},
+			}},
+			other: Environment{vars: []variable{
+				{name: "foo", value: nil},
+				{name: "quux", value: pointers.Ptr("baz")},
+			}},
+			want: `["foo",{"quux":"baz"}]`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			original, err := json.Marshal(tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have, err := json.Marshal(tc.in.Merge(tc.other))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(have) != tc.want {
+				t.Errorf("unexpected environment: have=%q want=%q", string(have), tc.want)
+			}
+
+			// The original environment must not be modified.
+			if after, _ := json.Marshal(tc.in); string(after) != string(original) {
+				t.Errorf("environment was modified: have=%q want=%q", string(after), string(original))
+			}
+		})
+	}
+}
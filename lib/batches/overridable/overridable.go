@@ -4,6 +4,7 @@ package overridable
 
 import (
 	"encoding/json"
+	"reflect"
 	"strings"
 
 	"github.com/gobwas/glob"
@@ -62,7 +63,7 @@ func newRule(pattern string, value any) (*rule, error) {
 }
 
 func (a rule) Equal(b rule) bool {
-	return a.pattern == b.pattern && a.value == b.value
+	return a.pattern == b.pattern && reflect.DeepEqual(a.value, b.value)
 }
 
 type rules []*rule
@@ -0,0 +1,99 @@
+package overridable
+
+import (
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// StringList represents a list of strings that can be modified on a per-repo
+// basis.
+type StringList struct {
+	rules rules
+}
+
+// FromStringList creates a StringList representing a static, scalar value.
+func FromStringList(l []string) StringList {
+	return StringList{
+		rules: rules{simpleRule(l)},
+	}
+}
+
+// Value returns the list of strings for the given repository.
+func (sl *StringList) Value(name string) []string {
+	v := sl.rules.Match(name)
+	if v == nil {
+		return nil
+	}
+	return v.([]string)
+}
+
+// MarshalJSON encodes the StringList overridable to a json representation.
+func (sl StringList) MarshalJSON() ([]byte, error) {
+	if len(sl.rules) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(sl.rules)
+}
+
+// UnmarshalJSON unmarshalls a JSON value into a StringList.
+func (sl *StringList) UnmarshalJSON(data []byte) error {
+	var all []string
+	if err := json.Unmarshal(data, &all); err == nil {
+		*sl = StringList{rules: rules{simpleRule(all)}}
+		return nil
+	}
+
+	var c complex
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	return sl.hydrateFromComplex(c)
+}
+
+// UnmarshalYAML unmarshalls a YAML value into a StringList.
+func (sl *StringList) UnmarshalYAML(unmarshal func(any) error) error {
+	var all []string
+	if err := unmarshal(&all); err == nil {
+		*sl = StringList{rules: rules{simpleRule(all)}}
+		return nil
+	}
+
+	var c complex
+	if err := unmarshal(&c); err != nil {
+		return err
+	}
+
+	return sl.hydrateFromComplex(c)
+}
+
+// hydrateFromComplex builds the rules out of a complex value, converting the
+// decoded lists of each rule into []string.
+func (sl *StringList) hydrateFromComplex(c []map[string]any) error {
+	if err := sl.rules.hydrateFromComplex(c); err != nil {
+		return err
+	}
+
+	for i, r := range sl.rules {
+		items, ok := r.value.([]any)
+		if !ok {
+			return errors.Errorf("unexpected value in the array at entry %d: %v (must be a list of strings)", i, r.value)
+		}
+		l := make([]string, len(items))
+		for j, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return errors.Errorf("unexpected value in the list at entry %d: %v (must be a string)", i, item)
+			}
+			l[j] = s
+		}
+		r.value = l
+	}
+	return nil
+}
+
+// Equal tests two StringLists for equality, used in cmp.
+func (sl StringList) Equal(other StringList) bool {
+	return sl.rules.Equal(other.rules)
+}
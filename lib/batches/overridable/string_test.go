@@ -0,0 +1,203 @@
+package overridable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+)
+
+func TestStringValue(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in   String
+		name string
+		want string
+	}{
+		"wildcard": {
+			in: String{
+				rules: rules{{pattern: allPattern, value: "a"}},
+			},
+			name: "foo",
+			want: "a",
+		},
+		"list exhausted": {
+			in: String{
+				rules: rules{{pattern: "bar*", value: "a"}},
+			},
+			name: "foo",
+			want: "",
+		},
+		"multiple matches": {
+			in: String{
+				rules: rules{
+					{pattern: allPattern, value: "a"},
+					{pattern: "bar*", value: "b"},
+				},
+			},
+			name: "bar",
+			want: "b",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := initString(&tc.in); err != nil {
+				t.Fatal(err)
+			}
+
+			if have := tc.in.Value(tc.name); have != tc.want {
+				t.Errorf("unexpected value: have=%q want=%q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringMarshalJSON(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in   String
+		want string
+	}{
+		"scalar": {
+			in:   FromString("a"),
+			want: `"a"`,
+		},
+		"rules": {
+			in: String{
+				rules{
+					{pattern: allPattern, value: "a"},
+					{pattern: "bar*", value: ""},
+				},
+			},
+			want: `[{"*":"a"},{"bar*":""}]`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(&tc.in)
+			if err != nil {
+				t.Errorf("unexpected non-nil error: %v", err)
+			}
+			if have := string(data); have != tc.want {
+				t.Errorf("unexpected JSON: have=%q want=%q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringUnmarshalJSON(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			in   string
+			want String
+		}{
+			"string": {
+				in: `"a"`,
+				want: String{
+					rules: rules{
+						{pattern: allPattern, value: "a"},
+					},
+				},
+			},
+			"multiple rule list": {
+				in: `[{"*":"a"},{"github.com/sourcegraph/*":"b"}]`,
+				want: String{
+					rules: rules{
+						{pattern: allPattern, value: "a"},
+						{pattern: "github.com/sourcegraph/*", value: "b"},
+					},
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have String
+				if err := json.Unmarshal([]byte(tc.in), &have); err != nil {
+					t.Errorf("unexpected non-nil error: %v", err)
+				}
+				if diff := cmp.Diff(&have, &tc.want); diff != "" {
+					t.Errorf("unexpected String: %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, in := range map[string]string{
+			"bool":            `true`,
+			"empty object":    `[{}]`,
+			"too many fields": `[{"foo": "a","bar":"b"}]`,
+			"invalid glob":    `[{"[":"a"}]`,
+			"list value":      `[{"foo": ["a"]}]`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have String
+				if err := json.Unmarshal([]byte(in), &have); err == nil {
+					t.Error("unexpected nil error")
+				}
+			})
+		}
+	})
+}
+
+func TestStringYAML(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			in   string
+			want String
+		}{
+			"string": {
+				in: `a`,
+				want: String{
+					rules: rules{
+						{pattern: allPattern, value: "a"},
+					},
+				},
+			},
+			"multiple rule list": {
+				in: "- \"*\": a\n- github.com/sourcegraph/*: b",
+				want: String{
+					rules: rules{
+						{pattern: allPattern, value: "a"},
+						{pattern: "github.com/sourcegraph/*", value: "b"},
+					},
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have String
+				if err := yaml.Unmarshal([]byte(tc.in), &have); err != nil {
+					t.Errorf("unexpected non-nil error: %v", err)
+				}
+				if diff := cmp.Diff(&have, &tc.want); diff != "" {
+					t.Errorf("unexpected String: %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, in := range map[string]string{
+			"empty object": `- {}`,
+			"invalid glob": `- "[": a`,
+			"list value":   `- foo: [a]`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have String
+				if err := yaml.Unmarshal([]byte(in), &have); err == nil {
+					t.Error("unexpected nil error")
+				}
+			})
+		}
+	})
+}
+
+// initString ensures all rules are compiled.
+func initString(s *String) (err error) {
+	for i, rule := range s.rules {
+		if rule.compiled == nil {
+			s.rules[i], err = newRule(rule.pattern, rule.value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
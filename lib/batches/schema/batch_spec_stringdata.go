@@ -43,6 +43,20 @@ const BatchSpecJSON = `{
                 "type": "string",
                 "description": "A Sourcegraph search query that matches a set of repositories (and branches). If the query matches files, symbols, or some other object inside a repository, the object's repository is included.",
                 "examples": ["file:README.md"]
+              },
+              "name": {
+                "type": "string",
+                "description": "A name for this entry, by which the dependsOn field of other entries can refer to it.",
+                "pattern": "^[\\w.-]+$",
+                "examples": ["shared-library"]
+              },
+              "dependsOn": {
+                "type": "array",
+                "description": "The entries or repositories whose changesets must be merged before the changesets of this entry are published. Each item is either the name of another entry or the name of a repository (as it is known to Sourcegraph). Until then, the changesets are held unpublished, or in draft if they are already published as drafts.",
+                "items": {
+                  "type": "string"
+                },
+                "examples": [["shared-library"], ["github.com/foo/lib"]]
               }
             }
           },
@@ -68,6 +82,20 @@ const BatchSpecJSON = `{
                 "items": {
                   "type": "string"
                 }
+              },
+              "name": {
+                "type": "string",
+                "description": "A name for this entry, by which the dependsOn field of other entries can refer to it.",
+                "pattern": "^[\\w.-]+$",
+                "examples": ["shared-library"]
+              },
+              "dependsOn": {
+                "type": "array",
+                "description": "The entries or repositories whose changesets must be merged before the changesets of this entry are published. Each item is either the name of another entry or the name of a repository (as it is known to Sourcegraph). Until then, the changesets are held unpublished, or in draft if they are already published as drafts.",
+                "items": {
+                  "type": "string"
+                },
+                "examples": [["shared-library"], ["github.com/foo/lib"]]
               }
             },
             "$comment": "This is a convoluted way of saying either ` + "`" + `branch` + "`" + ` or ` + "`" + `branches` + "`" + ` can be provided, but not both at once, and neither are required.",
@@ -128,8 +156,21 @@ const BatchSpecJSON = `{
         "type": "object",
         "description": "A command to run (as part of a sequence) in a repository branch to produce the required changes.",
         "additionalProperties": false,
-        "required": ["run", "container"],
+        "oneOf": [{ "required": ["run", "container"] }, { "required": ["uses"] }],
         "properties": {
+          "uses": {
+            "type": "string",
+            "description": "A step definition published to Sourcegraph to run instead of a command, referenced as <namespace>/<name>@<version>. The step definition is resolved when the batch spec is created. A step that uses a step definition can't have run, container, files, outputs or mount.",
+            "pattern": "^[^/@\\s]+/[^/@\\s]+@[^/@\\s]+$",
+            "examples": ["my-org/gofmt@1.0.0"]
+          },
+          "with": {
+            "type": ["object", "null"],
+            "description": "The inputs to pass to the step definition referenced by uses. They are set as INPUT_<NAME> environment variables in the step environment, and can be template strings.",
+            "additionalProperties": {
+              "type": "string"
+            }
+          },
           "run": {
             "type": "string",
             "description": "The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout."
@@ -404,8 +445,237 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "reviewers": {
+          "description": "The users to request a review of the changeset from. They are identified by their username, except on Bitbucket Cloud, which requires account IDs. Reviews are requested when the changeset is published and whenever it is updated. Reviewers that are dropped from the list are removed from the changeset, while reviewers added on the code host are kept.",
+          "oneOf": [
+            {
+              "type": "null"
+            },
+            {
+              "type": "array",
+              "description": "A single list to use for the entire batch change.",
+              "items": {
+                "type": "string",
+                "description": "The username of a user on the code host."
+              },
+              "examples": [["alice", "bob"]]
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the list for matching repositories.",
+                "additionalProperties": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
+        },
+        "teamReviewers": {
+          "description": "The teams to request a review of the changeset from, on code hosts with teams that can review changesets: GitHub team slugs, Azure DevOps team or group names, and Gerrit group names. Reviews are requested when the changeset is published and whenever it is updated. Team reviewers that are dropped from the list are removed from the changeset, while team reviewers added on the code host are kept.",
+          "oneOf": [
+            {
+              "type": "null"
+            },
+            {
+              "type": "array",
+              "description": "A single list to use for the entire batch change.",
+              "items": {
+                "type": "string",
+                "description": "The name of a team on the code host."
+              },
+              "examples": [["frontend-reviewers"]]
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the list for matching repositories.",
+                "additionalProperties": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
+        },
+        "labels": {
+          "description": "The labels to set on the changeset, on code hosts that support labels: GitHub, GitLab, Azure DevOps, and Gerrit, where they are set as hashtags. Labels that are dropped from the list are removed from the changeset, while labels added on the code host are kept.",
+          "oneOf": [
+            {
+              "type": "null"
+            },
+            {
+              "type": "array",
+              "description": "A single list to use for the entire batch change.",
+              "items": {
+                "type": "string",
+                "description": "The name of a label."
+              },
+              "examples": [["dependencies", "automated"]]
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the list for matching repositories.",
+                "additionalProperties": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
+        },
+        "assignees": {
+          "description": "The usernames of the users to assign the changeset to, on code hosts that support assignees: GitHub and GitLab. Assignees that are dropped from the list are removed from the changeset, while assignees added on the code host are kept.",
+          "oneOf": [
+            {
+              "type": "null"
+            },
+            {
+              "type": "array",
+              "description": "A single list to use for the entire batch change.",
+              "items": {
+                "type": "string",
+                "description": "The username of a user on the code host."
+              },
+              "examples": [["alice"]]
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the list for matching repositories.",
+                "additionalProperties": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
+        },
+        "milestone": {
+          "description": "The title of the milestone to add the changeset to, on code hosts that support milestones: GitHub and GitLab. The milestone must exist in the repository. If the milestone is dropped from the spec, it is removed from the changeset.",
+          "oneOf": [
+            {
+              "type": "null"
+            },
+            {
+              "type": "string",
+              "description": "A single milestone to use for the entire batch change.",
+              "examples": ["v1.0"]
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the milestone for matching repositories.",
+                "additionalProperties": {
+                  "type": "string"
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
+        }
+      }
+    },
+    "autoMerge": {
+      "title": "AutoMerge",
+      "type": "object",
+      "description": "A policy under which the changesets of the batch change are merged automatically once they are mergeable. Changesets are checked against the policy whenever they are synced from the code host. If omitted, changesets are only merged when requested.",
+      "additionalProperties": false,
+      "properties": {
+        "checks": {
+          "type": "string",
+          "description": "The state the checks of a changeset must be in for it to be merged: ` + "`" + `passed` + "`" + ` requires all checks to have passed, ` + "`" + `passedOrNone` + "`" + ` additionally merges changesets without checks, and ` + "`" + `any` + "`" + ` ignores checks. Defaults to ` + "`" + `passed` + "`" + `.",
+          "enum": ["passed", "passedOrNone", "any"]
+        },
+        "approvals": {
+          "type": "integer",
+          "description": "The number of approving reviews a changeset must have to be merged. Changesets on which changes were requested are never merged. Defaults to 0.",
+          "minimum": 0
+        },
+        "method": {
+          "type": "string",
+          "description": "The method used to merge changesets. ` + "`" + `squash` + "`" + ` squashes the commits of a changeset into one commit, on code hosts that support it. Defaults to ` + "`" + `merge` + "`" + `.",
+          "enum": ["merge", "squash"]
+        },
+        "windows": {
+          "type": "array",
+          "description": "The windows of time during which changesets may be merged. If omitted or empty, changesets are merged at any time.",
+          "items": {
+            "title": "AutoMergeWindow",
+            "type": "object",
+            "description": "A window of time, in UTC, during which changesets may be merged.",
+            "additionalProperties": false,
+            "properties": {
+              "days": {
+                "type": "array",
+                "description": "Day(s) the window applies to. If omitted, this window applies to all days of the week.",
+                "items": {
+                  "type": "string",
+                  "pattern": "^([mM]on(day)?|[tT]ue(s|sday)?|[wW]ed(nesday)?|[tT]hu(r|rs|rsday)?|[fF]ri(day)?|[sS]at(urday)?|[sS]un(day)?)$"
+                }
+              },
+              "start": {
+                "type": "string",
+                "description": "Window start time. If omitted, the window applies to the whole of the day(s) it applies to.",
+                "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+              },
+              "end": {
+                "type": "string",
+                "description": "Window end time. If omitted, the window applies to the whole of the day(s) it applies to.",
+                "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+              }
+            },
+            "dependencies": {
+              "start": ["end"],
+              "end": ["start"]
+            }
+          },
+          "examples": [
+            [
+              {
+                "days": ["monday", "tuesday", "wednesday", "thursday"],
+                "start": "09:00",
+                "end": "16:00"
+              }
+            ]
+          ]
         }
       }
+    },
+    "autoRebase": {
+      "type": "boolean",
+      "description": "Whether changesets that have merge conflicts with their base branch are regenerated automatically. When the code host reports a conflict, the steps are run again in the affected workspace against the new base commit and the changeset is force-pushed. Only applies to batch changes run server-side, and to code hosts that report conflicts. Defaults to true.",
+      "default": true
     }
   }
 }
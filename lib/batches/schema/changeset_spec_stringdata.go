@@ -111,7 +111,28 @@ const ChangesetSpecJSON = `{
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
-        }
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The users to request a review of the changeset from.",
+          "items": { "type": "string" }
+        },
+        "teamReviewers": {
+          "type": "array",
+          "description": "The teams to request a review of the changeset from.",
+          "items": { "type": "string" }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to set on the changeset.",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The users to assign the changeset to.",
+          "items": { "type": "string" }
+        },
+        "milestone": { "type": "string", "description": "The title of the milestone to add the changeset to." }
       },
       "required": ["baseRepository", "baseRef", "baseRev", "headRepository", "headRef", "title", "body", "commits"],
       "additionalProperties": false
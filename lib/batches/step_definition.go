@@ -0,0 +1,126 @@
+package batches
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/env"
+	"github.com/sourcegraph/sourcegraph/lib/batches/schema"
+	"github.com/sourcegraph/sourcegraph/lib/batches/yaml"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// StepDefinition is a reusable step that is published to Sourcegraph in a
+// namespace under a name and version. Steps of batch specs run it by
+// referencing it in their uses field.
+type StepDefinition struct {
+	Name        string                         `json:"name,omitempty" yaml:"name"`
+	Version     string                         `json:"version,omitempty" yaml:"version"`
+	Description string                         `json:"description,omitempty" yaml:"description"`
+	Inputs      map[string]StepDefinitionInput `json:"inputs,omitempty" yaml:"inputs"`
+	Run         string                         `json:"run,omitempty" yaml:"run"`
+	Container   string                         `json:"container,omitempty" yaml:"container"`
+	Env         env.Environment                `json:"env,omitempty" yaml:"env"`
+	Files       map[string]string              `json:"files,omitempty" yaml:"files,omitempty"`
+	Outputs     Outputs                        `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// StepDefinitionInput is an input of a StepDefinition, which steps using the
+// step definition pass in their with field.
+type StepDefinitionInput struct {
+	Description string `json:"description,omitempty" yaml:"description"`
+	Required    bool   `json:"required,omitempty" yaml:"required"`
+	Default     string `json:"default,omitempty" yaml:"default"`
+}
+
+// ParseStepDefinition parses and validates a step definition in YAML or JSON.
+func ParseStepDefinition(data []byte) (*StepDefinition, error) {
+	var def StepDefinition
+	if err := yaml.UnmarshalValidate(schema.BatchStepDefinitionJSON, data, &def); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// InputEnvVar returns the name of the environment variable that the value of
+// the input with the given name is set as.
+func InputEnvVar(input string) string {
+	return "INPUT_" + strings.ToUpper(input)
+}
+
+// Apply returns the step that runs the step definition with the inputs and
+// condition of the given step, which uses the step definition. The inputs are
+// set as environment variables in addition to the environment of the step
+// definition and the step, so that they can be template strings.
+func (d *StepDefinition) Apply(step Step) (Step, error) {
+	var errs error
+	for name := range step.With {
+		if _, ok := d.Inputs[name]; !ok {
+			errs = errors.Append(errs, errors.Newf("%s has no input %q", d.Name, name))
+		}
+	}
+
+	names := make([]string, 0, len(d.Inputs))
+	for name := range d.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	vars := make(map[string]string, len(d.Inputs))
+	for _, name := range names {
+		input := d.Inputs[name]
+		value, ok := step.With[name]
+		if !ok {
+			if input.Required {
+				errs = errors.Append(errs, errors.Newf("%s requires the input %q", d.Name, name))
+				continue
+			}
+			value = input.Default
+		}
+		vars[InputEnvVar(name)] = value
+	}
+	if errs != nil {
+		return Step{}, errs
+	}
+
+	// The environment of the step overrides the one of the step definition,
+	// so that steps can pass through secrets the step definition expects.
+	e := d.Env.Merge(step.Env).Merge(env.FromStatic(vars))
+
+	return Step{
+		Run:       d.Run,
+		Container: d.Container,
+		Env:       e,
+		Files:     d.Files,
+		Outputs:   d.Outputs,
+		If:        step.If,
+	}, nil
+}
+
+// StepReference is a reference to a version of a step definition in the uses
+// field of a step.
+type StepReference struct {
+	// Namespace is the name of the user or organization that published the
+	// step definition.
+	Namespace string
+	Name      string
+	Version   string
+}
+
+var stepReferencePattern = regexp.MustCompile(`^([^/@\s]+)/([^/@\s]+)@([^/@\s]+)$`)
+
+// ParseStepReference parses a reference of the form
+// <namespace>/<name>@<version>. Versions must always be given, so that the
+// step a batch spec runs doesn't change when a new version is published.
+func ParseStepReference(uses string) (StepReference, error) {
+	m := stepReferencePattern.FindStringSubmatch(uses)
+	if m == nil {
+		return StepReference{}, errors.Newf("invalid uses %q: must be of the form <namespace>/<name>@<version>", uses)
+	}
+	return StepReference{Namespace: m[1], Name: m[2], Version: m[3]}, nil
+}
+
+func (r StepReference) String() string {
+	return r.Namespace + "/" + r.Name + "@" + r.Version
+}
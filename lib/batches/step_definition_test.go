@@ -0,0 +1,129 @@
+package batches
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStepDefinition(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		const def = `
+name: gofmt
+version: 1.0.0
+description: Formats Go code
+inputs:
+  path:
+    description: The directory to format
+    default: .
+run: gofmt -w "$INPUT_PATH"
+container: golang:1
+outputs:
+  formatted:
+    value: ${{ step.stdout }}
+`
+		have, err := ParseStepDefinition([]byte(def))
+		require.NoError(t, err)
+		assert.Equal(t, "gofmt", have.Name)
+		assert.Equal(t, "1.0.0", have.Version)
+		assert.Equal(t, StepDefinitionInput{Description: "The directory to format", Default: "."}, have.Inputs["path"])
+		assert.Equal(t, Outputs{"formatted": {Value: "${{ step.stdout }}"}}, have.Outputs)
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		const def = `
+name: gofmt
+run: gofmt -w .
+container: golang:1
+`
+		_, err := ParseStepDefinition([]byte(def))
+		assert.ErrorContains(t, err, "version is required")
+	})
+
+	t.Run("invalid input name", func(t *testing.T) {
+		const def = `
+name: gofmt
+version: 1.0.0
+inputs:
+  the-path: {}
+run: gofmt -w .
+container: golang:1
+`
+		_, err := ParseStepDefinition([]byte(def))
+		assert.Error(t, err)
+	})
+}
+
+func TestStepDefinition_Apply(t *testing.T) {
+	const def = `
+name: gofmt
+version: 1.0.0
+inputs:
+  path:
+    required: true
+  flags:
+    default: -s
+env:
+  GOFLAGS: -mod=mod
+  TOKEN: unset
+run: gofmt $INPUT_FLAGS -w "$INPUT_PATH"
+container: golang:1
+`
+	d, err := ParseStepDefinition([]byte(def))
+	require.NoError(t, err)
+
+	t.Run("inputs", func(t *testing.T) {
+		var step Step
+		require.NoError(t, json.Unmarshal([]byte(`{"uses":"my-org/gofmt@1.0.0","with":{"path":"${{ repository.name }}"},"env":["TOKEN"],"if":true}`), &step))
+
+		have, err := d.Apply(step)
+		require.NoError(t, err)
+		assert.Equal(t, d.Run, have.Run)
+		assert.Equal(t, d.Container, have.Container)
+		assert.Equal(t, true, have.If)
+		assert.Empty(t, have.Uses)
+		assert.Equal(t, []string{"TOKEN"}, have.Env.OuterVars())
+
+		env, err := have.Env.Resolve([]string{"TOKEN=secret"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"GOFLAGS":     "-mod=mod",
+			"TOKEN":       "secret",
+			"INPUT_PATH":  "${{ repository.name }}",
+			"INPUT_FLAGS": "-s",
+		}, env)
+	})
+
+	t.Run("missing required input", func(t *testing.T) {
+		_, err := d.Apply(Step{Uses: "my-org/gofmt@1.0.0"})
+		assert.ErrorContains(t, err, `gofmt requires the input "path"`)
+	})
+
+	t.Run("unknown input", func(t *testing.T) {
+		_, err := d.Apply(Step{Uses: "my-org/gofmt@1.0.0", With: map[string]string{"path": ".", "dir": "."}})
+		assert.ErrorContains(t, err, `gofmt has no input "dir"`)
+	})
+}
+
+func TestParseStepReference(t *testing.T) {
+	for uses, want := range map[string]*StepReference{
+		"my-org/gofmt@1.0.0":    {Namespace: "my-org", Name: "gofmt", Version: "1.0.0"},
+		"alice/codemod@v2-beta": {Namespace: "alice", Name: "codemod", Version: "v2-beta"},
+		"my-org/gofmt":          nil,
+		"gofmt@1.0.0":           nil,
+		"my-org/go/fmt@1.0.0":   nil,
+	} {
+		t.Run(uses, func(t *testing.T) {
+			have, err := ParseStepReference(uses)
+			if want == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, *want, have)
+			assert.Equal(t, uses, have.String())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS gitserver_shard_assignment (
+    id integer DEFAULT 1 NOT NULL PRIMARY KEY,
+    addresses text[] NOT NULL,
+    target_addresses text[],
+    rebalance_cursor bigint DEFAULT 0 NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT gitserver_shard_assignment_singleton CHECK (id = 1)
+);
+
+COMMENT ON TABLE gitserver_shard_assignment IS 'Tracks which set of gitserver shards repositories are assigned to, and the progress of moving them to a new set of shards.';
+
+COMMENT ON COLUMN gitserver_shard_assignment.addresses IS 'The gitserver addresses repositories were last fully assigned to.';
+
+COMMENT ON COLUMN gitserver_shard_assignment.target_addresses IS 'The gitserver addresses repositories are being moved to, or NULL if no rebalance is in progress.';
+
+COMMENT ON COLUMN gitserver_shard_assignment.rebalance_cursor IS 'The bits of the unsigned shard hash of the first repository which has not been moved to target_addresses yet.';
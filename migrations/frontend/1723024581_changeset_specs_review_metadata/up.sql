@@ -0,0 +1,16 @@
+ALTER TABLE changeset_specs
+    ADD COLUMN IF NOT EXISTS reviewers text[],
+    ADD COLUMN IF NOT EXISTS team_reviewers text[],
+    ADD COLUMN IF NOT EXISTS labels text[],
+    ADD COLUMN IF NOT EXISTS assignees text[],
+    ADD COLUMN IF NOT EXISTS milestone text;
+
+COMMENT ON COLUMN changeset_specs.reviewers IS 'The users to request a review of the changeset from.';
+
+COMMENT ON COLUMN changeset_specs.team_reviewers IS 'The teams to request a review of the changeset from.';
+
+COMMENT ON COLUMN changeset_specs.labels IS 'The labels to set on the changeset.';
+
+COMMENT ON COLUMN changeset_specs.assignees IS 'The users to assign the changeset to.';
+
+COMMENT ON COLUMN changeset_specs.milestone IS 'The title of the milestone to add the changeset to.';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS changeset_dependencies (
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    depends_on_changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    held boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (changeset_id, depends_on_changeset_id)
+);
+
+CREATE INDEX IF NOT EXISTS changeset_dependencies_depends_on_changeset_id_idx ON changeset_dependencies (depends_on_changeset_id);
+
+CREATE INDEX IF NOT EXISTS changeset_dependencies_batch_change_id_idx ON changeset_dependencies (batch_change_id);
+
+COMMENT ON TABLE changeset_dependencies IS 'Changesets of a batch change that must be merged before another changeset of the batch change is published.';
+
+COMMENT ON COLUMN changeset_dependencies.depends_on_changeset_id IS 'The changeset that must be merged before the changeset is published.';
+
+COMMENT ON COLUMN changeset_dependencies.held IS 'Whether the reconciler held the publication of the changeset because this dependency wasn''t merged yet.';
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS batch_step_definitions (
+    id bigserial PRIMARY KEY,
+    namespace_user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    name text NOT NULL,
+    version text NOT NULL,
+    raw_definition text NOT NULL,
+    definition jsonb NOT NULL,
+    creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT batch_step_definitions_has_1_namespace CHECK ((namespace_user_id IS NULL) <> (namespace_org_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_step_definitions_unique_user_id ON batch_step_definitions (namespace_user_id, name, version) WHERE namespace_user_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_step_definitions_unique_org_id ON batch_step_definitions (namespace_org_id, name, version) WHERE namespace_org_id IS NOT NULL;
+
+COMMENT ON TABLE batch_step_definitions IS 'Reusable steps of batch specs, which steps of batch specs reference by namespace, name and version. Published versions are never changed.';
+
+COMMENT ON COLUMN batch_step_definitions.definition IS 'The parsed raw_definition.';
@@ -229,6 +229,28 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "builtin", "gerrit", "github", "gitlab", "http-header", "openidconnect", "saml"})
 }
 
+// AutoMerge description: A policy under which the changesets of the batch change are merged automatically once they are mergeable. Changesets are checked against the policy whenever they are synced from the code host. If omitted, changesets are only merged when requested.
+type AutoMerge struct {
+	// Approvals description: The number of approving reviews a changeset must have to be merged. Changesets on which changes were requested are never merged. Defaults to 0.
+	Approvals int `json:"approvals,omitempty"`
+	// Checks description: The state the checks of a changeset must be in for it to be merged: `passed` requires all checks to have passed, `passedOrNone` additionally merges changesets without checks, and `any` ignores checks. Defaults to `passed`.
+	Checks string `json:"checks,omitempty"`
+	// Method description: The method used to merge changesets. `squash` squashes the commits of a changeset into one commit, on code hosts that support it. Defaults to `merge`.
+	Method string `json:"method,omitempty"`
+	// Windows description: The windows of time during which changesets may be merged. If omitted or empty, changesets are merged at any time.
+	Windows []*AutoMergeWindow `json:"windows,omitempty"`
+}
+
+// AutoMergeWindow description: A window of time, in UTC, during which changesets may be merged.
+type AutoMergeWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, this window applies to all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time. If omitted, the window applies to the whole of the day(s) it applies to.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time. If omitted, the window applies to the whole of the day(s) it applies to.
+	Start string `json:"start,omitempty"`
+}
+
 // AzureDevOpsAuthProvider description: Azure auth provider for dev.azure.com
 type AzureDevOpsAuthProvider struct {
 	// AllowOrgs description: Restricts new logins and signups (if allowSignup is true) to members of these Azure DevOps organizations only. Existing sessions won't be invalidated. Leave empty or unset for no org restrictions.
@@ -301,6 +323,10 @@ type BatchChangeRolloutWindow struct {
 
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
+	// AutoMerge description: A policy under which the changesets of the batch change are merged automatically once they are mergeable. Changesets are checked against the policy whenever they are synced from the code host. If omitted, changesets are only merged when requested.
+	AutoMerge *AutoMerge `json:"autoMerge,omitempty"`
+	// AutoRebase description: Whether changesets that have merge conflicts with their base branch are regenerated automatically. When the code host reports a conflict, the steps are run again in the affected workspace against the new base commit and the changeset is force-pushed. Only applies to batch changes run server-side, and to code hosts that report conflicts. Defaults to true.
+	AutoRebase bool `json:"autoRebase,omitempty"`
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
 	// Description description: The description of the batch change.
@@ -321,6 +347,28 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BatchStepDefinition description: A reusable step of batch specs, which is published to Sourcegraph in a namespace and referenced by the uses field of steps in batch specs.
+type BatchStepDefinition struct {
+	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
+	Container string `json:"container"`
+	// Description description: The description of the step definition.
+	Description string `json:"description,omitempty"`
+	// Env description: Environment variables to set in the step environment.
+	Env any `json:"env,omitempty"`
+	// Files description: Files that should be mounted into or be created inside the Docker container.
+	Files map[string]string `json:"files,omitempty"`
+	// Inputs description: The inputs that steps using the step definition pass in their with field. Each input is set as an INPUT_<NAME> environment variable in the step environment, where <NAME> is the name of the input in upper case.
+	Inputs map[string]StepDefinitionInput `json:"inputs,omitempty"`
+	// Name description: The name of the step definition, which is unique among all step definitions in the namespace.
+	Name string `json:"name"`
+	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
+	Outputs map[string]StepDefinitionOutput `json:"outputs,omitempty"`
+	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
+	Run string `json:"run"`
+	// Version description: The version of the step definition. Published versions can't be changed, so that batch specs that pin a version always run the same step.
+	Version string `json:"version"`
+}
+
 // Batches description: The configuration for the batches queue.
 type Batches struct {
 	// Limit description: The maximum number of dequeues allowed within the expiration window.
@@ -369,6 +417,8 @@ type BitbucketCloudConnection struct {
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
 	Exclude []*ExcludedBitbucketCloudRepo `json:"exclude,omitempty"`
+	// GitLFS description: Controls whether Git LFS pointer files in repositories from this Bitbucket Cloud instance are resolved to the object content they point to when files are read, archived or searched.
+	GitLFS *BitbucketCloudGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Bitbucket Cloud.
 	//
 	// If "http", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form https://bitbucket.org/myteam/myproject.git.
@@ -397,6 +447,14 @@ type BitbucketCloudConnection struct {
 	WebhookSecret string `json:"webhookSecret,omitempty"`
 }
 
+// BitbucketCloudGitLFS description: Controls whether Git LFS pointer files in repositories from this Bitbucket Cloud instance are resolved to the object content they point to when files are read, archived or searched.
+type BitbucketCloudGitLFS struct {
+	// Enabled description: true if Git LFS pointers should be resolved. Objects are fetched from the code host's LFS server on first access and cached on gitserver.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxObjectSize description: The maximum size in bytes of an LFS object that will be fetched. Pointers to larger objects are served as is.
+	MaxObjectSize int `json:"maxObjectSize,omitempty"`
+}
+
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 type BitbucketCloudRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -425,6 +483,8 @@ type BitbucketServerConnection struct {
 	Exclude []*ExcludedBitbucketServerRepo `json:"exclude,omitempty"`
 	// ExcludePersonalRepositories description: Whether or not personal repositories should be excluded or not. When true, Sourcegraph will ignore personal repositories it may have access to. See https://sourcegraph.com/docs/integration/bitbucket_server#excluding-personal-repositories for more information.
 	ExcludePersonalRepositories bool `json:"excludePersonalRepositories,omitempty"`
+	// GitLFS description: Controls whether Git LFS pointer files in repositories from this Bitbucket Server / Bitbucket Data Center instance are resolved to the object content they point to when files are read, archived or searched.
+	GitLFS *BitbucketServerGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Bitbucket Server / Bitbucket Data Center instance.
 	//
 	// If "http", Sourcegraph will access Bitbucket Server / Bitbucket Data Center repositories using Git URLs of the form http(s)://bitbucket.example.com/scm/myproject/myrepo.git (using https: if the Bitbucket Server / Bitbucket Data Center instance uses HTTPS).
@@ -471,6 +531,14 @@ type BitbucketServerConnection struct {
 	Webhooks *Webhooks `json:"webhooks,omitempty"`
 }
 
+// BitbucketServerGitLFS description: Controls whether Git LFS pointer files in repositories from this Bitbucket Server / Bitbucket Data Center instance are resolved to the object content they point to when files are read, archived or searched.
+type BitbucketServerGitLFS struct {
+	// Enabled description: true if Git LFS pointers should be resolved. Objects are fetched from the code host's LFS server on first access and cached on gitserver.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxObjectSize description: The maximum size in bytes of an LFS object that will be fetched. Pointers to larger objects are served as is.
+	MaxObjectSize int `json:"maxObjectSize,omitempty"`
+}
+
 // BitbucketServerIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Server / Bitbucket Data Center identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Server / Bitbucket Data Center accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
 type BitbucketServerIdentityProvider struct {
 	Username *BitbucketServerUsernameIdentity
@@ -528,6 +596,8 @@ type BitbucketServerUsernameIdentity struct {
 	Type string `json:"type"`
 }
 type BranchChangesetSpec struct {
+	// Assignees description: The users to assign the changeset to.
+	Assignees []string `json:"assignees,omitempty"`
 	// BaseRef description: The full name of the Git ref in the base repository that this changeset is based on (and is proposing to be merged into). This ref must exist on the base repository.
 	BaseRef string `json:"baseRef"`
 	// BaseRepository description: The GraphQL ID of the repository that this changeset spec is proposing to change.
@@ -544,8 +614,16 @@ type BranchChangesetSpec struct {
 	HeadRef string `json:"headRef"`
 	// HeadRepository description: The GraphQL ID of the repository that contains the branch with this changeset's changes. Fork repositories and cross-repository changesets are not yet supported. Therefore, headRepository must be equal to baseRepository.
 	HeadRepository string `json:"headRepository"`
+	// Labels description: The labels to set on the changeset.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to add the changeset to.
+	Milestone string `json:"milestone,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published any `json:"published,omitempty"`
+	// Reviewers description: The users to request a review of the changeset from.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// TeamReviewers description: The teams to request a review of the changeset from.
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
 	// Title description: The title of the changeset on the code host.
 	Title string `json:"title"`
 	// Version description: A field for versioning the payload.
@@ -589,6 +667,8 @@ type CapabilitiesResult struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users to assign the changeset to, on code hosts that support assignees: GitHub and GitLab. Assignees that are dropped from the list are removed from the changeset, while assignees added on the code host are kept.
+	Assignees any `json:"assignees,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
@@ -597,8 +677,16 @@ type ChangesetTemplate struct {
 	Commit ExpandedGitCommitDescription `json:"commit"`
 	// Fork description: Whether to publish the changeset to a fork of the target repository. If omitted, the changeset will be published to a branch directly on the target repository, unless the global `batches.enforceFork` setting is enabled. If set, this property will override any global setting.
 	Fork bool `json:"fork,omitempty"`
+	// Labels description: The labels to set on the changeset, on code hosts that support labels: GitHub, GitLab, Azure DevOps, and Gerrit, where they are set as hashtags. Labels that are dropped from the list are removed from the changeset, while labels added on the code host are kept.
+	Labels any `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to add the changeset to, on code hosts that support milestones: GitHub and GitLab. The milestone must exist in the repository. If the milestone is dropped from the spec, it is removed from the changeset.
+	Milestone any `json:"milestone,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published any `json:"published,omitempty"`
+	// Reviewers description: The users to request a review of the changeset from. They are identified by their username, except on Bitbucket Cloud, which requires account IDs. Reviews are requested when the changeset is published and whenever it is updated. Reviewers that are dropped from the list are removed from the changeset, while reviewers added on the code host are kept.
+	Reviewers any `json:"reviewers,omitempty"`
+	// TeamReviewers description: The teams to request a review of the changeset from, on code hosts with teams that can review changesets: GitHub team slugs, Azure DevOps team or group names, and Gerrit group names. Reviews are requested when the changeset is published and whenever it is updated. Team reviewers that are dropped from the list are removed from the changeset, while team reviewers added on the code host are kept.
+	TeamReviewers any `json:"teamReviewers,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -1139,6 +1227,8 @@ type ExperimentalFeatures struct {
 	EnableStorm bool `json:"enableStorm,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// GitServerPartialClone description: Repositories that gitserver clones as partial clones, mapped to the object filter to clone them with. Objects omitted by the filter are fetched from the code host when they are first read. Only blob filters are supported. Removing a repository from this list only takes effect once it is recloned.
+	GitServerPartialClone map[string]string `json:"gitServerPartialClone,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
@@ -1155,6 +1245,8 @@ type ExperimentalFeatures struct {
 	LanguageDetection *LanguageDetection `json:"languageDetection,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
+	// NugetPackages description: Allow adding NuGet package code host connections
+	NugetPackages string `json:"nugetPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
 	Pagure string `json:"pagure,omitempty"`
 	// PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
@@ -1234,6 +1326,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "enablePermissionsWebhooks")
 	delete(m, "enableStorm")
 	delete(m, "eventLogging")
+	delete(m, "gitServerPartialClone")
 	delete(m, "gitServerPinnedRepos")
 	delete(m, "goPackages")
 	delete(m, "insightsAlternateLoadingStrategy")
@@ -1242,6 +1335,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "jvmPackages")
 	delete(m, "languageDetection")
 	delete(m, "npmPackages")
+	delete(m, "nugetPackages")
 	delete(m, "pagure")
 	delete(m, "passwordPolicy")
 	delete(m, "perforce")
@@ -1457,6 +1551,8 @@ type GitHubConnection struct {
 	Exclude []*ExcludedGitHubRepo `json:"exclude,omitempty"`
 	// GitHubAppDetails description: If non-null, this is a GitHub App connection with some additional properties.
 	GitHubAppDetails *GitHubAppDetails `json:"gitHubAppDetails,omitempty"`
+	// GitLFS description: Controls whether Git LFS pointer files in repositories from this GitHub instance are resolved to the object content they point to when files are read, archived or searched.
+	GitLFS *GitHubGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitHub instance.
 	//
 	// If "http", Sourcegraph will access GitHub repositories using Git URLs of the form http(s)://github.com/myteam/myproject.git (using https: if the GitHub instance uses HTTPS).
@@ -1508,6 +1604,14 @@ type GitHubConnection struct {
 	Webhooks []*GitHubWebhook `json:"webhooks,omitempty"`
 }
 
+// GitHubGitLFS description: Controls whether Git LFS pointer files in repositories from this GitHub instance are resolved to the object content they point to when files are read, archived or searched.
+type GitHubGitLFS struct {
+	// Enabled description: true if Git LFS pointers should be resolved. Objects are fetched from the code host's LFS server on first access and cached on gitserver.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxObjectSize description: The maximum size in bytes of an LFS object that will be fetched. Pointers to larger objects are served as is.
+	MaxObjectSize int `json:"maxObjectSize,omitempty"`
+}
+
 // GitHubRateLimit description: Rate limit applied when making background API requests to GitHub.
 type GitHubRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -1564,6 +1668,8 @@ type GitLabConnection struct {
 	Certificate string `json:"certificate,omitempty"`
 	// Exclude description: A list of projects to never mirror from this GitLab instance. Takes precedence over "projects" and "projectQuery" configuration. Supports excluding by name ({"name": "group/name"}) or by ID ({"id": 42}).
 	Exclude []*ExcludedGitLabProject `json:"exclude,omitempty"`
+	// GitLFS description: Controls whether Git LFS pointer files in repositories from this GitLab instance are resolved to the object content they point to when files are read, archived or searched.
+	GitLFS *GitLabGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitLab instance.
 	//
 	// If "http", Sourcegraph will access GitLab repositories using Git URLs of the form http(s)://gitlab.example.com/myteam/myproject.git (using https: if the GitLab instance uses HTTPS).
@@ -1603,6 +1709,14 @@ type GitLabConnection struct {
 	// Webhooks description: An array of webhook configurations
 	Webhooks []*GitLabWebhook `json:"webhooks,omitempty"`
 }
+
+// GitLabGitLFS description: Controls whether Git LFS pointer files in repositories from this GitLab instance are resolved to the object content they point to when files are read, archived or searched.
+type GitLabGitLFS struct {
+	// Enabled description: true if Git LFS pointers should be resolved. Objects are fetched from the code host's LFS server on first access and cached on gitserver.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxObjectSize description: The maximum size in bytes of an LFS object that will be fetched. Pointers to larger objects are served as is.
+	MaxObjectSize int `json:"maxObjectSize,omitempty"`
+}
 type GitLabNameTransformation struct {
 	// Regex description: The regex to match for the occurrences of its replacement.
 	Regex string `json:"regex,omitempty"`
@@ -1638,6 +1752,18 @@ type GitRecorder struct {
 	Size int `json:"size,omitempty"`
 }
 
+// GitSignatureVerification description: Verify GPG, SSH and X.509 commit signatures on gitserver against the given keys. Commit signatures aren't verified if this is not set.
+type GitSignatureVerification struct {
+	// CodeHostAccounts description: Code host accounts whose GPG and SSH signing keys are fetched from the code host API and trusted in addition to the keys above.
+	CodeHostAccounts []*SignatureVerificationCodeHostAccount `json:"codeHostAccounts,omitempty"`
+	// GpgPublicKeys description: ASCII armored GPG public keys to trust.
+	GpgPublicKeys []string `json:"gpgPublicKeys,omitempty"`
+	// SshAllowedSigners description: Lines of an SSH allowed signers file, e.g. "alice@example.com ssh-ed25519 AAAA...". See the ALLOWED SIGNERS section of ssh-keygen(1) for the format.
+	SshAllowedSigners []string `json:"sshAllowedSigners,omitempty"`
+	// X509Certificates description: PEM encoded X.509 certificates to trust. Signatures made by these certificates or certificates issued by them are verified.
+	X509Certificates []string `json:"x509Certificates,omitempty"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1981,6 +2107,26 @@ type NpmRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// NuGetPackagesConnection description: Configuration for a connection to a NuGet (.NET) package feed.
+type NuGetPackagesConnection struct {
+	// Credentials description: Credentials in the form "username:password" used to authenticate to private NuGet feeds with basic authentication. Feeds which use personal access tokens usually accept any username together with the token as the password.
+	Credentials string `json:"credentials,omitempty"`
+	// Dependencies description: An array of "packageId@version" strings specifying which NuGet packages to mirror on Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the NuGet feed.
+	RateLimit *NuGetRateLimit `json:"rateLimit,omitempty"`
+	// Registry description: The URL of the service index of the NuGet v3 feed.
+	Registry string `json:"registry,omitempty"`
+}
+
+// NuGetRateLimit description: Rate limit applied when making background API requests to the NuGet feed.
+type NuGetRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type OAuthIdentity struct {
 	Type string `json:"type"`
 }
@@ -2014,6 +2160,10 @@ type ObservabilityTracing struct {
 
 // OnQuery description: A Sourcegraph search query that matches a set of repositories (and branches). Each matched repository branch is added to the list of repositories that the batch change will be run on.
 type OnQuery struct {
+	// DependsOn description: The entries or repositories whose changesets must be merged before the changesets of this entry are published. Each item is either the name of another entry or the name of a repository (as it is known to Sourcegraph). Until then, the changesets are held unpublished, or in draft if they are already published as drafts.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Name description: A name for this entry, by which the dependsOn field of other entries can refer to it.
+	Name string `json:"name,omitempty"`
 	// RepositoriesMatchingQuery description: A Sourcegraph search query that matches a set of repositories (and branches). If the query matches files, symbols, or some other object inside a repository, the object's repository is included.
 	RepositoriesMatchingQuery string `json:"repositoriesMatchingQuery"`
 }
@@ -2024,6 +2174,10 @@ type OnRepository struct {
 	Branch string `json:"branch,omitempty"`
 	// Branches description: The repository branches to propose changes to. If unset, the repository's default branch is used. If this field is defined, branch cannot be.
 	Branches []string `json:"branches,omitempty"`
+	// DependsOn description: The entries or repositories whose changesets must be merged before the changesets of this entry are published. Each item is either the name of another entry or the name of a repository (as it is known to Sourcegraph). Until then, the changesets are held unpublished, or in draft if they are already published as drafts.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Name description: A name for this entry, by which the dependsOn field of other entries can refer to it.
+	Name string `json:"name,omitempty"`
 	// Repository description: The name of the repository (as it is known to Sourcegraph).
 	Repository string `json:"repository"`
 }
@@ -2859,6 +3013,8 @@ type Settings struct {
 	SearchIncludeArchived *bool `json:"search.includeArchived,omitempty"`
 	// SearchIncludeForks description: Whether searches should include searching forked repositories.
 	SearchIncludeForks *bool `json:"search.includeForks,omitempty"`
+	// SearchMacros description: Query macros that can be used in any search as @name, for example "@backend" for a set of repo: and -file: filters. The key is the name of the macro and the value is the query it expands to. Macros may refer to other macros. Macros defined in user settings take precedence over macros with the same name defined in organization or global settings.
+	SearchMacros map[string]string `json:"search.macros,omitempty"`
 	// SearchScopes description: Predefined search snippets that can be appended to any search (also known as search scopes)
 	SearchScopes []*SearchScope `json:"search.scopes,omitempty"`
 	Additional   map[string]any `json:"-"` // additionalProperties not explicitly defined in the schema
@@ -2925,6 +3081,7 @@ func (v *Settings) UnmarshalJSON(data []byte) error {
 	delete(m, "search.hideSuggestions")
 	delete(m, "search.includeArchived")
 	delete(m, "search.includeForks")
+	delete(m, "search.macros")
 	delete(m, "search.scopes")
 	if len(m) > 0 {
 		v.Additional = make(map[string]any, len(m))
@@ -3093,6 +3250,16 @@ type SettingsOpenInEditor struct {
 	// VscodeUseSSH description: If set, files will open on a remote server via SSH. This requires vscode.remoteHostForSSH to be specified and VS Code extension "Remote Development by Microsoft" installed in your VS Code.
 	VscodeUseSSH bool `json:"vscode.useSSH,omitempty"`
 }
+type SignatureVerificationCodeHostAccount struct {
+	// Kind description: The kind of code host.
+	Kind string `json:"kind"`
+	// Token description: An optional access token used to fetch the keys. Public keys of most accounts can be fetched without one.
+	Token string `json:"token,omitempty"`
+	// Url description: The URL of the code host, e.g. https://github.com.
+	Url string `json:"url"`
+	// Username description: The username of the account on the code host.
+	Username string `json:"username"`
+}
 
 // SiteConfiguration description: Configuration for a Sourcegraph site.
 type SiteConfiguration struct {
@@ -3263,6 +3430,8 @@ type SiteConfiguration struct {
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
 	// GitRecorder description: Record git operations that are executed on configured repositories.
 	GitRecorder *GitRecorder `json:"gitRecorder,omitempty"`
+	// GitSignatureVerification description: Verify GPG, SSH and X.509 commit signatures on gitserver against the given keys. Commit signatures aren't verified if this is not set.
+	GitSignatureVerification *GitSignatureVerification `json:"gitSignatureVerification,omitempty"`
 	// GitUpdateInterval description: JSON array of repo name patterns and update intervals. If a repo matches a pattern, the associated interval will be used. If it matches no patterns a default backoff heuristic will be used. Pattern matches are attempted in the order they are provided.
 	GitUpdateInterval []*UpdateIntervalRule `json:"gitUpdateInterval,omitempty"`
 	// GitserverDiskUsageWarningThreshold description: Disk usage threshold at which to display warning notification. Value is a percentage.
@@ -3377,6 +3546,8 @@ type SiteConfiguration struct {
 	SearchLargeFiles []string `json:"search.largeFiles,omitempty"`
 	// SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 	SearchLimits *SearchLimits `json:"search.limits,omitempty"`
+	// SearchSmartSearchRules description: Additional rules Smart Search applies to rewrite queries, for example to turn service names into repo filters. A rule rewrites every search term that matches its pattern. The description of a rule is shown in the alert when a query generated by it has results.
+	SearchSmartSearchRules []*SmartSearchRule `json:"search.smartSearchRules,omitempty"`
 	// SscApiBaseUrl description: The base URL of the Self-Serve Cody API.
 	SscApiBaseUrl string `json:"ssc.apiBaseUrl,omitempty"`
 	// SscSamsHostName description: The hostname of SAMS instance to connect.
@@ -3497,6 +3668,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "gitMaxCodehostRequestsPerSecond")
 	delete(m, "gitMaxConcurrentClones")
 	delete(m, "gitRecorder")
+	delete(m, "gitSignatureVerification")
 	delete(m, "gitUpdateInterval")
 	delete(m, "gitserver.diskUsageWarningThreshold")
 	delete(m, "htmlBodyBottom")
@@ -3555,6 +3727,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "search.index.symbols.enabled")
 	delete(m, "search.largeFiles")
 	delete(m, "search.limits")
+	delete(m, "search.smartSearchRules")
 	delete(m, "ssc.apiBaseUrl")
 	delete(m, "ssc.samsHostName")
 	delete(m, "syntaxHighlighting")
@@ -3586,6 +3759,26 @@ type SiteModelConfiguration struct {
 	SelfHostedModels []*SelfHostedModel      `json:"selfHostedModels,omitempty"`
 	Sourcegraph      *SourcegraphModelConfig `json:"sourcegraph,omitempty"`
 }
+type SmartSearchRule struct {
+	// Conditions description: Conditions the query must satisfy for the rule to apply.
+	Conditions *SmartSearchRuleConditions `json:"conditions,omitempty"`
+	// Description description: Describes the rewrite to users, e.g. "search service repository".
+	Description string `json:"description"`
+	// Kind description: Whether the rule narrows the query (yields fewer, more precise results) or widens it (yields more results). Narrowing rules are tried before widening rules.
+	Kind string `json:"kind,omitempty"`
+	// Pattern description: Regular expression which matches against a search term (e.g. "^(payments|billing)$"). The whole term is replaced if it matches.
+	Pattern string `json:"pattern"`
+	// Replacement description: The query that replaces a matching term. It may contain filters and patterns, and refer to groups of the pattern as $1, $2 or ${name}.
+	Replacement string `json:"replacement"`
+}
+
+// SmartSearchRuleConditions description: Conditions the query must satisfy for the rule to apply.
+type SmartSearchRuleConditions struct {
+	// IfFilters description: Apply the rule only if the query contains all of these filters (e.g. "lang").
+	IfFilters []string `json:"ifFilters,omitempty"`
+	// UnlessFilters description: Do not apply the rule if the query contains any of these filters (e.g. "repo").
+	UnlessFilters []string `json:"unlessFilters,omitempty"`
+}
 
 // SourcegraphModelConfig description: If null, Cody will not use Sourcegraph's servers for model discovery.
 type SourcegraphModelConfig struct {
@@ -3609,7 +3802,7 @@ type SrcCliVersionCache struct {
 // Step description: A command to run (as part of a sequence) in a repository branch to produce the required changes.
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
-	Container string `json:"container"`
+	Container string `json:"container,omitempty"`
 	// Env description: Environment variables to set in the step environment.
 	Env any `json:"env,omitempty"`
 	// Files description: Files that should be mounted into or be created inside the Docker container.
@@ -3621,7 +3814,25 @@ type Step struct {
 	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
 	Outputs map[string]OutputVariable `json:"outputs,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
-	Run string `json:"run"`
+	Run string `json:"run,omitempty"`
+	// Uses description: A step definition published to Sourcegraph to run instead of a command, referenced as <namespace>/<name>@<version>. The step definition is resolved when the batch spec is created. A step that uses a step definition can't have run, container, files, outputs or mount.
+	Uses string `json:"uses,omitempty"`
+	// With description: The inputs to pass to the step definition referenced by uses. They are set as INPUT_<NAME> environment variables in the step environment, and can be template strings.
+	With map[string]string `json:"with,omitempty"`
+}
+type StepDefinitionInput struct {
+	// Default description: The value of the input if a step doesn't pass it.
+	Default string `json:"default,omitempty"`
+	// Description description: The description of the input.
+	Description string `json:"description,omitempty"`
+	// Required description: Whether steps using the step definition must pass the input.
+	Required bool `json:"required,omitempty"`
+}
+type StepDefinitionOutput struct {
+	// Format description: The expected format of the output. If set, the output is being parsed in that format before being stored in the var. If not set, 'text' is assumed to the format.
+	Format string `json:"format,omitempty"`
+	// Value description: The value of the output, which can be a template string.
+	Value string `json:"value"`
 }
 
 // StyleOverrides description: Overrides for the notice's default style. You probably want to use notice 'variant' setting instead.
@@ -3642,6 +3853,38 @@ type SubRepoPermissions struct {
 	UserCacheTTLSeconds int `json:"userCacheTTLSeconds,omitempty"`
 }
 
+// SubversionConnection description: Configuration for a connection to Subversion repositories. Their history is converted to Git incrementally with git-svn.
+type SubversionConnection struct {
+	// Authors description: Maps Subversion usernames to Git authors in the form "Name <email>". Authors who aren't listed are imported as "username <username>". Changing this only affects revisions imported afterwards.
+	Authors map[string]string `json:"authors,omitempty"`
+	// Layout description: The directories of every repository that contain the trunk, branches and tags. Directories that are omitted or empty aren't imported. If all of them are omitted or empty, the repository is imported as a single branch. Defaults to the standard trunk/branches/tags layout.
+	Layout *SubversionLayout `json:"layout,omitempty"`
+	// Password description: The password used to authenticate to the Subversion server.
+	Password string `json:"password,omitempty"`
+	// Repos description: The paths of the Subversion repositories or projects to mirror, relative to `url`. Each of them becomes a separate repository on Sourcegraph.
+	Repos []string `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Subversion repository. In the pattern, the variable "{base}" is replaced with the host and path of `url`, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if `url` is https://svn.example.com/svn and `repos` contains the value "project", then a repositoryPathPattern of "{base}/{repo}" would mean that the repository is available on Sourcegraph at https://sourcegraph.example.com/svn.example.com/svn/project.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL of the Subversion server. The paths in `repos` are resolved relative to it.
+	Url string `json:"url"`
+	// Username description: The username used to authenticate to the Subversion server.
+	Username string `json:"username,omitempty"`
+}
+
+// SubversionLayout description: The directories of every repository that contain the trunk, branches and tags. Directories that are omitted or empty aren't imported. If all of them are omitted or empty, the repository is imported as a single branch. Defaults to the standard trunk/branches/tags layout.
+type SubversionLayout struct {
+	// Branches description: The directory whose subdirectories are branches.
+	Branches string `json:"branches,omitempty"`
+	// Tags description: The directory whose subdirectories are tags.
+	Tags string `json:"tags,omitempty"`
+	// Trunk description: The directory containing the trunk, which becomes the default branch.
+	Trunk string `json:"trunk,omitempty"`
+}
+
 // SymbolConfiguration description: Configure symbol generation
 type SymbolConfiguration struct {
 	// Engine description: Manually specify overrides for symbol generation engine per language
@@ -61,6 +61,9 @@ var JVMPackagesSchemaJSON string
 //go:embed npm-packages.schema.json
 var NpmPackagesSchemaJSON string
 
+//go:embed nuget-packages.schema.json
+var NuGetPackagesSchemaJSON string
+
 //go:embed python-packages.schema.json
 var PythonPackagesSchemaJSON string
 
@@ -90,6 +93,11 @@ var PhabricatorSchemaJSON string
 //go:embed pagure.schema.json
 var PagureSchemaJSON string
 
+// SubversionSchemaJSON is the content of the file "subversion.schema.json".
+//
+//go:embed subversion.schema.json
+var SubversionSchemaJSON string
+
 // SettingsSchemaJSON is the content of the file "settings.schema.json".
 //
 //go:embed settings.schema.json